@@ -23,6 +23,8 @@ import (
 type Agent struct {
 	comm client.Communicator
 	opts Options
+	// confirm, if set, is asked whether to run each command.
+	confirm func(string) bool
 }
 
 // Options contains startup options for the Agent.
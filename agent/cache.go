@@ -0,0 +1,234 @@
+package agent
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+const commandCacheDirName = "command_cache"
+
+// commandCache records the commands that have succeeded in a task
+// directory, keyed on a hash of the command's inputs. Because the
+// outputs of a cached command are the files it left in the task
+// directory, the cache for a task directory is evicted when the
+// directory is removed.
+type commandCache struct {
+	dir string
+}
+
+// commandCacheEntry is the result of a cached command, which records
+// the expansions the command set so that they can be restored when
+// the command is skipped.
+type commandCacheEntry struct {
+	Command    string            `json:"command"`
+	Key        string            `json:"key"`
+	Expansions map[string]string `json:"expansions,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// getCommandCache returns the cache for the task's directory, or nil if
+// the task does not have a directory.
+func (a *Agent) getCommandCache(tc *taskContext) *commandCache {
+	if tc.taskDirectory == "" || a.opts.WorkingDirectory == "" {
+		return nil
+	}
+
+	h := md5.Sum([]byte(tc.taskDirectory))
+	return &commandCache{
+		dir: filepath.Join(a.opts.WorkingDirectory, commandCacheDirName, hex.EncodeToString(h[:])),
+	}
+}
+
+// checkCommandCache looks up the cached result of the idx-th command
+// rendered from commandInfo. On a hit, it restores the expansions the
+// command set. It returns a nil cache if the command cannot be cached.
+func (a *Agent) checkCommandCache(tc *taskContext, commandInfo model.PluginCommandConf, idx int, name string) (*commandCache, string, bool) {
+	cache := a.getCommandCache(tc)
+	if cache == nil {
+		tc.logger.Task().Infof("Command cache is not available for command %s", name)
+		return nil, "", false
+	}
+
+	conf := commandInfo
+	if commandInfo.Function != "" {
+		if fn, ok := tc.taskConfig.Project.Functions[commandInfo.Function]; ok && idx < len(fn.List()) {
+			conf = fn.List()[idx]
+			conf.CacheKey = commandInfo.CacheKey
+			conf.CacheFiles = commandInfo.CacheFiles
+		}
+	}
+
+	hash, err := commandCacheHash(conf, tc.taskConfig.Expansions, tc.taskConfig.WorkDir)
+	if err != nil {
+		tc.logger.Task().Warningf("Not caching command %s: %v", name, err)
+		return nil, "", false
+	}
+
+	entry, ok := cache.get(hash)
+	if !ok {
+		tc.logger.Task().Infof("Cache miss for command %s (key %s)", name, hash)
+		return cache, hash, false
+	}
+
+	tc.taskConfig.Expansions.Update(entry.Expansions)
+	tc.logger.Task().Infof("Cache hit for command %s (key %s, cached at %s), skipping",
+		name, hash, entry.CreatedAt.Format(time.RFC3339))
+	return cache, hash, true
+}
+
+func (c *commandCache) get(hash string) (*commandCacheEntry, bool) {
+	data, err := ioutil.ReadFile(filepath.Join(c.dir, hash+".json"))
+	if err != nil {
+		return nil, false
+	}
+
+	entry := &commandCacheEntry{}
+	if err = json.Unmarshal(data, entry); err != nil {
+		return nil, false
+	}
+
+	return entry, true
+}
+
+func (c *commandCache) put(hash string, entry *commandCacheEntry) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return errors.Wrapf(err, "problem creating command cache directory '%s'", c.dir)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "problem encoding command cache entry")
+	}
+
+	return errors.Wrap(ioutil.WriteFile(filepath.Join(c.dir, hash+".json"), data, 0644),
+		"problem writing command cache entry")
+}
+
+func (c *commandCache) evict() error {
+	return errors.Wrapf(os.RemoveAll(c.dir), "problem removing command cache '%s'", c.dir)
+}
+
+// commandCacheHash hashes the command's name, its expanded cache key
+// and parameters, and the contents of its expanded cache files, which
+// are relative to the working directory.
+func commandCacheHash(conf model.PluginCommandConf, expansions *util.Expansions, workDir string) (string, error) {
+	key, err := expansions.ExpandString(conf.CacheKey)
+	if err != nil {
+		return "", errors.Wrapf(err, "problem expanding cache key '%s'", conf.CacheKey)
+	}
+
+	params, err := expandCacheParams(conf.Params, expansions)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	paramData, err := json.Marshal(params)
+	if err != nil {
+		return "", errors.Wrap(err, "problem encoding command params")
+	}
+
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\x00%s\x00%s\x00", conf.Command, key, paramData)
+
+	files := make([]string, 0, len(conf.CacheFiles))
+	for _, f := range conf.CacheFiles {
+		var path string
+		path, err = expansions.ExpandString(f)
+		if err != nil {
+			return "", errors.Wrapf(err, "problem expanding cache file '%s'", f)
+		}
+		files = append(files, path)
+	}
+	sort.Strings(files)
+
+	for _, f := range files {
+		if err = hashCacheFile(h, filepath.Join(workDir, f)); err != nil {
+			return "", errors.WithStack(err)
+		}
+		_, _ = fmt.Fprintf(h, "\x00%s\x00", f)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashCacheFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		_, _ = io.WriteString(w, "missing")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "problem opening cache file '%s'", path)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return errors.Wrapf(err, "problem reading cache file '%s'", path)
+}
+
+// expandCacheParams returns a copy of the params with expansions
+// applied to every string value, so that the hash changes when an
+// expansion used by the command changes.
+func expandCacheParams(in interface{}, expansions *util.Expansions) (interface{}, error) {
+	switch v := in.(type) {
+	case string:
+		return expansions.ExpandString(v)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			expanded, err := expandCacheParams(val, expansions)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = expanded
+		}
+		return out, nil
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			expanded, err := expandCacheParams(val, expansions)
+			if err != nil {
+				return nil, err
+			}
+			out[fmt.Sprint(key)] = expanded
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, val := range v {
+			expanded, err := expandCacheParams(val, expansions)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, expanded)
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// expansionsDiff returns the expansions in after that are new or
+// different from those in before.
+func expansionsDiff(before, after map[string]string) map[string]string {
+	out := map[string]string{}
+	for k, v := range after {
+		if old, ok := before[k]; !ok || old != v {
+			out[k] = v
+		}
+	}
+	return out
+}
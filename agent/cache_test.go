@@ -0,0 +1,140 @@
+package agent
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCommandCacheHash(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "command-cache-hash")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	expansions := util.NewExpansions(map[string]string{"branch": "master"})
+	conf := model.PluginCommandConf{
+		Command:    "git.get_project",
+		CacheKey:   "${branch}",
+		CacheFiles: []string{"lockfile"},
+		Params:     map[string]interface{}{"directory": "src/${branch}"},
+	}
+
+	first, err := commandCacheHash(conf, expansions, dir)
+	assert.NoError(err)
+	second, err := commandCacheHash(conf, expansions, dir)
+	assert.NoError(err)
+	assert.Equal(first, second)
+
+	// changing the contents of a cache file changes the hash
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "lockfile"), []byte("v1"), 0644))
+	withFile, err := commandCacheHash(conf, expansions, dir)
+	assert.NoError(err)
+	assert.NotEqual(first, withFile)
+
+	// changing an expansion used by the params changes the hash
+	expansions.Put("branch", "v4.0")
+	withBranch, err := commandCacheHash(conf, expansions, dir)
+	assert.NoError(err)
+	assert.NotEqual(withFile, withBranch)
+
+	assert.Equal(map[string]string{"b": "3", "c": "4"},
+		expansionsDiff(map[string]string{"a": "1", "b": "2"}, map[string]string{"a": "1", "b": "3", "c": "4"}))
+}
+
+type CommandCacheSuite struct {
+	suite.Suite
+	a      *Agent
+	tc     *taskContext
+	ctx    context.Context
+	cancel context.CancelFunc
+	dir    string
+}
+
+func TestCommandCacheSuite(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("cached command requires a posix shell")
+	}
+	suite.Run(t, new(CommandCacheSuite))
+}
+
+func (s *CommandCacheSuite) SetupTest() {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	var err error
+	s.dir, err = ioutil.TempDir("", "command-cache")
+	s.Require().NoError(err)
+	taskDir := filepath.Join(s.dir, "task")
+	s.Require().NoError(os.MkdirAll(taskDir, 0755))
+
+	s.a = &Agent{
+		opts: Options{WorkingDirectory: s.dir},
+		comm: client.NewMock("url"),
+	}
+	s.tc = &taskContext{
+		task:          client.TaskData{ID: "task_id", Secret: "task_secret"},
+		taskDirectory: taskDir,
+		taskConfig: &model.TaskConfig{
+			Project:      &model.Project{},
+			Task:         &task.Task{},
+			BuildVariant: &model.BuildVariant{Name: "bv"},
+			Expansions:   util.NewExpansions(map[string]string{}),
+			Timeout:      &model.Timeout{},
+			WorkDir:      taskDir,
+		},
+	}
+	s.tc.logger = s.a.comm.GetLoggerProducer(s.ctx, s.tc.task)
+}
+
+func (s *CommandCacheSuite) TearDownTest() {
+	s.cancel()
+	s.NoError(os.RemoveAll(s.dir))
+}
+
+func (s *CommandCacheSuite) TestCachedCommandRunsOncePerTaskDirectory() {
+	cmds := []model.PluginCommandConf{
+		{
+			Command:  "shell.exec",
+			CacheKey: "setup",
+			Params:   map[string]interface{}{"script": "echo ran >> out.txt"},
+		},
+		{
+			Command:  "expansions.update",
+			CacheKey: "setup",
+			Params: map[string]interface{}{
+				"updates": []interface{}{map[string]interface{}{"key": "from_cache", "value": "yes"}},
+			},
+		},
+	}
+
+	s.NoError(s.a.runCommands(s.ctx, s.tc, cmds, true))
+	s.NoError(s.a.runCommands(s.ctx, s.tc, cmds, true))
+
+	out, err := ioutil.ReadFile(filepath.Join(s.tc.taskDirectory, "out.txt"))
+	s.NoError(err)
+	s.Equal("ran\n", string(out))
+
+	// expansions set by a cached command are restored on a hit
+	s.tc.taskConfig.Expansions = util.NewExpansions(map[string]string{})
+	s.NoError(s.a.runCommands(s.ctx, s.tc, cmds, true))
+	s.Equal("yes", s.tc.taskConfig.Expansions.Get("from_cache"))
+
+	// removing the task directory evicts its cache
+	cacheDir := s.a.getCommandCache(s.tc).dir
+	_, err = os.Stat(cacheDir)
+	s.NoError(err)
+	s.a.removeTaskDirectory(s.tc)
+	_, err = os.Stat(cacheDir)
+	s.True(os.IsNotExist(err))
+}
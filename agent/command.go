@@ -3,10 +3,15 @@ package agent
 import (
 	"context"
 	"fmt"
+	"math"
+	"os/exec"
+	"syscall"
 	"time"
 
 	"github.com/evergreen-ci/evergreen/command"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/jpillora/backoff"
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/recovery"
 	"github.com/pkg/errors"
@@ -22,7 +27,8 @@ func (a *Agent) runCommands(ctx context.Context, tc *taskContext, commands []mod
 			return errors.New("runCommands canceled")
 		}
 
-		cmds, err = command.Render(commandInfo, tc.taskConfig.Project.Functions)
+		cmds, err = command.RenderWithPlugins(commandInfo, tc.taskConfig.Project.Functions,
+			tc.taskConfig.Project.CommandPlugins)
 		if err != nil {
 			tc.logger.Task().Errorf("Couldn't parse plugin command '%v': %v", commandInfo.Command, err)
 			if isTaskCommands {
@@ -51,6 +57,11 @@ func (a *Agent) runCommands(ctx context.Context, tc *taskContext, commands []mod
 				continue
 			}
 
+			if a.confirm != nil && !a.confirm(fullCommandName) {
+				tc.logger.Task().Infof("Skipping command %s (step %d.%d of %d)", fullCommandName, i+1, idx+1, len(commands))
+				continue
+			}
+
 			if len(cmds) == 1 {
 				tc.logger.Task().Infof("Running command %s (step %d of %d)", fullCommandName, i+1, len(commands))
 			} else {
@@ -75,32 +86,41 @@ func (a *Agent) runCommands(ctx context.Context, tc *taskContext, commands []mod
 				tc.setCurrentTimeout(nil)
 			}
 
+			var (
+				cache             *commandCache
+				cacheHash         string
+				cacheHit          bool
+				expansionsAtStart map[string]string
+			)
+			if commandInfo.CacheKey != "" {
+				cache, cacheHash, cacheHit = a.checkCommandCache(tc, commandInfo, idx, fullCommandName)
+				if cacheHit {
+					continue
+				}
+				expansionsAtStart = util.NewExpansions(tc.taskConfig.Expansions.Map()).Map()
+			}
+
 			start := time.Now()
-			// We have seen cases where calling exec.*Cmd.Wait() waits for too long if
-			// the process has called subprocesses. It will wait until a subprocess
-			// finishes, instead of returning immediately when the context is canceled.
-			// We therefore check both if the context is cancled and if Wait() has finished.
-			cmdChan := make(chan error, 1)
-			go func() {
-				defer func() {
-					// this channel will get read from twice even though we only send once, hence why it's buffered
-					cmdChan <- recovery.HandlePanicWithError(recover(), nil,
-						fmt.Sprintf("problem running command '%s'", cmd.Name()))
-				}()
-
-				cmdChan <- cmd.Execute(ctx, a.comm, tc.logger, tc.taskConfig)
-			}()
-			select {
-			case err = <-cmdChan:
-				if err != nil {
-					tc.logger.Task().Errorf("Command failed: %v", err)
-					if isTaskCommands {
-						return errors.Wrap(err, "command failed")
-					}
+			cmdErr := a.runCommandWithRetry(ctx, tc, cmd, a.commandRenderer(tc, commandInfo, idx),
+				commandInfo.Retry, fullCommandName)
+			if errors.Cause(cmdErr) == errCommandCanceled {
+				tc.logger.Task().Errorf("Command canceled: %v", cmdErr)
+				return errors.Wrap(cmdErr, "command canceled")
+			}
+			err = cmdErr
+			if err != nil {
+				tc.logger.Task().Errorf("Command failed: %v", err)
+				if isTaskCommands {
+					return errors.Wrap(err, "command failed")
 				}
-			case <-ctx.Done():
-				tc.logger.Task().Errorf("Command canceled: %v", err)
-				return errors.Wrap(err, "command canceled")
+			}
+			if cache != nil && err == nil {
+				tc.logger.Task().Warning(errors.Wrapf(cache.put(cacheHash, &commandCacheEntry{
+					Command:    cmd.Name(),
+					Key:        commandInfo.CacheKey,
+					Expansions: expansionsDiff(expansionsAtStart, tc.taskConfig.Expansions.Map()),
+					CreatedAt:  time.Now(),
+				}), "problem caching result of command %s", fullCommandName))
 			}
 			tc.logger.Execution().Infof("Finished %s in %s", fullCommandName, time.Since(start).String())
 		}
@@ -109,6 +129,143 @@ func (a *Agent) runCommands(ctx context.Context, tc *taskContext, commands []mod
 	return errors.WithStack(err)
 }
 
+var errCommandCanceled = errors.New("command canceled")
+
+// minCommandRetryBackoff is the shortest time to wait before retrying a
+// command.
+const minCommandRetryBackoff = 100 * time.Millisecond
+
+// runCommand executes a single command, returning errCommandCanceled
+// if the context is canceled before the command finishes.
+func (a *Agent) runCommand(ctx context.Context, tc *taskContext, cmd command.Command) error {
+	// We have seen cases where calling exec.*Cmd.Wait() waits for too long if
+	// the process has called subprocesses. It will wait until a subprocess
+	// finishes, instead of returning immediately when the context is canceled.
+	// We therefore check both if the context is cancled and if Wait() has finished.
+	cmdChan := make(chan error, 1)
+	go func() {
+		defer func() {
+			// this channel will get read from twice even though we only send once, hence why it's buffered
+			cmdChan <- recovery.HandlePanicWithError(recover(), nil,
+				fmt.Sprintf("problem running command '%s'", cmd.Name()))
+		}()
+
+		cmdChan <- cmd.Execute(ctx, a.comm, tc.logger, tc.taskConfig)
+	}()
+	select {
+	case err := <-cmdChan:
+		return err
+	case <-ctx.Done():
+		return errCommandCanceled
+	}
+}
+
+// commandRenderer returns a function that renders a new instance of
+// the idx-th command rendered from commandInfo. Commands may modify
+// their parameters when they execute, so each retry of a command runs
+// a new instance.
+func (a *Agent) commandRenderer(tc *taskContext, commandInfo model.PluginCommandConf, idx int) func() (command.Command, error) {
+	return func() (command.Command, error) {
+		cmds, err := command.RenderWithPlugins(commandInfo, tc.taskConfig.Project.Functions,
+			tc.taskConfig.Project.CommandPlugins)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if idx >= len(cmds) {
+			return nil, errors.Errorf("command %d of '%s' no longer exists", idx, commandInfo.Command)
+		}
+
+		cmds[idx].SetType(tc.taskConfig.Project.CommandType)
+		return cmds[idx], nil
+	}
+}
+
+// runCommandWithRetry executes a command, running a new instance of
+// the command from render with exponential backoff while it fails in
+// a way the retry policy allows. It stops retrying as soon as the
+// context is canceled, including while waiting between attempts.
+func (a *Agent) runCommandWithRetry(ctx context.Context, tc *taskContext, cmd command.Command,
+	render func() (command.Command, error), policy *model.CommandRetryPolicy, name string) error {
+
+	if policy == nil || policy.Attempts <= 1 {
+		return a.runCommand(ctx, tc, cmd)
+	}
+
+	initialBackoff, err := policy.GetBackoff()
+	if err != nil {
+		tc.logger.Task().Warningf("Using the default backoff for command %s: %v", name, err)
+	}
+	if initialBackoff < minCommandRetryBackoff {
+		initialBackoff = minCommandRetryBackoff
+	}
+	retryBackoff := &backoff.Backoff{
+		Min:    initialBackoff,
+		Max:    time.Duration(float64(initialBackoff) * math.Pow(2, float64(policy.Attempts))),
+		Factor: 2,
+		Jitter: true,
+	}
+
+	for attempt := 1; ; attempt++ {
+		if ctx.Err() != nil {
+			return errors.Wrapf(errCommandCanceled, "command %s", name)
+		}
+		if attempt > 1 {
+			tc.logger.Task().Infof("Retrying command %s (attempt %d of %d)", name, attempt, policy.Attempts)
+			next, err := render()
+			if err != nil {
+				return errors.Wrapf(err, "problem rendering command %s to retry", name)
+			}
+			if tc.getCurrentCommand() == cmd {
+				tc.setCurrentCommand(next)
+			}
+			cmd = next
+		}
+
+		err := a.runCommand(ctx, tc, cmd)
+		if err == nil {
+			return nil
+		}
+		if errors.Cause(err) == errCommandCanceled || ctx.Err() != nil {
+			return err
+		}
+
+		exitCode, hasExitCode := commandExitCode(err)
+		if !policy.ShouldRetry(exitCode, hasExitCode) {
+			tc.logger.Task().Infof("Not retrying command %s: exit code is not retryable", name)
+			return err
+		}
+
+		tc.logger.Task().Errorf("Command %s failed on attempt %d of %d: %v", name, attempt, policy.Attempts, err)
+		if attempt >= policy.Attempts {
+			return errors.Wrapf(err, "command %s failed after %d attempts", name, attempt)
+		}
+
+		timer := time.NewTimer(retryBackoff.Duration())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Wrapf(errCommandCanceled, "command %s waiting to retry", name)
+		case <-timer.C:
+		}
+	}
+}
+
+// commandExitCode returns the exit code of the process that caused
+// the command error, if any.
+func commandExitCode(err error) (int, bool) {
+	exitErr, ok := errors.Cause(err).(*exec.ExitError)
+	if !ok {
+		return 0, false
+	}
+
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok {
+		return 0, false
+	}
+
+	return status.ExitStatus(), true
+}
+
 // runTaskCommands runs all commands for the task currently assigned to the agent and
 // returns the task status
 func (a *Agent) runTaskCommands(ctx context.Context, tc *taskContext) error {
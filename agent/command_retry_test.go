@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+// flakyScript fails until it has been run the given number of times,
+// exiting with the given code.
+const flakyScript = `count=$(cat count 2>/dev/null || echo 0); count=$((count+1)); echo $count > count; [ $count -ge ${succeed_on} ] || exit ${exit_code}`
+
+type CommandRetrySuite struct {
+	suite.Suite
+	a      *Agent
+	tc     *taskContext
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func TestCommandRetrySuite(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("flaky command requires a posix shell")
+	}
+	suite.Run(t, new(CommandRetrySuite))
+}
+
+func (s *CommandRetrySuite) SetupTest() {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	dir, err := ioutil.TempDir("", "command-retry")
+	s.Require().NoError(err)
+
+	s.a = &Agent{comm: client.NewMock("url")}
+	s.tc = &taskContext{
+		task:          client.TaskData{ID: "task_id", Secret: "task_secret"},
+		taskDirectory: dir,
+		taskConfig: &model.TaskConfig{
+			Project:      &model.Project{},
+			Task:         &task.Task{},
+			BuildVariant: &model.BuildVariant{Name: "bv"},
+			Expansions:   util.NewExpansions(map[string]string{"succeed_on": "3", "exit_code": "2"}),
+			Timeout:      &model.Timeout{},
+			WorkDir:      dir,
+		},
+	}
+	s.tc.logger = s.a.comm.GetLoggerProducer(s.ctx, s.tc.task)
+}
+
+func (s *CommandRetrySuite) TearDownTest() {
+	s.cancel()
+	s.NoError(os.RemoveAll(s.tc.taskDirectory))
+}
+
+func (s *CommandRetrySuite) runCount() string {
+	out, err := ioutil.ReadFile(filepath.Join(s.tc.taskDirectory, "count"))
+	s.Require().NoError(err)
+	return string(out)
+}
+
+func (s *CommandRetrySuite) commands(policy *model.CommandRetryPolicy) []model.PluginCommandConf {
+	return []model.PluginCommandConf{
+		{
+			Command: "shell.exec",
+			Retry:   policy,
+			Params:  map[string]interface{}{"script": flakyScript},
+		},
+	}
+}
+
+func (s *CommandRetrySuite) TestCommandSucceedsWithinAttempts() {
+	s.NoError(s.a.runCommands(s.ctx, s.tc, s.commands(&model.CommandRetryPolicy{Attempts: 3}), true))
+	s.Equal("3\n", s.runCount())
+}
+
+func (s *CommandRetrySuite) TestCommandFailsAfterAttemptsAreExhausted() {
+	s.Error(s.a.runCommands(s.ctx, s.tc, s.commands(&model.CommandRetryPolicy{Attempts: 2}), true))
+	s.Equal("2\n", s.runCount())
+}
+
+func (s *CommandRetrySuite) TestCommandWithoutPolicyRunsOnce() {
+	s.Error(s.a.runCommands(s.ctx, s.tc, s.commands(nil), true))
+	s.Equal("1\n", s.runCount())
+}
+
+func (s *CommandRetrySuite) TestOnlyMatchingExitCodesAreRetried() {
+	s.Error(s.a.runCommands(s.ctx, s.tc, s.commands(&model.CommandRetryPolicy{Attempts: 3, OnExitCodes: []int{1}}), true))
+	s.Equal("1\n", s.runCount())
+
+	s.NoError(os.Remove(filepath.Join(s.tc.taskDirectory, "count")))
+	s.NoError(s.a.runCommands(s.ctx, s.tc, s.commands(&model.CommandRetryPolicy{Attempts: 3, OnExitCodes: []int{2}}), true))
+	s.Equal("3\n", s.runCount())
+}
+
+func (s *CommandRetrySuite) TestCancelStopsWaitingToRetry() {
+	commandInfo := s.commands(&model.CommandRetryPolicy{Attempts: 3, Backoff: "1h"})[0]
+	render := s.a.commandRenderer(s.tc, commandInfo, 0)
+	cmd, err := render()
+	s.Require().NoError(err)
+	go func() {
+		time.Sleep(time.Second)
+		s.cancel()
+	}()
+
+	start := time.Now()
+	err = s.a.runCommandWithRetry(s.ctx, s.tc, cmd, render, commandInfo.Retry, "shell.exec")
+	s.Error(err)
+	s.Equal(errCommandCanceled, errors.Cause(err))
+	s.True(time.Since(start) < time.Minute)
+	s.Equal("1\n", s.runCount())
+}
@@ -54,6 +54,12 @@ func (a *Agent) removeTaskDirectory(tc *taskContext) {
 	if err := os.RemoveAll(tc.taskDirectory); err != nil {
 		grip.Criticalf("Error removing working directory for the task: %v", err)
 	}
+
+	// cached command results refer to files in the task directory,
+	// so they are no longer valid once it is removed.
+	if cache := a.getCommandCache(tc); cache != nil {
+		grip.Warning(cache.evict())
+	}
 }
 
 // tryCleanupDirectory is a very conservative function that attempts
@@ -0,0 +1,62 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/evergreen-ci/evergreen/command"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/pkg/errors"
+)
+
+// CommandRunner runs a list of project commands for a task.
+type CommandRunner interface {
+	// RunCommands runs the commands in order. If isTaskCommands is
+	// true, the first failing command stops the run and its error is
+	// returned; otherwise failures are only logged.
+	RunCommands(ctx context.Context, commands []model.PluginCommandConf, isTaskCommands bool) error
+}
+
+// LocalCommandOptions configures a CommandRunner for a task that runs
+// without an evergreen service.
+type LocalCommandOptions struct {
+	// CacheDirectory is where the results of commands with a
+	// cache_key are stored. Caching is disabled if it is empty.
+	CacheDirectory string
+	// Confirm, if set, is called with the name of each command before
+	// it runs. Commands it returns false for are skipped.
+	Confirm func(string) bool
+}
+
+type localCommandRunner struct {
+	agent *Agent
+	tc    *taskContext
+}
+
+// NewLocalCommandRunner returns a CommandRunner that uses the agent's
+// command loop, so commands run locally are retried and cached the
+// same way they are on a host.
+func NewLocalCommandRunner(comm client.Communicator, logger client.LoggerProducer, conf *model.TaskConfig, opts LocalCommandOptions) (CommandRunner, error) {
+	factory, ok := command.GetCommandFactory("setup.initial")
+	if !ok {
+		return nil, errors.New("problem during configuring initial state")
+	}
+
+	return &localCommandRunner{
+		agent: &Agent{
+			comm:    comm,
+			opts:    Options{WorkingDirectory: opts.CacheDirectory},
+			confirm: opts.Confirm,
+		},
+		tc: &taskContext{
+			currentCommand: factory(),
+			logger:         logger,
+			taskConfig:     conf,
+			taskDirectory:  conf.WorkDir,
+		},
+	}, nil
+}
+
+func (r *localCommandRunner) RunCommands(ctx context.Context, commands []model.PluginCommandConf, isTaskCommands bool) error {
+	return r.agent.runCommands(ctx, r.tc, commands, isTaskCommands)
+}
@@ -270,6 +270,14 @@ func (a *Agent) makeTaskConfig(ctx context.Context, tc *taskContext) (*model.Tas
 			tc.logger.Execution().Error(err.Error())
 			return nil, err
 		}
+	} else if confVersion.Requester == evergreen.GitlabMRRequester {
+		tc.logger.Execution().Info("Fetching patch document for Gitlab MR request.")
+		confPatch, err = a.comm.GetTaskPatch(ctx, tc.task)
+		if err != nil {
+			err = errors.Wrap(err, "couldn't fetch patch for Gitlab MR request")
+			tc.logger.Execution().Error(err.Error())
+			return nil, err
+		}
 	}
 
 	tc.logger.Execution().Info("Constructing TaskConfig.")
@@ -88,6 +88,8 @@ func GetManager(ctx context.Context, providerName string, settings *evergreen.Se
 		provider = &openStackManager{}
 	case evergreen.ProviderNameGce:
 		provider = &gceManager{}
+	case evergreen.ProviderNameKubernetes:
+		provider = &kubernetesManager{}
 	case evergreen.ProviderNameVsphere:
 		provider = &vsphereManager{}
 	default:
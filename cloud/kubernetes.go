@@ -0,0 +1,334 @@
+// +build go1.7
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// kubernetesManager implements the Manager interface for Kubernetes,
+// running each host as a pod.
+type kubernetesManager struct {
+	client kubernetesClient
+}
+
+// kubernetesSettings specifies the settings used to configure a pod.
+type kubernetesSettings struct {
+	// Image is the container image that the pod runs. It must run an
+	// SSH daemon in the foreground on SSHPort.
+	Image string `mapstructure:"image" json:"image" bson:"image"`
+	// Command overrides the entrypoint of the image.
+	Command []string `mapstructure:"command" json:"command,omitempty" bson:"command,omitempty"`
+	// Namespace is the namespace to create pods in. It defaults to the
+	// namespace in the admin settings.
+	Namespace string `mapstructure:"namespace" json:"namespace,omitempty" bson:"namespace,omitempty"`
+	// SSHPort is the port the SSH daemon listens on, 22 by default.
+	SSHPort int `mapstructure:"ssh_port" json:"ssh_port,omitempty" bson:"ssh_port,omitempty"`
+
+	CPU    string `mapstructure:"cpu" json:"cpu,omitempty" bson:"cpu,omitempty"`
+	Memory string `mapstructure:"memory" json:"memory,omitempty" bson:"memory,omitempty"`
+
+	NodeSelector    map[string]string `mapstructure:"node_selector" json:"node_selector,omitempty" bson:"node_selector,omitempty"`
+	ServiceAccount  string            `mapstructure:"service_account" json:"service_account,omitempty" bson:"service_account,omitempty"`
+	ImagePullSecret string            `mapstructure:"image_pull_secret" json:"image_pull_secret,omitempty" bson:"image_pull_secret,omitempty"`
+}
+
+// Validate checks that the settings from the distro are sane.
+func (s *kubernetesSettings) Validate() error {
+	if s.Image == "" {
+		return errors.New("image must not be blank")
+	}
+
+	if s.SSHPort < 0 || s.SSHPort > 65535 {
+		return errors.Errorf("SSH port %d is not a valid port", s.SSHPort)
+	}
+
+	return nil
+}
+
+func (s *kubernetesSettings) sshPort() int {
+	if s.SSHPort == 0 {
+		return kubernetesDefaultSSHPort
+	}
+	return s.SSHPort
+}
+
+// GetSettings returns an empty kubernetesSettings struct.
+func (*kubernetesManager) GetSettings() ProviderSettings {
+	return &kubernetesSettings{}
+}
+
+// Configure loads the API server and credentials from the config.
+func (m *kubernetesManager) Configure(ctx context.Context, s *evergreen.Settings) error {
+	if m.client == nil {
+		m.client = &kubernetesClientImpl{}
+	}
+
+	if err := m.client.Init(s.Providers.Kubernetes); err != nil {
+		return errors.Wrap(err, "Failed to initialize client connection")
+	}
+
+	return nil
+}
+
+// getSettings decodes the provider settings of the host's distro.
+func (m *kubernetesManager) getSettings(h *host.Host) (*kubernetesSettings, error) {
+	settings := &kubernetesSettings{}
+	if h.Distro.ProviderSettings != nil {
+		if err := mapstructure.Decode(h.Distro.ProviderSettings, settings); err != nil {
+			return nil, errors.Wrapf(err, "Error decoding params for distro '%s'", h.Distro.Id)
+		}
+	}
+
+	if err := settings.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "Invalid Kubernetes settings in distro '%s'", h.Distro.Id)
+	}
+
+	if settings.Namespace == "" {
+		settings.Namespace = m.client.GetNamespace()
+	}
+
+	return settings, nil
+}
+
+// makeKubernetesPod returns the pod that runs the host.
+func makeKubernetesPod(h *host.Host, settings *kubernetesSettings) *kubernetesPod {
+	container := kubernetesContainer{
+		Name:    kubernetesContainerName,
+		Image:   settings.Image,
+		Command: settings.Command,
+		Ports: []kubernetesContainerPort{
+			{Name: "ssh", ContainerPort: settings.sshPort()},
+		},
+	}
+
+	resources := map[string]string{}
+	if settings.CPU != "" {
+		resources["cpu"] = settings.CPU
+	}
+	if settings.Memory != "" {
+		resources["memory"] = settings.Memory
+	}
+	if len(resources) > 0 {
+		// Requests equal to limits give the pod guaranteed quality of
+		// service, so that it is not evicted before other pods.
+		container.Resources = kubernetesResourceRequirement{
+			Limits:   resources,
+			Requests: resources,
+		}
+	}
+
+	pod := &kubernetesPod{
+		Metadata: kubernetesObjectMeta{
+			Name:      h.Id,
+			Namespace: settings.Namespace,
+			Labels: map[string]string{
+				kubernetesManagedLabel: "true",
+				kubernetesDistroLabel:  kubernetesLabelValue(h.Distro.Id),
+				kubernetesHostLabel:    kubernetesLabelValue(h.Id),
+			},
+		},
+		Spec: kubernetesPodSpec{
+			Containers:         []kubernetesContainer{container},
+			NodeSelector:       settings.NodeSelector,
+			ServiceAccountName: settings.ServiceAccount,
+			RestartPolicy:      "Never",
+		},
+	}
+	if settings.ImagePullSecret != "" {
+		pod.Spec.ImagePullSecrets = []kubernetesLocalObjectRef{{Name: settings.ImagePullSecret}}
+	}
+
+	return pod
+}
+
+// SpawnHost creates a pod for the host.
+func (m *kubernetesManager) SpawnHost(ctx context.Context, h *host.Host) (*host.Host, error) {
+	if h.Distro.Provider != evergreen.ProviderNameKubernetes {
+		return nil, errors.Errorf("Can't spawn instance of %s for distro %s: provider is %s",
+			evergreen.ProviderNameKubernetes, h.Distro.Id, h.Distro.Provider)
+	}
+
+	settings, err := m.getSettings(h)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Create the pod, and remove the intent host document if unsuccessful.
+	if _, err = m.client.CreatePod(ctx, makeKubernetesPod(h, settings)); err != nil {
+		if rmErr := h.Remove(); rmErr != nil {
+			grip.Errorf("Could not remove intent host '%s': %+v", h.Id, rmErr)
+		}
+		grip.Error(err)
+		return nil, errors.Wrapf(err, "Could not create pod for distro '%s'", h.Distro.Id)
+	}
+
+	grip.Debug(message.Fields{
+		"message":   "created pod",
+		"pod":       h.Id,
+		"namespace": settings.Namespace,
+		"distro":    h.Distro.Id,
+		"image":     settings.Image,
+	})
+	event.LogHostStarted(h.Id)
+
+	return h, nil
+}
+
+// GetInstanceStatus returns the status of the host's pod. A pod that
+// no longer exists is terminated.
+func (m *kubernetesManager) GetInstanceStatus(ctx context.Context, h *host.Host) (CloudStatus, error) {
+	settings, err := m.getSettings(h)
+	if err != nil {
+		return StatusUnknown, errors.WithStack(err)
+	}
+
+	pod, err := m.client.GetPod(ctx, settings.Namespace, h.Id)
+	if errors.Cause(err) == errKubernetesPodNotFound {
+		return StatusTerminated, nil
+	}
+	if err != nil {
+		return StatusUnknown, errors.Wrapf(err, "Failed to get pod for host '%s'", h.Id)
+	}
+
+	return kubernetesToEvgStatus(pod), nil
+}
+
+// GetInstanceStatuses returns the statuses of the hosts' pods, listing
+// the pods in each namespace once.
+func (m *kubernetesManager) GetInstanceStatuses(ctx context.Context, hosts []host.Host) ([]CloudStatus, error) {
+	namespaces := make([]string, len(hosts))
+	pods := map[string]map[string]*kubernetesPod{}
+	for i := range hosts {
+		settings, err := m.getSettings(&hosts[i])
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		namespaces[i] = settings.Namespace
+
+		if _, ok := pods[settings.Namespace]; ok {
+			continue
+		}
+
+		list, err := m.client.ListPods(ctx, settings.Namespace, map[string]string{kubernetesManagedLabel: "true"})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to list pods in namespace '%s'", settings.Namespace)
+		}
+		pods[settings.Namespace] = map[string]*kubernetesPod{}
+		for j := range list {
+			pods[settings.Namespace][list[j].Metadata.Name] = &list[j]
+		}
+	}
+
+	statuses := make([]CloudStatus, len(hosts))
+	for i := range hosts {
+		pod, ok := pods[namespaces[i]][hosts[i].Id]
+		if !ok {
+			statuses[i] = StatusTerminated
+			continue
+		}
+		statuses[i] = kubernetesToEvgStatus(pod)
+	}
+
+	return statuses, nil
+}
+
+// GetDNSName returns the IP address of the host's pod.
+func (m *kubernetesManager) GetDNSName(ctx context.Context, h *host.Host) (string, error) {
+	settings, err := m.getSettings(h)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	pod, err := m.client.GetPod(ctx, settings.Namespace, h.Id)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to get pod for host '%s'", h.Id)
+	}
+
+	if pod.Status.PodIP == "" {
+		return "", errors.Errorf("pod for host '%s' does not have an IP address", h.Id)
+	}
+
+	return pod.Status.PodIP, nil
+}
+
+// TerminateInstance deletes the host's pod.
+func (m *kubernetesManager) TerminateInstance(ctx context.Context, h *host.Host, user string) error {
+	if h.Status == evergreen.HostTerminated {
+		err := errors.Errorf("Can not terminate %s - already marked as terminated!", h.Id)
+		grip.Error(err)
+		return err
+	}
+
+	settings, err := m.getSettings(h)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err = m.client.DeletePod(ctx, settings.Namespace, h.Id); err != nil {
+		return errors.Wrapf(err, "API call to delete pod %s failed", h.Id)
+	}
+
+	grip.Info(message.Fields{
+		"message":   "deleted pod",
+		"pod":       h.Id,
+		"namespace": settings.Namespace,
+	})
+
+	// Set the host status as terminated and update its termination time
+	return errors.Wrapf(h.Terminate(user), "could not terminate host %s in db", h.Id)
+}
+
+// IsUp returns true if the host's pod is running.
+func (m *kubernetesManager) IsUp(ctx context.Context, h *host.Host) (bool, error) {
+	status, err := m.GetInstanceStatus(ctx, h)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return status == StatusRunning, nil
+}
+
+// OnUp does nothing since labels are attached when the pod is created.
+func (m *kubernetesManager) OnUp(context.Context, *host.Host) error {
+	return nil
+}
+
+// GetSSHOptions returns the options for connecting to the SSH daemon
+// in the host's pod, including the port if it is not the default.
+func (m *kubernetesManager) GetSSHOptions(h *host.Host, keyPath string) ([]string, error) {
+	if keyPath == "" {
+		return []string{}, errors.Errorf("No key specified for host %s", h.Id)
+	}
+
+	settings, err := m.getSettings(h)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	opts := []string{"-i", keyPath}
+	if port := settings.sshPort(); port != kubernetesDefaultSSHPort {
+		opts = append(opts, "-p", fmt.Sprintf("%d", port))
+	}
+	for _, opt := range h.Distro.SSHOptions {
+		opts = append(opts, "-o", opt)
+	}
+
+	return opts, nil
+}
+
+// TimeTilNextPayment returns the amount of time until the next payment is due
+// for the host. For Kubernetes this is not relevant.
+func (m *kubernetesManager) TimeTilNextPayment(_ *host.Host) time.Duration {
+	return time.Duration(0)
+}
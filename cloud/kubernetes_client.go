@@ -0,0 +1,245 @@
+// +build go1.7
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
+)
+
+const (
+	kubernetesServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	kubernetesDefaultNamespace  = "default"
+	kubernetesClientTimeout     = time.Minute
+)
+
+// errKubernetesPodNotFound is returned by the client when the API
+// server has no pod with the requested name.
+var errKubernetesPodNotFound = errors.New("pod not found")
+
+// The kubernetesClient interface wraps interaction with the Kubernetes
+// API server.
+type kubernetesClient interface {
+	Init(evergreen.KubernetesConfig) error
+	GetNamespace() string
+	CreatePod(context.Context, *kubernetesPod) (*kubernetesPod, error)
+	GetPod(context.Context, string, string) (*kubernetesPod, error)
+	ListPods(context.Context, string, map[string]string) ([]kubernetesPod, error)
+	DeletePod(context.Context, string, string) error
+}
+
+type kubernetesClientImpl struct {
+	apiServer  string
+	token      string
+	namespace  string
+	httpClient *http.Client
+}
+
+// Init configures the client to talk to the API server in the config,
+// or, if the config does not specify one, to the API server of the
+// cluster evergreen is running in, using its service account.
+func (c *kubernetesClientImpl) Init(config evergreen.KubernetesConfig) error {
+	c.apiServer = strings.TrimRight(config.APIServer, "/")
+	c.token = config.Token
+	c.namespace = config.Namespace
+	caCert := []byte(config.CACert)
+
+	if c.apiServer == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return errors.New("no Kubernetes API server is configured, and evergreen is not running in a cluster")
+		}
+		c.apiServer = "https://" + net.JoinHostPort(host, port)
+
+		if c.token == "" {
+			token, err := ioutil.ReadFile(kubernetesServiceAccountDir + "/token")
+			if err != nil {
+				return errors.Wrap(err, "problem reading service account token")
+			}
+			c.token = strings.TrimSpace(string(token))
+		}
+		if len(caCert) == 0 {
+			var err error
+			caCert, err = ioutil.ReadFile(kubernetesServiceAccountDir + "/ca.crt")
+			if err != nil {
+				return errors.Wrap(err, "problem reading service account CA certificate")
+			}
+		}
+		if c.namespace == "" {
+			if namespace, err := ioutil.ReadFile(kubernetesServiceAccountDir + "/namespace"); err == nil {
+				c.namespace = strings.TrimSpace(string(namespace))
+			}
+		}
+	}
+
+	if c.namespace == "" {
+		c.namespace = kubernetesDefaultNamespace
+	}
+
+	tlsConfig := &tls.Config{}
+	if len(caCert) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return errors.New("Kubernetes CA certificate is not a valid PEM certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	c.httpClient = &http.Client{
+		Timeout: kubernetesClientTimeout,
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			TLSClientConfig:     tlsConfig,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+
+	return nil
+}
+
+// GetNamespace returns the namespace that pods are created in when the
+// distro does not specify one.
+func (c *kubernetesClientImpl) GetNamespace() string {
+	return c.namespace
+}
+
+// CreatePod creates the pod, returning the pod as created by the API server.
+func (c *kubernetesClientImpl) CreatePod(ctx context.Context, pod *kubernetesPod) (*kubernetesPod, error) {
+	pod.APIVersion = "v1"
+	pod.Kind = "Pod"
+
+	out := &kubernetesPod{}
+	if err := c.do(ctx, http.MethodPost, c.podsPath(pod.Metadata.Namespace), nil, pod, out); err != nil {
+		return nil, errors.Wrapf(err, "problem creating pod '%s'", pod.Metadata.Name)
+	}
+
+	return out, nil
+}
+
+// GetPod returns the named pod, or errKubernetesPodNotFound if the pod
+// does not exist.
+func (c *kubernetesClientImpl) GetPod(ctx context.Context, namespace, name string) (*kubernetesPod, error) {
+	out := &kubernetesPod{}
+	if err := c.do(ctx, http.MethodGet, c.podPath(namespace, name), nil, nil, out); err != nil {
+		return nil, errors.Wrapf(err, "problem getting pod '%s'", name)
+	}
+
+	return out, nil
+}
+
+// ListPods returns the pods in the namespace that have all of the labels.
+func (c *kubernetesClientImpl) ListPods(ctx context.Context, namespace string, labels map[string]string) ([]kubernetesPod, error) {
+	selector := []string{}
+	for k, v := range labels {
+		selector = append(selector, fmt.Sprintf("%s=%s", k, v))
+	}
+	query := url.Values{}
+	if len(selector) > 0 {
+		query.Set("labelSelector", strings.Join(selector, ","))
+	}
+
+	out := &kubernetesPodList{}
+	if err := c.do(ctx, http.MethodGet, c.podsPath(namespace), query, nil, out); err != nil {
+		return nil, errors.Wrapf(err, "problem listing pods in namespace '%s'", namespace)
+	}
+
+	return out.Items, nil
+}
+
+// DeletePod deletes the named pod. Deleting a pod that does not exist
+// is not an error.
+func (c *kubernetesClientImpl) DeletePod(ctx context.Context, namespace, name string) error {
+	err := c.do(ctx, http.MethodDelete, c.podPath(namespace, name), nil, nil, nil)
+	if errors.Cause(err) == errKubernetesPodNotFound {
+		return nil
+	}
+
+	return errors.Wrapf(err, "problem deleting pod '%s'", name)
+}
+
+func (c *kubernetesClientImpl) podsPath(namespace string) string {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+	return fmt.Sprintf("/api/v1/namespaces/%s/pods", url.PathEscape(namespace))
+}
+
+func (c *kubernetesClientImpl) podPath(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", c.podsPath(namespace), url.PathEscape(name))
+}
+
+// do makes a request to the API server, encoding the body and decoding
+// the response into out, if they are not nil.
+func (c *kubernetesClientImpl) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	if c.httpClient == nil {
+		return errors.New("Kubernetes client is not initialized")
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(err, "problem encoding request")
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	u := c.apiServer + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(method, u, reqBody)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "problem making %s request to '%s'", method, path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errKubernetesPodNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		status := struct {
+			Message string `json:"message"`
+		}{}
+		data, _ := ioutil.ReadAll(resp.Body)
+		if json.Unmarshal(data, &status) != nil || status.Message == "" {
+			status.Message = strings.TrimSpace(string(data))
+		}
+		return errors.Errorf("Kubernetes API returned %d: %s", resp.StatusCode, status.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return errors.Wrap(json.NewDecoder(resp.Body).Decode(out), "problem decoding response")
+}
@@ -0,0 +1,130 @@
+// +build go1.7
+
+package cloud
+
+import (
+	"context"
+	"sync"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
+)
+
+// kubernetesClientMock fakes a Kubernetes API server, keeping the pods
+// it creates in memory, keyed by namespace and name.
+type kubernetesClientMock struct {
+	// API call options
+	failInit   bool
+	failCreate bool
+	failGet    bool
+	failList   bool
+	failDelete bool
+
+	// Other options
+	namespace string
+	// phase and conditions are the status given to created pods
+	phase      string
+	conditions []kubernetesPodCondition
+
+	mu   sync.Mutex
+	pods map[string]map[string]kubernetesPod
+}
+
+func (c *kubernetesClientMock) Init(config evergreen.KubernetesConfig) error {
+	if c.failInit {
+		return errors.New("failed to initialize client")
+	}
+	c.namespace = config.Namespace
+	if c.namespace == "" {
+		c.namespace = kubernetesDefaultNamespace
+	}
+	return nil
+}
+
+func (c *kubernetesClientMock) GetNamespace() string {
+	if c.namespace == "" {
+		return kubernetesDefaultNamespace
+	}
+	return c.namespace
+}
+
+func (c *kubernetesClientMock) CreatePod(_ context.Context, pod *kubernetesPod) (*kubernetesPod, error) {
+	if c.failCreate {
+		return nil, errors.New("failed to create pod")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pods == nil {
+		c.pods = map[string]map[string]kubernetesPod{}
+	}
+	if c.pods[pod.Metadata.Namespace] == nil {
+		c.pods[pod.Metadata.Namespace] = map[string]kubernetesPod{}
+	}
+	if _, ok := c.pods[pod.Metadata.Namespace][pod.Metadata.Name]; ok {
+		return nil, errors.Errorf("pod '%s' already exists", pod.Metadata.Name)
+	}
+
+	created := *pod
+	created.Status = kubernetesPodStatus{
+		Phase:      c.phase,
+		PodIP:      "10.0.0.1",
+		Conditions: c.conditions,
+	}
+	c.pods[pod.Metadata.Namespace][pod.Metadata.Name] = created
+
+	return &created, nil
+}
+
+func (c *kubernetesClientMock) GetPod(_ context.Context, namespace, name string) (*kubernetesPod, error) {
+	if c.failGet {
+		return nil, errors.New("failed to get pod")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pod, ok := c.pods[namespace][name]
+	if !ok {
+		return nil, errKubernetesPodNotFound
+	}
+
+	return &pod, nil
+}
+
+func (c *kubernetesClientMock) ListPods(_ context.Context, namespace string, labels map[string]string) ([]kubernetesPod, error) {
+	if c.failList {
+		return nil, errors.New("failed to list pods")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := []kubernetesPod{}
+	for _, pod := range c.pods[namespace] {
+		matches := true
+		for k, v := range labels {
+			if pod.Metadata.Labels[k] != v {
+				matches = false
+			}
+		}
+		if matches {
+			out = append(out, pod)
+		}
+	}
+
+	return out, nil
+}
+
+func (c *kubernetesClientMock) DeletePod(_ context.Context, namespace, name string) error {
+	if c.failDelete {
+		return errors.New("failed to delete pod")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.pods[namespace], name)
+	return nil
+}
@@ -0,0 +1,358 @@
+// +build go1.7
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type KubernetesSuite struct {
+	client   *kubernetesClientMock
+	manager  *kubernetesManager
+	distro   distro.Distro
+	hostOpts HostOptions
+	ctx      context.Context
+	cancel   context.CancelFunc
+	suite.Suite
+}
+
+func TestKubernetesSuite(t *testing.T) {
+	suite.Run(t, new(KubernetesSuite))
+}
+
+func (s *KubernetesSuite) SetupSuite() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func (s *KubernetesSuite) SetupTest() {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.client = &kubernetesClientMock{
+		phase:      kubernetesPodRunning,
+		conditions: []kubernetesPodCondition{{Type: kubernetesConditionReady, Status: "True"}},
+	}
+	s.manager = &kubernetesManager{
+		client: s.client,
+	}
+	s.NoError(s.manager.Configure(s.ctx, &evergreen.Settings{}))
+
+	s.distro = distro.Distro{
+		Id:       "pod_distro",
+		Provider: evergreen.ProviderNameKubernetes,
+		ProviderSettings: &map[string]interface{}{
+			"image":         "evergreen/ubuntu-sshd",
+			"namespace":     "tasks",
+			"cpu":           "2",
+			"memory":        "4Gi",
+			"node_selector": map[string]interface{}{"pool": "tasks"},
+		},
+	}
+}
+
+func (s *KubernetesSuite) TearDownTest() {
+	s.cancel()
+	s.NoError(db.ClearCollections(host.Collection))
+}
+
+func (s *KubernetesSuite) spawn() *host.Host {
+	h := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	h, err := s.manager.SpawnHost(s.ctx, h)
+	s.Require().NoError(err)
+	s.Require().NotNil(h)
+	return h
+}
+
+func (s *KubernetesSuite) TestValidateSettings() {
+	s.NoError((&kubernetesSettings{Image: "image"}).Validate())
+	s.NoError((&kubernetesSettings{Image: "image", SSHPort: 2222}).Validate())
+	s.Error((&kubernetesSettings{}).Validate())
+	s.Error((&kubernetesSettings{Image: "image", SSHPort: -1}).Validate())
+	s.Error((&kubernetesSettings{Image: "image", SSHPort: 70000}).Validate())
+}
+
+func (s *KubernetesSuite) TestConfigureAPICall() {
+	s.NoError(s.manager.Configure(s.ctx, &evergreen.Settings{}))
+	s.Equal(kubernetesDefaultNamespace, s.client.GetNamespace())
+
+	s.client.failInit = true
+	s.Error(s.manager.Configure(s.ctx, &evergreen.Settings{}))
+}
+
+func (s *KubernetesSuite) TestSpawnHostCreatesPod() {
+	h := s.spawn()
+
+	pod, err := s.client.GetPod(s.ctx, "tasks", h.Id)
+	s.Require().NoError(err)
+	s.Equal("evergreen/ubuntu-sshd", pod.Spec.Containers[0].Image)
+	s.Equal(kubernetesDefaultSSHPort, pod.Spec.Containers[0].Ports[0].ContainerPort)
+	s.Equal("2", pod.Spec.Containers[0].Resources.Limits["cpu"])
+	s.Equal("4Gi", pod.Spec.Containers[0].Resources.Requests["memory"])
+	s.Equal(map[string]string{"pool": "tasks"}, pod.Spec.NodeSelector)
+	s.Equal("true", pod.Metadata.Labels[kubernetesManagedLabel])
+	s.Equal("pod_distro", pod.Metadata.Labels[kubernetesDistroLabel])
+	s.Equal(h.Id, pod.Metadata.Labels[kubernetesHostLabel])
+}
+
+func (s *KubernetesSuite) TestSpawnHostRequiresKubernetesDistro() {
+	s.distro.Provider = evergreen.ProviderNameDocker
+	h := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	_, err := s.manager.SpawnHost(s.ctx, h)
+	s.Error(err)
+
+	s.distro.Provider = evergreen.ProviderNameKubernetes
+	s.distro.ProviderSettings = &map[string]interface{}{}
+	h = NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	_, err = s.manager.SpawnHost(s.ctx, h)
+	s.Error(err)
+}
+
+func (s *KubernetesSuite) TestSpawnHostFailAPICall() {
+	s.client.failCreate = true
+	h := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	s.NoError(h.Insert())
+
+	_, err := s.manager.SpawnHost(s.ctx, h)
+	s.Error(err)
+
+	dbHost, err := host.FindOne(host.ById(h.Id))
+	s.NoError(err)
+	s.Nil(dbHost)
+}
+
+func (s *KubernetesSuite) TestGetInstanceStatus() {
+	h := s.spawn()
+
+	status, err := s.manager.GetInstanceStatus(s.ctx, h)
+	s.NoError(err)
+	s.Equal(StatusRunning, status)
+
+	active, err := s.manager.IsUp(s.ctx, h)
+	s.NoError(err)
+	s.True(active)
+
+	s.NoError(s.client.DeletePod(s.ctx, "tasks", h.Id))
+	status, err = s.manager.GetInstanceStatus(s.ctx, h)
+	s.NoError(err)
+	s.Equal(StatusTerminated, status)
+
+	s.client.failGet = true
+	_, err = s.manager.GetInstanceStatus(s.ctx, h)
+	s.Error(err)
+	active, err = s.manager.IsUp(s.ctx, h)
+	s.Error(err)
+	s.False(active)
+}
+
+func (s *KubernetesSuite) TestGetInstanceStatuses() {
+	hosts := []host.Host{*s.spawn(), *s.spawn(), *s.spawn()}
+	s.NoError(s.client.DeletePod(s.ctx, "tasks", hosts[1].Id))
+
+	statuses, err := s.manager.GetInstanceStatuses(s.ctx, hosts)
+	s.NoError(err)
+	s.Equal([]CloudStatus{StatusRunning, StatusTerminated, StatusRunning}, statuses)
+
+	s.client.failList = true
+	_, err = s.manager.GetInstanceStatuses(s.ctx, hosts)
+	s.Error(err)
+}
+
+func (s *KubernetesSuite) TestGetDNSName() {
+	h := s.spawn()
+
+	name, err := s.manager.GetDNSName(s.ctx, h)
+	s.NoError(err)
+	s.Equal("10.0.0.1", name)
+
+	s.client.failGet = true
+	_, err = s.manager.GetDNSName(s.ctx, h)
+	s.Error(err)
+}
+
+func (s *KubernetesSuite) TestTerminateInstance() {
+	hostA := s.spawn()
+	_, err := hostA.Upsert()
+	s.NoError(err)
+	hostB := s.spawn()
+	_, err = hostB.Upsert()
+	s.NoError(err)
+
+	s.NoError(s.manager.TerminateInstance(s.ctx, hostA, evergreen.User))
+	_, err = s.client.GetPod(s.ctx, "tasks", hostA.Id)
+	s.Equal(errKubernetesPodNotFound, err)
+
+	dbHost, err := host.FindOne(host.ById(hostA.Id))
+	s.NoError(err)
+	s.Equal(evergreen.HostTerminated, dbHost.Status)
+	s.Error(s.manager.TerminateInstance(s.ctx, dbHost, evergreen.User))
+
+	s.client.failDelete = true
+	s.Error(s.manager.TerminateInstance(s.ctx, hostB, evergreen.User))
+}
+
+func (s *KubernetesSuite) TestGetSSHOptions() {
+	h := &host.Host{Distro: s.distro}
+	h.Distro.SSHOptions = []string{"Option"}
+
+	opts, err := s.manager.GetSSHOptions(h, "")
+	s.Error(err)
+	s.Empty(opts)
+
+	opts, err = s.manager.GetSSHOptions(h, "key")
+	s.NoError(err)
+	s.Equal([]string{"-i", "key", "-o", "Option"}, opts)
+
+	(*h.Distro.ProviderSettings)["ssh_port"] = 2222
+	opts, err = s.manager.GetSSHOptions(h, "key")
+	s.NoError(err)
+	s.Equal([]string{"-i", "key", "-p", "2222", "-o", "Option"}, opts)
+}
+
+func TestKubernetesToEvgStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	pod := &kubernetesPod{}
+	assert.Equal(StatusUnknown, kubernetesToEvgStatus(pod))
+
+	pod.Status.Phase = kubernetesPodPending
+	assert.Equal(StatusPending, kubernetesToEvgStatus(pod))
+	pod.Status.Conditions = []kubernetesPodCondition{{Type: kubernetesConditionScheduled, Status: "True"}}
+	assert.Equal(StatusInitializing, kubernetesToEvgStatus(pod))
+
+	pod.Status.Phase = kubernetesPodRunning
+	assert.Equal(StatusInitializing, kubernetesToEvgStatus(pod))
+	pod.Status.Conditions = append(pod.Status.Conditions, kubernetesPodCondition{Type: kubernetesConditionReady, Status: "True"})
+	assert.Equal(StatusRunning, kubernetesToEvgStatus(pod))
+
+	pod.Status.Phase = kubernetesPodSucceeded
+	assert.Equal(StatusTerminated, kubernetesToEvgStatus(pod))
+	pod.Status.Phase = kubernetesPodFailed
+	assert.Equal(StatusFailed, kubernetesToEvgStatus(pod))
+
+	deleted := "2018-01-01T00:00:00Z"
+	pod.Status.Phase = kubernetesPodRunning
+	pod.Metadata.DeletionTimestamp = &deleted
+	assert.Equal(StatusTerminated, kubernetesToEvgStatus(pod))
+}
+
+func TestKubernetesLabelValue(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("ubuntu1604-large", kubernetesLabelValue("ubuntu1604-large"))
+	assert.Equal("rhel_7.0_s390x", kubernetesLabelValue("rhel 7.0/s390x"))
+	assert.Equal("a", kubernetesLabelValue("_a-"))
+	assert.Len(kubernetesLabelValue(strings.Repeat("a", 100)), 63)
+}
+
+func TestKubernetesClient(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pods := map[string]kubernetesPod{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		const prefix = "/api/v1/namespaces/tasks/pods"
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+
+		switch {
+		case r.Method == http.MethodPost && name == "":
+			pod := kubernetesPod{}
+			if err := json.NewDecoder(r.Body).Decode(&pod); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if _, ok := pods[pod.Metadata.Name]; ok {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"message": "pod already exists"}`))
+				return
+			}
+			pod.Status.Phase = kubernetesPodPending
+			pods[pod.Metadata.Name] = pod
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(pod)
+		case r.Method == http.MethodGet && name == "":
+			list := kubernetesPodList{}
+			for _, pod := range pods {
+				if r.URL.Query().Get("labelSelector") == kubernetesManagedLabel+"=true" {
+					list.Items = append(list.Items, pod)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(list)
+		case r.Method == http.MethodGet:
+			pod, ok := pods[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(pod)
+		case r.Method == http.MethodDelete:
+			if _, ok := pods[name]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(pods, name)
+			_, _ = w.Write([]byte("{}"))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	client := &kubernetesClientImpl{}
+	require.NoError(client.Init(evergreen.KubernetesConfig{APIServer: server.URL, Token: "token", Namespace: "tasks"}))
+	assert.Equal("tasks", client.GetNamespace())
+	assert.Error((&kubernetesClientImpl{}).Init(evergreen.KubernetesConfig{APIServer: server.URL, CACert: "not a cert"}))
+
+	h := &host.Host{Id: "pod", Distro: distro.Distro{Id: "distro"}}
+	created, err := client.CreatePod(ctx, makeKubernetesPod(h, &kubernetesSettings{Image: "image", Namespace: "tasks"}))
+	require.NoError(err)
+	assert.Equal("pod", created.Metadata.Name)
+	assert.Equal("v1", created.APIVersion)
+	assert.Equal("Pod", created.Kind)
+
+	_, err = client.CreatePod(ctx, makeKubernetesPod(h, &kubernetesSettings{Image: "image", Namespace: "tasks"}))
+	require.Error(err)
+	assert.Contains(err.Error(), "pod already exists")
+
+	pod, err := client.GetPod(ctx, "tasks", "pod")
+	require.NoError(err)
+	assert.Equal(kubernetesPodPending, pod.Status.Phase)
+	assert.Equal("image", pod.Spec.Containers[0].Image)
+
+	list, err := client.ListPods(ctx, "", map[string]string{kubernetesManagedLabel: "true"})
+	require.NoError(err)
+	assert.Len(list, 1)
+
+	assert.NoError(client.DeletePod(ctx, "tasks", "pod"))
+	assert.NoError(client.DeletePod(ctx, "tasks", "pod"))
+	_, err = client.GetPod(ctx, "tasks", "pod")
+	assert.Equal(errKubernetesPodNotFound, errors.Cause(err))
+
+	unauthorized := &kubernetesClientImpl{}
+	require.NoError(unauthorized.Init(evergreen.KubernetesConfig{APIServer: server.URL, Namespace: "tasks"}))
+	_, err = unauthorized.GetPod(ctx, "tasks", "pod")
+	assert.Error(err)
+}
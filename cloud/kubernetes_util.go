@@ -0,0 +1,147 @@
+// +build go1.7
+
+package cloud
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The types below are the subset of the Kubernetes core/v1 API objects
+// that evergreen reads and writes when managing pods.
+
+type kubernetesObjectMeta struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	DeletionTimestamp *string           `json:"deletionTimestamp,omitempty"`
+}
+
+type kubernetesPod struct {
+	APIVersion string               `json:"apiVersion,omitempty"`
+	Kind       string               `json:"kind,omitempty"`
+	Metadata   kubernetesObjectMeta `json:"metadata"`
+	Spec       kubernetesPodSpec    `json:"spec"`
+	Status     kubernetesPodStatus  `json:"status,omitempty"`
+}
+
+type kubernetesPodList struct {
+	Items []kubernetesPod `json:"items"`
+}
+
+type kubernetesPodSpec struct {
+	Containers         []kubernetesContainer      `json:"containers"`
+	NodeSelector       map[string]string          `json:"nodeSelector,omitempty"`
+	ServiceAccountName string                     `json:"serviceAccountName,omitempty"`
+	ImagePullSecrets   []kubernetesLocalObjectRef `json:"imagePullSecrets,omitempty"`
+	RestartPolicy      string                     `json:"restartPolicy,omitempty"`
+}
+
+type kubernetesLocalObjectRef struct {
+	Name string `json:"name"`
+}
+
+type kubernetesContainer struct {
+	Name      string                        `json:"name"`
+	Image     string                        `json:"image"`
+	Command   []string                      `json:"command,omitempty"`
+	Ports     []kubernetesContainerPort     `json:"ports,omitempty"`
+	Resources kubernetesResourceRequirement `json:"resources,omitempty"`
+}
+
+type kubernetesContainerPort struct {
+	Name          string `json:"name,omitempty"`
+	ContainerPort int    `json:"containerPort"`
+}
+
+type kubernetesResourceRequirement struct {
+	Limits   map[string]string `json:"limits,omitempty"`
+	Requests map[string]string `json:"requests,omitempty"`
+}
+
+type kubernetesPodStatus struct {
+	Phase      string                   `json:"phase,omitempty"`
+	PodIP      string                   `json:"podIP,omitempty"`
+	HostIP     string                   `json:"hostIP,omitempty"`
+	Reason     string                   `json:"reason,omitempty"`
+	Conditions []kubernetesPodCondition `json:"conditions,omitempty"`
+}
+
+type kubernetesPodCondition struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+const (
+	kubernetesPodPending   = "Pending"
+	kubernetesPodRunning   = "Running"
+	kubernetesPodSucceeded = "Succeeded"
+	kubernetesPodFailed    = "Failed"
+
+	kubernetesConditionScheduled = "PodScheduled"
+	kubernetesConditionReady     = "Ready"
+
+	// kubernetesManagedLabel is set on every pod evergreen creates, so
+	// that they can be listed together.
+	kubernetesManagedLabel = "evergreen-managed"
+	kubernetesDistroLabel  = "evergreen-distro"
+	kubernetesHostLabel    = "evergreen-host"
+
+	kubernetesContainerName  = "evergreen"
+	kubernetesDefaultSSHPort = 22
+)
+
+// kubernetesToEvgStatus maps the phase of a pod to a CloudStatus. A
+// pending pod that has not been scheduled to a node is pending, and one
+// that has is initializing, since its containers are being created. A
+// running pod is not considered running until it is ready. A pod that
+// is being deleted is terminated.
+func kubernetesToEvgStatus(pod *kubernetesPod) CloudStatus {
+	if pod.Metadata.DeletionTimestamp != nil {
+		return StatusTerminated
+	}
+
+	switch pod.Status.Phase {
+	case kubernetesPodPending:
+		if pod.hasCondition(kubernetesConditionScheduled) {
+			return StatusInitializing
+		}
+		return StatusPending
+	case kubernetesPodRunning:
+		if pod.hasCondition(kubernetesConditionReady) {
+			return StatusRunning
+		}
+		return StatusInitializing
+	case kubernetesPodSucceeded:
+		return StatusTerminated
+	case kubernetesPodFailed:
+		return StatusFailed
+	default:
+		return StatusUnknown
+	}
+}
+
+func (p *kubernetesPod) hasCondition(condition string) bool {
+	for _, c := range p.Status.Conditions {
+		if c.Type == condition {
+			return c.Status == "True"
+		}
+	}
+	return false
+}
+
+var kubernetesInvalidLabelChars = regexp.MustCompile("[^A-Za-z0-9_.-]+")
+
+// kubernetesLabelValue makes a string into a valid label value, which
+// has at most 63 alphanumeric, '-', '_' or '.' characters, and begins
+// and ends with an alphanumeric character.
+func kubernetesLabelValue(value string) string {
+	const maxLength = 63
+
+	value = kubernetesInvalidLabelChars.ReplaceAllString(value, "_")
+	if len(value) > maxLength {
+		value = value[:maxLength]
+	}
+
+	return strings.Trim(value, "-_.")
+}
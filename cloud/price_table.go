@@ -0,0 +1,73 @@
+package cloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// priceTableCostCalculator implements CostCalculator for any provider
+// using the hourly prices in the admin settings' price table.
+type priceTableCostCalculator struct {
+	prices evergreen.PriceTableConfig
+}
+
+// GetCostCalculator returns the manager's CostCalculator, or, if the
+// provider cannot compute costs, one that uses the price table. It
+// returns false if neither can compute costs.
+func GetCostCalculator(m Manager, settings *evergreen.Settings) (CostCalculator, bool) {
+	if calc, ok := m.(CostCalculator); ok {
+		return calc, true
+	}
+
+	if settings == nil {
+		return nil, false
+	}
+	prices := settings.PriceTable
+	if len(prices.Distros) == 0 && len(prices.InstanceTypes) == 0 && len(prices.ContainerPools) == 0 {
+		return nil, false
+	}
+
+	return &priceTableCostCalculator{prices: prices}, true
+}
+
+// CostForDuration returns the hourly price of the host multiplied by
+// the duration. Hosts without a price cost nothing.
+func (c *priceTableCostCalculator) CostForDuration(_ context.Context, h *host.Host, start, end time.Time) (float64, error) {
+	if end.Before(start) || util.IsZeroTime(start) || util.IsZeroTime(end) {
+		return 0, errors.New("task timing data is malformed")
+	}
+
+	price, ok := c.prices.GetHourlyPrice(h.Distro.Id, h.InstanceType, containerPoolForHost(h))
+	if !ok {
+		return 0, nil
+	}
+
+	return price * end.Sub(start).Hours(), nil
+}
+
+// containerPoolForHost returns the ID of the container pool of a
+// parent host, or of the pool that a container's distro runs in.
+func containerPoolForHost(h *host.Host) string {
+	if h.ContainerPoolSettings != nil {
+		return h.ContainerPoolSettings.Id
+	}
+
+	if h.ParentID == "" || h.Distro.ProviderSettings == nil {
+		return ""
+	}
+
+	settings := struct {
+		PoolID string `mapstructure:"pool_id"`
+	}{}
+	if err := mapstructure.WeakDecode(h.Distro.ProviderSettings, &settings); err != nil {
+		return ""
+	}
+
+	return settings.PoolID
+}
@@ -0,0 +1,79 @@
+package cloud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCostCalculator(t *testing.T) {
+	assert := assert.New(t)
+
+	settings := &evergreen.Settings{}
+	_, ok := GetCostCalculator(&staticManager{}, settings)
+	assert.False(ok)
+
+	settings.PriceTable.Distros = []evergreen.HourlyPrice{{Id: "d1", Price: 1}}
+	calc, ok := GetCostCalculator(&staticManager{}, settings)
+	assert.True(ok)
+	assert.IsType(&priceTableCostCalculator{}, calc)
+
+	ec2 := NewEC2Manager(&EC2ManagerOptions{client: &awsClientMock{}, provider: onDemandProvider})
+	calc, ok = GetCostCalculator(ec2, settings)
+	assert.True(ok)
+	assert.Equal(ec2, calc)
+}
+
+func TestPriceTableCostForDuration(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calc := &priceTableCostCalculator{prices: evergreen.PriceTableConfig{
+		Distros:        []evergreen.HourlyPrice{{Id: "static", Price: 2}},
+		InstanceTypes:  []evergreen.HourlyPrice{{Id: "large", Price: 1}},
+		ContainerPools: []evergreen.HourlyPrice{{Id: "pool", Price: 0.5}},
+	}}
+	start := time.Now()
+	end := start.Add(90 * time.Minute)
+
+	h := &host.Host{Distro: distro.Distro{Id: "static"}, InstanceType: "large"}
+	cost, err := calc.CostForDuration(ctx, h, start, end)
+	assert.NoError(err)
+	assert.Equal(3.0, cost)
+
+	_, err = calc.CostForDuration(ctx, h, end, start)
+	assert.Error(err)
+	_, err = calc.CostForDuration(ctx, h, time.Time{}, end)
+	assert.Error(err)
+
+	h.Distro.Id = "other"
+	cost, err = calc.CostForDuration(ctx, h, start, end)
+	assert.NoError(err)
+	assert.Equal(1.5, cost)
+
+	container := &host.Host{
+		ParentID: "parent",
+		Distro: distro.Distro{
+			Id:               "container",
+			ProviderSettings: &map[string]interface{}{"image_name": "image", "pool_id": "pool"},
+		},
+	}
+	cost, err = calc.CostForDuration(ctx, container, start, end)
+	assert.NoError(err)
+	assert.Equal(0.75, cost)
+
+	parent := &host.Host{ContainerPoolSettings: &evergreen.ContainerPool{Id: "pool"}}
+	cost, err = calc.CostForDuration(ctx, parent, start, end)
+	assert.NoError(err)
+	assert.Equal(0.75, cost)
+
+	cost, err = calc.CostForDuration(ctx, &host.Host{}, start, end)
+	assert.NoError(err)
+	assert.Zero(cost)
+}
@@ -0,0 +1,325 @@
+package command
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/subprocess"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/level"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// External command plugins are executables, discovered on the host at
+// agent startup, which implement a command out of process. The agent
+// communicates with the plugin over a simple wire protocol: it writes
+// a single externalCommandRequest document as JSON to the plugin's
+// standard input, and reads newline delimited externalCommandMessage
+// documents from the plugin's standard output. Output that is not a
+// JSON document is logged to the task log as is, and standard error
+// is logged to the task log at the error level.
+//
+// Plugins are invoked once with the "parse_params" operation when the
+// command is rendered, and once with the "execute" operation when the
+// command runs. A plugin exiting with a non-zero status, or sending
+// an "error" message, fails the operation.
+
+const (
+	externalCommandOperationParseParams = "parse_params"
+	externalCommandOperationExecute     = "execute"
+
+	externalCommandMessageLog        = "log"
+	externalCommandMessageExpansions = "expansions"
+	externalCommandMessageError      = "error"
+
+	externalCommandLogChannelTask      = "task"
+	externalCommandLogChannelExecution = "execution"
+	externalCommandLogChannelSystem    = "system"
+
+	// externalCommandParseParamsTimeout bounds how long a plugin may
+	// take to validate its parameters, since rendering a command is not
+	// otherwise subject to the task's timeouts.
+	externalCommandParseParamsTimeout = time.Minute
+)
+
+type externalCommandRequest struct {
+	Operation    string                 `json:"operation"`
+	Command      string                 `json:"command"`
+	Params       map[string]interface{} `json:"params"`
+	Expansions   map[string]string      `json:"expansions,omitempty"`
+	WorkDir      string                 `json:"work_dir,omitempty"`
+	TaskID       string                 `json:"task_id,omitempty"`
+	Execution    int                    `json:"execution,omitempty"`
+	BuildVariant string                 `json:"build_variant,omitempty"`
+	Project      string                 `json:"project,omitempty"`
+}
+
+type externalCommandMessage struct {
+	Type       string            `json:"type"`
+	Channel    string            `json:"channel,omitempty"`
+	Level      string            `json:"level,omitempty"`
+	Message    string            `json:"message,omitempty"`
+	Expansions map[string]string `json:"expansions,omitempty"`
+}
+
+// externalCommand implements the Command interface by delegating to
+// an external plugin executable. If the path is not set, the plugin
+// was declared by the project but not found on the host, which
+// causes Execute to fail.
+type externalCommand struct {
+	name   string
+	path   string
+	params map[string]interface{}
+
+	base
+}
+
+func externalCommandFactory(name, path string) CommandFactory {
+	return func() Command { return &externalCommand{name: name, path: path} }
+}
+
+func (c *externalCommand) Name() string { return c.name }
+
+// ParseParams records the command's parameters and, if the plugin is
+// available on this host, asks the plugin to validate them.
+func (c *externalCommand) ParseParams(params map[string]interface{}) error {
+	c.params = params
+	if c.params == nil {
+		c.params = map[string]interface{}{}
+	}
+
+	if c.path == "" {
+		return nil
+	}
+
+	req := externalCommandRequest{
+		Operation: externalCommandOperationParseParams,
+		Command:   c.name,
+		Params:    c.params,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), externalCommandParseParamsTimeout)
+	defer cancel()
+
+	msgs, err := c.invoke(ctx, req, nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing '%s' params", c.name)
+	}
+
+	for _, m := range msgs {
+		if m.Type == externalCommandMessageError {
+			return errors.Errorf("error parsing '%s' params: %s", c.name, m.Message)
+		}
+	}
+
+	return nil
+}
+
+// Execute runs the plugin executable, forwarding its logs to the task
+// logger and applying any expansion updates that the plugin reports.
+func (c *externalCommand) Execute(ctx context.Context,
+	_ client.Communicator, logger client.LoggerProducer, conf *model.TaskConfig) error {
+
+	if c.path == "" {
+		return errors.Errorf("command plugin '%s' is not installed on this host", c.name)
+	}
+
+	req := externalCommandRequest{
+		Operation: externalCommandOperationExecute,
+		Command:   c.name,
+		Params:    c.params,
+		WorkDir:   conf.WorkDir,
+	}
+	if conf.Expansions != nil {
+		req.Expansions = conf.Expansions.Map()
+	}
+	if conf.Task != nil {
+		req.TaskID = conf.Task.Id
+		req.Execution = conf.Task.Execution
+		req.BuildVariant = conf.Task.BuildVariant
+		req.Project = conf.Task.Project
+	}
+
+	logger.Execution().Infof("Executing command plugin '%s' from '%s'", c.name, c.path)
+
+	errOut := logger.TaskWriter(level.Error)
+	defer errOut.Close()
+
+	catcher := grip.NewBasicCatcher()
+	_, err := c.invoke(ctx, req, func(m externalCommandMessage) {
+		switch m.Type {
+		case externalCommandMessageLog:
+			logExternalCommandMessage(logger, m)
+		case externalCommandMessageExpansions:
+			if conf.Expansions != nil {
+				conf.Expansions.Update(m.Expansions)
+			}
+		case externalCommandMessageError:
+			catcher.Add(errors.New(m.Message))
+		default:
+			logger.Task().Warningf("command plugin '%s' sent unknown message type '%s'", c.name, m.Type)
+		}
+	}, errOut)
+	catcher.Add(err)
+
+	return errors.Wrapf(catcher.Resolve(), "command plugin '%s' failed", c.name)
+}
+
+// invoke runs the plugin with the given request. If handler is nil,
+// all messages are collected and returned, otherwise each message is
+// passed to the handler as it arrives. If stderr is nil, the plugin's
+// standard error is included in the error when the plugin fails.
+func (c *externalCommand) invoke(ctx context.Context, req externalCommandRequest,
+	handler func(externalCommandMessage), stderr io.Writer) ([]externalCommandMessage, error) {
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem encoding plugin request")
+	}
+
+	cmd := exec.CommandContext(ctx, c.path)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Dir = req.WorkDir
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("%s=%s", subprocess.MarkerTaskID, req.TaskID),
+		fmt.Sprintf("%s=%d", subprocess.MarkerAgentPID, os.Getpid()))
+
+	errBuf := &bytes.Buffer{}
+	if stderr != nil {
+		cmd.Stderr = stderr
+	} else {
+		cmd.Stderr = errBuf
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err = cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "problem starting plugin '%s'", c.path)
+	}
+
+	var out []externalCommandMessage
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		m := externalCommandMessage{}
+		if err = json.Unmarshal(line, &m); err != nil || m.Type == "" {
+			m = externalCommandMessage{Type: externalCommandMessageLog, Message: string(line)}
+		}
+
+		if handler != nil {
+			handler(m)
+		} else {
+			out = append(out, m)
+		}
+	}
+
+	catcher := grip.NewBasicCatcher()
+	catcher.Add(scanner.Err())
+	if err = cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(errBuf.String()); msg != "" {
+			err = errors.Wrap(err, msg)
+		}
+		catcher.Add(err)
+	}
+
+	return out, catcher.Resolve()
+}
+
+func logExternalCommandMessage(logger client.LoggerProducer, m externalCommandMessage) {
+	var journaler grip.Journaler
+	switch m.Channel {
+	case externalCommandLogChannelExecution:
+		journaler = logger.Execution()
+	case externalCommandLogChannelSystem:
+		journaler = logger.System()
+	default:
+		journaler = logger.Task()
+	}
+
+	priority := level.FromString(m.Level)
+	if priority == level.Invalid {
+		priority = level.Info
+	}
+
+	journaler.Log(priority, message.NewString(m.Message))
+}
+
+// RegisterExternalCommands registers every executable in the given
+// directory as a command plugin, using the name of the file, without
+// any extension on Windows, as the name of the command. Plugins
+// cannot replace built-in commands.
+func RegisterExternalCommands(dir string) error {
+	if dir == "" {
+		return nil
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Wrapf(err, "problem reading command plugin directory '%s'", dir)
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for _, info := range files {
+		name, ok := externalCommandName(info)
+		if !ok {
+			continue
+		}
+
+		path, err := filepath.Abs(filepath.Join(dir, info.Name()))
+		if err != nil {
+			catcher.Add(errors.WithStack(err))
+			continue
+		}
+
+		catcher.Add(RegisterCommand(name, externalCommandFactory(name, path)))
+	}
+
+	return catcher.Resolve()
+}
+
+func externalCommandName(info os.FileInfo) (string, bool) {
+	if !info.Mode().IsRegular() {
+		return "", false
+	}
+
+	name := info.Name()
+	if strings.HasPrefix(name, ".") {
+		return "", false
+	}
+
+	if runtime.GOOS == "windows" {
+		ext := filepath.Ext(name)
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		return strings.TrimSuffix(name, ext), true
+	}
+
+	if info.Mode().Perm()&0111 == 0 {
+		return "", false
+	}
+
+	return name, true
+}
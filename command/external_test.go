@@ -0,0 +1,123 @@
+package command
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/suite"
+)
+
+const externalCommandTestPlugin = `#!/bin/sh
+request=$(cat)
+case "$request" in
+  *'"operation":"parse_params"'*)
+    case "$request" in
+      *'"fail":true'*) echo '{"type":"error","message":"fail is not allowed"}' ;;
+    esac
+    ;;
+  *)
+    echo "plain output"
+    echo '{"type":"log","channel":"execution","level":"warning","message":"from plugin"}'
+    echo '{"type":"expansions","expansions":{"plugin_ran":"true"}}'
+    case "$request" in
+      *'"exit":true'*) echo "plugin failed" >&2; exit 1 ;;
+    esac
+    ;;
+esac
+`
+
+type externalCommandSuite struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	dir    string
+	conf   *model.TaskConfig
+	comm   client.Communicator
+	logger client.LoggerProducer
+
+	suite.Suite
+}
+
+func TestExternalCommand(t *testing.T) {
+	suite.Run(t, new(externalCommandSuite))
+}
+
+func (s *externalCommandSuite) SetupTest() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("plugin fixture requires a posix shell")
+	}
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	var err error
+	s.dir, err = ioutil.TempDir("", "command-plugins")
+	s.Require().NoError(err)
+	s.Require().NoError(ioutil.WriteFile(filepath.Join(s.dir, "test.plugin"), []byte(externalCommandTestPlugin), 0755))
+	s.Require().NoError(ioutil.WriteFile(filepath.Join(s.dir, "README"), []byte("not a plugin"), 0644))
+
+	s.comm = client.NewMock("http://localhost.com")
+	s.conf = &model.TaskConfig{Expansions: &util.Expansions{}, Task: &task.Task{}, Project: &model.Project{}, WorkDir: s.dir}
+	s.logger = s.comm.GetLoggerProducer(s.ctx, client.TaskData{ID: s.conf.Task.Id, Secret: s.conf.Task.Secret})
+}
+
+func (s *externalCommandSuite) TearDownTest() {
+	s.cancel()
+	s.NoError(os.RemoveAll(s.dir))
+}
+
+func (s *externalCommandSuite) TestDiscoveryRegistersExecutables() {
+	r := newCommandRegistry()
+	files, err := ioutil.ReadDir(s.dir)
+	s.Require().NoError(err)
+
+	for _, info := range files {
+		name, ok := externalCommandName(info)
+		if ok {
+			s.NoError(r.registerCommand(name, externalCommandFactory(name, filepath.Join(s.dir, info.Name()))))
+		}
+	}
+
+	s.Equal([]string{"test.plugin"}, r.registeredCommandNames())
+	s.Error(RegisterExternalCommands(filepath.Join(s.dir, "does-not-exist")))
+	s.NoError(RegisterExternalCommands(""))
+}
+
+func (s *externalCommandSuite) TestParseParamsIsDelegatedToPlugin() {
+	cmd := externalCommandFactory("test.plugin", filepath.Join(s.dir, "test.plugin"))()
+	s.Equal("test.plugin", cmd.Name())
+	s.NoError(cmd.ParseParams(map[string]interface{}{"fail": false}))
+	s.Error(cmd.ParseParams(map[string]interface{}{"fail": true}))
+}
+
+func (s *externalCommandSuite) TestExecuteUpdatesExpansions() {
+	cmd := externalCommandFactory("test.plugin", filepath.Join(s.dir, "test.plugin"))()
+	s.NoError(cmd.ParseParams(nil))
+	s.NoError(cmd.Execute(s.ctx, s.comm, s.logger, s.conf))
+	s.Equal("true", s.conf.Expansions.Get("plugin_ran"))
+}
+
+func (s *externalCommandSuite) TestExecuteFailsWhenPluginExitsNonZero() {
+	cmd := externalCommandFactory("test.plugin", filepath.Join(s.dir, "test.plugin"))()
+	s.NoError(cmd.ParseParams(map[string]interface{}{"exit": true}))
+	s.Error(cmd.Execute(s.ctx, s.comm, s.logger, s.conf))
+}
+
+func (s *externalCommandSuite) TestDeclaredPluginsRenderButFailWhenMissing() {
+	conf := model.PluginCommandConf{Command: "missing.plugin"}
+
+	_, err := Render(conf, nil)
+	s.Error(err)
+
+	cmds, err := RenderWithPlugins(conf, nil, []string{"missing.plugin"})
+	s.Require().NoError(err)
+	s.Require().Len(cmds, 1)
+	s.Equal("missing.plugin", cmds[0].Name())
+	s.Error(cmds[0].Execute(s.ctx, s.comm, s.logger, s.conf))
+}
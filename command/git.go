@@ -149,6 +149,17 @@ func (c *gitFetchProject) buildCloneCommand(conf *model.TaskConfig) ([]string, e
 			fmt.Sprintf("git reset --hard %s", conf.GithubPatchData.HeadHash),
 		}...)
 
+	} else if conf.GitlabPatchData.MRNumber != 0 {
+		branchName := fmt.Sprintf("evg-mr-test-%s", util.RandomString())
+
+		gitCommands = append(gitCommands, []string{
+			// GitLab creates a ref called refs/merge-requests/[mr number]/head
+			// in the target project, even for merge requests from forks
+			fmt.Sprintf(`git fetch origin "merge-requests/%d/head:%s"`, conf.GitlabPatchData.MRNumber, branchName),
+			fmt.Sprintf(`git checkout "%s"`, branchName),
+			fmt.Sprintf("git reset --hard %s", conf.GitlabPatchData.HeadHash),
+		}...)
+
 	} else {
 		gitCommands = append(gitCommands,
 			fmt.Sprintf("git reset --hard %s", conf.Task.Revision))
@@ -8,6 +8,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 )
@@ -66,7 +67,16 @@ func GetCommandFactory(name string) (CommandFactory, bool) {
 }
 
 func Render(c model.PluginCommandConf, fns map[string]*model.YAMLCommandSet) ([]Command, error) {
-	return evgRegistry.renderCommands(c, fns)
+	return evgRegistry.renderCommands(c, fns, nil)
+}
+
+// RenderWithPlugins renders commands like Render, but also accepts
+// the names of command plugins declared by the project. Declared
+// plugins that were not registered on this host render as commands
+// that fail when executed, which allows the server to validate
+// projects that use plugins it does not have itself.
+func RenderWithPlugins(c model.PluginCommandConf, fns map[string]*model.YAMLCommandSet, plugins []string) ([]Command, error) {
+	return evgRegistry.renderCommands(c, fns, plugins)
 }
 
 func RegisteredCommandNames() []string { return evgRegistry.registeredCommandNames() }
@@ -127,7 +137,7 @@ func (r *commandRegistry) getCommandFactory(name string) (CommandFactory, bool)
 }
 
 func (r *commandRegistry) renderCommands(commandInfo model.PluginCommandConf,
-	funcs map[string]*model.YAMLCommandSet) ([]Command, error) {
+	funcs map[string]*model.YAMLCommandSet, plugins []string) ([]Command, error) {
 
 	var (
 		parsed []model.PluginCommandConf
@@ -170,6 +180,9 @@ func (r *commandRegistry) renderCommands(commandInfo model.PluginCommandConf,
 
 	for _, c := range parsed {
 		factory, ok := r.getCommandFactory(c.Command)
+		if !ok && util.StringSliceContains(plugins, c.Command) {
+			factory, ok = externalCommandFactory(c.Command, ""), true
+		}
 		if !ok {
 			errs = append(errs, fmt.Sprintf("command '%s' is not registered", c.Command))
 			continue
@@ -0,0 +1,116 @@
+package evergreen
+
+import (
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// EventArchiveCollection archives old events into a collection
+	// alongside the event log they were removed from.
+	EventArchiveCollection = "collection"
+	// EventArchiveFile archives old events into gzipped JSON lines files.
+	EventArchiveFile = "file"
+
+	defaultEventArchiveBatchSize = 1000
+)
+
+// EventRetentionConfig holds settings for how long events are kept in the
+// event log, and where they're archived once they're older than that.
+type EventRetentionConfig struct {
+	// DefaultTTLDays is how many days the events of resource types without
+	// a policy of their own are kept. If it is 0, they are kept forever.
+	DefaultTTLDays int                    `bson:"default_ttl_days" json:"default_ttl_days" yaml:"default_ttl_days"`
+	Policies       []EventRetentionPolicy `bson:"policies" json:"policies" yaml:"policies"`
+	// Archive is either "collection" or "file", which archives events
+	// into files in ArchivePath.
+	Archive     string `bson:"archive" json:"archive" yaml:"archive"`
+	ArchivePath string `bson:"archive_path" json:"archive_path" yaml:"archive_path"`
+	BatchSize   int    `bson:"batch_size" json:"batch_size" yaml:"batch_size"`
+}
+
+// EventRetentionPolicy is how many days the events of a resource type are
+// kept. If TTLDays is 0, they are kept forever.
+type EventRetentionPolicy struct {
+	ResourceType string `bson:"resource_type" json:"resource_type" yaml:"resource_type"`
+	TTLDays      int    `bson:"ttl_days" json:"ttl_days" yaml:"ttl_days"`
+}
+
+func (c *EventRetentionConfig) SectionId() string { return "event_retention" }
+
+func (c *EventRetentionConfig) Get() error {
+	err := db.FindOneQ(ConfigCollection, db.Query(byId(c.SectionId())), c)
+	if err != nil && err.Error() == errNotFound {
+		*c = EventRetentionConfig{}
+		return nil
+	}
+	return errors.Wrapf(err, "error retrieving section %s", c.SectionId())
+}
+
+func (c *EventRetentionConfig) Set() error {
+	_, err := db.Upsert(ConfigCollection, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			"default_ttl_days": c.DefaultTTLDays,
+			"policies":         c.Policies,
+			"archive":          c.Archive,
+			"archive_path":     c.ArchivePath,
+			"batch_size":       c.BatchSize,
+		},
+	})
+	return errors.Wrapf(err, "error updating section %s", c.SectionId())
+}
+
+func (c *EventRetentionConfig) ValidateAndDefault() error {
+	catcher := grip.NewSimpleCatcher()
+	if c.Archive == "" {
+		c.Archive = EventArchiveCollection
+	}
+	switch c.Archive {
+	case EventArchiveCollection:
+	case EventArchiveFile:
+		if c.ArchivePath == "" {
+			catcher.Add(errors.New("must specify a path to archive events to files in"))
+		}
+	default:
+		catcher.Add(errors.Errorf("'%s' is not a valid event archive", c.Archive))
+	}
+	if c.DefaultTTLDays < 0 {
+		catcher.Add(errors.New("default event TTL must not be negative"))
+	}
+	if c.BatchSize < 0 {
+		catcher.Add(errors.New("event archive batch size must not be negative"))
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = defaultEventArchiveBatchSize
+	}
+
+	seen := map[string]bool{}
+	for _, policy := range c.Policies {
+		if policy.ResourceType == "" {
+			catcher.Add(errors.New("event retention policy must have a resource type"))
+		}
+		if seen[policy.ResourceType] {
+			catcher.Add(errors.Errorf("resource type '%s' has more than one event retention policy", policy.ResourceType))
+		}
+		seen[policy.ResourceType] = true
+		if policy.TTLDays < 0 {
+			catcher.Add(errors.Errorf("event TTL for resource type '%s' must not be negative", policy.ResourceType))
+		}
+	}
+
+	return catcher.Resolve()
+}
+
+// TTLDays returns how many days the events of the resource type are kept,
+// or 0 if they are kept forever.
+func (c *EventRetentionConfig) TTLDays(resourceType string) int {
+	for _, policy := range c.Policies {
+		if policy.ResourceType == resourceType {
+			return policy.TTLDays
+		}
+	}
+
+	return c.DefaultTTLDays
+}
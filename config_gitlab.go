@@ -0,0 +1,66 @@
+package evergreen
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const defaultGitlabURL = "https://gitlab.com"
+
+// GitlabConfig holds settings for testing GitLab merge requests.
+type GitlabConfig struct {
+	// URL is the base URL of the GitLab instance, e.g. https://gitlab.com
+	URL string `yaml:"url" bson:"url" json:"url"`
+	// Token is a personal or project access token with the api scope.
+	Token string `yaml:"token" bson:"token" json:"token"`
+	// WebhookSecret is the secret token that GitLab sends with merge
+	// request webhooks. Merge request testing is disabled if it's empty.
+	WebhookSecret string `yaml:"webhook_secret" bson:"webhook_secret" json:"webhook_secret"`
+}
+
+func (c *GitlabConfig) SectionId() string { return "gitlab" }
+
+func (c *GitlabConfig) Get() error {
+	err := db.FindOneQ(ConfigCollection, db.Query(byId(c.SectionId())), c)
+	if err != nil && err.Error() == errNotFound {
+		*c = GitlabConfig{}
+		return nil
+	}
+	return errors.Wrapf(err, "error retrieving section %s", c.SectionId())
+}
+
+func (c *GitlabConfig) Set() error {
+	_, err := db.Upsert(ConfigCollection, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			"url":            c.URL,
+			"token":          c.Token,
+			"webhook_secret": c.WebhookSecret,
+		},
+	})
+	return errors.Wrapf(err, "error updating section %s", c.SectionId())
+}
+
+func (c *GitlabConfig) ValidateAndDefault() error {
+	catcher := grip.NewSimpleCatcher()
+	if c.URL == "" {
+		c.URL = defaultGitlabURL
+	}
+	c.URL = strings.TrimRight(c.URL, "/")
+
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		catcher.Add(errors.Wrapf(err, "gitlab URL '%s' is invalid", c.URL))
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		catcher.Add(errors.Errorf("gitlab URL '%s' must be http or https", c.URL))
+	}
+	if c.WebhookSecret != "" && c.Token == "" {
+		catcher.Add(errors.New("must specify a gitlab token to test merge requests"))
+	}
+
+	return catcher.Resolve()
+}
@@ -0,0 +1,97 @@
+package evergreen
+
+import (
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// HourlyPrice is the price per hour of running a host, keyed by the ID
+// of a distro, an instance type or a container pool.
+type HourlyPrice struct {
+	Id    string  `bson:"id" json:"id" yaml:"id"`
+	Price float64 `bson:"price" json:"price" yaml:"price"`
+}
+
+// PriceTableConfig holds the hourly prices of hosts from providers
+// that cannot compute their own costs, such as static and container
+// hosts. A host's price is looked up by its distro, then by its
+// instance type, then by its container pool.
+type PriceTableConfig struct {
+	Distros        []HourlyPrice `bson:"distros" json:"distros" yaml:"distros"`
+	InstanceTypes  []HourlyPrice `bson:"instance_types" json:"instance_types" yaml:"instance_types"`
+	ContainerPools []HourlyPrice `bson:"container_pools" json:"container_pools" yaml:"container_pools"`
+}
+
+func (c *PriceTableConfig) SectionId() string { return "price_table" }
+
+func (c *PriceTableConfig) Get() error {
+	err := db.FindOneQ(ConfigCollection, db.Query(byId(c.SectionId())), c)
+	if err != nil && err.Error() == errNotFound {
+		*c = PriceTableConfig{}
+		return nil
+	}
+	return errors.Wrapf(err, "error retrieving section %s", c.SectionId())
+}
+
+func (c *PriceTableConfig) Set() error {
+	_, err := db.Upsert(ConfigCollection, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			priceTableDistrosKey:        c.Distros,
+			priceTableInstanceTypesKey:  c.InstanceTypes,
+			priceTableContainerPoolsKey: c.ContainerPools,
+		},
+	})
+	return errors.Wrapf(err, "error updating section %s", c.SectionId())
+}
+
+func (c *PriceTableConfig) ValidateAndDefault() error {
+	catcher := grip.NewSimpleCatcher()
+	for name, prices := range map[string][]HourlyPrice{
+		"distro":         c.Distros,
+		"instance type":  c.InstanceTypes,
+		"container pool": c.ContainerPools,
+	} {
+		seen := map[string]bool{}
+		for _, p := range prices {
+			if p.Id == "" {
+				catcher.Add(errors.Errorf("%s price must have an id", name))
+			}
+			if p.Price < 0 {
+				catcher.Add(errors.Errorf("%s '%s' must not have a negative price", name, p.Id))
+			}
+			if seen[p.Id] {
+				catcher.Add(errors.Errorf("%s '%s' has more than one price", name, p.Id))
+			}
+			seen[p.Id] = true
+		}
+	}
+	return catcher.Resolve()
+}
+
+// GetHourlyPrice returns the most specific hourly price for a host
+// with the given distro, instance type and container pool, any of
+// which may be empty. It returns false if the table has no price for
+// the host.
+func (c *PriceTableConfig) GetHourlyPrice(distro, instanceType, containerPool string) (float64, bool) {
+	for _, lookup := range []struct {
+		id     string
+		prices []HourlyPrice
+	}{
+		{id: distro, prices: c.Distros},
+		{id: instanceType, prices: c.InstanceTypes},
+		{id: containerPool, prices: c.ContainerPools},
+	} {
+		if lookup.id == "" {
+			continue
+		}
+		for _, p := range lookup.prices {
+			if p.Id == lookup.id {
+				return p.Price, true
+			}
+		}
+	}
+
+	return 0, false
+}
@@ -37,10 +37,13 @@ func resetRegistry() error {
 		&AuthConfig{},
 		&CloudProviders{},
 		&ContainerPoolsConfig{},
+		&EventRetentionConfig{},
+		&GitlabConfig{},
 		&HostInitConfig{},
 		&JiraConfig{},
 		&LoggerConfig{},
 		&NotifyConfig{},
+		&PriceTableConfig{},
 		&RepoTrackerConfig{},
 		&SchedulerConfig{},
 		&ServiceFlags{},
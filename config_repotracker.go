@@ -11,6 +11,9 @@ type RepoTrackerConfig struct {
 	NumNewRepoRevisionsToFetch int `bson:"revs_to_fetch" json:"revs_to_fetch" yaml:"numnewreporevisionstofetch"`
 	MaxRepoRevisionsToSearch   int `bson:"max_revs_to_search" json:"max_revs_to_search" yaml:"maxreporevisionstosearch"`
 	MaxConcurrentRequests      int `bson:"max_con_requests" json:"max_con_requests" yaml:"maxconcurrentrequests"`
+	// MirrorDirectory is where the repotracker keeps local mirrors of the
+	// repositories of projects that aren't hosted on github.
+	MirrorDirectory string `bson:"mirror_dir" json:"mirror_dir" yaml:"mirror_dir"`
 }
 
 func (c *RepoTrackerConfig) SectionId() string { return "repotracker" }
@@ -30,6 +33,7 @@ func (c *RepoTrackerConfig) Set() error {
 			"revs_to_fetch":      c.NumNewRepoRevisionsToFetch,
 			"max_revs_to_search": c.MaxRepoRevisionsToSearch,
 			"max_con_requests":   c.MaxConcurrentRequests,
+			"mirror_dir":         c.MirrorDirectory,
 		},
 	})
 	return errors.Wrapf(err, "error updating section %s", c.SectionId())
@@ -12,6 +12,32 @@ type SchedulerConfig struct {
 	TaskFinder       string  `bson:"task_finder" json:"task_finder" yaml:"task_finder"`
 	HostAllocator    string  `bson:"host_allocator" json:"host_allocator" yaml:"host_allocator"`
 	FreeHostFraction float64 `bson:"free_host_fraction" json:"free_host_fraction" yaml:"free_host_fraction"`
+	// TaskPrioritizer is the prioritizer used for distros that don't
+	// specify their own.
+	TaskPrioritizer string `bson:"task_prioritizer" json:"task_prioritizer" yaml:"task_prioritizer"`
+	// ProjectWeights are the relative shares of distros that the fair
+	// share prioritizer gives projects. Projects not listed have a weight
+	// of 1.
+	ProjectWeights []ProjectWeight `bson:"project_weights" json:"project_weights" yaml:"project_weights"`
+}
+
+// ProjectWeight is the relative share of a distro that a project gets.
+type ProjectWeight struct {
+	Project string  `bson:"project" json:"project" yaml:"project"`
+	Weight  float64 `bson:"weight" json:"weight" yaml:"weight"`
+}
+
+// TaskPrioritizers are the names of the supported task prioritizers, the
+// first of which is the default.
+var TaskPrioritizers = []string{"comparator", "fairshare"}
+
+// GetProjectWeights returns a map of project identifier -> weight.
+func (c *SchedulerConfig) GetProjectWeights() map[string]float64 {
+	weights := make(map[string]float64, len(c.ProjectWeights))
+	for _, w := range c.ProjectWeights {
+		weights[w.Project] = w.Weight
+	}
+	return weights
 }
 
 func (c *SchedulerConfig) SectionId() string { return "scheduler" }
@@ -31,12 +57,35 @@ func (c *SchedulerConfig) Set() error {
 			"task_finder":        c.TaskFinder,
 			"host_allocator":     c.HostAllocator,
 			"free_host_fraction": c.FreeHostFraction,
+			"task_prioritizer":   c.TaskPrioritizer,
+			"project_weights":    c.ProjectWeights,
 		},
 	})
 	return errors.Wrapf(err, "error updating section %s", c.SectionId())
 }
 
 func (c *SchedulerConfig) ValidateAndDefault() error {
+	if c.TaskPrioritizer == "" {
+		c.TaskPrioritizer = TaskPrioritizers[0]
+	} else if !util.StringSliceContains(TaskPrioritizers, c.TaskPrioritizer) {
+		return errors.Errorf("supported prioritizers are %s; %s is not supported",
+			TaskPrioritizers, c.TaskPrioritizer)
+	}
+
+	projects := map[string]bool{}
+	for _, w := range c.ProjectWeights {
+		if w.Project == "" {
+			return errors.New("project weights must specify a project")
+		}
+		if w.Weight <= 0 {
+			return errors.Errorf("weight for project '%s' must be positive", w.Project)
+		}
+		if projects[w.Project] {
+			return errors.Errorf("duplicate weight for project '%s'", w.Project)
+		}
+		projects[w.Project] = true
+	}
+
 	finders := []string{"legacy", "alternate", "parallel", "pipeline"}
 
 	if c.TaskFinder == "" {
@@ -50,7 +99,7 @@ func (c *SchedulerConfig) ValidateAndDefault() error {
 			finders, c.TaskFinder)
 	}
 
-	allocators := []string{"duration", "deficit", "utilization"}
+	allocators := []string{"duration", "deficit", "utilization", "predictive"}
 	if c.HostAllocator == "" {
 		c.HostAllocator = allocators[0]
 		return nil
@@ -16,6 +16,8 @@ type ServiceFlags struct {
 	RepotrackerDisabled          bool `bson:"repotracker_disabled" json:"repotracker_disabled"`
 	SchedulerDisabled            bool `bson:"scheduler_disabled" json:"scheduler_disabled"`
 	GithubPRTestingDisabled      bool `bson:"github_pr_testing_disabled" json:"github_pr_testing_disabled"`
+	GitlabMRTestingDisabled      bool `bson:"gitlab_mr_testing_disabled" json:"gitlab_mr_testing_disabled"`
+	CommitQueueDisabled          bool `bson:"commit_queue_disabled" json:"commit_queue_disabled"`
 	RepotrackerPushEventDisabled bool `bson:"repotracker_push_event_disabled" json:"repotracker_push_event_disabled"`
 	CLIUpdatesDisabled           bool `bson:"cli_updates_disabled" json:"cli_updates_disabled"`
 	BackgroundStatsDisabled      bool `bson:"background_stats_disabled" json:"background_stats_disabled"`
@@ -28,6 +30,7 @@ type ServiceFlags struct {
 	EmailNotificationsDisabled   bool `bson:"email_notifications_disabled" json:"email_notifications_disabled"`
 	WebhookNotificationsDisabled bool `bson:"webhook_notifications_disabled" json:"webhook_notifications_disabled"`
 	GithubStatusAPIDisabled      bool `bson:"github_status_api_disabled" json:"github_status_api_disabled"`
+	GitlabStatusAPIDisabled      bool `bson:"gitlab_status_api_disabled" json:"gitlab_status_api_disabled"`
 }
 
 func (c *ServiceFlags) SectionId() string { return "service_flags" }
@@ -52,6 +55,8 @@ func (c *ServiceFlags) Set() error {
 			repotrackerKey:                  c.RepotrackerDisabled,
 			schedulerKey:                    c.SchedulerDisabled,
 			githubPRTestingDisabledKey:      c.GithubPRTestingDisabled,
+			gitlabMRTestingDisabledKey:      c.GitlabMRTestingDisabled,
+			commitQueueDisabledKey:          c.CommitQueueDisabled,
 			repotrackerPushEventDisabledKey: c.RepotrackerPushEventDisabled,
 			cliUpdatesDisabledKey:           c.CLIUpdatesDisabled,
 			backgroundStatsDisabledKey:      c.BackgroundStatsDisabled,
@@ -61,6 +66,7 @@ func (c *ServiceFlags) Set() error {
 			emailNotificationsDisabledKey:   c.EmailNotificationsDisabled,
 			webhookNotificationsDisabledKey: c.WebhookNotificationsDisabled,
 			githubStatusAPIDisabledKey:      c.GithubStatusAPIDisabled,
+			gitlabStatusAPIDisabledKey:      c.GitlabStatusAPIDisabled,
 			taskLoggingDisabledKey:          c.TaskLoggingDisabled,
 		},
 	})
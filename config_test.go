@@ -312,6 +312,11 @@ func (s *AdminSuite) TestProvidersConfig() {
 			PrivateKeyID: "gce_key_id",
 			TokenURI:     "gce_token",
 		},
+		Kubernetes: KubernetesConfig{
+			APIServer: "https://kubernetes.example.com",
+			Token:     "kubernetes_token",
+			Namespace: "evergreen",
+		},
 		OpenStack: OpenStackConfig{
 			IdentityEndpoint: "endpoint",
 			Username:         "username",
@@ -336,6 +341,25 @@ func (s *AdminSuite) TestProvidersConfig() {
 	s.Equal(config, settings.Providers)
 }
 
+func (s *AdminSuite) TestEventRetentionConfig() {
+	config := EventRetentionConfig{
+		DefaultTTLDays: 365,
+		Policies: []EventRetentionPolicy{
+			{ResourceType: "SCHEDULER", TTLDays: 30},
+		},
+		Archive:     EventArchiveFile,
+		ArchivePath: "/data/events",
+		BatchSize:   100,
+	}
+
+	err := config.Set()
+	s.NoError(err)
+	settings, err := GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.EventRetention)
+}
+
 func (s *AdminSuite) TestRepotrackerConfig() {
 	config := RepoTrackerConfig{
 		NumNewRepoRevisionsToFetch: 10,
@@ -353,7 +377,11 @@ func (s *AdminSuite) TestRepotrackerConfig() {
 
 func (s *AdminSuite) TestSchedulerConfig() {
 	config := SchedulerConfig{
-		TaskFinder: "task_finder",
+		TaskFinder:      "task_finder",
+		TaskPrioritizer: "fairshare",
+		ProjectWeights: []ProjectWeight{
+			{Project: "mci", Weight: 2},
+		},
 	}
 
 	err := config.Set()
@@ -558,3 +586,129 @@ func (s *AdminSuite) TestContainerPoolsConfig() {
 	lookup = settings.ContainerPools.GetContainerPool("test-pool-3")
 	s.Nil(lookup)
 }
+
+func (s *AdminSuite) TestPriceTableConfig() {
+	invalidConfig := PriceTableConfig{
+		Distros:       []HourlyPrice{{Id: "d1", Price: -1}},
+		InstanceTypes: []HourlyPrice{{Id: "", Price: 1}, {Id: "large", Price: 1}, {Id: "large", Price: 2}},
+	}
+	s.Error(invalidConfig.ValidateAndDefault())
+
+	validConfig := PriceTableConfig{
+		Distros:        []HourlyPrice{{Id: "d1", Price: 0.5}},
+		InstanceTypes:  []HourlyPrice{{Id: "large", Price: 1.5}},
+		ContainerPools: []HourlyPrice{{Id: "pool", Price: 0.1}},
+	}
+	s.NoError(validConfig.ValidateAndDefault())
+	s.NoError(validConfig.Set())
+
+	settings, err := GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(validConfig, settings.PriceTable)
+}
+
+func TestPriceTableGetHourlyPrice(t *testing.T) {
+	assert := assert.New(t)
+
+	table := PriceTableConfig{
+		Distros:        []HourlyPrice{{Id: "d1", Price: 0.5}},
+		InstanceTypes:  []HourlyPrice{{Id: "large", Price: 1.5}},
+		ContainerPools: []HourlyPrice{{Id: "pool", Price: 0.1}},
+	}
+
+	price, ok := table.GetHourlyPrice("d1", "large", "pool")
+	assert.True(ok)
+	assert.Equal(0.5, price)
+
+	price, ok = table.GetHourlyPrice("d2", "large", "pool")
+	assert.True(ok)
+	assert.Equal(1.5, price)
+
+	price, ok = table.GetHourlyPrice("d2", "", "pool")
+	assert.True(ok)
+	assert.Equal(0.1, price)
+
+	_, ok = table.GetHourlyPrice("d2", "small", "")
+	assert.False(ok)
+}
+
+func TestSchedulerConfigValidateAndDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	config := SchedulerConfig{}
+	assert.NoError(config.ValidateAndDefault())
+	assert.Equal("comparator", config.TaskPrioritizer)
+
+	config = SchedulerConfig{TaskPrioritizer: "random"}
+	assert.Error(config.ValidateAndDefault())
+
+	config = SchedulerConfig{
+		TaskPrioritizer: "fairshare",
+		ProjectWeights:  []ProjectWeight{{Project: "mci", Weight: 2}, {Project: "evg", Weight: 0.5}},
+	}
+	assert.NoError(config.ValidateAndDefault())
+	assert.Equal(map[string]float64{"mci": 2, "evg": 0.5}, config.GetProjectWeights())
+
+	config.ProjectWeights = []ProjectWeight{{Project: "mci", Weight: 0}}
+	assert.Error(config.ValidateAndDefault())
+
+	config.ProjectWeights = []ProjectWeight{{Project: "", Weight: 1}}
+	assert.Error(config.ValidateAndDefault())
+
+	config.ProjectWeights = []ProjectWeight{{Project: "mci", Weight: 1}, {Project: "mci", Weight: 2}}
+	assert.Error(config.ValidateAndDefault())
+}
+
+func TestEventRetentionConfigValidateAndDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	config := EventRetentionConfig{}
+	assert.NoError(config.ValidateAndDefault())
+	assert.Equal(EventArchiveCollection, config.Archive)
+	assert.Equal(defaultEventArchiveBatchSize, config.BatchSize)
+	assert.Equal(0, config.TTLDays("HOST"))
+
+	config = EventRetentionConfig{Archive: EventArchiveFile}
+	assert.Error(config.ValidateAndDefault())
+	config.ArchivePath = "/data/events"
+	assert.NoError(config.ValidateAndDefault())
+
+	config = EventRetentionConfig{Archive: "s3"}
+	assert.Error(config.ValidateAndDefault())
+
+	config = EventRetentionConfig{
+		DefaultTTLDays: 365,
+		Policies:       []EventRetentionPolicy{{ResourceType: "SCHEDULER", TTLDays: 30}, {ResourceType: "ADMIN", TTLDays: 0}},
+	}
+	assert.NoError(config.ValidateAndDefault())
+	assert.Equal(30, config.TTLDays("SCHEDULER"))
+	assert.Equal(0, config.TTLDays("ADMIN"))
+	assert.Equal(365, config.TTLDays("HOST"))
+
+	config.Policies = []EventRetentionPolicy{{ResourceType: "HOST", TTLDays: 1}, {ResourceType: "HOST", TTLDays: 2}}
+	assert.Error(config.ValidateAndDefault())
+
+	config.Policies = []EventRetentionPolicy{{ResourceType: "HOST", TTLDays: -1}}
+	assert.Error(config.ValidateAndDefault())
+}
+
+func TestGitlabConfigValidateAndDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	config := GitlabConfig{}
+	assert.NoError(config.ValidateAndDefault())
+	assert.Equal(defaultGitlabURL, config.URL)
+
+	config = GitlabConfig{URL: "https://gitlab.example.com/"}
+	assert.NoError(config.ValidateAndDefault())
+	assert.Equal("https://gitlab.example.com", config.URL)
+
+	config = GitlabConfig{URL: "gitlab.example.com"}
+	assert.Error(config.ValidateAndDefault())
+
+	config = GitlabConfig{WebhookSecret: "secret"}
+	assert.Error(config.ValidateAndDefault())
+	config.Token = "token"
+	assert.NoError(config.ValidateAndDefault())
+}
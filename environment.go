@@ -387,6 +387,14 @@ func (e *envState) initSenders() error {
 		e.senders[SenderGithubStatus] = sender
 	}
 
+	if gitlab := &e.settings.Gitlab; len(gitlab.Token) != 0 {
+		sender, err = util.NewGitlabStatusLogger("evergreen", gitlab.URL, gitlab.Token)
+		if err != nil {
+			return errors.Wrap(err, "Failed to setup gitlab status logger")
+		}
+		e.senders[SenderGitlabStatus] = sender
+	}
+
 	if jira := &e.settings.Jira; len(jira.GetHostURL()) != 0 {
 		sender, err = send.NewJiraLogger(&send.JiraOptions{
 			Name:     "evergreen",
@@ -433,6 +441,18 @@ func (e *envState) initSenders() error {
 	}
 	e.senders[SenderEvergreenWebhook] = sender
 
+	sender, err = util.NewChatWebhookLogger("evergreen")
+	if err != nil {
+		return errors.Wrap(err, "Failed to setup microsoft teams logger")
+	}
+	e.senders[SenderMSTeams] = sender
+
+	sender, err = util.NewChatWebhookLogger("evergreen")
+	if err != nil {
+		return errors.Wrap(err, "Failed to setup chat webhook logger")
+	}
+	e.senders[SenderChatWebhook] = sender
+
 	catcher := grip.NewBasicCatcher()
 	for _, s := range e.senders {
 		catcher.Add(s.SetLevel(levelInfo))
@@ -10,6 +10,7 @@ import (
 const (
 	User            = "mci"
 	GithubPatchUser = "github_pull_request"
+	GitlabPatchUser = "gitlab_merge_request"
 
 	HostRunning         = "running"
 	HostTerminated      = "terminated"
@@ -133,6 +134,7 @@ const (
 	ProviderNameDockerStatic  = "docker-static"
 	ProviderNameDockerDynamic = "docker-dynamic"
 	ProviderNameGce           = "gce"
+	ProviderNameKubernetes    = "kubernetes"
 	ProviderNameStatic        = "static"
 	ProviderNameOpenstack     = "openstack"
 	ProviderNameVsphere       = "vsphere"
@@ -151,6 +153,7 @@ var (
 		ProviderNameEc2Spot,
 		ProviderNameEc2Auto,
 		ProviderNameGce,
+		ProviderNameKubernetes,
 		ProviderNameOpenstack,
 		ProviderNameVsphere,
 	}
@@ -168,6 +171,7 @@ const (
 	// version requester types
 	PatchVersionRequester       = "patch_request"
 	GithubPRRequester           = "github_pull_request"
+	GitlabMRRequester           = "gitlab_merge_request"
 	RepotrackerVersionRequester = "gitter_request"
 )
 
@@ -185,6 +189,9 @@ const (
 	SenderJIRAIssue
 	SenderJIRAComment
 	SenderEmail
+	SenderMSTeams
+	SenderChatWebhook
+	SenderGitlabStatus
 )
 
 const (
@@ -204,6 +211,7 @@ var (
 	PatchRequesters = []string{
 		PatchVersionRequester,
 		GithubPRRequester,
+		GitlabMRRequester,
 	}
 
 	// UphostStatus is a list of all host statuses that are considered "up."
@@ -248,5 +256,5 @@ func IsSystemActivator(caller string) bool {
 }
 
 func IsPatchRequester(requester string) bool {
-	return requester == PatchVersionRequester || requester == GithubPRRequester
+	return requester == PatchVersionRequester || requester == GithubPRRequester || requester == GitlabMRRequester
 }
@@ -23,6 +23,7 @@ const (
 	LastRevisionNotFound            = "last_revision_not_found"
 	taskRegressionByTest            = "task-regression-by-test"
 	taskRegressionByTestWithNoTests = "task-regression-by-test-with-no-tests"
+	testFlaky                       = "test-flaky"
 )
 
 // Host triggers
@@ -148,6 +149,17 @@ func FindByLastTaskRegressionByTestWithNoTests(taskDisplayName, variant, project
 	}).Sort([]string{"-" + RevisionOrderNumberKey}))
 }
 
+// FindByLastTestFlaky finds the most recent alert that a test was flaky.
+func FindByLastTestFlaky(testName, taskDisplayName, variant, projectID string) (*AlertRecord, error) {
+	return FindOne(db.Query(bson.M{
+		TypeKey:      testFlaky,
+		testNameKey:  testName,
+		TaskNameKey:  taskDisplayName,
+		VariantKey:   variant,
+		ProjectIdKey: projectID,
+	}).Sort([]string{"-" + RevisionOrderNumberKey}))
+}
+
 func (ar *AlertRecord) Insert() error {
 	return db.Insert(Collection, ar)
 }
@@ -179,3 +191,17 @@ func InsertNewTaskRegressionByTestWithNoTestsRecord(taskDisplayName, taskStatus,
 
 	return errors.Wrapf(record.Insert(), "failed to insert alert record %s", taskRegressionByTestWithNoTests)
 }
+
+func InsertNewTestFlakyRecord(testName, taskDisplayName, variant, projectID string, revision int) error {
+	record := AlertRecord{
+		Id:                  bson.NewObjectId(),
+		Type:                testFlaky,
+		ProjectId:           projectID,
+		TaskName:            taskDisplayName,
+		Variant:             variant,
+		TestName:            testName,
+		RevisionOrderNumber: revision,
+	}
+
+	return errors.Wrapf(record.Insert(), "failed to insert alert record %s", testFlaky)
+}
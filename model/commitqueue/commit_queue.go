@@ -0,0 +1,122 @@
+package commitqueue
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CommitQueueItem is a pull request waiting to be merged.
+type CommitQueueItem struct {
+	// Issue is the number of the pull request.
+	Issue string `bson:"issue" json:"issue"`
+
+	// Version is the patch that tests the item once it reaches the head
+	// of the queue.
+	Version string `bson:"version,omitempty" json:"version"`
+
+	// HeadHash is the revision of the pull request that the version tests,
+	// which is the only revision that can be merged.
+	HeadHash string `bson:"head_hash,omitempty" json:"head_hash"`
+
+	// ProcessingStartTime is when the item reached the head of the queue
+	// and its version was created.
+	ProcessingStartTime time.Time `bson:"processing_start_time,omitempty" json:"processing_start_time"`
+
+	EnqueuedBy  string    `bson:"enqueued_by" json:"enqueued_by"`
+	EnqueueTime time.Time `bson:"enqueue_time" json:"enqueue_time"`
+}
+
+// CommitQueue is the queue of pull requests waiting to be merged into a
+// project's branch. Only the item at the head of the queue is tested; it's
+// tested on top of the branch, which includes every item merged before it.
+type CommitQueue struct {
+	ProjectID string            `bson:"_id" json:"project_id"`
+	Queue     []CommitQueueItem `bson:"queue,omitempty" json:"queue"`
+}
+
+// Enqueue adds an item to the end of the queue, creating the queue if the
+// project doesn't have one, and returns its position in the queue.
+func Enqueue(projectID string, item CommitQueueItem) (int, error) {
+	if item.Issue == "" {
+		return 0, errors.New("can't enqueue an item without an issue")
+	}
+	if item.EnqueueTime.IsZero() {
+		item.EnqueueTime = time.Now().Round(time.Millisecond)
+	}
+
+	cq, err := FindOneId(projectID)
+	if err != nil {
+		return 0, err
+	}
+	if cq == nil {
+		cq = &CommitQueue{ProjectID: projectID}
+		if err = InsertQueue(cq); err != nil {
+			return 0, errors.Wrapf(err, "can't create commit queue for project '%s'", projectID)
+		}
+	}
+	if cq.FindItem(item.Issue) >= 0 {
+		return 0, errors.Errorf("'%s' is already in the queue", item.Issue)
+	}
+
+	if err = add(projectID, item); err != nil {
+		return 0, err
+	}
+	cq.Queue = append(cq.Queue, item)
+
+	return len(cq.Queue) - 1, nil
+}
+
+// Next returns the item at the head of the queue.
+func (cq *CommitQueue) Next() (CommitQueueItem, bool) {
+	if len(cq.Queue) == 0 {
+		return CommitQueueItem{}, false
+	}
+
+	return cq.Queue[0], true
+}
+
+// FindItem returns the position of an issue in the queue, or -1 if it isn't
+// queued.
+func (cq *CommitQueue) FindItem(issue string) int {
+	for i, item := range cq.Queue {
+		if item.Issue == issue {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Remove removes an issue from the queue, and returns false if it wasn't
+// queued.
+func (cq *CommitQueue) Remove(issue string) (bool, error) {
+	removed, err := remove(cq.ProjectID, issue)
+	if err != nil || !removed {
+		return false, err
+	}
+
+	if i := cq.FindItem(issue); i >= 0 {
+		cq.Queue = append(cq.Queue[:i], cq.Queue[i+1:]...)
+	}
+
+	return true, nil
+}
+
+// SetHeadVersion records the version that tests the item at the head of
+// the queue, the revision of the pull request that it tests, and when it
+// started testing.
+func (cq *CommitQueue) SetHeadVersion(issue, version, headHash string) error {
+	if len(cq.Queue) == 0 || cq.Queue[0].Issue != issue {
+		return errors.Errorf("'%s' is not at the head of the queue", issue)
+	}
+	startTime := time.Now().Round(time.Millisecond)
+	if err := setHeadVersion(cq.ProjectID, issue, version, headHash, startTime); err != nil {
+		return err
+	}
+	cq.Queue[0].Version = version
+	cq.Queue[0].HeadHash = headHash
+	cq.Queue[0].ProcessingStartTime = startTime
+
+	return nil
+}
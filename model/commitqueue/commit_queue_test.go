@@ -0,0 +1,109 @@
+package commitqueue
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommitQueueSuite struct {
+	suite.Suite
+}
+
+func TestCommitQueueSuite(t *testing.T) {
+	suite.Run(t, new(CommitQueueSuite))
+}
+
+func (s *CommitQueueSuite) SetupSuite() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func (s *CommitQueueSuite) SetupTest() {
+	s.NoError(db.Clear(Collection))
+}
+
+func (s *CommitQueueSuite) TestEnqueue() {
+	pos, err := Enqueue("mci", CommitQueueItem{Issue: "1", EnqueuedBy: "octocat"})
+	s.NoError(err)
+	s.Equal(0, pos)
+	pos, err = Enqueue("mci", CommitQueueItem{Issue: "2"})
+	s.NoError(err)
+	s.Equal(1, pos)
+
+	_, err = Enqueue("mci", CommitQueueItem{Issue: "1"})
+	s.Error(err)
+	_, err = Enqueue("mci", CommitQueueItem{})
+	s.Error(err)
+
+	cq, err := FindOneId("mci")
+	s.NoError(err)
+	s.Require().NotNil(cq)
+	s.Require().Len(cq.Queue, 2)
+	s.Equal("1", cq.Queue[0].Issue)
+	s.Equal("octocat", cq.Queue[0].EnqueuedBy)
+	s.False(cq.Queue[0].EnqueueTime.IsZero())
+	s.Equal("2", cq.Queue[1].Issue)
+
+	cq, err = FindOneId("other")
+	s.NoError(err)
+	s.Nil(cq)
+}
+
+func (s *CommitQueueSuite) TestNextAndRemove() {
+	cq := &CommitQueue{ProjectID: "mci"}
+	s.NoError(InsertQueue(cq))
+	_, ok := cq.Next()
+	s.False(ok)
+
+	for _, issue := range []string{"1", "2", "3"} {
+		_, err := Enqueue("mci", CommitQueueItem{Issue: issue})
+		s.NoError(err)
+	}
+	cq, err := FindOneId("mci")
+	s.NoError(err)
+	s.Require().NotNil(cq)
+
+	next, ok := cq.Next()
+	s.True(ok)
+	s.Equal("1", next.Issue)
+	s.Equal(1, cq.FindItem("2"))
+	s.Equal(-1, cq.FindItem("4"))
+
+	removed, err := cq.Remove("2")
+	s.NoError(err)
+	s.True(removed)
+	s.Equal(-1, cq.FindItem("2"))
+	removed, err = cq.Remove("2")
+	s.NoError(err)
+	s.False(removed)
+
+	cq, err = FindOneId("mci")
+	s.NoError(err)
+	s.Require().Len(cq.Queue, 2)
+	s.Equal("1", cq.Queue[0].Issue)
+	s.Equal("3", cq.Queue[1].Issue)
+}
+
+func (s *CommitQueueSuite) TestSetHeadVersion() {
+	for _, issue := range []string{"1", "2"} {
+		_, err := Enqueue("mci", CommitQueueItem{Issue: issue})
+		s.NoError(err)
+	}
+	cq, err := FindOneId("mci")
+	s.NoError(err)
+	s.Require().NotNil(cq)
+
+	s.Error(cq.SetHeadVersion("2", "version", "abcdef"))
+	s.NoError(cq.SetHeadVersion("1", "version", "abcdef"))
+	s.Equal("version", cq.Queue[0].Version)
+
+	cq, err = FindOneId("mci")
+	s.NoError(err)
+	s.Equal("version", cq.Queue[0].Version)
+	s.Equal("abcdef", cq.Queue[0].HeadHash)
+	s.False(cq.Queue[0].ProcessingStartTime.IsZero())
+	s.Empty(cq.Queue[1].Version)
+	s.True(cq.Queue[1].ProcessingStartTime.IsZero())
+}
@@ -0,0 +1,107 @@
+package commitqueue
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const Collection = "commit_queue"
+
+var (
+	// bson fields for the CommitQueue struct
+	IdKey    = bsonutil.MustHaveTag(CommitQueue{}, "ProjectID")
+	QueueKey = bsonutil.MustHaveTag(CommitQueue{}, "Queue")
+
+	// bson fields for the CommitQueueItem struct
+	IssueKey               = bsonutil.MustHaveTag(CommitQueueItem{}, "Issue")
+	VersionKey             = bsonutil.MustHaveTag(CommitQueueItem{}, "Version")
+	HeadHashKey            = bsonutil.MustHaveTag(CommitQueueItem{}, "HeadHash")
+	ProcessingStartTimeKey = bsonutil.MustHaveTag(CommitQueueItem{}, "ProcessingStartTime")
+	EnqueuedByKey          = bsonutil.MustHaveTag(CommitQueueItem{}, "EnqueuedBy")
+	EnqueueTimeKey         = bsonutil.MustHaveTag(CommitQueueItem{}, "EnqueueTime")
+)
+
+// FindOneId returns the commit queue of a project, or nil if the project
+// has no queue.
+func FindOneId(id string) (*CommitQueue, error) {
+	cq := &CommitQueue{}
+	err := db.FindOneQ(Collection, db.Query(bson.M{IdKey: id}), cq)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't find commit queue for project '%s'", id)
+	}
+
+	return cq, nil
+}
+
+// InsertQueue inserts a commit queue.
+func InsertQueue(cq *CommitQueue) error {
+	return db.Insert(Collection, cq)
+}
+
+// add appends an item to the queue, unless the issue is already queued.
+func add(id string, item CommitQueueItem) error {
+	err := db.Update(
+		Collection,
+		bson.M{
+			IdKey: id,
+			bsonutil.GetDottedKeyName(QueueKey, IssueKey): bson.M{"$ne": item.Issue},
+		},
+		bson.M{"$push": bson.M{QueueKey: item}},
+	)
+	if err == mgo.ErrNotFound {
+		return errors.Errorf("'%s' is already in the queue", item.Issue)
+	}
+
+	return errors.Wrapf(err, "can't add '%s' to the queue", item.Issue)
+}
+
+// remove removes an item from the queue. It returns false if the issue
+// wasn't in the queue.
+func remove(id, issue string) (bool, error) {
+	err := db.Update(
+		Collection,
+		bson.M{
+			IdKey: id,
+			bsonutil.GetDottedKeyName(QueueKey, IssueKey): issue,
+		},
+		bson.M{"$pull": bson.M{QueueKey: bson.M{IssueKey: issue}}},
+	)
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "can't remove '%s' from the queue", issue)
+	}
+
+	return true, nil
+}
+
+// setHeadVersion records the version that tests the item at the head of
+// the queue, and the revision of the pull request that it tests.
+func setHeadVersion(id, issue, version, headHash string, startTime time.Time) error {
+	err := db.Update(
+		Collection,
+		bson.M{
+			IdKey: id,
+			bsonutil.GetDottedKeyName(QueueKey, "0", IssueKey): issue,
+		},
+		bson.M{"$set": bson.M{
+			bsonutil.GetDottedKeyName(QueueKey, "0", VersionKey):             version,
+			bsonutil.GetDottedKeyName(QueueKey, "0", HeadHashKey):            headHash,
+			bsonutil.GetDottedKeyName(QueueKey, "0", ProcessingStartTimeKey): startTime,
+		}},
+	)
+	if err == mgo.ErrNotFound {
+		return errors.Errorf("'%s' is not at the head of the queue", issue)
+	}
+
+	return errors.Wrapf(err, "can't set the version of '%s'", issue)
+}
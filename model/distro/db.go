@@ -23,6 +23,7 @@ var (
 	ExpansionsKey       = bsonutil.MustHaveTag(Distro{}, "Expansions")
 	DisabledKey         = bsonutil.MustHaveTag(Distro{}, "Disabled")
 	ContainerPoolKey    = bsonutil.MustHaveTag(Distro{}, "ContainerPool")
+	CommandPluginDirKey = bsonutil.MustHaveTag(Distro{}, "CommandPluginDir")
 )
 
 const Collection = "distro"
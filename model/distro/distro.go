@@ -34,6 +34,14 @@ type Distro struct {
 	Disabled     bool        `bson:"disabled,omitempty" json:"disabled,omitempty" mapstructure:"disabled,omitempty"`
 
 	ContainerPool string `bson:"container_pool,omitempty" json:"container_pool,omitempty" mapstructure:"container_pool,omitempty"`
+
+	// TaskPrioritizer is the name of the task prioritizer used to order
+	// the distro's task queue, overriding the one in the admin settings.
+	TaskPrioritizer string `bson:"task_prioritizer,omitempty" json:"task_prioritizer,omitempty" mapstructure:"task_prioritizer,omitempty"`
+
+	// CommandPluginDir is a directory on the distro's hosts containing
+	// executables that the agent registers as command plugins.
+	CommandPluginDir string `bson:"command_plugin_dir,omitempty" json:"command_plugin_dir,omitempty" mapstructure:"command_plugin_dir,omitempty"`
 }
 
 type DistroGroup []Distro
@@ -54,6 +62,8 @@ func init() {
 func (d *Distro) GenerateName() string {
 	// gceMaxNameLength is the maximum length of an instance name permitted by GCE.
 	const gceMaxNameLength = 63
+	// kubernetesMaxNameLength is the maximum length of a Kubernetes label value.
+	const kubernetesMaxNameLength = 63
 
 	switch d.Provider {
 	case evergreen.ProviderNameStatic:
@@ -75,6 +85,19 @@ func (d *Distro) GenerateName() string {
 		}
 	}
 
+	if d.Provider == evergreen.ProviderNameKubernetes {
+		// Pod names must be DNS labels, since they are also used as
+		// label values on the pod
+		r, _ := regexp.Compile("[^a-z0-9-]+")
+		name = string(r.ReplaceAll([]byte(strings.ToLower(name)), []byte("")))
+
+		// Truncate the distro ID rather than the unique suffix
+		if len(name) > kubernetesMaxNameLength {
+			name = name[len(name)-kubernetesMaxNameLength:]
+		}
+		name = strings.Trim(name, "-")
+	}
+
 	return name
 }
 
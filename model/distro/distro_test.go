@@ -153,3 +153,16 @@ func TestGetDistroIds(t *testing.T) {
 	ids := hosts.GetDistroIds()
 	assert.Equal([]string{"d1", "d2", "d3"}, ids)
 }
+
+func TestGenerateKubernetesName(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := regexp.Compile("^[a-z0-9](?:[-a-z0-9]{0,61}[a-z0-9])?$")
+	assert.NoError(err)
+	d := Distro{Id: "ubuntu1604_Pod.large", Provider: evergreen.ProviderNameKubernetes}
+
+	assert.True(r.MatchString(d.GenerateName()))
+
+	d.Id = strings.Repeat("abc", 30)
+	assert.True(r.MatchString(d.GenerateName()))
+}
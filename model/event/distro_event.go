@@ -9,6 +9,9 @@ import (
 
 func init() {
 	registry.AddType(ResourceTypeDistro, distroEventDataFactory)
+	registry.AllowSubscription(ResourceTypeDistro, EventDistroAdded)
+	registry.AllowSubscription(ResourceTypeDistro, EventDistroModified)
+	registry.AllowSubscription(ResourceTypeDistro, EventDistroRemoved)
 }
 
 const (
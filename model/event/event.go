@@ -125,7 +125,7 @@ func (e *EventLogEntry) validateEvent() error {
 	if !e.ID.Valid() {
 		e.ID = bson.NewObjectId()
 	}
-	if !registry.IsSubscribable(e.ResourceType, e.EventType) {
+	if !registry.isSubscribableEvent(e) {
 		loc, _ := time.LoadLocation("UTC")
 		notSubscribableTime, err := time.ParseInLocation(time.RFC3339, notSubscribableTimeString, loc)
 		if err != nil {
@@ -0,0 +1,172 @@
+package event
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ArchiveCollection returns the collection that events removed from the
+// event log collection are archived in.
+func ArchiveCollection(logCollection string) string {
+	return logCollection + "_archive"
+}
+
+// LogCollection returns the event log collection that the events of the
+// resource type are logged in.
+func LogCollection(resourceType string) string {
+	switch resourceType {
+	case EventTaskSystemInfo, EventTaskProcessInfo:
+		return TaskLogCollection
+	default:
+		return AllLogCollection
+	}
+}
+
+// ExpiredEvents returns a query for up to n processed events of the resource
+// types that were logged before the time, oldest first. If exclude is true,
+// the events are of all other resource types instead.
+func ExpiredEvents(resourceTypes []string, exclude bool, before time.Time, n int) db.Q {
+	op := "$in"
+	if exclude {
+		op = "$nin"
+	}
+
+	return db.Query(bson.M{
+		resourceTypeKey: bson.M{op: resourceTypes},
+		TimestampKey:    bson.M{"$lt": before},
+		processedAtKey:  bson.M{"$ne": time.Time{}},
+	}).Sort([]string{idKey}).Limit(n)
+}
+
+// EventArchiver stores events that are removed from an event log collection.
+type EventArchiver interface {
+	Archive(logCollection string, events []bson.M) error
+}
+
+// NewEventArchiver returns the archiver that the retention settings
+// configure.
+func NewEventArchiver(conf *evergreen.EventRetentionConfig) (EventArchiver, error) {
+	switch conf.Archive {
+	case evergreen.EventArchiveCollection, "":
+		return &collectionArchiver{}, nil
+	case evergreen.EventArchiveFile:
+		if conf.ArchivePath == "" {
+			return nil, errors.New("no path to archive events in")
+		}
+		return &fileArchiver{dir: conf.ArchivePath}, nil
+	default:
+		return nil, errors.Errorf("'%s' is not a valid event archive", conf.Archive)
+	}
+}
+
+// collectionArchiver archives events in a collection named after the event
+// log collection they were removed from.
+type collectionArchiver struct{}
+
+func (a *collectionArchiver) Archive(logCollection string, events []bson.M) error {
+	items := make([]interface{}, 0, len(events))
+	for i := range events {
+		items = append(items, events[i])
+	}
+
+	// a previous attempt may have archived some of the events before
+	// failing to remove them
+	err := db.InsertManyUnordered(ArchiveCollection(logCollection), items...)
+	if err != nil && !db.IsDuplicateKey(err) {
+		return errors.Wrap(err, "problem archiving events")
+	}
+
+	return nil
+}
+
+// fileArchiver archives each batch of events in a gzipped file of JSON
+// lines, named after the event log collection they were removed from and
+// the IDs of the first and last events, so that archiving the same batch
+// again replaces the file.
+type fileArchiver struct {
+	dir string
+}
+
+func (a *fileArchiver) Archive(logCollection string, events []bson.M) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(a.dir, 0755); err != nil {
+		return errors.Wrapf(err, "problem creating event archive directory '%s'", a.dir)
+	}
+
+	name := fmt.Sprintf("%s-%s-%s.jsonl.gz", logCollection, archivedEventID(events[0]), archivedEventID(events[len(events)-1]))
+	tmp, err := ioutil.TempFile(a.dir, name+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "problem creating event archive file")
+	}
+	defer os.Remove(tmp.Name()) // nolint
+
+	if err = writeEventsJSONL(tmp, events); err != nil {
+		_ = tmp.Close()
+		return errors.Wrapf(err, "problem writing events to '%s'", tmp.Name())
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrapf(err, "problem closing '%s'", tmp.Name())
+	}
+
+	path := filepath.Join(a.dir, name)
+	return errors.Wrapf(os.Rename(tmp.Name(), path), "problem saving event archive file '%s'", path)
+}
+
+func writeEventsJSONL(f *os.File, events []bson.M) error {
+	gz := gzip.NewWriter(f)
+	encoder := json.NewEncoder(gz)
+	for i := range events {
+		if err := encoder.Encode(events[i]); err != nil {
+			_ = gz.Close()
+			return errors.Wrap(err, "problem encoding event")
+		}
+	}
+
+	return errors.WithStack(gz.Close())
+}
+
+func archivedEventID(e bson.M) string {
+	if id, ok := e[idKey].(bson.ObjectId); ok {
+		return id.Hex()
+	}
+
+	return fmt.Sprint(e[idKey])
+}
+
+// ArchiveEvents moves the events that the query finds from the event log
+// collection to the archive, and returns how many it moved. Events are
+// archived before they're removed, so that an event may be archived twice
+// if removing it fails, but is never lost.
+func ArchiveEvents(logCollection string, query db.Q, archiver EventArchiver) (int, error) {
+	events := []bson.M{}
+	if err := db.FindAllQ(logCollection, query, &events); err != nil {
+		return 0, errors.Wrap(err, "problem finding events to archive")
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	if err := archiver.Archive(logCollection, events); err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	ids := make([]interface{}, 0, len(events))
+	for i := range events {
+		ids = append(ids, events[i][idKey])
+	}
+	err := db.RemoveAll(logCollection, bson.M{idKey: bson.M{"$in": ids}})
+
+	return len(events), errors.Wrap(err, "problem removing archived events")
+}
@@ -0,0 +1,76 @@
+package event
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestFileArchiver(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "event-archive")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	archiver, err := NewEventArchiver(&evergreen.EventRetentionConfig{
+		Archive:     evergreen.EventArchiveFile,
+		ArchivePath: dir,
+	})
+	require.NoError(err)
+
+	events := []bson.M{
+		{idKey: bson.NewObjectId(), resourceTypeKey: ResourceTypeHost, TimestampKey: time.Now()},
+		{idKey: bson.NewObjectId(), resourceTypeKey: ResourceTypeHost, TimestampKey: time.Now()},
+	}
+	require.NoError(archiver.Archive(AllLogCollection, events))
+	// archiving the same batch again replaces its file
+	require.NoError(archiver.Archive(AllLogCollection, events))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(err)
+	require.Len(files, 1)
+	assert.Equal(filepath.Join(dir, AllLogCollection+"-"+events[0][idKey].(bson.ObjectId).Hex()+"-"+
+		events[1][idKey].(bson.ObjectId).Hex()+".jsonl.gz"), files[0])
+
+	f, err := os.Open(files[0])
+	require.NoError(err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(err)
+	scanner := bufio.NewScanner(gz)
+	ids := []string{}
+	for scanner.Scan() {
+		line := map[string]interface{}{}
+		require.NoError(json.Unmarshal(scanner.Bytes(), &line))
+		assert.Equal(ResourceTypeHost, line[resourceTypeKey])
+		ids = append(ids, line[idKey].(string))
+	}
+	require.NoError(scanner.Err())
+	assert.Equal([]string{events[0][idKey].(bson.ObjectId).Hex(), events[1][idKey].(bson.ObjectId).Hex()}, ids)
+
+	_, err = NewEventArchiver(&evergreen.EventRetentionConfig{Archive: evergreen.EventArchiveFile})
+	assert.Error(err)
+	_, err = NewEventArchiver(&evergreen.EventRetentionConfig{Archive: "s3"})
+	assert.Error(err)
+}
+
+func TestLogCollection(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(TaskLogCollection, LogCollection(EventTaskSystemInfo))
+	assert.Equal(TaskLogCollection, LogCollection(EventTaskProcessInfo))
+	assert.Equal(AllLogCollection, LogCollection(ResourceTypeHost))
+	assert.Equal("event_log_archive", ArchiveCollection(AllLogCollection))
+}
@@ -106,6 +106,68 @@ func CountUnprocessedEvents() (int, error) {
 
 // === Queries ===
 
+// RecentEvents returns the n most recent events of the resource type.
+func RecentEvents(resourceType string, n int) db.Q {
+	return db.Query(resourceTypeKeyIs(resourceType)).Sort([]string{"-" + TimestampKey}).Limit(n)
+}
+
+// EventByID returns the event with the ID.
+func EventByID(id bson.ObjectId) db.Q {
+	return db.Query(bson.M{idKey: id})
+}
+
+// EventFilter selects events by their resource, type and the time that they
+// were logged. Empty fields match all events, and EndTime is exclusive.
+type EventFilter struct {
+	ResourceType string
+	ResourceID   string
+	EventType    string
+	StartTime    time.Time
+	EndTime      time.Time
+}
+
+// FilteredEvents returns a query for up to n events that match the filter,
+// from the event with the ID. Unless newer is true, they are the event with
+// the ID and the events logged before it, newest first; otherwise they are
+// the events logged after it, oldest first. An empty ID starts from the
+// newest event.
+func FilteredEvents(filter EventFilter, id string, newer bool, n int) db.Q {
+	query := bson.M{}
+	if filter.ResourceType != "" {
+		query[resourceTypeKey] = filter.ResourceType
+	}
+	if filter.ResourceID != "" {
+		query[ResourceIdKey] = filter.ResourceID
+	}
+	if filter.EventType != "" {
+		query[TypeKey] = filter.EventType
+	}
+	ts := bson.M{}
+	if !filter.StartTime.IsZero() {
+		ts["$gte"] = filter.StartTime
+	}
+	if !filter.EndTime.IsZero() {
+		ts["$lt"] = filter.EndTime
+	}
+	if len(ts) != 0 {
+		query[TimestampKey] = ts
+	}
+
+	sort := "-" + idKey
+	if newer {
+		sort = idKey
+	}
+	if bson.IsObjectIdHex(id) {
+		if newer {
+			query[idKey] = bson.M{"$gt": bson.ObjectIdHex(id)}
+		} else {
+			query[idKey] = bson.M{"$lte": bson.ObjectIdHex(id)}
+		}
+	}
+
+	return db.Query(query).Sort([]string{sort}).Limit(n)
+}
+
 // Host Events
 func HostEventsForId(id string) db.Q {
 	filter := resourceTypeKeyIs(ResourceTypeHost)
@@ -166,6 +228,19 @@ func RecentSchedulerEvents(distroId string, n int) db.Q {
 	return SchedulerEventsForId(distroId).Sort([]string{"-" + TimestampKey}).Limit(n)
 }
 
+// PreviousSchedulerEvent returns a query for the scheduler event of the type
+// for the distro that was logged last before the time.
+func PreviousSchedulerEvent(distroID, eventType string, before time.Time) db.Q {
+	filter := resourceTypeKeyIs(ResourceTypeScheduler)
+	filter[ResourceIdKey] = distroID
+	filter[TypeKey] = eventType
+	filter[TimestampKey] = bson.M{
+		"$lt": before,
+	}
+
+	return db.Query(filter).Sort([]string{"-" + TimestampKey}).Limit(1)
+}
+
 // Admin Events
 // RecentAdminEvents returns the N most recent admin events
 func RecentAdminEvents(n int) db.Q {
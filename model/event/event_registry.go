@@ -10,13 +10,15 @@ type eventDataFactory func() interface{}
 type eventRegistry struct {
 	lock sync.RWMutex
 
-	types          map[string]eventDataFactory
-	isSubscribable map[EventLogEntry]bool
+	types            map[string]eventDataFactory
+	isSubscribable   map[EventLogEntry]bool
+	subscribableWhen map[EventLogEntry]func(interface{}) bool
 }
 
 var registry eventRegistry = eventRegistry{
-	types:          map[string]eventDataFactory{},
-	isSubscribable: map[EventLogEntry]bool{},
+	types:            map[string]eventDataFactory{},
+	isSubscribable:   map[EventLogEntry]bool{},
+	subscribableWhen: map[EventLogEntry]func(interface{}) bool{},
 }
 
 // AddType adds an event data factory to the registry with the given resource
@@ -57,6 +59,39 @@ func (r *eventRegistry) AllowSubscription(resourceType, eventType string) {
 	r.isSubscribable[e] = true
 }
 
+// AllowSubscriptionWhen marks a (resourceType, eventType) pair as
+// subscribable like AllowSubscription, but only for events whose data
+// the filter returns true for. This keeps frequent events that are only
+// sometimes of interest out of the notification jobs.
+func (r *eventRegistry) AllowSubscriptionWhen(resourceType, eventType string, filter func(interface{}) bool) {
+	r.AllowSubscription(resourceType, eventType)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.subscribableWhen[EventLogEntry{
+		ResourceType: resourceType,
+		EventType:    eventType,
+	}] = filter
+}
+
+// isSubscribableEvent checks that an event's type is subscribable and, if
+// the type was registered with a filter, that the filter accepts its data.
+func (r *eventRegistry) isSubscribableEvent(e *EventLogEntry) bool {
+	if !r.IsSubscribable(e.ResourceType, e.EventType) {
+		return false
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	filter, ok := r.subscribableWhen[EventLogEntry{
+		ResourceType: e.ResourceType,
+		EventType:    e.EventType,
+	}]
+	return !ok || filter(e.Data)
+}
+
 // IsSubscribable looks to see if a (resourceType, eventType) pair is allowed
 // to be subscribed to
 func (r *eventRegistry) IsSubscribable(resourceType, eventType string) bool {
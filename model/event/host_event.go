@@ -1,6 +1,7 @@
 package event
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -15,6 +16,10 @@ import (
 func init() {
 	registry.AddType(ResourceTypeHost, hostEventDataFactory)
 	registry.AllowSubscription(ResourceTypeHost, EventHostExpirationWarningSent)
+	registry.AllowSubscription(ResourceTypeHost, EventHostProvisionError)
+	registry.AllowSubscription(ResourceTypeHost, EventHostProvisionFailed)
+	registry.AllowSubscription(ResourceTypeHost, EventHostAgentDeployFailed)
+	registry.AllowSubscriptionWhen(ResourceTypeHost, EventHostStatusChanged, isSubscribableHostStatusChange)
 }
 
 const (
@@ -38,6 +43,7 @@ const (
 	EventTaskFinished              = "HOST_TASK_FINISHED"
 	EventHostTeardown              = "HOST_TEARDOWN"
 	EventHostTerminatedExternally  = "HOST_TERMINATED_EXTERNALLY"
+	EventHostIdleTerminated        = "HOST_IDLE_TERMINATED"
 	EventHostExpirationWarningSent = "HOST_EXPIRATION_WARNING_SENT"
 )
 
@@ -63,6 +69,29 @@ var (
 	hostDataStatusKey = bsonutil.MustHaveTag(HostEventData{}, "TaskStatus")
 )
 
+// isSubscribableHostStatusChange returns whether a host changed to a
+// status that host triggers notify about. Hosts change status too often
+// for every change to go through the notification jobs.
+func isSubscribableHostStatusChange(data interface{}) bool {
+	var newStatus string
+	switch d := data.(type) {
+	case HostEventData:
+		newStatus = d.NewStatus
+	case *HostEventData:
+		newStatus = d.NewStatus
+	default:
+		return false
+	}
+
+	switch newStatus {
+	case evergreen.HostQuarantined, evergreen.HostDecommissioned,
+		EventHostIdleTerminated, EventHostTerminatedExternally:
+		return true
+	default:
+		return false
+	}
+}
+
 func LogHostEvent(hostId string, eventType string, eventData HostEventData) {
 	event := EventLogEntry{
 		Timestamp:    time.Now(),
@@ -106,6 +135,13 @@ func LogHostTerminatedExternally(hostId string) {
 	LogHostEvent(hostId, EventHostStatusChanged, HostEventData{NewStatus: EventHostTerminatedExternally})
 }
 
+func LogHostIdleTerminated(hostId string, idleTime time.Duration) {
+	LogHostEvent(hostId, EventHostStatusChanged, HostEventData{
+		NewStatus: EventHostIdleTerminated,
+		Logs:      fmt.Sprintf("host was idle for %s", idleTime),
+	})
+}
+
 func LogHostStatusChanged(hostId, oldStatus, newStatus, user string, logs string) {
 	if oldStatus == newStatus {
 		return
@@ -8,6 +8,7 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/testutil"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 )
 
 func init() {
@@ -160,3 +161,34 @@ func TestLoggingHostEvents(t *testing.T) {
 		})
 	})
 }
+
+func TestHostStatusChangeSubscribability(t *testing.T) {
+	assert := assert.New(t)
+
+	statusChanged := func(newStatus string) *EventLogEntry {
+		return &EventLogEntry{
+			ResourceType: ResourceTypeHost,
+			EventType:    EventHostStatusChanged,
+			Data:         HostEventData{OldStatus: evergreen.HostRunning, NewStatus: newStatus},
+		}
+	}
+
+	for _, status := range []string{evergreen.HostQuarantined, evergreen.HostDecommissioned,
+		EventHostIdleTerminated, EventHostTerminatedExternally} {
+		assert.True(registry.isSubscribableEvent(statusChanged(status)), status)
+	}
+	for _, status := range []string{evergreen.HostProvisioning, evergreen.HostStarting, evergreen.HostTerminated} {
+		assert.False(registry.isSubscribableEvent(statusChanged(status)), status)
+	}
+
+	assert.True(registry.isSubscribableEvent(&EventLogEntry{
+		ResourceType: ResourceTypeHost,
+		EventType:    EventHostProvisionFailed,
+		Data:         HostEventData{},
+	}))
+	assert.False(registry.isSubscribableEvent(&EventLogEntry{
+		ResourceType: ResourceTypeHost,
+		EventType:    EventHostDNSNameSet,
+		Data:         HostEventData{},
+	}))
+}
@@ -9,6 +9,7 @@ import (
 
 func init() {
 	registry.AddType(ResourceTypeScheduler, schedulerEventDataFactory)
+	registry.AllowSubscription(ResourceTypeScheduler, EventSchedulerRun)
 }
 
 const (
@@ -23,6 +24,15 @@ type TaskQueueInfo struct {
 	TaskQueueLength  int           `bson:"tq_l" json:"task_queue_length"`
 	NumHostsRunning  int           `bson:"n_h" json:"num_hosts_running"`
 	ExpectedDuration time.Duration `bson:"ex_d" json:"expected_duration,"`
+	// MaxWaitTime is how long the task in the queue that has been waiting
+	// to run for the longest has waited, since it was first scheduled.
+	MaxWaitTime time.Duration `bson:"mx_wt,omitempty" json:"max_wait_time,omitempty"`
+	// PoolSize is the distro's maximum number of hosts, and AtPoolSizeCap is
+	// set when the host allocator could not start hosts for the queue
+	// because the distro had that many.
+	PoolSize      int  `bson:"p_s,omitempty" json:"pool_size,omitempty"`
+	NumNewHosts   int  `bson:"n_nh,omitempty" json:"num_new_hosts,omitempty"`
+	AtPoolSizeCap bool `bson:"cap,omitempty" json:"at_pool_size_cap,omitempty"`
 }
 
 // implements EventData
@@ -2,6 +2,8 @@ package event
 
 import (
 	"fmt"
+	"net/url"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/anser/bsonutil"
@@ -11,21 +13,30 @@ import (
 )
 
 const (
-	GithubPullRequestSubscriberType = "github_pull_request"
-	JIRAIssueSubscriberType         = "jira-issue"
-	JIRACommentSubscriberType       = "jira-comment"
-	EvergreenWebhookSubscriberType  = "evergreen-webhook"
-	EmailSubscriberType             = "email"
-	SlackSubscriberType             = "slack"
+	GithubPullRequestSubscriberType  = "github_pull_request"
+	GitlabMergeRequestSubscriberType = "gitlab_merge_request"
+	JIRAIssueSubscriberType          = "jira-issue"
+	JIRACommentSubscriberType        = "jira-comment"
+	EvergreenWebhookSubscriberType   = "evergreen-webhook"
+	EmailSubscriberType              = "email"
+	SlackSubscriberType              = "slack"
+	// MSTeamsSubscriberType and ChatWebhookSubscriberType post to the URL
+	// of an incoming webhook, of Microsoft Teams, or of a chat service,
+	// like Mattermost or Rocket.Chat, that accepts Slack's message format.
+	MSTeamsSubscriberType     = "ms-teams"
+	ChatWebhookSubscriberType = "chat-webhook"
 )
 
 var SubscriberTypes = []string{
 	GithubPullRequestSubscriberType,
+	GitlabMergeRequestSubscriberType,
 	JIRAIssueSubscriberType,
 	JIRACommentSubscriberType,
 	EvergreenWebhookSubscriberType,
 	EmailSubscriberType,
 	SlackSubscriberType,
+	MSTeamsSubscriberType,
+	ChatWebhookSubscriberType,
 }
 
 //nolint: deadcode, megacheck
@@ -59,10 +70,14 @@ func (s *Subscriber) SetBSON(raw bson.Raw) error {
 	case GithubPullRequestSubscriberType:
 		s.Target = &GithubPullRequestSubscriber{}
 
+	case GitlabMergeRequestSubscriberType:
+		s.Target = &GitlabMergeRequestSubscriber{}
+
 	case EvergreenWebhookSubscriberType:
 		s.Target = &WebhookSubscriber{}
 
-	case JIRAIssueSubscriberType, JIRACommentSubscriberType, EmailSubscriberType, SlackSubscriberType:
+	case JIRAIssueSubscriberType, JIRACommentSubscriberType, EmailSubscriberType, SlackSubscriberType,
+		MSTeamsSubscriberType, ChatWebhookSubscriberType:
 		str := ""
 		s.Target = &str
 
@@ -86,6 +101,11 @@ func (s *Subscriber) String() string {
 	case *GithubPullRequestSubscriber:
 		subscriberStr = v.String()
 
+	case GitlabMergeRequestSubscriber:
+		subscriberStr = v.String()
+	case *GitlabMergeRequestSubscriber:
+		subscriberStr = v.String()
+
 	case WebhookSubscriber:
 		subscriberStr = v.String()
 	case *WebhookSubscriber:
@@ -108,12 +128,98 @@ func (s *Subscriber) Validate() error {
 	if s.Target == nil {
 		catcher.Add(errors.New("type is required for subscriber"))
 	}
+	switch s.Type {
+	case MSTeamsSubscriberType, ChatWebhookSubscriberType:
+		catcher.Add(validateWebhookURL(s.Type, s.Target))
+	case EvergreenWebhookSubscriberType:
+		switch v := s.Target.(type) {
+		case WebhookSubscriber:
+			catcher.Add(v.Validate())
+		case *WebhookSubscriber:
+			catcher.Add(v.Validate())
+		}
+	}
 	return catcher.Resolve()
 }
 
+// validateWebhookURL checks that the target of a subscriber that posts to
+// an incoming webhook is an https URL.
+func validateWebhookURL(subscriberType string, target interface{}) error {
+	var webhookURL string
+	switch v := target.(type) {
+	case string:
+		webhookURL = v
+	case *string:
+		if v != nil {
+			webhookURL = *v
+		}
+	default:
+		return errors.Errorf("target of %s subscriber must be a URL", subscriberType)
+	}
+
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return errors.Wrapf(err, "target of %s subscriber is not a valid URL", subscriberType)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.Errorf("target of %s subscriber must be an https URL", subscriberType)
+	}
+
+	return nil
+}
+
+const (
+	// webhookRetriesLimit is the most times that a webhook can be retried
+	webhookRetriesLimit = 10
+	// webhookDefaultMinDelay is the delay before the first retry of a
+	// webhook, if its subscriber doesn't set one
+	webhookDefaultMinDelay = time.Minute
+	// webhookMaxDelay is the longest delay between retries of a webhook
+	webhookMaxDelay = 6 * time.Hour
+)
+
 type WebhookSubscriber struct {
 	URL    string `bson:"url"`
 	Secret []byte `bson:"secret"`
+	// Retries is how many times a failed webhook is retried. The delay
+	// between retries starts at MinDelayMS, and doubles after each one.
+	// If Retries is 0, which it is for subscriptions that don't set it,
+	// failed webhooks are not retried, but can still be replayed.
+	Retries    int `bson:"retries,omitempty"`
+	MinDelayMS int `bson:"min_delay_ms,omitempty"`
+}
+
+func (s *WebhookSubscriber) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	if s.Retries < 0 || s.Retries > webhookRetriesLimit {
+		catcher.Add(errors.Errorf("webhook retries must be between 0 and %d", webhookRetriesLimit))
+	}
+	if s.MinDelayMS < 0 {
+		catcher.Add(errors.New("webhook retry delay cannot be negative"))
+	}
+
+	return catcher.Resolve()
+}
+
+// RetryDelay returns how long to wait to retry a webhook that has failed
+// the number of times, and false if it shouldn't be retried again.
+func (s *WebhookSubscriber) RetryDelay(failures int) (time.Duration, bool) {
+	if failures < 1 || failures > s.Retries {
+		return 0, false
+	}
+
+	delay := webhookDefaultMinDelay
+	if s.MinDelayMS > 0 {
+		delay = time.Duration(s.MinDelayMS) * time.Millisecond
+	}
+	for i := 1; i < failures && delay < webhookMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > webhookMaxDelay {
+		delay = webhookMaxDelay
+	}
+
+	return delay, true
 }
 
 func (s *WebhookSubscriber) String() string {
@@ -141,6 +247,25 @@ func NewGithubStatusAPISubscriber(s GithubPullRequestSubscriber) Subscriber {
 	}
 }
 
+// GitlabMergeRequestSubscriber sets commit statuses on the head commit of a
+// GitLab merge request.
+type GitlabMergeRequestSubscriber struct {
+	ProjectPath string `bson:"project_path"`
+	MRNumber    int    `bson:"mr_number"`
+	Ref         string `bson:"ref"`
+}
+
+func (s *GitlabMergeRequestSubscriber) String() string {
+	return fmt.Sprintf("%s-%d-%s", s.ProjectPath, s.MRNumber, s.Ref)
+}
+
+func NewGitlabStatusAPISubscriber(s GitlabMergeRequestSubscriber) Subscriber {
+	return Subscriber{
+		Type:   GitlabMergeRequestSubscriberType,
+		Target: s,
+	}
+}
+
 func NewEmailSubscriber(t string) Subscriber {
 	return Subscriber{
 		Type:   EmailSubscriberType,
@@ -154,3 +279,17 @@ func NewSlackSubscriber(t string) Subscriber {
 		Target: t,
 	}
 }
+
+func NewMSTeamsSubscriber(webhookURL string) Subscriber {
+	return Subscriber{
+		Type:   MSTeamsSubscriberType,
+		Target: webhookURL,
+	}
+}
+
+func NewChatWebhookSubscriber(webhookURL string) Subscriber {
+	return Subscriber{
+		Type:   ChatWebhookSubscriberType,
+		Target: webhookURL,
+	}
+}
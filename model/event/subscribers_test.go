@@ -3,6 +3,7 @@ package event
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/stretchr/testify/assert"
@@ -103,3 +104,54 @@ func TestSubscribersStringerWithMissingAttributes(t *testing.T) {
 
 	assert.True(strings.HasSuffix(webhookSub.String(), "NIL_URL"))
 }
+
+func TestValidateWebhookURL(t *testing.T) {
+	assert := assert.New(t)
+
+	teams := NewMSTeamsSubscriber("https://outlook.office.com/webhook/abc")
+	assert.NoError(teams.Validate())
+	chat := NewChatWebhookSubscriber("https://chat.example.com/hooks/abc")
+	assert.NoError(chat.Validate())
+
+	url := "https://chat.example.com/hooks/abc"
+	chat.Target = &url
+	assert.NoError(chat.Validate())
+
+	for _, target := range []interface{}{"http://chat.example.com/hooks/abc", "chat.example.com", "", 5} {
+		chat.Target = target
+		assert.Error(chat.Validate(), "%v", target)
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	assert := assert.New(t)
+
+	sub := WebhookSubscriber{URL: "https://example.com/hook"}
+	assert.NoError(sub.Validate())
+	_, ok := sub.RetryDelay(1)
+	assert.False(ok)
+
+	sub.Retries = 3
+	delay, ok := sub.RetryDelay(1)
+	assert.True(ok)
+	assert.Equal(time.Minute, delay)
+	delay, ok = sub.RetryDelay(3)
+	assert.True(ok)
+	assert.Equal(4*time.Minute, delay)
+	_, ok = sub.RetryDelay(4)
+	assert.False(ok)
+
+	sub.Retries = webhookRetriesLimit
+	sub.MinDelayMS = int(time.Hour / time.Millisecond)
+	delay, ok = sub.RetryDelay(webhookRetriesLimit)
+	assert.True(ok)
+	assert.Equal(webhookMaxDelay, delay)
+
+	subscriber := Subscriber{Type: EvergreenWebhookSubscriberType, Target: &sub}
+	assert.NoError(subscriber.Validate())
+	sub.Retries = webhookRetriesLimit + 1
+	assert.Error(subscriber.Validate())
+	sub.Retries = 1
+	sub.MinDelayMS = -1
+	assert.Error(subscriber.Validate())
+}
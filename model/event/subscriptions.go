@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/util"
@@ -30,6 +31,8 @@ var (
 	subscriptionOwnerKey          = bsonutil.MustHaveTag(Subscription{}, "Owner")
 	subscriptionOwnerTypeKey      = bsonutil.MustHaveTag(Subscription{}, "OwnerType")
 	subscriptionTriggerDataKey    = bsonutil.MustHaveTag(Subscription{}, "TriggerData")
+	subscriptionDigestKey         = bsonutil.MustHaveTag(Subscription{}, "Digest")
+	subscriptionTemplateKey       = bsonutil.MustHaveTag(Subscription{}, "Template")
 )
 
 type OwnerType string
@@ -43,9 +46,17 @@ const (
 	BuildPercentChangeKey                             = "build-percent-change"
 	VersionDurationKey                                = "version-duration-secs"
 	VersionPercentChangeKey                           = "version-percent-change"
+	TestFlakinessThresholdKey                         = "test-flakiness-threshold"
+	SuppressFlakyTestsKey                             = "suppress-flaky-tests"
+	AgentDeployFailuresKey                            = "agent-deploy-failures"
+	DistroQueueLengthKey                              = "queue-length"
+	TaskWaitMinutesKey                                = "task-wait-mins"
 	ImplicitSubscriptionPatchOutcome                  = "patch-outcome"
 	ImplicitSubscriptionBuildBreak                    = "build-break"
 	ImplicitSubscriptionSpawnhostExpiration           = "spawnhost-expiration"
+
+	DigestGroupByVersion = "version"
+	DigestGroupByBuild   = "build"
 )
 
 type Subscription struct {
@@ -58,6 +69,8 @@ type Subscription struct {
 	OwnerType      OwnerType         `bson:"owner_type"`
 	Owner          string            `bson:"owner"`
 	TriggerData    map[string]string `bson:"trigger_data,omitempty"`
+	Digest         *Digest           `bson:"digest,omitempty"`
+	Template       *Template         `bson:"template,omitempty"`
 }
 
 type unmarshalSubscription struct {
@@ -70,6 +83,8 @@ type unmarshalSubscription struct {
 	OwnerType      OwnerType         `bson:"owner_type"`
 	Owner          string            `bson:"owner"`
 	TriggerData    map[string]string `bson:"trigger_data,omitempty"`
+	Digest         *Digest           `bson:"digest,omitempty"`
+	Template       *Template         `bson:"template,omitempty"`
 }
 
 func (s *Subscription) SetBSON(raw bson.Raw) error {
@@ -88,10 +103,54 @@ func (s *Subscription) SetBSON(raw bson.Raw) error {
 	s.Owner = temp.Owner
 	s.OwnerType = temp.OwnerType
 	s.TriggerData = temp.TriggerData
+	s.Digest = temp.Digest
+	s.Template = temp.Template
 
 	return nil
 }
 
+// Digest batches the notifications for a subscription, so that the
+// subscriber is sent at most one message per interval listing everything
+// that happened in it, rather than a message for each event.
+type Digest struct {
+	IntervalMinutes int `bson:"interval_minutes"`
+	// GroupBy, if set to DigestGroupByVersion or DigestGroupByBuild, sends
+	// a separate digest for each version or build.
+	GroupBy string `bson:"group_by,omitempty"`
+}
+
+func (d *Digest) Interval() time.Duration {
+	return time.Duration(d.IntervalMinutes) * time.Minute
+}
+
+func (d *Digest) Validate(subscriberType string) error {
+	catcher := grip.NewBasicCatcher()
+	if d.IntervalMinutes <= 0 {
+		catcher.Add(errors.New("digest interval must be positive"))
+	}
+	if d.GroupBy != "" && d.GroupBy != DigestGroupByVersion && d.GroupBy != DigestGroupByBuild {
+		catcher.Add(errors.Errorf("cannot group digests by '%s'", d.GroupBy))
+	}
+	if subscriberType != EmailSubscriberType && subscriberType != SlackSubscriberType {
+		catcher.Add(errors.Errorf("digests are not supported for %s subscribers", subscriberType))
+	}
+	return catcher.Resolve()
+}
+
+// Template replaces the text of the notifications for a subscription. The
+// subject and body are Go templates, which are given the ID, DisplayName,
+// Object, Project, Description, URL and PastTenseStatus of the task, build,
+// version, patch or host that the notification is about.
+type Template struct {
+	// Subject is the subject of emails, and the title of JIRA issues and
+	// Microsoft Teams messages.
+	Subject string `bson:"subject,omitempty"`
+	// Body is the body of emails and JIRA issues and comments, and the
+	// text of Slack, Microsoft Teams and chat webhook messages. The bodies
+	// of emails are HTML templates.
+	Body string `bson:"body,omitempty"`
+}
+
 type Selector struct {
 	Type string `bson:"type"`
 	Data string `bson:"data"`
@@ -141,6 +200,25 @@ func FindSubscriptions(resourceType string, selectors []Selector) ([]Subscriptio
 	return out, nil
 }
 
+// Matches returns true if the subscription would be notified of an event
+// with the selectors.
+func (s *Subscription) Matches(selectors []Selector) bool {
+	for i := range s.Selectors {
+		found := false
+		for j := range selectors {
+			if s.Selectors[i] == selectors[j] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return len(s.RegexSelectors) == 0 || regexSelectorsMatch(selectors, s.RegexSelectors)
+}
+
 func regexSelectorsMatch(selectors []Selector, regexSelectors []Selector) bool {
 	for i := range regexSelectors {
 		selector := findSelector(selectors, regexSelectors[i].Type)
@@ -185,6 +263,8 @@ func (s *Subscription) Upsert() error {
 		subscriptionOwnerKey:          s.Owner,
 		subscriptionOwnerTypeKey:      s.OwnerType,
 		subscriptionTriggerDataKey:    s.TriggerData,
+		subscriptionDigestKey:         s.Digest,
+		subscriptionTemplateKey:       s.Template,
 	}
 
 	// note: this prevents changing the owner of an existing subscription, which is desired
@@ -261,6 +341,9 @@ func (s *Subscription) Validate() error {
 	}
 	catcher.Add(s.runCustomValidation())
 	catcher.Add(s.Subscriber.Validate())
+	if s.Digest != nil {
+		catcher.Add(s.Digest.Validate(s.Subscriber.Type))
+	}
 	return catcher.Resolve()
 }
 
@@ -285,6 +368,23 @@ func (s *Subscription) runCustomValidation() error {
 	if buildPercentVal, ok := s.TriggerData[BuildPercentChangeKey]; ok {
 		catcher.Add(validatePositiveFloat(buildPercentVal))
 	}
+	if thresholdVal, ok := s.TriggerData[TestFlakinessThresholdKey]; ok {
+		catcher.Add(validateFraction(thresholdVal))
+	}
+	if suppressVal, ok := s.TriggerData[SuppressFlakyTestsKey]; ok {
+		if _, err := strconv.ParseBool(suppressVal); err != nil {
+			catcher.Add(errors.Errorf("%s must be true or false", suppressVal))
+		}
+	}
+	if failuresVal, ok := s.TriggerData[AgentDeployFailuresKey]; ok {
+		catcher.Add(validatePositiveInt(failuresVal))
+	}
+	if queueLengthVal, ok := s.TriggerData[DistroQueueLengthKey]; ok {
+		catcher.Add(validatePositiveInt(queueLengthVal))
+	}
+	if waitVal, ok := s.TriggerData[TaskWaitMinutesKey]; ok {
+		catcher.Add(validatePositiveInt(waitVal))
+	}
 	return catcher.Resolve()
 }
 
@@ -310,6 +410,18 @@ func validatePositiveFloat(s string) error {
 	return nil
 }
 
+// validateFraction checks that a string is a number from 0 to 1.
+func validateFraction(s string) error {
+	val, err := util.TryParseFloat(s)
+	if err != nil {
+		return err
+	}
+	if val < 0 || val > 1 {
+		return fmt.Errorf("%f must be between 0 and 1", val)
+	}
+	return nil
+}
+
 func (s *Subscription) String() string {
 	id := "???"
 	if s.ID.Valid() {
@@ -331,6 +443,13 @@ func (s *Subscription) String() string {
 	}
 	tmpl = append(tmpl, "", "issue the following notification:",
 		fmt.Sprintf("\t%s", s.Subscriber))
+	if s.Digest != nil {
+		digest := fmt.Sprintf("at most once every %d minutes", s.Digest.IntervalMinutes)
+		if s.Digest.GroupBy != "" {
+			digest += fmt.Sprintf(" for each %s", s.Digest.GroupBy)
+		}
+		tmpl = append(tmpl, "", "batched into a digest sent "+digest)
+	}
 
 	out := ""
 	for i := range tmpl {
@@ -2,9 +2,11 @@ package event
 
 import (
 	"testing"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -293,3 +295,47 @@ func (s *subscriptionsSuite) TestFindSubscriptionsByOwner() {
 	s.NoError(err)
 	s.Nil(sub)
 }
+
+func TestDigestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	digest := Digest{IntervalMinutes: 30, GroupBy: DigestGroupByVersion}
+	assert.NoError(digest.Validate(EmailSubscriberType))
+	assert.NoError(digest.Validate(SlackSubscriberType))
+	assert.Error(digest.Validate(EvergreenWebhookSubscriberType))
+	assert.Equal(30*time.Minute, digest.Interval())
+
+	digest.GroupBy = "task"
+	assert.Error(digest.Validate(EmailSubscriberType))
+
+	digest = Digest{}
+	assert.Error(digest.Validate(EmailSubscriberType))
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	sub := Subscription{
+		Selectors: []Selector{
+			{Type: "project", Data: "mci"},
+		},
+		RegexSelectors: []Selector{
+			{Type: "display-name", Data: "^lint"},
+		},
+	}
+	assert.True(sub.Matches([]Selector{
+		{Type: "project", Data: "mci"},
+		{Type: "display-name", Data: "lint-go"},
+	}))
+	assert.False(sub.Matches([]Selector{
+		{Type: "project", Data: "mci"},
+		{Type: "display-name", Data: "compile"},
+	}))
+	assert.False(sub.Matches([]Selector{
+		{Type: "project", Data: "other"},
+		{Type: "display-name", Data: "lint-go"},
+	}))
+
+	sub.RegexSelectors = nil
+	assert.True(sub.Matches([]Selector{{Type: "project", Data: "mci"}}))
+}
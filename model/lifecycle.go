@@ -537,6 +537,8 @@ func CreateTasksFromGroup(in BuildVariantTaskUnit, proj *Project) []BuildVariant
 			Distros:         in.Distros,
 			ExecTimeoutSecs: in.ExecTimeoutSecs,
 			Stepback:        in.Stepback,
+			Paths:           in.Paths,
+			IgnorePaths:     in.IgnorePaths,
 		}
 		bvt.Populate(taskMap[t])
 		tasks = append(tasks, bvt)
@@ -625,7 +627,10 @@ func createTasksForBuild(project *Project, buildVariant *BuildVariant, b *build.
 		}
 		execTaskIds := []string{}
 		for _, et := range dt.ExecutionTasks {
-			execTaskIds = append(execTaskIds, execTable.GetId(b.BuildVariant, et))
+			// execution tasks that aren't created don't have ids
+			if execId := execTable.GetId(b.BuildVariant, et); execId != "" {
+				execTaskIds = append(execTaskIds, execId)
+			}
 		}
 		t := createDisplayTask(id, dt.Name, execTaskIds, buildVariant, b, v, project)
 		tasks = append(tasks, t)
@@ -703,6 +708,18 @@ func createTasksForBuild(project *Project, buildVariant *BuildVariant, b *build.
 				newTask.DependsOn = append(newTask.DependsOn, newDeps...)
 			}
 		}
+		if t.DependsOnExpr != "" {
+			expr, err := task.ParseDependencyExpression(t.DependsOnExpr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "problem parsing dependencies of task '%s'", t.Name)
+			}
+			resolved := expr.Resolve(func(name, variant string) []string {
+				return dependencyExpressionIds(name, variant, newTask, b.BuildVariant, tasksToCreate, execTable)
+			})
+			if resolved != nil {
+				newTask.DependsOnExpr = resolved.String()
+			}
+		}
 		newTask.DisplayTask = displayTasks[newTask.DisplayName]
 
 		newTask.GeneratedBy = generatedBy
@@ -720,6 +737,41 @@ func createTasksForBuild(project *Project, buildVariant *BuildVariant, b *build.
 	return tasks, nil
 }
 
+// dependencyExpressionIds returns the ids of the tasks that a dependency
+// in a task's dependency expression refers to, the same way as the task's
+// dependencies are resolved.
+func dependencyExpressionIds(name, variant string, t *task.Task, buildVariant string,
+	tasksInBuild []BuildVariantTaskUnit, execTable TaskIdTable) []string {
+	ids := []string{}
+	switch {
+	case variant == AllVariants && name == AllDependencies:
+		ids = execTable.GetIdsForAllTasks(buildVariant, t.DisplayName)
+	case variant == AllVariants:
+		ids = execTable.GetIdsForAllVariantsExcluding(name,
+			TVPair{TaskName: t.DisplayName, Variant: t.BuildVariant})
+	case name == AllDependencies:
+		for _, dep := range tasksInBuild {
+			if dep.Name != t.DisplayName {
+				ids = append(ids, execTable.GetId(buildVariant, dep.Name))
+			}
+		}
+	default:
+		if variant == "" {
+			variant = buildVariant
+		}
+		ids = append(ids, execTable.GetId(variant, name))
+	}
+
+	resolved := []string{}
+	for _, id := range ids {
+		if id != "" {
+			resolved = append(resolved, id)
+		}
+	}
+
+	return resolved
+}
+
 // setNumDeps sets NumDependents for each task in tasks.
 // NumDependents is the number of tasks depending on the task. Only tasks created at the same time
 // and in the same variant are included.
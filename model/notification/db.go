@@ -26,6 +26,14 @@ var (
 	payloadKey    = bsonutil.MustHaveTag(Notification{}, "Payload")
 	sentAtKey     = bsonutil.MustHaveTag(Notification{}, "SentAt")
 	errorKey      = bsonutil.MustHaveTag(Notification{}, "Error")
+	digestKeyKey  = bsonutil.MustHaveTag(Notification{}, "DigestKey")
+	sendAfterKey  = bsonutil.MustHaveTag(Notification{}, "SendAfter")
+	digestIDKey   = bsonutil.MustHaveTag(Notification{}, "DigestID")
+
+	subscriptionIDKey = bsonutil.MustHaveTag(Notification{}, "SubscriptionID")
+	attemptsKey       = bsonutil.MustHaveTag(Notification{}, "Attempts")
+	retryAfterKey     = bsonutil.MustHaveTag(Notification{}, "RetryAfter")
+	deferredUntilKey  = bsonutil.MustHaveTag(Notification{}, "DeferredUntil")
 )
 
 type unmarshalNotification struct {
@@ -35,6 +43,15 @@ type unmarshalNotification struct {
 
 	SentAt time.Time `bson:"sent_at,omitempty"`
 	Error  string    `bson:"error,omitempty"`
+
+	DigestKey string    `bson:"digest_key,omitempty"`
+	SendAfter time.Time `bson:"send_after,omitempty"`
+	DigestID  string    `bson:"digest_id,omitempty"`
+
+	SubscriptionID string            `bson:"subscription_id,omitempty"`
+	Attempts       []DeliveryAttempt `bson:"attempts,omitempty"`
+	RetryAfter     time.Time         `bson:"retry_after,omitempty"`
+	DeferredUntil  time.Time         `bson:"deferred_until,omitempty"`
 }
 
 func (n *Notification) SetBSON(raw bson.Raw) error {
@@ -63,6 +80,15 @@ func (n *Notification) SetBSON(raw bson.Raw) error {
 	case event.GithubPullRequestSubscriberType:
 		n.Payload = &message.GithubStatus{}
 
+	case event.GitlabMergeRequestSubscriberType:
+		n.Payload = &util.GitlabStatus{}
+
+	case event.MSTeamsSubscriberType:
+		n.Payload = &MSTeamsPayload{}
+
+	case event.ChatWebhookSubscriberType:
+		n.Payload = &ChatWebhookPayload{}
+
 	default:
 		return errors.Errorf("unknown payload type %s", temp.Subscriber.Type)
 	}
@@ -75,6 +101,13 @@ func (n *Notification) SetBSON(raw bson.Raw) error {
 	n.Subscriber = temp.Subscriber
 	n.SentAt = temp.SentAt
 	n.Error = temp.Error
+	n.DigestKey = temp.DigestKey
+	n.SendAfter = temp.SendAfter
+	n.DigestID = temp.DigestID
+	n.SubscriptionID = temp.SubscriptionID
+	n.Attempts = temp.Attempts
+	n.RetryAfter = temp.RetryAfter
+	n.DeferredUntil = temp.DeferredUntil
 
 	return nil
 }
@@ -129,3 +162,64 @@ func byID(id string) db.Q {
 		idKey: id,
 	})
 }
+
+// FindHeld finds the notifications that are waiting to be sent in digests.
+func FindHeld() ([]Notification, error) {
+	notifications := []Notification{}
+	err := db.FindAllQ(Collection, db.Query(bson.M{
+		digestKeyKey: bson.M{"$exists": true},
+		sentAtKey:    time.Time{},
+	}), &notifications)
+
+	return notifications, errors.Wrap(err, "problem finding held notifications")
+}
+
+// FindRetriesDue finds the failed notifications that are due to be retried.
+func FindRetriesDue(now time.Time) ([]Notification, error) {
+	notifications := []Notification{}
+	err := db.FindAllQ(Collection, db.Query(bson.M{
+		retryAfterKey: bson.M{"$lte": now},
+	}), &notifications)
+
+	return notifications, errors.Wrap(err, "problem finding notifications to retry")
+}
+
+// FindDeferredDue finds the deferred notifications that are due to be sent.
+func FindDeferredDue(now time.Time) ([]Notification, error) {
+	notifications := []Notification{}
+	err := db.FindAllQ(Collection, db.Query(bson.M{
+		deferredUntilKey: bson.M{"$lte": now},
+		sentAtKey:        time.Time{},
+	}), &notifications)
+
+	return notifications, errors.Wrap(err, "problem finding deferred notifications")
+}
+
+// FindFailedWebhooks finds the most recent webhook notifications that failed
+// and won't be retried, for the subscription if its ID isn't empty.
+func FindFailedWebhooks(subscriptionID string, limit int) ([]Notification, error) {
+	query := bson.M{
+		bsonutil.GetDottedKeyName(subscriberKey, "type"): event.EvergreenWebhookSubscriberType,
+		errorKey:      bson.M{"$exists": true},
+		retryAfterKey: bson.M{"$exists": false},
+	}
+	if len(subscriptionID) != 0 {
+		query[subscriptionIDKey] = subscriptionID
+	}
+
+	notifications := []Notification{}
+	err := db.FindAllQ(Collection, db.Query(query).Sort([]string{"-" + sentAtKey}).Limit(limit), &notifications)
+
+	return notifications, errors.Wrap(err, "problem finding failed webhooks")
+}
+
+// PrepareReplay stops a notification from being retried, before it is
+// sent again because a user asked for it to be.
+func (n *Notification) PrepareReplay() error {
+	n.RetryAfter = time.Time{}
+	err := db.UpdateId(Collection, n.ID, bson.M{
+		"$unset": bson.M{retryAfterKey: 1},
+	})
+
+	return errors.Wrap(err, "failed to prepare notification to be replayed")
+}
@@ -0,0 +1,178 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// digestSlackAttachmentsLimit is the most attachments that a Slack digest
+// includes from the notifications in it.
+const digestSlackAttachmentsLimit = 10
+
+const digestEmailTemplate string = `<html>
+<head>
+</head>
+<body>
+<p>Hi,</p>
+
+<p>Here is what happened in Evergreen since your last digest:</p>
+<ul>
+{{ range . }}<li>{{ . }}</li>
+{{ end }}</ul>
+
+</body>
+</html>
+`
+
+// DueDigests groups held notifications by their digest keys, and returns
+// the groups that are due to be sent, each sorted by when its notifications
+// were held.
+func DueDigests(held []Notification, now time.Time) map[string][]Notification {
+	groups := map[string][]Notification{}
+	for _, n := range held {
+		if !n.IsHeld() {
+			continue
+		}
+		groups[n.DigestKey] = append(groups[n.DigestKey], n)
+	}
+
+	due := map[string][]Notification{}
+	for key, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool { return group[i].SendAfter.Before(group[j].SendAfter) })
+		if !group[0].SendAfter.After(now) {
+			due[key] = group
+		}
+	}
+
+	return due
+}
+
+// NewDigest combines held notifications with the same digest key into a
+// single notification to their subscriber. The notifications must be
+// sorted by when they were held.
+func NewDigest(notifications []Notification) (*Notification, error) {
+	if len(notifications) == 0 {
+		return nil, errors.New("cannot create a digest of no notifications")
+	}
+	first := notifications[0]
+	for _, n := range notifications {
+		if n.DigestKey != first.DigestKey {
+			return nil, errors.Errorf("notification '%s' is not in digest '%s'", n.ID, first.DigestKey)
+		}
+		if n.Subscriber.Type != first.Subscriber.Type {
+			return nil, errors.Errorf("notification '%s' is for a different subscriber", n.ID)
+		}
+	}
+
+	digest := &Notification{
+		ID:             fmt.Sprintf("digest-%s-%d", first.DigestKey, first.SendAfter.Unix()),
+		Subscriber:     first.Subscriber,
+		SubscriptionID: first.SubscriptionID,
+	}
+
+	var err error
+	switch first.Subscriber.Type {
+	case event.EmailSubscriberType:
+		digest.Payload, err = emailDigest(digest.ID, notifications)
+	case event.SlackSubscriberType:
+		digest.Payload, err = slackDigest(notifications)
+	default:
+		err = errors.Errorf("digests are not supported for %s subscribers", first.Subscriber.Type)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem creating digest '%s'", digest.ID)
+	}
+
+	return digest, nil
+}
+
+func emailDigest(id string, notifications []Notification) (*message.Email, error) {
+	entries := []template.HTML{}
+	headers := map[string][]string{}
+	for _, n := range notifications {
+		payload, ok := n.Payload.(*message.Email)
+		if !ok || payload == nil {
+			return nil, errors.Errorf("email payload of notification '%s' is invalid", n.ID)
+		}
+		// the bodies of held emails are generated from templates that
+		// escape their contents
+		entries = append(entries, template.HTML(payload.Body))
+		for key, values := range payload.Headers {
+			for _, value := range values {
+				if !util.StringSliceContains(headers[key], value) {
+					headers[key] = append(headers[key], value)
+				}
+			}
+		}
+	}
+
+	bodyTmpl, err := template.New("emailDigest").Parse(digestEmailTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse digest template")
+	}
+	buf := &bytes.Buffer{}
+	if err = bodyTmpl.Execute(buf, entries); err != nil {
+		return nil, errors.Wrap(err, "failed to execute digest template")
+	}
+
+	// prevent Gmail from threading digests with each other
+	headers["X-Entity-Ref-Id"] = []string{id}
+
+	return &message.Email{
+		Subject:           fmt.Sprintf("Evergreen: %d notifications since your last digest", len(notifications)),
+		Body:              buf.String(),
+		PlainTextContents: false,
+		Headers:           headers,
+	}, nil
+}
+
+func slackDigest(notifications []Notification) (*SlackPayload, error) {
+	lines := []string{fmt.Sprintf("%d notifications since your last digest:", len(notifications))}
+	attachments := []message.SlackAttachment{}
+	for _, n := range notifications {
+		payload, ok := n.Payload.(*SlackPayload)
+		if !ok || payload == nil {
+			return nil, errors.Errorf("slack payload of notification '%s' is invalid", n.ID)
+		}
+		lines = append(lines, payload.Body)
+		for _, attachment := range payload.Attachments {
+			if len(attachments) < digestSlackAttachmentsLimit {
+				attachments = append(attachments, attachment)
+			}
+		}
+	}
+
+	return &SlackPayload{
+		Body:        strings.Join(lines, "\n"),
+		Attachments: attachments,
+	}, nil
+}
+
+// MarkDigested marks held notifications as sent in the digest.
+func MarkDigested(ids []string, digestID string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := db.UpdateAll(Collection, bson.M{
+		idKey: bson.M{"$in": ids},
+	}, bson.M{
+		"$set": bson.M{
+			sentAtKey:   time.Now().Truncate(time.Millisecond),
+			digestIDKey: digestID,
+		},
+	})
+
+	return errors.Wrapf(err, "failed to mark notifications as sent in digest '%s'", digestID)
+}
@@ -0,0 +1,127 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/mongodb/grip/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestHold(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	sub := event.Subscription{ID: bson.NewObjectId()}
+	n := Notification{ID: "n"}
+	n.Hold(&sub, "v1", now)
+	assert.False(n.IsHeld())
+
+	sub.Digest = &event.Digest{IntervalMinutes: 30}
+	n.Hold(&sub, "v1", now)
+	assert.True(n.IsHeld())
+	assert.Equal(sub.ID.Hex()+"-v1", n.DigestKey)
+	assert.Equal(now.Add(30*time.Minute), n.SendAfter)
+
+	n.Hold(&sub, "", now)
+	assert.Equal(sub.ID.Hex(), n.DigestKey)
+
+	n.SentAt = now
+	assert.False(n.IsHeld())
+}
+
+func TestDueDigests(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	held := []Notification{
+		{ID: "a2", DigestKey: "a", SendAfter: now.Add(time.Minute)},
+		{ID: "a1", DigestKey: "a", SendAfter: now.Add(-time.Minute)},
+		{ID: "b1", DigestKey: "b", SendAfter: now.Add(time.Minute)},
+		{ID: "c1", DigestKey: "c", SendAfter: now.Add(-time.Minute), SentAt: now},
+		{ID: "d1", SendAfter: now.Add(-time.Minute)},
+	}
+
+	due := DueDigests(held, now)
+	assert.Len(due, 1)
+	if assert.Len(due["a"], 2) {
+		assert.Equal("a1", due["a"][0].ID)
+		assert.Equal("a2", due["a"][1].ID)
+	}
+}
+
+func TestNewDigest(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	now := time.Now()
+	email := "me@example.com"
+	subscriber := event.Subscriber{Type: event.EmailSubscriberType, Target: &email}
+	notifications := []Notification{
+		{
+			ID:         "n1",
+			Subscriber: subscriber,
+			Payload: &message.Email{
+				Body:    `The task in 'proj' <a href="url1">compile</a> has failed.`,
+				Headers: map[string][]string{"X-Evergreen-project": {"proj"}},
+			},
+			DigestKey: "key",
+			SendAfter: now,
+		},
+		{
+			ID:         "n2",
+			Subscriber: subscriber,
+			Payload: &message.Email{
+				Body:    `The task in 'proj' <a href="url2">test</a> has failed.`,
+				Headers: map[string][]string{"X-Evergreen-project": {"proj"}, "X-Evergreen-id": {"test"}},
+			},
+			DigestKey: "key",
+			SendAfter: now.Add(time.Minute),
+		},
+	}
+
+	digest, err := NewDigest(notifications)
+	require.NoError(err)
+	assert.Equal(subscriber, digest.Subscriber)
+	assert.False(digest.IsHeld())
+	payload, ok := digest.Payload.(*message.Email)
+	require.True(ok)
+	assert.Equal("Evergreen: 2 notifications since your last digest", payload.Subject)
+	assert.Contains(payload.Body, `<li>The task in 'proj' <a href="url1">compile</a> has failed.</li>`)
+	assert.Contains(payload.Body, `<li>The task in 'proj' <a href="url2">test</a> has failed.</li>`)
+	assert.Equal([]string{"proj"}, payload.Headers["X-Evergreen-project"])
+	assert.Equal([]string{"test"}, payload.Headers["X-Evergreen-id"])
+	assert.Equal([]string{digest.ID}, payload.Headers["X-Entity-Ref-Id"])
+
+	channel := "#channel"
+	slackSubscriber := event.Subscriber{Type: event.SlackSubscriberType, Target: &channel}
+	notifications = []Notification{}
+	for _, body := range []string{"first", "second", "third"} {
+		notifications = append(notifications, Notification{
+			ID:         body,
+			Subscriber: slackSubscriber,
+			Payload: &SlackPayload{
+				Body:        body,
+				Attachments: make([]message.SlackAttachment, 4),
+			},
+			DigestKey: "key",
+		})
+	}
+	digest, err = NewDigest(notifications)
+	require.NoError(err)
+	slack, ok := digest.Payload.(*SlackPayload)
+	require.True(ok)
+	assert.Equal("3 notifications since your last digest:\nfirst\nsecond\nthird", slack.Body)
+	assert.Len(slack.Attachments, digestSlackAttachmentsLimit)
+
+	// notifications in different digests can't be combined
+	notifications[1].DigestKey = "other"
+	_, err = NewDigest(notifications)
+	assert.Error(err)
+
+	_, err = NewDigest(nil)
+	assert.Error(err)
+}
@@ -1,7 +1,10 @@
 package notification
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -16,6 +19,10 @@ import (
 	"gopkg.in/mgo.v2/bson"
 )
 
+// webhookAttemptHeader is the number of the attempt to send a webhook,
+// which is more than 1 for retries and replays.
+const webhookAttemptHeader = "X-Evergreen-Delivery-Attempt"
+
 // makeNotificationID creates a string representing the notification generated
 // from the given event, with the given trigger, for the given subscriber.
 // This function will produce an ID that will collide to prevent duplicate
@@ -53,6 +60,145 @@ type Notification struct {
 
 	SentAt time.Time `bson:"sent_at"`
 	Error  string    `bson:"error,omitempty"`
+
+	// DigestKey is set for a notification that is held to be sent in a
+	// digest, along with the other held notifications with the same key,
+	// once it is past the SendAfter time of any of them.
+	DigestKey string    `bson:"digest_key,omitempty"`
+	SendAfter time.Time `bson:"send_after,omitempty"`
+	// DigestID is the ID of the digest that a held notification was sent in.
+	DigestID string `bson:"digest_id,omitempty"`
+
+	// SubscriptionID is the ID of the subscription that the notification
+	// was created for.
+	SubscriptionID string `bson:"subscription_id,omitempty"`
+	// Attempts is the history of attempts to send a webhook notification,
+	// which is retried after RetryAfter if its last attempt failed.
+	Attempts   []DeliveryAttempt `bson:"attempts,omitempty"`
+	RetryAfter time.Time         `bson:"retry_after,omitempty"`
+	// DeferredUntil is set for a notification that was held during its
+	// subscriber's quiet hours, to be sent once they end.
+	DeferredUntil time.Time `bson:"deferred_until,omitempty"`
+}
+
+// DeliveryAttempt is an attempt to send a webhook notification.
+type DeliveryAttempt struct {
+	Time  time.Time `bson:"time"`
+	Error string    `bson:"error,omitempty"`
+	// Replay is true for an attempt that a user asked for after the
+	// notification failed.
+	Replay bool `bson:"replay,omitempty"`
+}
+
+// Hold holds the notification to be sent in a digest for the subscription.
+func (n *Notification) Hold(sub *event.Subscription, group string, now time.Time) {
+	if sub.Digest == nil {
+		return
+	}
+	n.DigestKey = sub.ID.Hex()
+	if group != "" {
+		n.DigestKey += "-" + group
+	}
+	n.SendAfter = now.Add(sub.Digest.Interval())
+}
+
+// IsHeld returns true if the notification is waiting to be sent in a digest.
+func (n *Notification) IsHeld() bool {
+	return n.DigestKey != "" && n.SentAt.IsZero()
+}
+
+// Defer holds the notification until the time, after which it is sent.
+func (n *Notification) Defer(until time.Time) error {
+	if len(n.ID) == 0 {
+		return errors.New("notification has no ID")
+	}
+
+	n.DeferredUntil = until.Truncate(time.Millisecond)
+	err := db.UpdateId(Collection, n.ID, bson.M{
+		"$set": bson.M{deferredUntilKey: n.DeferredUntil},
+	})
+
+	return errors.Wrap(err, "failed to defer notification")
+}
+
+// IsRetried returns true if the notification's attempts to send it are
+// recorded, and it is retried if they fail.
+func (n *Notification) IsRetried() bool {
+	return n.Subscriber.Type == event.EvergreenWebhookSubscriberType
+}
+
+// RecordAttempt records an attempt to send the notification, marking it as
+// sent, and with the error if it failed. If the subscriber retries failed
+// notifications, and the notification hasn't run out of retries, it is
+// marked to be retried.
+func (n *Notification) RecordAttempt(sendErr error, replay bool, now time.Time) error {
+	if len(n.ID) == 0 {
+		return errors.New("notification has no ID")
+	}
+
+	attempt := n.addAttempt(sendErr, replay, now)
+	set := bson.M{
+		sentAtKey: n.SentAt,
+	}
+	unset := bson.M{}
+	if len(n.Error) != 0 {
+		set[errorKey] = n.Error
+	} else {
+		unset[errorKey] = 1
+	}
+	if !n.RetryAfter.IsZero() {
+		set[retryAfterKey] = n.RetryAfter
+	} else {
+		unset[retryAfterKey] = 1
+	}
+	update := bson.M{
+		"$set":  set,
+		"$push": bson.M{attemptsKey: attempt},
+	}
+	if len(unset) != 0 {
+		update["$unset"] = unset
+	}
+
+	return errors.Wrap(db.UpdateId(Collection, n.ID, update), "failed to record notification attempt")
+}
+
+func (n *Notification) addAttempt(sendErr error, replay bool, now time.Time) DeliveryAttempt {
+	now = now.Truncate(time.Millisecond)
+	attempt := DeliveryAttempt{
+		Time:   now,
+		Replay: replay,
+	}
+	n.SentAt = now
+	n.Error = ""
+	n.RetryAfter = time.Time{}
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+		n.Error = attempt.Error
+	}
+	n.Attempts = append(n.Attempts, attempt)
+
+	if sendErr == nil || replay {
+		return attempt
+	}
+
+	failures := 0
+	for i := len(n.Attempts) - 1; i >= 0 && n.Attempts[i].Error != "" && !n.Attempts[i].Replay; i-- {
+		failures++
+	}
+	var sub *event.WebhookSubscriber
+	switch v := n.Subscriber.Target.(type) {
+	case *event.WebhookSubscriber:
+		sub = v
+	case event.WebhookSubscriber:
+		sub = &v
+	}
+	if sub != nil {
+		if delay, ok := sub.RetryDelay(failures); ok {
+			n.RetryAfter = now.Add(delay)
+		}
+	}
+
+	return attempt
 }
 
 // SenderKey returns an evergreen.SenderKey to get a grip sender for this
@@ -77,6 +223,15 @@ func (n *Notification) SenderKey() (evergreen.SenderKey, error) {
 	case event.GithubPullRequestSubscriberType:
 		return evergreen.SenderGithubStatus, nil
 
+	case event.GitlabMergeRequestSubscriberType:
+		return evergreen.SenderGitlabStatus, nil
+
+	case event.MSTeamsSubscriberType:
+		return evergreen.SenderMSTeams, nil
+
+	case event.ChatWebhookSubscriberType:
+		return evergreen.SenderChatWebhook, nil
+
 	default:
 		return evergreen.SenderEmail, errors.Errorf("unknown type '%s'", n.Subscriber.Type)
 	}
@@ -101,6 +256,10 @@ func (n *Notification) Composer() (message.Composer, error) {
 		payload.Secret = sub.Secret
 		payload.URL = sub.URL
 		payload.NotificationID = n.ID
+		if len(n.Attempts) != 0 {
+			payload.Headers = copyHeaders(payload.Headers)
+			payload.Headers.Set(webhookAttemptHeader, strconv.Itoa(len(n.Attempts)+1))
+		}
 
 		return util.NewWebhookMessageWithStruct(*payload), nil
 
@@ -170,11 +329,70 @@ func (n *Notification) Composer() (message.Composer, error) {
 
 		return message.NewGithubStatusMessageWithRepo(level.Notice, *payload), nil
 
+	case event.GitlabMergeRequestSubscriberType:
+		sub, ok := n.Subscriber.Target.(*event.GitlabMergeRequestSubscriber)
+		if !ok {
+			return nil, errors.New("gitlab-merge-request subscriber is invalid")
+		}
+		payload, ok := n.Payload.(*util.GitlabStatus)
+		if !ok || payload == nil {
+			return nil, errors.New("gitlab-merge-request payload is invalid")
+		}
+		payload.ProjectPath = sub.ProjectPath
+		payload.SHA = sub.Ref
+
+		return util.NewGitlabStatusMessage(*payload), nil
+
+	case event.MSTeamsSubscriberType:
+		sub, ok := n.Subscriber.Target.(*string)
+		if !ok {
+			return nil, errors.New("ms-teams subscriber is invalid")
+		}
+
+		payload, ok := n.Payload.(*MSTeamsPayload)
+		if !ok || payload == nil {
+			return nil, errors.New("ms-teams payload is invalid")
+		}
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "error building ms-teams message")
+		}
+
+		return util.NewChatWebhookMessage(*sub, body), nil
+
+	case event.ChatWebhookSubscriberType:
+		sub, ok := n.Subscriber.Target.(*string)
+		if !ok {
+			return nil, errors.New("chat-webhook subscriber is invalid")
+		}
+
+		payload, ok := n.Payload.(*ChatWebhookPayload)
+		if !ok || payload == nil {
+			return nil, errors.New("chat-webhook payload is invalid")
+		}
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "error building chat-webhook message")
+		}
+
+		return util.NewChatWebhookMessage(*sub, body), nil
+
 	default:
 		return nil, errors.Errorf("unknown type '%s'", n.Subscriber.Type)
 	}
 }
 
+func copyHeaders(headers http.Header) http.Header {
+	out := http.Header{}
+	for k, v := range headers {
+		out[k] = append([]string{}, v...)
+	}
+
+	return out
+}
+
 func (n *Notification) MarkSent() error {
 	if len(n.ID) == 0 {
 		return errors.New("notification has no ID")
@@ -225,23 +443,67 @@ func (n *Notification) MarkError(sendErr error) error {
 }
 
 type NotificationStats struct {
-	GithubPullRequest int `json:"github_pull_request" bson:"github_pull_request" yaml:"github_pull_request"`
-	JIRAIssue         int `json:"jira_issue" bson:"jira_issue" yaml:"jira_issue"`
-	JIRAComment       int `json:"jira_comment" bson:"jira_comment" yaml:"jira_comment"`
-	EvergreenWebhook  int `json:"evergreen_webhook" bson:"evergreen_webhook" yaml:"evergreen_webhook"`
-	Email             int `json:"email" bson:"email" yaml:"email"`
-	Slack             int `json:"slack" bson:"slack" yaml:"slack"`
+	GithubPullRequest  int `json:"github_pull_request" bson:"github_pull_request" yaml:"github_pull_request"`
+	GitlabMergeRequest int `json:"gitlab_merge_request" bson:"gitlab_merge_request" yaml:"gitlab_merge_request"`
+	JIRAIssue          int `json:"jira_issue" bson:"jira_issue" yaml:"jira_issue"`
+	JIRAComment        int `json:"jira_comment" bson:"jira_comment" yaml:"jira_comment"`
+	EvergreenWebhook   int `json:"evergreen_webhook" bson:"evergreen_webhook" yaml:"evergreen_webhook"`
+	Email              int `json:"email" bson:"email" yaml:"email"`
+	Slack              int `json:"slack" bson:"slack" yaml:"slack"`
+	MSTeams            int `json:"ms_teams" bson:"ms_teams" yaml:"ms_teams"`
+	ChatWebhook        int `json:"chat_webhook" bson:"chat_webhook" yaml:"chat_webhook"`
 }
 
+// CollectUnsentNotificationStats counts the notifications waiting to be
+// sent, other than those held for digests.
 func CollectUnsentNotificationStats() (*NotificationStats, error) {
+	stats, err := collectNotificationStats(bson.M{
+		sentAtKey: bson.M{
+			"$eq": time.Time{},
+		},
+		digestKeyKey: bson.M{
+			"$exists": false,
+		},
+		deferredUntilKey: bson.M{
+			"$exists": false,
+		},
+	})
+	return stats, errors.Wrap(err, "failed to count unsent notifications")
+}
+
+// CollectDeferredNotificationStats counts the notifications deferred until
+// their subscribers' quiet hours end.
+func CollectDeferredNotificationStats() (*NotificationStats, error) {
+	stats, err := collectNotificationStats(bson.M{
+		sentAtKey: bson.M{
+			"$eq": time.Time{},
+		},
+		deferredUntilKey: bson.M{
+			"$exists": true,
+		},
+	})
+	return stats, errors.Wrap(err, "failed to count deferred notifications")
+}
+
+// CollectHeldNotificationStats counts the notifications held to be sent in
+// digests.
+func CollectHeldNotificationStats() (*NotificationStats, error) {
+	stats, err := collectNotificationStats(bson.M{
+		sentAtKey: bson.M{
+			"$eq": time.Time{},
+		},
+		digestKeyKey: bson.M{
+			"$exists": true,
+		},
+	})
+	return stats, errors.Wrap(err, "failed to count held notifications")
+}
+
+func collectNotificationStats(match bson.M) (*NotificationStats, error) {
 	const subscriberTypeKey = "type"
 	pipeline := []bson.M{
 		{
-			"$match": bson.M{
-				sentAtKey: bson.M{
-					"$eq": time.Time{},
-				},
-			},
+			"$match": match,
 		},
 		{
 			"$group": bson.M{
@@ -259,7 +521,7 @@ func CollectUnsentNotificationStats() (*NotificationStats, error) {
 	}{}
 
 	if err := db.Aggregate(Collection, pipeline, &stats); err != nil {
-		return nil, errors.Wrap(err, "failed to count unsent notifications")
+		return nil, errors.WithStack(err)
 	}
 
 	nStats := NotificationStats{}
@@ -269,6 +531,9 @@ func CollectUnsentNotificationStats() (*NotificationStats, error) {
 		case event.GithubPullRequestSubscriberType:
 			nStats.GithubPullRequest = data.Count
 
+		case event.GitlabMergeRequestSubscriberType:
+			nStats.GitlabMergeRequest = data.Count
+
 		case event.JIRAIssueSubscriberType:
 			nStats.JIRAIssue = data.Count
 
@@ -284,6 +549,12 @@ func CollectUnsentNotificationStats() (*NotificationStats, error) {
 		case event.SlackSubscriberType:
 			nStats.Slack = data.Count
 
+		case event.MSTeamsSubscriberType:
+			nStats.MSTeams = data.Count
+
+		case event.ChatWebhookSubscriberType:
+			nStats.ChatWebhook = data.Count
+
 		default:
 			grip.Error(message.Fields{
 				"message": fmt.Sprintf("unknown subscriber %s", data.Key),
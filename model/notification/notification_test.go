@@ -11,6 +11,7 @@ import (
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip/message"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -371,3 +372,59 @@ func (s *notificationSuite) TestCollectUnsentNotificationStats() {
 		s.Equal(1, int(f.Int()))
 	}
 }
+
+func TestAddAttempt(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now().Truncate(time.Millisecond)
+	n := Notification{
+		ID: "n",
+		Subscriber: event.Subscriber{
+			Type: event.EvergreenWebhookSubscriberType,
+			Target: &event.WebhookSubscriber{
+				URL:     "https://example.com/hook",
+				Retries: 2,
+			},
+		},
+	}
+	assert.True(n.IsRetried())
+
+	n.addAttempt(errors.New("503"), false, now)
+	assert.Equal(now, n.SentAt)
+	assert.Equal("503", n.Error)
+	assert.Equal(now.Add(time.Minute), n.RetryAfter)
+
+	n.addAttempt(errors.New("503"), false, now)
+	assert.Equal(now.Add(2*time.Minute), n.RetryAfter)
+
+	// out of retries
+	n.addAttempt(errors.New("503"), false, now)
+	assert.True(n.RetryAfter.IsZero())
+	assert.Len(n.Attempts, 3)
+
+	// replays aren't retried, and reset the count of failures
+	n.addAttempt(errors.New("503"), true, now)
+	assert.True(n.RetryAfter.IsZero())
+	n.addAttempt(errors.New("503"), false, now)
+	assert.Equal(now.Add(time.Minute), n.RetryAfter)
+
+	n.addAttempt(nil, false, now)
+	assert.Empty(n.Error)
+	assert.True(n.RetryAfter.IsZero())
+	if assert.Len(n.Attempts, 6) {
+		assert.True(n.Attempts[3].Replay)
+		assert.Empty(n.Attempts[5].Error)
+	}
+
+	composer, err := n.Composer()
+	assert.Error(err)
+	assert.Nil(composer)
+
+	n.Payload = &util.EvergreenWebhook{Body: []byte("{}")}
+	composer, err = n.Composer()
+	assert.NoError(err)
+	raw, ok := composer.Raw().(*util.EvergreenWebhook)
+	if assert.True(ok) {
+		assert.Equal("7", raw.Headers.Get(webhookAttemptHeader))
+	}
+}
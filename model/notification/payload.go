@@ -1,8 +1,87 @@
 package notification
 
-import "github.com/mongodb/grip/message"
+import (
+	"encoding/json"
+
+	"github.com/mongodb/grip/message"
+)
 
 type SlackPayload struct {
 	Body        string                    `bson:"body"`
 	Attachments []message.SlackAttachment `bson:"attachments"`
 }
+
+// MSTeamsPayload is a Microsoft Teams message card.
+type MSTeamsPayload struct {
+	Summary    string        `bson:"summary"`
+	Title      string        `bson:"title"`
+	Text       string        `bson:"text"`
+	ThemeColor string        `bson:"theme_color,omitempty"`
+	Links      []MSTeamsLink `bson:"links,omitempty"`
+	Facts      []MSTeamsFact `bson:"facts,omitempty"`
+}
+
+// MSTeamsLink is a button on a Microsoft Teams message card that opens a URL.
+type MSTeamsLink struct {
+	Name string `bson:"name"`
+	URL  string `bson:"url"`
+}
+
+// MSTeamsFact is a name and value listed on a Microsoft Teams message card.
+type MSTeamsFact struct {
+	Name  string `bson:"name" json:"name"`
+	Value string `bson:"value" json:"value"`
+}
+
+// MarshalJSON returns the message card in the format of the Microsoft
+// Teams incoming webhook API.
+func (p *MSTeamsPayload) MarshalJSON() ([]byte, error) {
+	type target struct {
+		OS  string `json:"os"`
+		URI string `json:"uri"`
+	}
+	type action struct {
+		Type    string   `json:"@type"`
+		Name    string   `json:"name"`
+		Targets []target `json:"targets"`
+	}
+	type section struct {
+		Facts []MSTeamsFact `json:"facts"`
+	}
+	card := struct {
+		Type       string    `json:"@type"`
+		Context    string    `json:"@context"`
+		Summary    string    `json:"summary"`
+		Title      string    `json:"title"`
+		Text       string    `json:"text"`
+		ThemeColor string    `json:"themeColor,omitempty"`
+		Sections   []section `json:"sections,omitempty"`
+		Actions    []action  `json:"potentialAction,omitempty"`
+	}{
+		Type:       "MessageCard",
+		Context:    "https://schema.org/extensions",
+		Summary:    p.Summary,
+		Title:      p.Title,
+		Text:       p.Text,
+		ThemeColor: p.ThemeColor,
+	}
+	if len(p.Facts) > 0 {
+		card.Sections = []section{{Facts: p.Facts}}
+	}
+	for _, link := range p.Links {
+		card.Actions = append(card.Actions, action{
+			Type:    "OpenUri",
+			Name:    link.Name,
+			Targets: []target{{OS: "default", URI: link.URL}},
+		})
+	}
+
+	return json.Marshal(card)
+}
+
+// ChatWebhookPayload is a message in the format of Slack's incoming
+// webhooks, which other chat services, like Mattermost, also accept.
+type ChatWebhookPayload struct {
+	Text        string                    `bson:"text" json:"text"`
+	Attachments []message.SlackAttachment `bson:"attachments" json:"attachments,omitempty"`
+}
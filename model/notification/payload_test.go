@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMSTeamsPayloadJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	payload := &MSTeamsPayload{
+		Summary:    "title",
+		Title:      "title",
+		Text:       "text",
+		ThemeColor: "ce3c3e",
+		Links:      []MSTeamsLink{{Name: "Task", URL: "https://example.com/task"}},
+		Facts:      []MSTeamsFact{{Name: "Task", Value: "failed"}},
+	}
+	out, err := json.Marshal(payload)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"@type": "MessageCard",
+		"@context": "https://schema.org/extensions",
+		"summary": "title",
+		"title": "title",
+		"text": "text",
+		"themeColor": "ce3c3e",
+		"sections": [{"facts": [{"name": "Task", "value": "failed"}]}],
+		"potentialAction": [{
+			"@type": "OpenUri",
+			"name": "Task",
+			"targets": [{"os": "default", "uri": "https://example.com/task"}]
+		}]
+	}`, string(out))
+}
+
+func TestChatComposers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	url := "https://chat.example.com/hooks/abc"
+	n := Notification{
+		ID:         "n",
+		Subscriber: event.Subscriber{Type: event.MSTeamsSubscriberType, Target: &url},
+		Payload:    &MSTeamsPayload{Title: "title", Text: "text"},
+	}
+	key, err := n.SenderKey()
+	assert.NoError(err)
+	assert.Equal(evergreen.SenderMSTeams, key)
+	c, err := n.Composer()
+	require.NoError(err)
+	assert.True(c.Loggable())
+	raw, ok := c.Raw().(*util.ChatWebhook)
+	require.True(ok)
+	assert.Equal(url, raw.URL)
+	assert.Contains(string(raw.Body), `"@type":"MessageCard"`)
+
+	n = Notification{
+		ID:         "n",
+		Subscriber: event.Subscriber{Type: event.ChatWebhookSubscriberType, Target: &url},
+		Payload: &ChatWebhookPayload{
+			Text:        "text",
+			Attachments: []message.SlackAttachment{{Title: "Task", Text: "failed"}},
+		},
+	}
+	key, err = n.SenderKey()
+	assert.NoError(err)
+	assert.Equal(evergreen.SenderChatWebhook, key)
+	c, err = n.Composer()
+	require.NoError(err)
+	raw, ok = c.Raw().(*util.ChatWebhook)
+	require.True(ok)
+	assert.JSONEq(`{"text": "text", "attachments": [{"title": "Task", "text": "failed", "fallback": ""}]}`, string(raw.Body))
+
+	n.Payload = &SlackPayload{}
+	_, err = n.Composer()
+	assert.Error(err)
+}
@@ -35,6 +35,8 @@ var (
 	ActivatedKey       = bsonutil.MustHaveTag(Patch{}, "Activated")
 	PatchedConfigKey   = bsonutil.MustHaveTag(Patch{}, "PatchedConfig")
 	githubPatchDataKey = bsonutil.MustHaveTag(Patch{}, "GithubPatchData")
+	gitlabPatchDataKey = bsonutil.MustHaveTag(Patch{}, "GitlabPatchData")
+	FilterByPathsKey   = bsonutil.MustHaveTag(Patch{}, "FilterByPaths")
 
 	// BSON fields for the module patch struct
 	ModulePatchNameKey    = bsonutil.MustHaveTag(ModulePatch{}, "ModuleName")
@@ -59,6 +61,10 @@ var (
 	githubPatchHeadRepoKey   = bsonutil.MustHaveTag(GithubPatch{}, "HeadRepo")
 	githubPatchHeadHashKey   = bsonutil.MustHaveTag(GithubPatch{}, "HeadHash")
 	githubPatchAuthorKey     = bsonutil.MustHaveTag(GithubPatch{}, "Author")
+
+	// BSON fields for GitlabPatch
+	gitlabPatchMRNumberKey    = bsonutil.MustHaveTag(GitlabPatch{}, "MRNumber")
+	gitlabPatchProjectPathKey = bsonutil.MustHaveTag(GitlabPatch{}, "ProjectPath")
 )
 
 // Query Validation
@@ -197,6 +203,16 @@ func PatchesByProject(projectId string, ts time.Time, limit int, sortAsc bool) d
 	return db.Query(filter).Sort([]string{sortSpec}).Limit(limit)
 }
 
+// ByGithubPR builds a query for the patches of a GitHub pull request,
+// newest first.
+func ByGithubPR(owner, repo string, prNumber int) db.Q {
+	return db.Query(bson.M{
+		bsonutil.GetDottedKeyName(githubPatchDataKey, githubPatchBaseOwnerKey): owner,
+		bsonutil.GetDottedKeyName(githubPatchDataKey, githubPatchBaseRepoKey):  repo,
+		bsonutil.GetDottedKeyName(githubPatchDataKey, githubPatchPRNumberKey):  prNumber,
+	}).Sort([]string{"-" + CreateTimeKey})
+}
+
 func ByGithubPRAndCreatedBefore(t time.Time, owner, repo string, prNumber int) db.Q {
 	return db.Query(bson.M{
 		CreateTimeKey: bson.M{
@@ -207,3 +223,13 @@ func ByGithubPRAndCreatedBefore(t time.Time, owner, repo string, prNumber int) d
 		bsonutil.GetDottedKeyName(githubPatchDataKey, githubPatchPRNumberKey):  prNumber,
 	})
 }
+
+func ByGitlabMRAndCreatedBefore(t time.Time, projectPath string, mrNumber int) db.Q {
+	return db.Query(bson.M{
+		CreateTimeKey: bson.M{
+			"$lt": t,
+		},
+		bsonutil.GetDottedKeyName(gitlabPatchDataKey, gitlabPatchProjectPathKey): projectPath,
+		bsonutil.GetDottedKeyName(gitlabPatchDataKey, gitlabPatchMRNumberKey):    mrNumber,
+	})
+}
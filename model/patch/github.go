@@ -77,6 +77,10 @@ type githubIntent struct {
 
 	// IntentType indicates the type of the patch intent, e.g. GithubIntentType
 	IntentType string `bson:"intent_type"`
+
+	// Alias defines the variants and tasks to run the patch on, instead of
+	// the project's GitHub alias
+	Alias string `bson:"alias,omitempty"`
 }
 
 // BSON fields for the patches
@@ -148,6 +152,22 @@ func NewGithubIntent(msgDeliveryID string, event *github.PullRequestEvent) (Inte
 	}, nil
 }
 
+// NewGithubIntentWithAlias creates an Intent like NewGithubIntent, whose
+// patch runs the variants and tasks of the given alias instead of the
+// project's GitHub alias.
+func NewGithubIntentWithAlias(msgDeliveryID, alias string, event *github.PullRequestEvent) (Intent, error) {
+	if alias == "" {
+		return nil, errors.New("alias cannot be empty")
+	}
+	intent, err := NewGithubIntent(msgDeliveryID, event)
+	if err != nil {
+		return nil, err
+	}
+	intent.(*githubIntent).Alias = alias
+
+	return intent, nil
+}
+
 // SetProcessed should be called by an amboy queue after creating a patch from an intent.
 func (g *githubIntent) SetProcessed() error {
 	g.Processed = true
@@ -219,7 +239,7 @@ func (g *githubIntent) NewPatch() *Patch {
 	headRepo := strings.Split(g.HeadRepoName, "/")
 	pullURL := fmt.Sprintf("https://github.com/%s/pull/%d", g.BaseRepoName, g.PRNumber)
 	patchDoc := &Patch{
-		Alias:       GithubAlias,
+		Alias:       g.GetAlias(),
 		Description: fmt.Sprintf("'%s' pull request #%d by %s: %s (%s)", g.BaseRepoName, g.PRNumber, g.User, g.Title, pullURL),
 		Author:      evergreen.GithubPatchUser,
 		Status:      evergreen.PatchCreated,
@@ -240,5 +260,8 @@ func (g *githubIntent) NewPatch() *Patch {
 }
 
 func (g *githubIntent) GetAlias() string {
+	if g.Alias != "" {
+		return g.Alias
+	}
 	return GithubAlias
 }
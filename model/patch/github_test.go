@@ -239,3 +239,22 @@ func (s *GithubSuite) TestNewPatch() {
 	s.Equal("octocat", patchDoc.GithubPatchData.Author)
 	s.Equal(1234, patchDoc.GithubPatchData.AuthorUID)
 }
+
+func (s *GithubSuite) TestNewGithubIntentWithAlias() {
+	event := testutil.NewGithubPREvent(s.pr, s.baseRepo, s.headRepo, s.hash, s.user, s.title)
+	intent, err := NewGithubIntentWithAlias("5", "", event)
+	s.Error(err)
+	s.Nil(intent)
+
+	intent, err = NewGithubIntentWithAlias("5", "lint", event)
+	s.NoError(err)
+	s.Require().NotNil(intent)
+	s.Equal("lint", intent.GetAlias())
+	s.Equal("lint", intent.NewPatch().Alias)
+	s.NoError(intent.Insert())
+
+	intents, err := FindUnprocessedGithubIntents()
+	s.NoError(err)
+	s.Require().Len(intents, 1)
+	s.Equal("lint", intents[0].GetAlias())
+}
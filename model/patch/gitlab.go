@@ -0,0 +1,239 @@
+package patch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GitlabIntentType represents patch intents created for GitLab.
+const GitlabIntentType = "gitlab"
+
+// GitlabMergeRequestEvent is the payload of the merge request webhooks that
+// GitLab sends, with only the fields that evergreen uses.
+type GitlabMergeRequestEvent struct {
+	ObjectKind       string                       `json:"object_kind"`
+	User             GitlabUser                   `json:"user"`
+	Project          GitlabProject                `json:"project"`
+	ObjectAttributes GitlabMergeRequestAttributes `json:"object_attributes"`
+}
+
+type GitlabUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+type GitlabProject struct {
+	ID                int    `json:"id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+type GitlabMergeRequestAttributes struct {
+	// IID is the number of the merge request in its target project.
+	IID          int           `json:"iid"`
+	AuthorID     int           `json:"author_id"`
+	Title        string        `json:"title"`
+	TargetBranch string        `json:"target_branch"`
+	SourceBranch string        `json:"source_branch"`
+	State        string        `json:"state"`
+	Action       string        `json:"action"`
+	OldRev       string        `json:"oldrev"`
+	URL          string        `json:"url"`
+	Source       GitlabProject `json:"source"`
+	Target       GitlabProject `json:"target"`
+	LastCommit   struct {
+		ID        string `json:"id"`
+		Timestamp string `json:"timestamp"`
+	} `json:"last_commit"`
+}
+
+// gitlabIntent represents an intent to create a patch build as a result of a
+// merge request webhook from GitLab. These intents are processed
+// asynchronously by an amboy queue.
+type gitlabIntent struct {
+	// DocumentID is the same as the MsgID.
+	DocumentID string `bson:"_id"`
+
+	// MsgID is a GUID provided by GitLab (X-Gitlab-Event-UUID) for the
+	// event, or one that identifies the head revision of the merge request
+	// if GitLab doesn't provide one.
+	MsgID string `bson:"msg_id"`
+
+	// ProjectPath is the namespaced path, ex: group/repo, of the project that
+	// this merge request will be merged into
+	ProjectPath string `bson:"project_path"`
+
+	// BaseBranch is the branch that this merge request will be merged into
+	BaseBranch string `bson:"base_branch"`
+
+	// HeadProjectPath is the namespaced path of the project that contains
+	// the changes to be merged
+	HeadProjectPath string `bson:"head_project_path"`
+
+	// MRNumber is the number of the merge request in the GitLab project.
+	MRNumber int `bson:"mr_number"`
+
+	// User is the username of the GitLab user that triggered the event
+	User string `bson:"user"`
+
+	// UID is the merge request author's GitLab UID
+	UID int `bson:"author_uid"`
+
+	// HeadHash is the hash of the most recent commit of the merge request
+	HeadHash string `bson:"head_hash"`
+
+	// Title is the title of the merge request
+	Title string `bson:"title"`
+
+	// WebURL is the URL of the merge request in GitLab
+	WebURL string `bson:"web_url"`
+
+	// PushedAt is the time of the most recent commit of the merge request
+	PushedAt time.Time `bson:"pushed_at"`
+
+	// CreatedAt is the time that this intent was stored in the database
+	CreatedAt time.Time `bson:"created_at"`
+
+	// Processed indicates whether a patch intent has been processed by the amboy queue.
+	Processed bool `bson:"processed"`
+
+	// ProcessedAt is the time that this intent was processed
+	ProcessedAt time.Time `bson:"processed_at"`
+
+	// IntentType indicates the type of the patch intent, i.e. GitlabIntentType
+	IntentType string `bson:"intent_type"`
+}
+
+// NewGitlabIntent creates an Intent from a GitLab merge request event, or
+// returns an error if some part of the event is invalid
+func NewGitlabIntent(msgID string, event *GitlabMergeRequestEvent) (Intent, error) {
+	if event == nil {
+		return nil, errors.New("merge request event is missing")
+	}
+	attrs := event.ObjectAttributes
+	if msgID == "" {
+		return nil, errors.New("Unique msg id cannot be empty")
+	}
+	if len(strings.Split(event.Project.PathWithNamespace, "/")) < 2 {
+		return nil, errors.New("Project path is invalid (expected [namespace]/[project])")
+	}
+	if len(strings.Split(attrs.Source.PathWithNamespace, "/")) < 2 {
+		return nil, errors.New("Source project path is invalid (expected [namespace]/[project])")
+	}
+	if attrs.TargetBranch == "" {
+		return nil, errors.New("Target branch is empty")
+	}
+	if attrs.IID == 0 {
+		return nil, errors.New("MR number must not be 0")
+	}
+	if event.User.Username == "" {
+		return nil, errors.New("Gitlab user missing username")
+	}
+	if attrs.AuthorID == 0 {
+		return nil, errors.New("Gitlab merge request missing author uid")
+	}
+	if attrs.LastCommit.ID == "" {
+		return nil, errors.New("Head hash must not be empty")
+	}
+
+	// GitLab sends the time of the commit in RFC-3339 format, but don't
+	// reject an event because of the format of a time
+	pushedAt, err := time.Parse(time.RFC3339, attrs.LastCommit.Timestamp)
+	if err != nil {
+		pushedAt = time.Now()
+	}
+
+	return &gitlabIntent{
+		DocumentID:      msgID,
+		MsgID:           msgID,
+		ProjectPath:     event.Project.PathWithNamespace,
+		BaseBranch:      attrs.TargetBranch,
+		HeadProjectPath: attrs.Source.PathWithNamespace,
+		MRNumber:        attrs.IID,
+		User:            event.User.Username,
+		UID:             attrs.AuthorID,
+		HeadHash:        attrs.LastCommit.ID,
+		Title:           attrs.Title,
+		WebURL:          attrs.URL,
+		PushedAt:        pushedAt.Round(time.Millisecond),
+		IntentType:      GitlabIntentType,
+	}, nil
+}
+
+// SetProcessed should be called by an amboy queue after creating a patch from an intent.
+func (g *gitlabIntent) SetProcessed() error {
+	g.Processed = true
+	g.ProcessedAt = time.Now().Round(time.Millisecond)
+	return updateOneIntent(
+		bson.M{documentIDKey: g.DocumentID},
+		bson.M{"$set": bson.M{
+			processedKey:   g.Processed,
+			processedAtKey: g.ProcessedAt,
+		}},
+	)
+}
+
+// IsProcessed returns whether a patch exists for this intent.
+func (g *gitlabIntent) IsProcessed() bool {
+	return g.Processed
+}
+
+// GetType returns the patch intent, i.e. GitlabIntentType.
+func (g *gitlabIntent) GetType() string {
+	return g.IntentType
+}
+
+// Insert inserts a patch intent in the database.
+func (g *gitlabIntent) Insert() error {
+	g.CreatedAt = time.Now().Round(time.Millisecond)
+	err := db.Insert(IntentCollection, g)
+	if err != nil {
+		g.CreatedAt = time.Time{}
+		return err
+	}
+
+	return nil
+}
+
+func (g *gitlabIntent) ID() string {
+	return g.MsgID
+}
+
+func (g *gitlabIntent) ShouldFinalizePatch() bool {
+	return true
+}
+
+func (g *gitlabIntent) RequesterIdentity() string {
+	return evergreen.GitlabMRRequester
+}
+
+func (g *gitlabIntent) NewPatch() *Patch {
+	return &Patch{
+		Alias:       GithubAlias,
+		Description: fmt.Sprintf("'%s' merge request !%d by %s: %s (%s)", g.ProjectPath, g.MRNumber, g.User, g.Title, g.WebURL),
+		Author:      evergreen.GitlabPatchUser,
+		Status:      evergreen.PatchCreated,
+		CreateTime:  g.PushedAt,
+		GitlabPatchData: GitlabPatch{
+			MRNumber:        g.MRNumber,
+			ProjectPath:     g.ProjectPath,
+			BaseBranch:      g.BaseBranch,
+			HeadProjectPath: g.HeadProjectPath,
+			HeadHash:        g.HeadHash,
+			Author:          g.User,
+			AuthorUID:       g.UID,
+			WebURL:          g.WebURL,
+		},
+	}
+}
+
+// GetAlias returns the alias of pull request testing, since merge requests
+// run the same variants and tasks as pull requests.
+func (g *gitlabIntent) GetAlias() string {
+	return GithubAlias
+}
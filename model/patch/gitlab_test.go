@@ -0,0 +1,98 @@
+package patch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGitlabMREvent() *GitlabMergeRequestEvent {
+	event := &GitlabMergeRequestEvent{
+		ObjectKind: "merge_request",
+		User:       GitlabUser{ID: 3, Username: "reviewer"},
+		Project:    GitlabProject{ID: 1, PathWithNamespace: "group/repo"},
+	}
+	event.ObjectAttributes.IID = 7
+	event.ObjectAttributes.AuthorID = 2
+	event.ObjectAttributes.Title = "Add a feature"
+	event.ObjectAttributes.TargetBranch = "master"
+	event.ObjectAttributes.SourceBranch = "feature"
+	event.ObjectAttributes.Action = "open"
+	event.ObjectAttributes.URL = "https://gitlab.example.com/group/repo/merge_requests/7"
+	event.ObjectAttributes.Source = GitlabProject{ID: 2, PathWithNamespace: "someone/repo"}
+	event.ObjectAttributes.Target = event.Project
+	event.ObjectAttributes.LastCommit.ID = "67da19930b1b18d346477e99a8e18094a672f48a"
+	event.ObjectAttributes.LastCommit.Timestamp = "2018-06-01T12:30:00+02:00"
+
+	return event
+}
+
+func TestNewGitlabIntent(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	intent, err := NewGitlabIntent("1", newGitlabMREvent())
+	require.NoError(err)
+	require.NotNil(intent)
+	assert.Implements((*Intent)(nil), intent)
+	assert.Equal("1", intent.ID())
+	assert.Equal(GitlabIntentType, intent.GetType())
+	assert.Equal(evergreen.GitlabMRRequester, intent.RequesterIdentity())
+	assert.Equal(GithubAlias, intent.GetAlias())
+	assert.True(intent.ShouldFinalizePatch())
+	assert.False(intent.IsProcessed())
+
+	p := intent.NewPatch()
+	require.NotNil(p)
+	assert.Equal(evergreen.GitlabPatchUser, p.Author)
+	assert.Equal(evergreen.PatchCreated, p.Status)
+	assert.Equal("'group/repo' merge request !7 by reviewer: Add a feature (https://gitlab.example.com/group/repo/merge_requests/7)", p.Description)
+	assert.True(p.CreateTime.Equal(time.Date(2018, 6, 1, 10, 30, 0, 0, time.UTC)))
+	assert.True(p.IsGitlabMRPatch())
+	assert.False(p.IsGithubPRPatch())
+	assert.Equal(GitlabPatch{
+		MRNumber:        7,
+		ProjectPath:     "group/repo",
+		BaseBranch:      "master",
+		HeadProjectPath: "someone/repo",
+		HeadHash:        "67da19930b1b18d346477e99a8e18094a672f48a",
+		Author:          "reviewer",
+		AuthorUID:       2,
+		WebURL:          "https://gitlab.example.com/group/repo/merge_requests/7",
+	}, p.GitlabPatchData)
+
+	for name, modify := range map[string]func(*GitlabMergeRequestEvent){
+		"NoProject":      func(e *GitlabMergeRequestEvent) { e.Project.PathWithNamespace = "repo" },
+		"NoSource":       func(e *GitlabMergeRequestEvent) { e.ObjectAttributes.Source.PathWithNamespace = "" },
+		"NoTargetBranch": func(e *GitlabMergeRequestEvent) { e.ObjectAttributes.TargetBranch = "" },
+		"NoNumber":       func(e *GitlabMergeRequestEvent) { e.ObjectAttributes.IID = 0 },
+		"NoUser":         func(e *GitlabMergeRequestEvent) { e.User.Username = "" },
+		"NoAuthor":       func(e *GitlabMergeRequestEvent) { e.ObjectAttributes.AuthorID = 0 },
+		"NoHeadHash":     func(e *GitlabMergeRequestEvent) { e.ObjectAttributes.LastCommit.ID = "" },
+	} {
+		event := newGitlabMREvent()
+		modify(event)
+		intent, err = NewGitlabIntent("2", event)
+		assert.Error(err, name)
+		assert.Nil(intent, name)
+	}
+
+	_, err = NewGitlabIntent("", newGitlabMREvent())
+	assert.Error(err)
+	_, err = NewGitlabIntent("3", nil)
+	assert.Error(err)
+
+	// subgroups are part of the namespace
+	event := newGitlabMREvent()
+	event.Project.PathWithNamespace = "group/subgroup/repo"
+	intent, err = NewGitlabIntent("4", event)
+	require.NoError(err)
+	assert.Equal("group/subgroup/repo", intent.NewPatch().GitlabPatchData.ProjectPath)
+
+	intent, ok := GetIntent(GitlabIntentType)
+	assert.True(ok)
+	assert.IsType(&gitlabIntent{}, intent)
+}
@@ -46,6 +46,12 @@ type Patch struct {
 	PatchedConfig   string         `bson:"patched_config"`
 	Alias           string         `bson:"alias"`
 	GithubPatchData GithubPatch    `bson:"github_patch_data,omitempty"`
+	GitlabPatchData GitlabPatch    `bson:"gitlab_patch_data,omitempty"`
+
+	// FilterByPaths is set when the user didn't pick the patch's tasks, so
+	// the tasks whose path filters don't match the patch's changes are
+	// skipped when it's finalized.
+	FilterByPaths bool `bson:"filter_by_paths,omitempty"`
 }
 
 // GithubPatch stores patch data for patches create from GitHub pull requests
@@ -61,6 +67,18 @@ type GithubPatch struct {
 	AuthorUID  int    `bson:"author_uid"`
 }
 
+// GitlabPatch stores patch data for patches created from GitLab merge requests
+type GitlabPatch struct {
+	MRNumber        int    `bson:"mr_number"`
+	ProjectPath     string `bson:"project_path"`
+	BaseBranch      string `bson:"base_branch"`
+	HeadProjectPath string `bson:"head_project_path"`
+	HeadHash        string `bson:"head_hash"`
+	Author          string `bson:"author"`
+	AuthorUID       int    `bson:"author_uid"`
+	WebURL          string `bson:"web_url"`
+}
+
 // ModulePatch stores request details for a patch
 type ModulePatch struct {
 	ModuleName string   `bson:"name"`
@@ -253,6 +271,21 @@ func (p *Patch) ConfigChanged(remotePath string) bool {
 	return false
 }
 
+// FilesChanged returns the names of the files that the patch changes in the
+// project's own repository, ignoring module patches.
+func (p *Patch) FilesChanged() []string {
+	var files []string
+	for _, patchPart := range p.Patches {
+		if patchPart.ModuleName != "" {
+			continue
+		}
+		for _, summary := range patchPart.PatchSet.Summary {
+			files = append(files, summary.Name)
+		}
+	}
+	return files
+}
+
 // SetActivated sets the patch to activated in the db
 func (p *Patch) SetActivated(versionId string) error {
 	p.Version = versionId
@@ -328,3 +361,7 @@ func (p *Patch) RemoveModulePatch(moduleName string) error {
 func (p *Patch) IsGithubPRPatch() bool {
 	return p.GithubPatchData.PRNumber != 0
 }
+
+func (p *Patch) IsGitlabMRPatch() bool {
+	return p.GitlabPatchData.MRNumber != 0
+}
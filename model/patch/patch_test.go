@@ -32,6 +32,28 @@ func TestConfigChanged(t *testing.T) {
 	assert.False(p.ConfigChanged(remoteConfigPath))
 }
 
+func TestFilesChanged(t *testing.T) {
+	assert := assert.New(t)
+	p := &Patch{
+		Patches: []ModulePatch{
+			{
+				PatchSet: PatchSet{
+					Summary: []Summary{{Name: "src/main.go"}, {Name: "docs/README.md"}},
+				},
+			},
+			{
+				ModuleName: "enterprise",
+				PatchSet: PatchSet{
+					Summary: []Summary{{Name: "src/module.go"}},
+				},
+			},
+		},
+	}
+
+	assert.Equal([]string{"src/main.go", "docs/README.md"}, p.FilesChanged())
+	assert.Empty((&Patch{}).FilesChanged())
+}
+
 type patchSuite struct {
 	suite.Suite
 	testConfig *evergreen.Settings
@@ -11,6 +11,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/subprocess"
@@ -95,6 +96,76 @@ func AddNewTasksForPatch(p *patch.Patch, patchVersion *version.Version, project
 	return AddNewTasks(p.Activated, patchVersion, project, pairs, "")
 }
 
+// AddVariantsToPatch adds build variants, with all of their tasks and the
+// tasks those depend on, to a patch that has already been finalized.
+func AddVariantsToPatch(p *patch.Patch, variants []string) error {
+	if p.Version == "" {
+		return errors.Errorf("patch '%s' has not been finalized", p.Id.Hex())
+	}
+	patchVersion, err := version.FindOne(version.ById(p.Version))
+	if err != nil {
+		return errors.Wrapf(err, "can't find version '%s'", p.Version)
+	}
+	if patchVersion == nil {
+		return errors.Errorf("version '%s' not found", p.Version)
+	}
+	project := &Project{}
+	if err = LoadProjectInto([]byte(p.PatchedConfig), p.Project, project); err != nil {
+		return errors.Wrapf(err, "can't load the config of patch '%s'", p.Id.Hex())
+	}
+
+	tasks := VariantTasksToTVPairs(p.VariantsTasks)
+	existing := map[TVPair]bool{}
+	for _, pair := range tasks.ExecTasks {
+		existing[pair] = true
+	}
+	for _, pair := range tasks.DisplayTasks {
+		existing[pair] = true
+	}
+	addPair := func(pairs []TVPair, pair TVPair) []TVPair {
+		if existing[pair] {
+			return pairs
+		}
+		existing[pair] = true
+		return append(pairs, pair)
+	}
+	for _, name := range variants {
+		bv := project.FindBuildVariant(name)
+		if bv == nil {
+			return errors.Errorf("build variant '%s' does not exist", name)
+		}
+		for _, t := range bv.Tasks {
+			if tg := project.FindTaskGroup(t.Name); tg != nil {
+				for _, groupTask := range tg.Tasks {
+					tasks.ExecTasks = addPair(tasks.ExecTasks, TVPair{Variant: bv.Name, TaskName: groupTask})
+				}
+			} else {
+				tasks.ExecTasks = addPair(tasks.ExecTasks, TVPair{Variant: bv.Name, TaskName: t.Name})
+			}
+		}
+		for _, dt := range bv.DisplayTasks {
+			tasks.DisplayTasks = addPair(tasks.DisplayTasks, TVPair{Variant: bv.Name, TaskName: dt.Name})
+		}
+	}
+	tasks.ExecTasks = IncludePatchDependencies(project, tasks.ExecTasks)
+	if err = ValidateTVPairs(project, tasks.ExecTasks); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err = p.SetVariantsTasks(tasks.TVPairsToVariantTasks()); err != nil {
+		return errors.Wrap(err, "can't set the variants and tasks of the patch")
+	}
+	p.Activated = true
+	if err = AddNewTasksForPatch(p, patchVersion, project, tasks); err != nil {
+		return errors.Wrapf(err, "can't create new tasks for version '%s'", patchVersion.Id)
+	}
+	if err = AddNewBuildsForPatch(p, patchVersion, project, tasks); err != nil {
+		return errors.Wrapf(err, "can't create new builds for version '%s'", patchVersion.Id)
+	}
+
+	return nil
+}
+
 // IncludePatchDependencies takes a project and a slice of variant/task pairs names
 // and returns the expanded set of variant/task pairs to include all the dependencies/requirements
 // for the given set of tasks.
@@ -239,9 +310,13 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	_, err = thirdparty.GetCommitEvent(ctx, githubOauthToken, projectRef.Owner, projectRef.Repo, p.Githash)
-	if err != nil {
-		return nil, errors.Wrap(err, "Couldn't fetch commit information")
+	// repositories that aren't on GitHub, like GitLab projects, are
+	// tracked from their mirrors, so there's no commit to look up
+	if projectRef.RepoKind != GitRepoType {
+		_, err = thirdparty.GetCommitEvent(ctx, githubOauthToken, projectRef.Owner, projectRef.Repo, p.Githash)
+		if err != nil {
+			return nil, errors.Wrap(err, "Couldn't fetch commit information")
+		}
 	}
 
 	patchVersion := &version.Version{
@@ -280,6 +355,19 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 		}).TVPairsToVariantTasks()
 	}
 
+	// unless the user picked the tasks, only run the tasks whose path
+	// filters match the files that the patch changes
+	if p.FilterByPaths && project.HasPathFilters() {
+		selected, skipped := project.SelectTasksByPaths(tasks.ExecTasks, p.FilesChanged())
+		if len(skipped) > 0 {
+			if err = p.SetVariantsTasks(selectVariantsTasks(project, p.VariantsTasks, selected)); err != nil {
+				return nil, errors.Wrap(err, "can't update the tasks of the patch")
+			}
+			tasks = VariantTasksToTVPairs(p.VariantsTasks)
+			patchVersion.SkippedTasks = skipped.ToSkippedTasks()
+		}
+	}
+
 	taskIds := NewPatchTaskIdTable(project, patchVersion, tasks)
 	variantsProcessed := map[string]bool{}
 	for _, vt := range p.VariantsTasks {
@@ -307,15 +395,80 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 		)
 	}
 
+	// a patch whose tasks were all skipped has nothing left to run
+	noTasks := len(patchVersion.BuildIds) == 0 && len(patchVersion.SkippedTasks) > 0
+	if noTasks {
+		patchVersion.Status = evergreen.VersionSucceeded
+		patchVersion.FinishTime = patchVersion.CreateTime
+	}
+
 	if err = patchVersion.Insert(); err != nil {
 		return nil, errors.WithStack(err)
 	}
 	if err = p.SetActivated(patchVersion.Id); err != nil {
 		return nil, errors.WithStack(err)
 	}
+	if noTasks {
+		if err = patch.TryMarkFinished(patchVersion.Id, patchVersion.FinishTime, evergreen.PatchSucceeded); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		p.Status = evergreen.PatchSucceeded
+		event.LogPatchStateChangeEvent(patchVersion.Id, p.Status)
+	}
 	return patchVersion, nil
 }
 
+// selectVariantsTasks returns the variants and tasks that are among the given
+// pairs. Display tasks are kept if any of their execution tasks are, and
+// variants without any tasks are dropped.
+func selectVariantsTasks(project *Project, vts []patch.VariantTasks, pairs TVPairSet) []patch.VariantTasks {
+	selected := map[TVPair]bool{}
+	for _, pair := range pairs {
+		selected[pair] = true
+	}
+
+	out := []patch.VariantTasks{}
+	for _, vt := range vts {
+		newVT := patch.VariantTasks{Variant: vt.Variant}
+		for _, t := range vt.Tasks {
+			if selected[TVPair{vt.Variant, t}] {
+				newVT.Tasks = append(newVT.Tasks, t)
+			}
+		}
+
+		bv := project.FindBuildVariant(vt.Variant)
+		for _, dt := range vt.DisplayTasks {
+			execTasks := dt.ExecTasks
+			if len(execTasks) == 0 && bv != nil {
+				for _, projectDT := range bv.DisplayTasks {
+					if projectDT.Name == dt.Name {
+						execTasks = projectDT.ExecutionTasks
+					}
+				}
+			}
+
+			keep := false
+			newDT := patch.DisplayTask{Name: dt.Name}
+			for _, et := range execTasks {
+				if selected[TVPair{vt.Variant, et}] {
+					keep = true
+					if len(dt.ExecTasks) > 0 {
+						newDT.ExecTasks = append(newDT.ExecTasks, et)
+					}
+				}
+			}
+			if keep {
+				newVT.DisplayTasks = append(newVT.DisplayTasks, newDT)
+			}
+		}
+
+		if len(newVT.Tasks) > 0 || len(newVT.DisplayTasks) > 0 {
+			out = append(out, newVT)
+		}
+	}
+	return out
+}
+
 func CancelPatch(p *patch.Patch, caller string) error {
 	if p.Version != "" {
 		if err := SetVersionActivation(p.Version, false, caller); err != nil {
@@ -366,3 +519,41 @@ func AbortPatchesWithGithubPatchData(createdBefore time.Time, owner, repo string
 
 	return errors.Wrap(catcher.Resolve(), "error aborting patches")
 }
+
+// AbortPatchesWithGitlabPatchData runs CancelPatch on patches created before
+// the given time, with the same merge request number, and project. Tasks
+// which are abortable (see model/task.IsAbortable()) will be aborted, while
+// dispatched/running/completed tasks will not be affected
+func AbortPatchesWithGitlabPatchData(createdBefore time.Time, projectPath string, mrNumber int) error {
+	patches, err := patch.Find(patch.ByGitlabMRAndCreatedBefore(createdBefore, projectPath, mrNumber))
+	if err != nil {
+		return errors.Wrap(err, "initial patch fetch failed")
+	}
+	grip.Info(message.Fields{
+		"source":         "gitlab hook",
+		"created_before": createdBefore.String(),
+		"project":        projectPath,
+		"message":        "fetched patches to abort",
+		"num_patches":    len(patches),
+	})
+
+	catcher := grip.NewSimpleCatcher()
+	for i := range patches {
+		if patches[i].Version != "" {
+			if err = CancelPatch(&patches[i], evergreen.GitlabMRRequester); err != nil {
+				grip.Error(message.WrapError(err, message.Fields{
+					"source":         "gitlab hook",
+					"created_before": createdBefore.String(),
+					"project":        projectPath,
+					"message":        "failed to abort patch's version",
+					"patch_id":       patches[i].Id,
+					"version":        patches[i].Version,
+				}))
+
+				catcher.Add(err)
+			}
+		}
+	}
+
+	return errors.Wrap(catcher.Resolve(), "error aborting patches")
+}
@@ -339,6 +339,64 @@ func TestVariantTasksToTVPairs(t *testing.T) {
 	assert.Equal(input, original)
 }
 
+func TestSelectVariantsTasks(t *testing.T) {
+	assert := assert.New(t)
+
+	project := &Project{
+		BuildVariants: []BuildVariant{
+			{
+				Name: "variant",
+				DisplayTasks: []DisplayTask{
+					{Name: "displaytask1", ExecutionTasks: []string{"task2", "task3"}},
+					{Name: "displaytask2", ExecutionTasks: []string{"task4"}},
+				},
+			},
+		},
+	}
+	input := []patch.VariantTasks{
+		{
+			Variant: "variant",
+			Tasks:   []string{"task1", "task2", "task3", "task4"},
+			DisplayTasks: []patch.DisplayTask{
+				{Name: "displaytask1"},
+				{Name: "displaytask2"},
+			},
+		},
+		{
+			Variant: "other",
+			Tasks:   []string{"task1"},
+		},
+	}
+
+	output := selectVariantsTasks(project, input, TVPairSet{{"variant", "task1"}, {"variant", "task3"}})
+	assert.Equal([]patch.VariantTasks{
+		{
+			Variant:      "variant",
+			Tasks:        []string{"task1", "task3"},
+			DisplayTasks: []patch.DisplayTask{{Name: "displaytask1"}},
+		},
+	}, output)
+
+	// the execution tasks of display tasks are filtered when the patch lists them
+	input = []patch.VariantTasks{
+		{
+			Variant: "variant",
+			DisplayTasks: []patch.DisplayTask{
+				{Name: "displaytask1", ExecTasks: []string{"task2", "task3"}},
+			},
+		},
+	}
+	output = selectVariantsTasks(project, input, TVPairSet{{"variant", "task2"}})
+	assert.Equal([]patch.VariantTasks{
+		{
+			Variant:      "variant",
+			DisplayTasks: []patch.DisplayTask{{Name: "displaytask1", ExecTasks: []string{"task2"}}},
+		},
+	}, output)
+
+	assert.Empty(selectVariantsTasks(project, input, nil))
+}
+
 func TestAddNewPatch(t *testing.T) {
 	assert := assert.New(t)
 
@@ -405,3 +463,68 @@ func TestAddNewPatch(t *testing.T) {
 	assert.Equal(dbTasks[2].DisplayName, "task2")
 	assert.Equal(dbTasks[3].DisplayName, "task3")
 }
+
+func TestAddVariantsToPatch(t *testing.T) {
+	assert := assert.New(t)
+
+	testutil.HandleTestingErr(db.ClearCollections(patch.Collection, version.Collection, build.Collection, task.Collection), t, "problem clearing collections")
+	config := `
+tasks:
+- name: compile
+- name: test
+  depends_on:
+  - name: compile
+buildvariants:
+- name: linux
+  run_on: [distro]
+  tasks:
+  - name: compile
+  - name: test
+- name: windows
+  run_on: [distro]
+  tasks:
+  - name: test
+  - name: compile
+`
+	p := &patch.Patch{
+		Id:            patch.NewId("aabbccddeeff001122334455"),
+		Project:       "project",
+		PatchedConfig: config,
+		Activated:     true,
+		VariantsTasks: []patch.VariantTasks{
+			{Variant: "linux", Tasks: []string{"compile", "test"}},
+		},
+	}
+	assert.Error(AddVariantsToPatch(p, []string{"windows"}))
+
+	p.Version = "version"
+	v := &version.Version{
+		Id:         "version",
+		Revision:   "1234",
+		Requester:  evergreen.PatchVersionRequester,
+		CreateTime: time.Now(),
+	}
+	assert.NoError(p.Insert())
+	assert.NoError(v.Insert())
+
+	assert.Error(AddVariantsToPatch(p, []string{"osx"}))
+	assert.NoError(AddVariantsToPatch(p, []string{"windows"}))
+
+	dbPatch, err := patch.FindOne(patch.ById(p.Id))
+	assert.NoError(err)
+	assert.Len(dbPatch.VariantsTasks, 2)
+	for _, vt := range dbPatch.VariantsTasks {
+		assert.Len(vt.Tasks, 2)
+	}
+	builds, err := build.Find(build.ByVersion("version"))
+	assert.NoError(err)
+	assert.Len(builds, 2)
+	tasks, err := task.Find(task.ByVersion("version"))
+	assert.NoError(err)
+	assert.Len(tasks, 4)
+
+	assert.NoError(AddVariantsToPatch(p, []string{"windows"}))
+	tasks, err = task.Find(task.ByVersion("version"))
+	assert.NoError(err)
+	assert.Len(tasks, 4)
+}
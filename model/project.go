@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/build"
@@ -56,6 +57,10 @@ type Project struct {
 	Tasks           []ProjectTask              `yaml:"tasks,omitempty" bson:"tasks"`
 	ExecTimeoutSecs int                        `yaml:"exec_timeout_secs,omitempty" bson:"exec_timeout_secs"`
 
+	// CommandPlugins names the external command plugins, installed on
+	// hosts, that the project's commands may use.
+	CommandPlugins []string `yaml:"command_plugins,omitempty" bson:"command_plugins"`
+
 	// Flag that indicates a project as requiring user authentication
 	Private bool `yaml:"private,omitempty" bson:"private"`
 }
@@ -73,6 +78,9 @@ type BuildVariantTaskUnit struct {
 	Priority  int64                 `yaml:"priority,omitempty" bson:"priority"`
 	DependsOn []TaskUnitDependency  `yaml:"depends_on,omitempty" bson:"depends_on"`
 	Requires  []TaskUnitRequirement `yaml:"requires,omitempty" bson:"requires"`
+	// DependsOnExpr is a task.DependencyExpression over the dependencies,
+	// which replaces the statuses required of the dependencies it refers to.
+	DependsOnExpr string `yaml:"depends_on_expr,omitempty" bson:"depends_on_expr,omitempty"`
 
 	// the distros that the task can be run on
 	Distros []string `yaml:"distros,omitempty" bson:"distros"`
@@ -80,6 +88,10 @@ type BuildVariantTaskUnit struct {
 	// currently unsupported (TODO EVG-578)
 	ExecTimeoutSecs int   `yaml:"exec_timeout_secs,omitempty" bson:"exec_timeout_secs"`
 	Stepback        *bool `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+
+	// Paths and IgnorePaths override the path filters of the ProjectTask.
+	Paths       []string `yaml:"paths,omitempty" bson:"paths,omitempty"`
+	IgnorePaths []string `yaml:"ignore_paths,omitempty" bson:"ignore_paths,omitempty"`
 }
 
 func (b BuildVariant) Get(name string) (BuildVariantTaskUnit, error) {
@@ -119,6 +131,7 @@ func (bvt *BuildVariantTaskUnit) Populate(pt ProjectTask) {
 	// We never update "Name" or "Commands"
 	if len(bvt.DependsOn) == 0 {
 		bvt.DependsOn = pt.DependsOn
+		bvt.DependsOnExpr = pt.DependsOnExpr
 	}
 	if len(bvt.Requires) == 0 {
 		bvt.Requires = pt.Requires
@@ -136,6 +149,10 @@ func (bvt *BuildVariantTaskUnit) Populate(pt ProjectTask) {
 	if bvt.Stepback == nil {
 		bvt.Stepback = pt.Stepback
 	}
+	if len(bvt.Paths) == 0 && len(bvt.IgnorePaths) == 0 {
+		bvt.Paths = pt.Paths
+		bvt.IgnorePaths = pt.IgnorePaths
+	}
 }
 
 // UnmarshalYAML allows tasks to be referenced as single selector strings.
@@ -187,6 +204,11 @@ type BuildVariant struct {
 	// all of the tasks/groups to be run on the build variant, compile through tests.
 	Tasks        []BuildVariantTaskUnit `yaml:"tasks,omitempty" bson:"tasks"`
 	DisplayTasks []DisplayTask          `yaml:"display_tasks,omitempty" bson:"display_tasks,omitempty"`
+
+	// Paths and IgnorePaths are gitignore-style patterns that restrict the
+	// variant to commits and patches that change matching files.
+	Paths       []string `yaml:"paths,omitempty" bson:"paths,omitempty"`
+	IgnorePaths []string `yaml:"ignore_paths,omitempty" bson:"ignore_paths,omitempty"`
 }
 
 type Module struct {
@@ -229,6 +251,68 @@ type PluginCommandConf struct {
 
 	// Vars defines variables that can be used within commands.
 	Vars map[string]string `yaml:"vars,omitempty" bson:"vars"`
+
+	// CacheKey, if set, allows the agent to skip the command when it
+	// has already succeeded in the same task directory with the same
+	// expanded key, parameters, and CacheFiles contents.
+	CacheKey   string   `yaml:"cache_key,omitempty" bson:"cache_key"`
+	CacheFiles []string `yaml:"cache_files,omitempty" bson:"cache_files"`
+
+	// Retry, if set, allows the agent to run a failed command again.
+	Retry *CommandRetryPolicy `yaml:"retry,omitempty" bson:"retry,omitempty"`
+}
+
+// CommandRetryPolicy describes when the agent should run a failed
+// command again.
+type CommandRetryPolicy struct {
+	// Attempts is the maximum number of times to run the command,
+	// including the first attempt.
+	Attempts int `yaml:"attempts,omitempty" bson:"attempts"`
+
+	// Backoff is the initial time to wait between attempts, as a
+	// duration string (e.g. "10s"), which increases exponentially.
+	Backoff string `yaml:"backoff,omitempty" bson:"backoff"`
+
+	// OnExitCodes limits retries to failures with one of these exit
+	// codes. If it is empty, the command is retried on any failure.
+	OnExitCodes []int `yaml:"on_exit_codes,omitempty" bson:"on_exit_codes"`
+}
+
+// GetBackoff returns the initial time to wait between attempts.
+func (p *CommandRetryPolicy) GetBackoff() (time.Duration, error) {
+	if p.Backoff == "" {
+		return 0, nil
+	}
+
+	backoff, err := time.ParseDuration(p.Backoff)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid backoff '%s'", p.Backoff)
+	}
+	if backoff < 0 {
+		return 0, errors.Errorf("backoff '%s' cannot be negative", p.Backoff)
+	}
+
+	return backoff, nil
+}
+
+// ShouldRetry returns true if a command that failed with the given exit
+// code should be retried. hasExitCode is false if the command failed
+// without exiting with a code.
+func (p *CommandRetryPolicy) ShouldRetry(exitCode int, hasExitCode bool) bool {
+	if len(p.OnExitCodes) == 0 {
+		return true
+	}
+	if !hasExitCode {
+		return false
+	}
+
+	for _, code := range p.OnExitCodes {
+		if code == exitCode {
+			return true
+		}
+	}
+
+	return false
 }
 
 type ArtifactInstructions struct {
@@ -327,6 +411,7 @@ type ProjectTask struct {
 	Priority        int64                 `yaml:"priority,omitempty" bson:"priority"`
 	ExecTimeoutSecs int                   `yaml:"exec_timeout_secs,omitempty" bson:"exec_timeout_secs"`
 	DependsOn       []TaskUnitDependency  `yaml:"depends_on,omitempty" bson:"depends_on"`
+	DependsOnExpr   string                `yaml:"depends_on_expr,omitempty" bson:"depends_on_expr,omitempty"`
 	Requires        []TaskUnitRequirement `yaml:"requires,omitempty" bson:"requires"`
 	Commands        []PluginCommandConf   `yaml:"commands,omitempty" bson:"commands"`
 	Tags            []string              `yaml:"tags,omitempty" bson:"tags"`
@@ -337,6 +422,11 @@ type ProjectTask struct {
 	//   3. false = overriding the project setting with false
 	Patchable *bool `yaml:"patchable,omitempty" bson:"patchable,omitempty"`
 	Stepback  *bool `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+
+	// Paths and IgnorePaths are gitignore-style patterns that restrict the
+	// task to commits and patches that change matching files.
+	Paths       []string `yaml:"paths,omitempty" bson:"paths,omitempty"`
+	IgnorePaths []string `yaml:"ignore_paths,omitempty" bson:"ignore_paths,omitempty"`
 }
 
 // TaskIdTable is a map of [variant, task display name]->[task id].
@@ -387,6 +477,15 @@ func (tvps TVPairSet) TaskNames(variant string) []string {
 	return taskNames
 }
 
+// ToSkippedTasks converts the pairs to the skipped tasks of a version.
+func (tvps TVPairSet) ToSkippedTasks() []version.SkippedTask {
+	skipped := make([]version.SkippedTask, 0, len(tvps))
+	for _, pair := range tvps {
+		skipped = append(skipped, version.SkippedTask{BuildVariant: pair.Variant, Task: pair.TaskName})
+	}
+	return skipped
+}
+
 // String returns the pair's name in a readable form.
 func (p TVPair) String() string {
 	return fmt.Sprintf("%v/%v", p.Variant, p.TaskName)
@@ -594,6 +693,11 @@ func populateExpansions(d *distro.Distro, v *version.Version, bv *BuildVariant,
 			expansions.Put("github_repo", p.GithubPatchData.BaseRepo)
 			expansions.Put("github_author", p.GithubPatchData.Author)
 		}
+		if v.Requester == evergreen.GitlabMRRequester && p != nil {
+			expansions.Put("gitlab_mr_number", fmt.Sprintf("%d", p.GitlabPatchData.MRNumber))
+			expansions.Put("gitlab_project", p.GitlabPatchData.ProjectPath)
+			expansions.Put("gitlab_author", p.GitlabPatchData.Author)
+		}
 
 	} else {
 		expansions.Put("revision_order_id", strconv.Itoa(v.RevisionOrderNumber))
@@ -1007,6 +1111,143 @@ func (p *Project) IgnoresAllFiles(files []string) bool {
 	return true
 }
 
+// HasPathFilters returns true if any build variant or task of the project
+// only runs for changes to certain paths.
+func (p *Project) HasPathFilters() bool {
+	for _, bv := range p.BuildVariants {
+		if len(bv.Paths) > 0 || len(bv.IgnorePaths) > 0 {
+			return true
+		}
+		for _, bvt := range bv.Tasks {
+			if len(bvt.Paths) > 0 || len(bvt.IgnorePaths) > 0 {
+				return true
+			}
+		}
+	}
+	for _, t := range p.Tasks {
+		if len(t.Paths) > 0 || len(t.IgnorePaths) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectTasksByPaths splits task/variant pairs into the pairs that run for a
+// change to the given files and the pairs that are skipped. A pair runs if
+// any of the files passes the path filters of both its build variant and its
+// task; a file passes a filter if it matches the filter's paths, when there
+// are any, and doesn't match its ignore_paths. The pairs that the running
+// pairs depend on run as well, as long as they're among the given pairs.
+// If there are no files, all the pairs run.
+func (p *Project) SelectTasksByPaths(pairs TVPairSet, files []string) (TVPairSet, TVPairSet) {
+	if len(files) == 0 {
+		return pairs, nil
+	}
+
+	selected := map[TVPair]bool{}
+	queue := []TVPair{}
+	for _, pair := range pairs {
+		if p.pathsMatch(pair, files) {
+			selected[pair] = true
+			queue = append(queue, pair)
+		}
+	}
+
+	candidates := map[TVPair]bool{}
+	for _, pair := range pairs {
+		candidates[pair] = true
+	}
+	di := &dependencyIncluder{Project: p}
+	for len(queue) > 0 {
+		pair := queue[0]
+		queue = queue[1:]
+		bvt := p.FindTaskForVariant(pair.TaskName, pair.Variant)
+		if bvt == nil {
+			continue
+		}
+		deps := append(
+			di.expandRequirements(pair, bvt.Requires),
+			di.expandDependencies(pair, bvt.DependsOn)...)
+		for _, dep := range deps {
+			if candidates[dep] && !selected[dep] {
+				selected[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	var run, skipped TVPairSet
+	for _, pair := range pairs {
+		if selected[pair] {
+			run = append(run, pair)
+		} else {
+			skipped = append(skipped, pair)
+		}
+	}
+	return run, skipped
+}
+
+// pathsMatch returns true if any of the files passes the path filters of
+// both the build variant and the task of the pair.
+func (p *Project) pathsMatch(pair TVPair, files []string) bool {
+	bv := p.FindBuildVariant(pair.Variant)
+	if bv == nil {
+		return true
+	}
+	// task groups aren't project tasks, so they only have the filters of
+	// the build variant's task unit
+	bvt := p.FindTaskForVariant(pair.TaskName, pair.Variant)
+	if bvt == nil {
+		for i := range bv.Tasks {
+			if bv.Tasks[i].Name == pair.TaskName {
+				bvt = &bv.Tasks[i]
+				break
+			}
+		}
+	}
+	if bvt == nil {
+		return true
+	}
+
+	variantFilter := newPathFilter(bv.Paths, bv.IgnorePaths)
+	taskFilter := newPathFilter(bvt.Paths, bvt.IgnorePaths)
+	for _, f := range files {
+		if variantFilter.passes(f) && taskFilter.passes(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathFilter restricts files to the ones that match its paths, if it has
+// any, and don't match its ignore paths.
+type pathFilter struct {
+	paths       *ignore.GitIgnore
+	ignorePaths *ignore.GitIgnore
+}
+
+func newPathFilter(paths, ignorePaths []string) pathFilter {
+	f := pathFilter{}
+	// CompileIgnoreLines always returns a nil error.
+	if len(paths) > 0 {
+		f.paths, _ = ignore.CompileIgnoreLines(paths...)
+	}
+	if len(ignorePaths) > 0 {
+		f.ignorePaths, _ = ignore.CompileIgnoreLines(ignorePaths...)
+	}
+	return f
+}
+
+func (f pathFilter) passes(file string) bool {
+	if f.paths != nil && !f.paths.MatchesPath(file) {
+		return false
+	}
+	if f.ignorePaths != nil && f.ignorePaths.MatchesPath(file) {
+		return false
+	}
+	return true
+}
+
 func (p *Project) BuildProjectTVPairs(patchDoc *patch.Patch, alias string) {
 	//expand tasks and build variants and include dependencies
 	if len(patchDoc.BuildVariants) == 1 && patchDoc.BuildVariants[0] == "all" {
@@ -41,6 +41,8 @@ type matrix struct {
 	RunOn       parserStringSlice `yaml:"run_on"`
 	Tasks       parserBVTaskUnits `yaml:"tasks"`
 	Rules       []matrixRule      `yaml:"rules"`
+	Paths       parserStringSlice `yaml:"paths"`
+	IgnorePaths parserStringSlice `yaml:"ignore_paths"`
 }
 
 // matrixAxis represents one axis of a matrix definition.
@@ -295,13 +297,15 @@ func buildMatrixVariants(axes []matrixAxis, ase *axisSelectorEvaluator, matrices
 // execution.
 func buildMatrixVariant(axes []matrixAxis, mv matrixValue, m *matrix, ase *axisSelectorEvaluator) (*parserBV, error) {
 	v := parserBV{
-		matrixVal:  mv,
-		matrixId:   m.Id,
-		Stepback:   m.Stepback,
-		BatchTime:  m.BatchTime,
-		Modules:    m.Modules,
-		RunOn:      m.RunOn,
-		Expansions: *util.NewExpansions(mv),
+		matrixVal:   mv,
+		matrixId:    m.Id,
+		Stepback:    m.Stepback,
+		BatchTime:   m.BatchTime,
+		Modules:     m.Modules,
+		RunOn:       m.RunOn,
+		Paths:       m.Paths,
+		IgnorePaths: m.IgnorePaths,
+		Expansions:  *util.NewExpansions(mv),
 	}
 	// we declare a separate expansion map for evaluating the display name
 	displayNameExp := util.Expansions{}
@@ -510,6 +514,10 @@ func expandParserBVTask(pbvt parserBVTaskUnit, exp util.Expansions) (parserBVTas
 		newDeps = append(newDeps, newDep)
 	}
 	newTask.DependsOn = newDeps
+	newTask.DependsOnExpr, err = exp.ExpandString(pbvt.DependsOnExpr)
+	if err != nil {
+		return parserBVTaskUnit{}, errors.Wrap(err, "expanding depends_on_expr")
+	}
 	var newReqs taskSelectors
 	for i, r := range pbvt.Requires {
 		newReq, err := expandTaskSelector(r, exp)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
@@ -59,6 +60,7 @@ type parserProject struct {
 	TaskGroups      []parserTaskGroup          `yaml:"task_groups,omitempty"`
 	Tasks           []parserTask               `yaml:"tasks,omitempty"`
 	ExecTimeoutSecs int                        `yaml:"exec_timeout_secs,omitempty"`
+	CommandPlugins  parserStringSlice          `yaml:"command_plugins,omitempty"`
 
 	// Matrix code
 	Axes []matrixAxis `yaml:"axes,omitempty"`
@@ -92,11 +94,14 @@ type parserTask struct {
 	Priority        int64               `yaml:"priority,omitempty"`
 	ExecTimeoutSecs int                 `yaml:"exec_timeout_secs,omitempty"`
 	DependsOn       parserDependencies  `yaml:"depends_on,omitempty"`
+	DependsOnExpr   string              `yaml:"depends_on_expr,omitempty"`
 	Requires        taskSelectors       `yaml:"requires,omitempty"`
 	Commands        []PluginCommandConf `yaml:"commands,omitempty"`
 	Tags            parserStringSlice   `yaml:"tags,omitempty"`
 	Patchable       *bool               `yaml:"patchable,omitempty"`
 	Stepback        *bool               `yaml:"stepback,omitempty"`
+	Paths           parserStringSlice   `yaml:"paths,omitempty"`
+	IgnorePaths     parserStringSlice   `yaml:"ignore_paths,omitempty"`
 }
 
 type displayTask struct {
@@ -262,6 +267,8 @@ type parserBV struct {
 	DisplayTasks []displayTask      `yaml:"display_tasks,omitempty"`
 	DependsOn    parserDependencies `yaml:"depends_on,omitempty"`
 	Requires     taskSelectors      `yaml:"requires,omitempty"`
+	Paths        parserStringSlice  `yaml:"paths,omitempty"`
+	IgnorePaths  parserStringSlice  `yaml:"ignore_paths,omitempty"`
 
 	// internal matrix stuff
 	matrixId  string
@@ -309,11 +316,14 @@ type parserBVTaskUnit struct {
 	Patchable       *bool              `yaml:"patchable,omitempty"`
 	Priority        int64              `yaml:"priority,omitempty"`
 	DependsOn       parserDependencies `yaml:"depends_on,omitempty"`
+	DependsOnExpr   string             `yaml:"depends_on_expr,omitempty"`
 	Requires        taskSelectors      `yaml:"requires,omitempty"`
 	ExecTimeoutSecs int                `yaml:"exec_timeout_secs,omitempty"`
 	Stepback        *bool              `yaml:"stepback,omitempty"`
 	Distros         parserStringSlice  `yaml:"distros,omitempty"`
 	RunOn           parserStringSlice  `yaml:"run_on,omitempty"` // Alias for "Distros" TODO: deprecate Distros
+	Paths           parserStringSlice  `yaml:"paths,omitempty"`
+	IgnorePaths     parserStringSlice  `yaml:"ignore_paths,omitempty"`
 }
 
 // UnmarshalYAML allows the YAML parser to read both a single selector string or
@@ -460,6 +470,7 @@ func translateProject(pp *parserProject) (*Project, []error) {
 		Modules:         pp.Modules,
 		Functions:       pp.Functions,
 		ExecTimeoutSecs: pp.ExecTimeoutSecs,
+		CommandPlugins:  pp.CommandPlugins,
 	}
 	tse := NewParserTaskSelectorEvaluator(pp.Tasks)
 	tgse := newTaskGroupSelectorEvaluator(pp.TaskGroups)
@@ -497,6 +508,7 @@ func evaluateTaskUnits(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluator, v
 	tasks := []ProjectTask{}
 	groups := []TaskGroup{}
 	var evalErrs, errs []error
+	var err error
 	for _, pt := range pts {
 		t := ProjectTask{
 			Name:            pt.Name,
@@ -506,9 +518,17 @@ func evaluateTaskUnits(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluator, v
 			Tags:            pt.Tags,
 			Patchable:       pt.Patchable,
 			Stepback:        pt.Stepback,
+			Paths:           pt.Paths,
+			IgnorePaths:     pt.IgnorePaths,
 		}
 		t.DependsOn, errs = evaluateDependsOn(tse.tagEval, tgse, vse, pt.DependsOn)
 		evalErrs = append(evalErrs, errs...)
+		if pt.DependsOnExpr != "" {
+			t.DependsOnExpr = pt.DependsOnExpr
+			if t.DependsOn, err = evaluateDependsOnExpr(pt.DependsOnExpr, t.DependsOn); err != nil {
+				evalErrs = append(evalErrs, errors.Wrapf(err, "task '%s'", pt.Name))
+			}
+		}
 		t.Requires, errs = evaluateRequires(tse.tagEval, tgse, vse, pt.Requires)
 		evalErrs = append(evalErrs, errs...)
 		tasks = append(tasks, t)
@@ -561,6 +581,8 @@ func evaluateBuildVariants(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluato
 			Stepback:    pbv.Stepback,
 			RunOn:       pbv.RunOn,
 			Tags:        pbv.Tags,
+			Paths:       pbv.Paths,
+			IgnorePaths: pbv.IgnorePaths,
 		}
 		bv.Tasks, errs = evaluateBVTasks(tse, tgse, vse, pbv)
 		// evaluate any rules passed in during matrix construction
@@ -713,6 +735,8 @@ func evaluateBVTasks(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluator, vse
 				ExecTimeoutSecs: pt.ExecTimeoutSecs,
 				Stepback:        pt.Stepback,
 				Distros:         pt.Distros,
+				Paths:           pt.Paths,
+				IgnorePaths:     pt.IgnorePaths,
 			}
 
 			// Task-level dependencies in the variant override variant-level dependencies
@@ -724,7 +748,7 @@ func evaluateBVTasks(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluator, vse
 			if len(pbv.DependsOn) > 0 {
 				dependsOn = pbv.DependsOn
 			}
-			if len(pt.DependsOn) > 0 {
+			if len(pt.DependsOn) > 0 || pt.DependsOnExpr != "" {
 				dependsOn = pt.DependsOn
 			}
 			if len(pbv.Requires) > 0 {
@@ -736,6 +760,13 @@ func evaluateBVTasks(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluator, vse
 
 			t.DependsOn, errs = evaluateDependsOn(tse.tagEval, tgse, vse, dependsOn)
 			evalErrs = append(evalErrs, errs...)
+			if pt.DependsOnExpr != "" {
+				t.DependsOnExpr = pt.DependsOnExpr
+				var err error
+				if t.DependsOn, err = evaluateDependsOnExpr(pt.DependsOnExpr, t.DependsOn); err != nil {
+					evalErrs = append(evalErrs, errors.Wrapf(err, "task '%s' in build variant '%s'", name, pbv.Name))
+				}
+			}
 			t.Requires, errs = evaluateRequires(tse.tagEval, tgse, vse, requires)
 			evalErrs = append(evalErrs, errs...)
 			t.IsGroup = isGroup
@@ -825,6 +856,37 @@ func evaluateDependsOn(tse *tagSelectorEvaluator, tgse *tagSelectorEvaluator, vs
 	return newDeps, evalErrs
 }
 
+// evaluateDependsOnExpr checks that a dependency expression is valid, and
+// adds the tasks it refers to that aren't already dependencies to deps, so
+// that they are created, and checked for cycles, like any other dependency.
+func evaluateDependsOnExpr(expr string, deps []TaskUnitDependency) ([]TaskUnitDependency, error) {
+	e, err := task.ParseDependencyExpression(expr)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	newDeps := append([]TaskUnitDependency{}, deps...)
+	for _, d := range e.Dependencies() {
+		covered := false
+		for _, dep := range newDeps {
+			if (dep.Name == d.Name || dep.Name == AllDependencies) &&
+				(dep.Variant == d.Variant || dep.Variant == AllVariants) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			newDeps = append(newDeps, TaskUnitDependency{
+				Name:    d.Name,
+				Variant: d.Variant,
+				Status:  d.Status,
+			})
+		}
+	}
+
+	return newDeps, nil
+}
+
 // evaluateRequires expands any selectors in a requirement definition.
 func evaluateRequires(tse *tagSelectorEvaluator, tgse *tagSelectorEvaluator, vse *variantSelectorEvaluator,
 	reqs []taskSelector) ([]TaskUnitRequirement, []error) {
@@ -7,8 +7,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ShouldContainResembling tests whether a slice contains an element that DeepEquals
@@ -1115,3 +1118,103 @@ buildvariants:
 	assert.Equal("task_3", proj.BuildVariants[2].Tasks[0].Requires[0].Name)
 	assert.Equal("task_3", proj.BuildVariants[2].Tasks[1].Requires[0].Name)
 }
+
+func TestDependsOnExprAddsDependencies(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	yml := `
+tasks:
+- name: compile
+- name: lint
+- name: push
+- name: notify
+  depends_on:
+  - name: push
+  depends_on_expr: (failed(compile) || failed(lint)) && finished(push)
+- name: cleanup
+buildvariants:
+- name: bv_1
+  tasks:
+  - name: compile
+  - name: lint
+  - name: push
+  - name: notify
+  - name: cleanup
+    depends_on_expr: finished(*)
+`
+	proj, errs := projectFromYAML([]byte(yml))
+	require.NotNil(proj)
+	require.Empty(errs)
+
+	notify := proj.FindProjectTask("notify")
+	require.NotNil(notify)
+	assert.Equal("(failed(compile) || failed(lint)) && finished(push)", notify.DependsOnExpr)
+	require.Len(notify.DependsOn, 3)
+	// the existing dependency is kept, and those only in the expression are added
+	assert.Equal(TaskUnitDependency{Name: "push"}, notify.DependsOn[0])
+	assert.Equal(TaskUnitDependency{Name: "compile", Status: evergreen.TaskFailed}, notify.DependsOn[1])
+	assert.Equal(TaskUnitDependency{Name: "lint", Status: evergreen.TaskFailed}, notify.DependsOn[2])
+
+	require.Len(proj.BuildVariants[0].Tasks, 5)
+	cleanup := proj.BuildVariants[0].Tasks[4]
+	assert.Equal("finished(*)", cleanup.DependsOnExpr)
+	require.Len(cleanup.DependsOn, 1)
+	assert.Equal(TaskUnitDependency{Name: AllDependencies, Status: task.AllStatuses}, cleanup.DependsOn[0])
+
+	yml = `
+tasks:
+- name: compile
+  depends_on_expr: failed(lint
+`
+	_, errs = projectFromYAML([]byte(yml))
+	assert.NotEmpty(errs)
+}
+
+func TestPathFilterParsing(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	yml := `
+tasks:
+- name: compile
+  paths: src/
+- name: docs
+  paths:
+  - docs/
+  - "*.md"
+  ignore_paths: docs/internal/
+buildvariants:
+- name: bv_1
+  ignore_paths: "*.md"
+  tasks:
+  - name: compile
+    paths: [src/, build/]
+  - name: docs
+`
+	proj, errs := projectFromYAML([]byte(yml))
+	require.NotNil(proj)
+	require.Empty(errs)
+
+	compile := proj.FindProjectTask("compile")
+	require.NotNil(compile)
+	assert.Equal([]string{"src/"}, compile.Paths)
+	docs := proj.FindProjectTask("docs")
+	require.NotNil(docs)
+	assert.Equal([]string{"docs/", "*.md"}, docs.Paths)
+	assert.Equal([]string{"docs/internal/"}, docs.IgnorePaths)
+
+	bv := proj.BuildVariants[0]
+	assert.Empty(bv.Paths)
+	assert.Equal([]string{"*.md"}, bv.IgnorePaths)
+	require.Len(bv.Tasks, 2)
+	assert.Equal([]string{"src/", "build/"}, bv.Tasks[0].Paths)
+	assert.Empty(bv.Tasks[1].Paths)
+
+	// the task's filters apply unless the variant overrides them
+	bvt := proj.FindTaskForVariant("docs", "bv_1")
+	require.NotNil(bvt)
+	assert.Equal([]string{"docs/", "*.md"}, bvt.Paths)
+	assert.Equal([]string{"docs/internal/"}, bvt.IgnorePaths)
+	bvt = proj.FindTaskForVariant("compile", "bv_1")
+	require.NotNil(bvt)
+	assert.Equal([]string{"src/", "build/"}, bvt.Paths)
+}
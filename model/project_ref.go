@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"math"
 	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/mongodb/anser/bsonutil"
@@ -19,6 +23,7 @@ type ProjectRef struct {
 	Repo               string `bson:"repo_name" json:"repo_name" yaml:"repo"`
 	Branch             string `bson:"branch_name" json:"branch_name" yaml:"branch"`
 	RepoKind           string `bson:"repo_kind" json:"repo_kind" yaml:"repokind"`
+	RepoURL            string `bson:"repo_url" json:"repo_url" yaml:"repo_url"`
 	Enabled            bool   `bson:"enabled" json:"enabled" yaml:"enabled"`
 	Private            bool   `bson:"private" json:"private" yaml:"private"`
 	BatchTime          int    `bson:"batch_time" json:"batch_time" yaml:"batchtime"`
@@ -34,6 +39,10 @@ type ProjectRef struct {
 
 	PRTestingEnabled bool `bson:"pr_testing_enabled" json:"pr_testing_enabled" yaml:"pr_testing_enabled"`
 
+	// CommitQueue configures the commit queue, which merges pull requests
+	// after testing them on top of the branch. It requires PR testing.
+	CommitQueue CommitQueueParams `bson:"commit_queue" json:"commit_queue" yaml:"commit_queue"`
+
 	//Tracked determines whether or not the project is discoverable in the UI
 	Tracked          bool `bson:"tracked" json:"tracked"`
 	PatchingDisabled bool `bson:"patching_disabled" json:"patching_disabled"`
@@ -53,6 +62,36 @@ type ProjectRef struct {
 	RepotrackerError *RepositoryErrorDetails `bson:"repotracker_error" json:"repotracker_error"`
 }
 
+// CommitQueueParams are the settings of a project's commit queue.
+type CommitQueueParams struct {
+	Enabled bool `bson:"enabled" json:"enabled" yaml:"enabled"`
+
+	// MergeMethod is how GitHub merges pull requests: squash, merge or
+	// rebase.
+	MergeMethod string `bson:"merge_method" json:"merge_method" yaml:"merge_method"`
+
+	// PatchAlias selects the variants and tasks that a pull request must
+	// pass before it is merged. The alias of PR testing is used if it's
+	// empty.
+	PatchAlias string `bson:"patch_alias" json:"patch_alias" yaml:"patch_alias"`
+
+	// EnqueueLabel, if set, adds a pull request to the queue when the
+	// label is added to it.
+	EnqueueLabel string `bson:"enqueue_label" json:"enqueue_label" yaml:"enqueue_label"`
+}
+
+const (
+	CommitQueueMergeMethodSquash = "squash"
+	CommitQueueMergeMethodMerge  = "merge"
+	CommitQueueMergeMethodRebase = "rebase"
+)
+
+var ValidCommitQueueMergeMethods = []string{
+	CommitQueueMergeMethodSquash,
+	CommitQueueMergeMethodMerge,
+	CommitQueueMergeMethodRebase,
+}
+
 // RepositoryErrorDetails indicates whether or not there is an invalid revision and if there is one,
 // what the guessed merge base revision is.
 type RepositoryErrorDetails struct {
@@ -87,6 +126,7 @@ var (
 	ProjectRefRepoKey               = bsonutil.MustHaveTag(ProjectRef{}, "Repo")
 	ProjectRefBranchKey             = bsonutil.MustHaveTag(ProjectRef{}, "Branch")
 	ProjectRefRepoKindKey           = bsonutil.MustHaveTag(ProjectRef{}, "RepoKind")
+	ProjectRefRepoURLKey            = bsonutil.MustHaveTag(ProjectRef{}, "RepoURL")
 	ProjectRefEnabledKey            = bsonutil.MustHaveTag(ProjectRef{}, "Enabled")
 	ProjectRefPrivateKey            = bsonutil.MustHaveTag(ProjectRef{}, "Private")
 	ProjectRefBatchTimeKey          = bsonutil.MustHaveTag(ProjectRef{}, "BatchTime")
@@ -101,6 +141,8 @@ var (
 	ProjectRefAdminsKey             = bsonutil.MustHaveTag(ProjectRef{}, "Admins")
 	projectRefTracksPushEventsKey   = bsonutil.MustHaveTag(ProjectRef{}, "TracksPushEvents")
 	projectRefPRTestingEnabledKey   = bsonutil.MustHaveTag(ProjectRef{}, "PRTestingEnabled")
+	projectRefCommitQueueKey        = bsonutil.MustHaveTag(ProjectRef{}, "CommitQueue")
+	commitQueueEnabledKey           = bsonutil.MustHaveTag(CommitQueueParams{}, "Enabled")
 	projectRefPatchingDisabledKey   = bsonutil.MustHaveTag(ProjectRef{}, "PatchingDisabled")
 	projectRefNotifyOnFailureKey    = bsonutil.MustHaveTag(ProjectRef{}, "NotifyOnBuildFailure")
 )
@@ -240,6 +282,44 @@ func FindOneProjectRefByRepoAndBranchWithPRTesting(owner, repo, branch string) (
 	return &projectRefs[target], nil
 }
 
+// FindProjectRefsWithCommitQueueEnabled returns the enabled projects whose
+// commit queue is enabled.
+func FindProjectRefsWithCommitQueueEnabled() ([]ProjectRef, error) {
+	projectRefs := []ProjectRef{}
+
+	err := db.FindAll(
+		ProjectRefCollection,
+		bson.M{
+			ProjectRefEnabledKey:          true,
+			projectRefPRTestingEnabledKey: true,
+			bsonutil.GetDottedKeyName(projectRefCommitQueueKey, commitQueueEnabledKey): true,
+		},
+		db.NoProjection,
+		db.NoSort,
+		db.NoSkip,
+		db.NoLimit,
+		&projectRefs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return projectRefs, nil
+}
+
+// FindOneProjectRefByGitlabPathAndBranchWithPRTesting finds the project ref
+// that is set up for testing the merge requests of a GitLab project. GitLab
+// projects are stored with the namespace, which may include subgroups, as
+// the owner.
+func FindOneProjectRefByGitlabPathAndBranchWithPRTesting(projectPath, branch string) (*ProjectRef, error) {
+	idx := strings.LastIndex(projectPath, "/")
+	if idx <= 0 || idx == len(projectPath)-1 {
+		return nil, errors.Errorf("invalid gitlab project path '%s'", projectPath)
+	}
+
+	return FindOneProjectRefByRepoAndBranchWithPRTesting(projectPath[:idx], projectPath[idx+1:], branch)
+}
+
 // FindProjectRefs returns limit refs starting at project identifier key
 // in the sortDir direction
 func FindProjectRefs(key string, limit int, sortDir int, isAuthenticated bool) ([]ProjectRef, error) {
@@ -295,6 +375,7 @@ func (projectRef *ProjectRef) Upsert() error {
 		bson.M{
 			"$set": bson.M{
 				ProjectRefRepoKindKey:           projectRef.RepoKind,
+				ProjectRefRepoURLKey:            projectRef.RepoURL,
 				ProjectRefEnabledKey:            projectRef.Enabled,
 				ProjectRefPrivateKey:            projectRef.Private,
 				ProjectRefBatchTimeKey:          projectRef.BatchTime,
@@ -312,6 +393,7 @@ func (projectRef *ProjectRef) Upsert() error {
 				ProjectRefAdminsKey:             projectRef.Admins,
 				projectRefTracksPushEventsKey:   projectRef.TracksPushEvents,
 				projectRefPRTestingEnabledKey:   projectRef.PRTestingEnabled,
+				projectRefCommitQueueKey:        projectRef.CommitQueue,
 				projectRefPatchingDisabledKey:   projectRef.PatchingDisabled,
 				projectRefNotifyOnFailureKey:    projectRef.NotifyOnBuildFailure,
 			},
@@ -344,6 +426,12 @@ func (p *ProjectRef) GetBatchTime(variant *BuildVariant) int {
 
 // Location generates and returns the ssh hostname and path to the repo.
 func (projectRef *ProjectRef) Location() (string, error) {
+	if projectRef.RepoKind == GitRepoType {
+		if err := ValidateRepoURL(projectRef.RepoURL); err != nil {
+			return "", errors.Wrapf(err, "Invalid repository URL in project ref: %v", projectRef.Identifier)
+		}
+		return projectRef.RepoURL, nil
+	}
 	if projectRef.Owner == "" {
 		return "", errors.Errorf("No owner in project ref: %v", projectRef.Identifier)
 	}
@@ -353,8 +441,64 @@ func (projectRef *ProjectRef) Location() (string, error) {
 	return fmt.Sprintf("git@github.com:%v/%v.git", projectRef.Owner, projectRef.Repo), nil
 }
 
-// HTTPLocation creates a url.URL for HTTPS checkout of a Github repository
+// scpLikeRepoURL matches the scp-like syntax of ssh URLs that git accepts,
+// e.g. git@gitlab.com:owner/repo.git
+var scpLikeRepoURL = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*@[A-Za-z0-9][A-Za-z0-9.-]*:[^-]`)
+
+// ValidateRepoURL checks that a repository URL is an https, ssh,
+// user@host:path or file URL, or an absolute path to a local repository,
+// so that git can't interpret it as an option or a relative path. Other
+// transports are also disabled when git runs.
+func ValidateRepoURL(repoURL string) error {
+	if repoURL == "" {
+		return errors.New("no repository URL specified")
+	}
+	if strings.HasPrefix(repoURL, "-") || strings.ContainsAny(repoURL, " \t\r\n") {
+		return errors.Errorf("invalid repository URL '%s'", repoURL)
+	}
+	if strings.HasPrefix(repoURL, "https://") || strings.HasPrefix(repoURL, "ssh://") {
+		location, err := url.Parse(repoURL)
+		if err != nil {
+			return errors.Wrapf(err, "invalid repository URL '%s'", repoURL)
+		}
+		if location.Hostname() == "" || strings.HasPrefix(location.Hostname(), "-") {
+			return errors.Errorf("repository URL '%s' has no valid host", repoURL)
+		}
+		return nil
+	}
+	if strings.HasPrefix(repoURL, "file://") {
+		location, err := url.Parse(repoURL)
+		if err != nil {
+			return errors.Wrapf(err, "invalid repository URL '%s'", repoURL)
+		}
+		if location.Host != "" || !path.IsAbs(location.Path) {
+			return errors.Errorf("repository URL '%s' must be an absolute file URL", repoURL)
+		}
+		return nil
+	}
+	if filepath.IsAbs(repoURL) {
+		return nil
+	}
+	if scpLikeRepoURL.MatchString(repoURL) {
+		return nil
+	}
+
+	return errors.Errorf("repository URL '%s' must be an https, ssh, user@host:path or file URL, or an absolute path", repoURL)
+}
+
+// HTTPLocation creates a url.URL for HTTPS checkout of a Github repository,
+// or of the repository URL of a git repository
 func (projectRef *ProjectRef) HTTPLocation() (*url.URL, error) {
+	if projectRef.RepoKind == GitRepoType {
+		location, err := url.Parse(projectRef.RepoURL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid repository URL in project ref: %s", projectRef.Identifier)
+		}
+		if location.Scheme != "https" && location.Scheme != "http" {
+			return nil, errors.Errorf("Repository URL in project ref '%s' is not an http(s) URL", projectRef.Identifier)
+		}
+		return location, nil
+	}
 	if projectRef.Owner == "" {
 		return nil, errors.Errorf("No owner in project ref: %s", projectRef.Identifier)
 	}
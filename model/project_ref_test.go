@@ -94,6 +94,37 @@ func TestProjectRefHTTPLocation(t *testing.T) {
 	assert.Nil(url)
 }
 
+func TestValidateRepoURL(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, repoURL := range []string{
+		"https://gitlab.example.com/owner/repo.git",
+		"ssh://git@gitlab.example.com/owner/repo.git",
+		"git@gitlab.example.com:owner/repo.git",
+		"file:///srv/repos/repo.git",
+		"/mnt/nfs/repos/repo.git",
+	} {
+		assert.NoError(ValidateRepoURL(repoURL), repoURL)
+	}
+	for _, repoURL := range []string{
+		"",
+		"--upload-pack=touch /tmp/pwned",
+		"-oProxyCommand=sh",
+		"file://host/srv/repos/repo.git",
+		"file://repos/repo.git",
+		"../secret.git",
+		"repos/repo.git",
+		"ext::sh -c touch% /tmp/pwned",
+		"http://gitlab.example.com/owner/repo.git",
+		"ssh://-oProxyCommand=sh/repo.git",
+		"git@-oProxyCommand=sh:repo.git",
+		"git@gitlab.example.com:-repo.git",
+		"https://gitlab.example.com/owner/repo.git --upload-pack=sh",
+	} {
+		assert.Error(ValidateRepoURL(repoURL), repoURL)
+	}
+}
+
 func TestProjectRefLocation(t *testing.T) {
 	assert := assert.New(t)
 
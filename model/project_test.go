@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
@@ -14,6 +15,7 @@ import (
 	"github.com/evergreen-ci/evergreen/util"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	yaml "gopkg.in/yaml.v2"
 )
@@ -276,6 +278,82 @@ func TestIgnoresAllFiles(t *testing.T) {
 	})
 }
 
+func TestSelectTasksByPaths(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	yml := `
+tasks:
+- name: compile
+  paths: src/
+- name: test
+  paths: src/
+  depends_on:
+  - name: compile
+- name: lint
+  ignore_paths: "*.md"
+- name: docs
+  paths: docs/
+- name: package
+  paths: build/
+- name: release
+  paths: release/
+  depends_on:
+  - name: package
+buildvariants:
+- name: linux
+  tasks:
+  - compile
+  - test
+  - lint
+  - docs
+  - package
+  - release
+- name: windows
+  paths: src/windows/
+  tasks:
+  - compile
+  - name: docs
+    paths: "*"
+`
+	p, errs := projectFromYAML([]byte(yml))
+	require.NotNil(p)
+	require.Empty(errs)
+	assert.True(p.HasPathFilters())
+	assert.False((&Project{}).HasPathFilters())
+
+	pairs := TVPairSet{
+		{"linux", "compile"}, {"linux", "test"}, {"linux", "lint"}, {"linux", "docs"},
+		{"linux", "package"}, {"linux", "release"}, {"windows", "compile"}, {"windows", "docs"},
+	}
+
+	// a docs change only runs the docs task and the tasks that don't ignore it
+	selected, skipped := p.SelectTasksByPaths(pairs, []string{"docs/index.md"})
+	assert.Equal(TVPairSet{{"linux", "docs"}}, selected)
+	assert.Len(skipped, 7)
+
+	// a source change runs the source tasks, and windows only runs for
+	// changes to windows sources
+	selected, skipped = p.SelectTasksByPaths(pairs, []string{"src/linux/main.go"})
+	assert.Equal(TVPairSet{{"linux", "compile"}, {"linux", "test"}, {"linux", "lint"}}, selected)
+	assert.Len(skipped, 5)
+	selected, _ = p.SelectTasksByPaths(pairs, []string{"src/windows/main.go"})
+	assert.Contains(selected, TVPair{"windows", "compile"})
+	assert.Contains(selected, TVPair{"windows", "docs"})
+
+	// dependencies run even if they don't match
+	selected, _ = p.SelectTasksByPaths(pairs, []string{"release/notes.md"})
+	assert.Equal(TVPairSet{{"linux", "package"}, {"linux", "release"}}, selected)
+	// but only if they're among the given pairs
+	selected, skipped = p.SelectTasksByPaths(TVPairSet{{"linux", "release"}}, []string{"release/notes.md"})
+	assert.Equal(TVPairSet{{"linux", "release"}}, selected)
+	assert.Empty(skipped)
+
+	// without changed files everything runs
+	selected, skipped = p.SelectTasksByPaths(pairs, nil)
+	assert.Equal(pairs, selected)
+	assert.Empty(skipped)
+}
+
 func boolPtr(b bool) *bool {
 	return &b
 }
@@ -1133,3 +1211,29 @@ func (s *projectSuite) TestIsGenerateTask() {
 	s.False(s.project.IsGenerateTask("another_disabled_task"))
 	s.False(s.project.IsGenerateTask("task_does_not_exist"))
 }
+
+func TestCommandRetryPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	policy := &CommandRetryPolicy{Attempts: 3}
+	backoff, err := policy.GetBackoff()
+	assert.NoError(err)
+	assert.Zero(backoff)
+	assert.True(policy.ShouldRetry(1, true))
+	assert.True(policy.ShouldRetry(0, false))
+
+	policy = &CommandRetryPolicy{Attempts: 3, Backoff: "1m", OnExitCodes: []int{2}}
+	backoff, err = policy.GetBackoff()
+	assert.NoError(err)
+	assert.Equal(time.Minute, backoff)
+	assert.True(policy.ShouldRetry(2, true))
+	assert.False(policy.ShouldRetry(1, true))
+	assert.False(policy.ShouldRetry(0, false))
+
+	policy.Backoff = "-1s"
+	_, err = policy.GetBackoff()
+	assert.Error(err)
+	policy.Backoff = "later"
+	_, err = policy.GetBackoff()
+	assert.Error(err)
+}
@@ -40,6 +40,15 @@ type AvgBucket struct {
 	End         time.Time     `json:"end_time" csv:"end_time"`
 }
 
+// ArrivalBucket is one element in the results of TaskArrivalStatistics.
+type ArrivalBucket struct {
+	Id            int           `bson:"_id" json:"index"`
+	NumberTasks   int           `bson:"n" json:"number_tasks"`
+	NumberCommits int           `bson:"c" json:"number_commits"`
+	TotalDuration time.Duration `bson:"d" json:"total_duration"`
+	Start         time.Time     `json:"start_time"`
+}
+
 // FrameBounds is a set of information about the inputs of buckets
 type FrameBounds struct {
 	StartTime     time.Time
@@ -345,6 +354,69 @@ func AverageStatistics(distroId string, bounds FrameBounds) (AvgBuckets, error)
 	return convertBucketsToNanoseconds(buckets, bounds), nil
 }
 
+// TaskArrivalStatistics uses an agg pipeline that buckets the tasks scheduled on a distro in
+// a time frame by scheduled time. For each bucket, it counts the tasks, the versions they belong
+// to, which is the cadence of commits and patches that ran on the distro, and sums their expected
+// durations, each capped at maxDuration. Buckets that no task was scheduled in are left out.
+func TaskArrivalStatistics(distroId string, bounds FrameBounds, maxDuration time.Duration) ([]ArrivalBucket, error) {
+	intBucketSize := util.FromNanoseconds(bounds.BucketSize)
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			task.DistroIdKey: distroId,
+			task.ScheduledTimeKey: bson.M{
+				"$gte": bounds.StartTime,
+				"$lt":  bounds.EndTime,
+			},
+			task.DisplayOnlyKey: bson.M{
+				"$ne": true,
+			},
+		}},
+		{"$project": bson.M{
+			task.VersionKey: 1,
+			// $min ignores missing values, so tasks without an expected duration count as 0
+			"d": bson.M{
+				"$min": []interface{}{
+					bson.M{"$ifNull": []interface{}{"$" + task.ExpectedDurationKey, 0}},
+					int64(maxDuration)},
+			},
+			"b": bson.M{
+				"$floor": bson.M{
+					"$divide": []interface{}{
+						bson.M{"$subtract": []interface{}{"$" + task.ScheduledTimeKey, bounds.StartTime}},
+						intBucketSize},
+				},
+			},
+		}},
+		// group the tasks of each version in a bucket, so versions are only counted once
+		{"$group": bson.M{
+			"_id": bson.M{
+				"b": "$b",
+				"v": "$" + task.VersionKey,
+			},
+			"n": bson.M{"$sum": 1},
+			"d": bson.M{"$sum": "$d"},
+		}},
+		{"$group": bson.M{
+			"_id": "$_id.b",
+			"n":   bson.M{"$sum": "$n"},
+			"c":   bson.M{"$sum": 1},
+			"d":   bson.M{"$sum": "$d"},
+		}},
+		{"$sort": bson.M{
+			"_id": 1,
+		}},
+	}
+
+	buckets := []ArrivalBucket{}
+	if err := db.Aggregate(task.Collection, pipeline, &buckets); err != nil {
+		return nil, errors.Wrap(err, "error running task arrival aggregation")
+	}
+	for i, b := range buckets {
+		buckets[i].Start = bounds.StartTime.Add(time.Duration(b.Id) * bounds.BucketSize)
+	}
+	return buckets, nil
+}
+
 // AverageTaskLatency finds the average task latency by distro and requester.
 func AverageTaskLatency(since time.Duration) (*AverageTimes, error) {
 	now := time.Now()
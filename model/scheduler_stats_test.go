@@ -296,6 +296,47 @@ func TestAverageStatistics(t *testing.T) {
 
 }
 
+func TestTaskArrivalStatistics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(task.Collection))
+
+	now := time.Now().Round(time.Millisecond)
+	tasks := []task.Task{
+		{Id: "t1", DistroId: "d", Version: "v1", ScheduledTime: now, ExpectedDuration: time.Minute},
+		{Id: "t2", DistroId: "d", Version: "v1", ScheduledTime: now, ExpectedDuration: time.Hour},
+		{Id: "t3", DistroId: "d", Version: "v2", ScheduledTime: now.Add(time.Second)},
+		{Id: "t4", DistroId: "d", Version: "v3", ScheduledTime: now.Add(25 * time.Second), ExpectedDuration: time.Minute},
+		{Id: "t5", DistroId: "other", Version: "v1", ScheduledTime: now},
+		{Id: "t6", DistroId: "d", Version: "v1", ScheduledTime: now.Add(-time.Second)},
+	}
+	for _, tsk := range tasks {
+		require.NoError(tsk.Insert())
+	}
+
+	bounds := FrameBounds{
+		StartTime:     now,
+		EndTime:       now.Add(30 * time.Second),
+		BucketSize:    10 * time.Second,
+		NumberBuckets: 3,
+	}
+	buckets, err := TaskArrivalStatistics("d", bounds, 30*time.Minute)
+	require.NoError(err)
+	require.Len(buckets, 2)
+
+	assert.Equal(0, buckets[0].Id)
+	assert.Equal(now, buckets[0].Start)
+	assert.Equal(3, buckets[0].NumberTasks)
+	assert.Equal(2, buckets[0].NumberCommits)
+	assert.Equal(31*time.Minute, buckets[0].TotalDuration)
+
+	assert.Equal(2, buckets[1].Id)
+	assert.Equal(now.Add(20*time.Second), buckets[1].Start)
+	assert.Equal(1, buckets[1].NumberTasks)
+	assert.Equal(1, buckets[1].NumberCommits)
+	assert.Equal(time.Minute, buckets[1].TotalDuration)
+}
+
 func TestFindPredictedMakespan(t *testing.T) {
 	Convey("With a simple set of tasks that are dependent on each other and different times taken", t, func() {
 
@@ -38,6 +38,7 @@ var (
 	DistroIdKey            = bsonutil.MustHaveTag(Task{}, "DistroId")
 	BuildVariantKey        = bsonutil.MustHaveTag(Task{}, "BuildVariant")
 	DependsOnKey           = bsonutil.MustHaveTag(Task{}, "DependsOn")
+	DependsOnExprKey       = bsonutil.MustHaveTag(Task{}, "DependsOnExpr")
 	NumDepsKey             = bsonutil.MustHaveTag(Task{}, "NumDependents")
 	DisplayNameKey         = bsonutil.MustHaveTag(Task{}, "DisplayName")
 	HostIdKey              = bsonutil.MustHaveTag(Task{}, "HostId")
@@ -161,6 +162,29 @@ func ByVersion(version string) db.Q {
 	})
 }
 
+// ByVersionAndStatus creates a query to return the tasks of a version that
+// have one of the statuses
+func ByVersionAndStatus(version string, statuses []string) db.Q {
+	return db.Query(bson.M{
+		VersionKey: version,
+		StatusKey: bson.M{
+			"$in": statuses,
+		},
+	})
+}
+
+// ByVersionAbortedOrDeactivated creates a query to return the tasks of a
+// version that were aborted or deactivated, so won't run to completion
+func ByVersionAbortedOrDeactivated(version string) db.Q {
+	return db.Query(bson.M{
+		VersionKey: version,
+		"$or": []bson.M{
+			{AbortedKey: true},
+			{ActivatedKey: false},
+		},
+	})
+}
+
 // ByIdsBuildIdAndStatus creates a query to return tasks with a certain build id and statuses
 func ByIdsBuildAndStatus(taskIds []string, buildId string, statuses []string) db.Q {
 	return db.Query(bson.M{
@@ -0,0 +1,364 @@
+package task
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
+)
+
+const (
+	DependencyAnd = "&&"
+	DependencyOr  = "||"
+)
+
+// dependencyPredicates maps the predicates that may be used in a dependency
+// expression to the dependency status they require.
+var dependencyPredicates = map[string]string{
+	"success":       evergreen.TaskSucceeded,
+	"failed":        evergreen.TaskFailed,
+	"setup-failed":  evergreen.TaskSetupFailed,
+	"system-failed": evergreen.TaskSystemFailed,
+	"finished":      AllStatuses,
+}
+
+// DependencyExpression is a boolean combination of requirements on the
+// statuses of a task's dependencies, such as
+//
+//	(failed(compile) || failed(lint)) && success(push, ubuntu1604)
+//
+// Each dependency is written as a predicate on its status, one of success,
+// failed, setup-failed, system-failed, or finished (any of them), taking the
+// name of the dependency and, optionally, its variant. In a project the
+// name is a task name, or * for all tasks in the variant, and the variant
+// is a build variant name, or * for all variants. Once tasks are created,
+// each dependency is named by the id of the task.
+type DependencyExpression struct {
+	// Operator is DependencyAnd or DependencyOr for an expression that
+	// combines Terms, and empty for a single dependency.
+	Operator string
+	Terms    []DependencyExpression
+
+	// Status, Name and Variant describe a single dependency.
+	Status  string
+	Name    string
+	Variant string
+}
+
+// ParseDependencyExpression parses a dependency expression.
+func ParseDependencyExpression(expr string) (*DependencyExpression, error) {
+	p := &dependencyExpressionParser{tokens: tokenizeDependencyExpression(expr)}
+	if len(p.tokens) == 0 {
+		return nil, errors.New("dependency expression is empty")
+	}
+
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid dependency expression '%s'", expr)
+	}
+	if !p.done() {
+		return nil, errors.Errorf("invalid dependency expression '%s': unexpected '%s'", expr, p.peek())
+	}
+
+	return e, nil
+}
+
+// IsDependency returns true if the expression is a single dependency.
+func (e *DependencyExpression) IsDependency() bool {
+	return e.Operator == ""
+}
+
+// String returns the expression in the syntax it is parsed from.
+func (e *DependencyExpression) String() string {
+	if e.IsDependency() {
+		predicate := e.Status
+		for name, status := range dependencyPredicates {
+			if status == e.Status {
+				predicate = name
+			}
+		}
+		if e.Variant == "" {
+			return fmt.Sprintf("%s(%s)", predicate, e.Name)
+		}
+		return fmt.Sprintf("%s(%s, %s)", predicate, e.Name, e.Variant)
+	}
+
+	terms := make([]string, 0, len(e.Terms))
+	for _, term := range e.Terms {
+		if term.IsDependency() {
+			terms = append(terms, term.String())
+		} else {
+			terms = append(terms, "("+term.String()+")")
+		}
+	}
+
+	return strings.Join(terms, " "+e.Operator+" ")
+}
+
+// Dependencies returns the single dependencies in the expression.
+func (e *DependencyExpression) Dependencies() []DependencyExpression {
+	if e.IsDependency() {
+		return []DependencyExpression{*e}
+	}
+
+	deps := []DependencyExpression{}
+	for _, term := range e.Terms {
+		deps = append(deps, term.Dependencies()...)
+	}
+
+	return deps
+}
+
+// Resolve returns a copy of the expression with each dependency replaced by
+// the task ids that resolve returns for it. A dependency that resolves to
+// more than one task requires all of them, and one that resolves to no
+// tasks is dropped. Resolve returns nil if no dependencies are left.
+func (e *DependencyExpression) Resolve(resolve func(name, variant string) []string) *DependencyExpression {
+	if e.IsDependency() {
+		ids := resolve(e.Name, e.Variant)
+		if len(ids) == 0 {
+			return nil
+		}
+		resolved := &DependencyExpression{Operator: DependencyAnd}
+		for _, id := range ids {
+			resolved.Terms = append(resolved.Terms, DependencyExpression{Status: e.Status, Name: id})
+		}
+		return resolved.simplify()
+	}
+
+	resolved := &DependencyExpression{Operator: e.Operator}
+	for _, term := range e.Terms {
+		if r := term.Resolve(resolve); r != nil {
+			resolved.addTerm(*r)
+		}
+	}
+
+	return resolved.simplify()
+}
+
+// Satisfied returns true if the statuses of the dependencies, which are
+// looked up by task id, satisfy the expression.
+func (e *DependencyExpression) Satisfied(deps map[string]Task) bool {
+	switch e.Operator {
+	case DependencyAnd:
+		for _, term := range e.Terms {
+			if !term.Satisfied(deps) {
+				return false
+			}
+		}
+		return true
+	case DependencyOr:
+		for _, term := range e.Terms {
+			if term.Satisfied(deps) {
+				return true
+			}
+		}
+		return false
+	default:
+		depTask, ok := deps[e.Name]
+		return ok && dependencyStatusMet(e.Status, &depTask)
+	}
+}
+
+// UnmetClauses returns the clauses of the expression that the dependencies
+// don't satisfy yet: each unsatisfied term of an expression that requires
+// all of its terms, or otherwise the whole expression if it is unsatisfied.
+func (e *DependencyExpression) UnmetClauses(deps map[string]Task) []string {
+	unmet := []string{}
+	if e.Operator == DependencyAnd {
+		for _, term := range e.Terms {
+			if !term.Satisfied(deps) {
+				unmet = append(unmet, term.String())
+			}
+		}
+	} else if !e.Satisfied(deps) {
+		unmet = append(unmet, e.String())
+	}
+
+	return unmet
+}
+
+func (e *DependencyExpression) addTerm(term DependencyExpression) {
+	// flatten nested expressions with the same operator
+	if term.Operator == e.Operator {
+		e.Terms = append(e.Terms, term.Terms...)
+		return
+	}
+	e.Terms = append(e.Terms, term)
+}
+
+func (e *DependencyExpression) simplify() *DependencyExpression {
+	switch len(e.Terms) {
+	case 0:
+		return nil
+	case 1:
+		return &e.Terms[0]
+	default:
+		return e
+	}
+}
+
+// dependencyStatusMet returns true if the status of a dependency satisfies
+// the required status. An empty status requires the dependency to succeed.
+func dependencyStatusMet(status string, depTask *Task) bool {
+	switch status {
+	case evergreen.TaskSucceeded, "":
+		return depTask.Status == evergreen.TaskSucceeded
+	case evergreen.TaskFailed:
+		return depTask.Status == evergreen.TaskFailed
+	case evergreen.TaskSetupFailed:
+		return depTask.ResultStatus() == evergreen.TaskSetupFailed
+	case evergreen.TaskSystemFailed:
+		resultStatus := depTask.ResultStatus()
+		return resultStatus == evergreen.TaskSystemFailed ||
+			resultStatus == evergreen.TaskSystemTimedOut ||
+			resultStatus == evergreen.TaskSystemUnresponse
+	case AllStatuses:
+		return depTask.Status == evergreen.TaskFailed || depTask.Status == evergreen.TaskSucceeded
+	}
+	return false
+}
+
+func tokenizeDependencyExpression(expr string) []string {
+	tokens := []string{}
+	current := bytes.Buffer{}
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	runes := []rune(expr)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case r == '(' || r == ')' || r == ',':
+			flush()
+			tokens = append(tokens, string(r))
+		case (r == '&' || r == '|') && i+1 < len(runes) && runes[i+1] == r:
+			flush()
+			tokens = append(tokens, string([]rune{r, r}))
+			i++
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+type dependencyExpressionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *dependencyExpressionParser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *dependencyExpressionParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *dependencyExpressionParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *dependencyExpressionParser) expect(token string) error {
+	if p.done() {
+		return errors.Errorf("expected '%s' at end of expression", token)
+	}
+	if next := p.next(); next != token {
+		return errors.Errorf("expected '%s' but found '%s'", token, next)
+	}
+	return nil
+}
+
+func (p *dependencyExpressionParser) parseOr() (*DependencyExpression, error) {
+	return p.parseOperator(DependencyOr, p.parseAnd)
+}
+
+func (p *dependencyExpressionParser) parseAnd() (*DependencyExpression, error) {
+	return p.parseOperator(DependencyAnd, p.parseTerm)
+}
+
+func (p *dependencyExpressionParser) parseOperator(operator string,
+	parseTerm func() (*DependencyExpression, error)) (*DependencyExpression, error) {
+
+	e := &DependencyExpression{Operator: operator}
+	for {
+		term, err := parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		e.addTerm(*term)
+		if p.peek() != operator {
+			break
+		}
+		p.next()
+	}
+
+	return e.simplify(), nil
+}
+
+func (p *dependencyExpressionParser) parseTerm() (*DependencyExpression, error) {
+	if p.done() {
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	token := p.next()
+	if token == "(" {
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+
+	status, ok := dependencyPredicates[token]
+	if !ok {
+		return nil, errors.Errorf("'%s' is not a dependency status", token)
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	e := &DependencyExpression{Status: status}
+	e.Name = p.next()
+	if !isDependencyName(e.Name) {
+		return nil, errors.Errorf("expected a dependency name but found '%s'", e.Name)
+	}
+	if p.peek() == "," {
+		p.next()
+		e.Variant = p.next()
+		if !isDependencyName(e.Variant) {
+			return nil, errors.Errorf("expected a variant name but found '%s'", e.Variant)
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func isDependencyName(token string) bool {
+	switch token {
+	case "", "(", ")", ",", DependencyAnd, DependencyOr:
+		return false
+	}
+	return true
+}
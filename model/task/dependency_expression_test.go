@@ -0,0 +1,150 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDependencyExpression(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	e, err := ParseDependencyExpression("(failed(compile) || failed(lint)) && success(push, ubuntu1604)")
+	require.NoError(err)
+	assert.Equal(DependencyAnd, e.Operator)
+	require.Len(e.Terms, 2)
+	assert.Equal(DependencyOr, e.Terms[0].Operator)
+	assert.Equal(DependencyExpression{Status: evergreen.TaskFailed, Name: "compile"}, e.Terms[0].Terms[0])
+	assert.Equal(DependencyExpression{Status: evergreen.TaskFailed, Name: "lint"}, e.Terms[0].Terms[1])
+	assert.Equal(DependencyExpression{Status: evergreen.TaskSucceeded, Name: "push", Variant: "ubuntu1604"}, e.Terms[1])
+	assert.Equal("(failed(compile) || failed(lint)) && success(push, ubuntu1604)", e.String())
+	assert.Len(e.Dependencies(), 3)
+
+	// && binds more tightly than ||, and terms with the same operator are flattened
+	e, err = ParseDependencyExpression("finished(a) || setup-failed(b) && system-failed(c, *) || success(d)")
+	require.NoError(err)
+	assert.Equal(DependencyOr, e.Operator)
+	require.Len(e.Terms, 3)
+	assert.Equal(DependencyAnd, e.Terms[1].Operator)
+	assert.Equal(AllStatuses, e.Terms[0].Status)
+	assert.Equal(evergreen.TaskSetupFailed, e.Terms[1].Terms[0].Status)
+	assert.Equal(evergreen.TaskSystemFailed, e.Terms[1].Terms[1].Status)
+	assert.Equal("finished(a) || (setup-failed(b) && system-failed(c, *)) || success(d)", e.String())
+
+	e, err = ParseDependencyExpression("((success(a)))")
+	require.NoError(err)
+	assert.True(e.IsDependency())
+	assert.Equal("success(a)", e.String())
+
+	for _, expr := range []string{
+		"",
+		"success",
+		"success()",
+		"success(a",
+		"success(a,)",
+		"succeeded(a)",
+		"success(a) &&",
+		"success(a) success(b)",
+		"(success(a) || success(b)",
+		"success(a) & success(b)",
+	} {
+		_, err = ParseDependencyExpression(expr)
+		assert.Error(err, expr)
+	}
+}
+
+func TestDependencyExpressionSatisfied(t *testing.T) {
+	assert := assert.New(t)
+
+	e, err := ParseDependencyExpression("(failed(t1) || setup-failed(t2)) && finished(t3)")
+	assert.NoError(err)
+
+	deps := map[string]Task{
+		"t1": {Id: "t1", Status: evergreen.TaskSucceeded},
+		"t2": {Id: "t2", Status: evergreen.TaskFailed, Details: apimodels.TaskEndDetail{Type: "setup"}},
+		"t3": {Id: "t3", Status: evergreen.TaskStarted},
+	}
+	assert.False(e.Satisfied(deps))
+	assert.Equal([]string{"finished(t3)"}, e.UnmetClauses(deps))
+
+	deps["t3"] = Task{Id: "t3", Status: evergreen.TaskFailed}
+	assert.True(e.Satisfied(deps))
+	assert.Empty(e.UnmetClauses(deps))
+
+	deps["t2"] = Task{Id: "t2", Status: evergreen.TaskFailed}
+	assert.False(e.Satisfied(deps))
+	assert.Equal([]string{"failed(t1) || setup-failed(t2)"}, e.UnmetClauses(deps))
+
+	// dependencies that can't be found are not met
+	delete(deps, "t3")
+	deps["t1"] = Task{Id: "t1", Status: evergreen.TaskFailed}
+	assert.False(e.Satisfied(deps))
+
+	e, err = ParseDependencyExpression("system-failed(t1)")
+	assert.NoError(err)
+	timedOut := Task{Id: "t1", Status: evergreen.TaskFailed, Details: apimodels.TaskEndDetail{Type: "system", TimedOut: true}}
+	assert.Equal(evergreen.TaskSystemTimedOut, timedOut.ResultStatus())
+	deps["t1"] = timedOut
+	assert.True(e.Satisfied(deps))
+}
+
+func TestDependencyExpressionResolve(t *testing.T) {
+	assert := assert.New(t)
+
+	e, err := ParseDependencyExpression("(failed(compile) || failed(lint)) && success(*, ubuntu1604)")
+	assert.NoError(err)
+
+	ids := map[string][]string{
+		"compile":     {"compile_id"},
+		"*ubuntu1604": {"push_id", "test_id"},
+	}
+	resolved := e.Resolve(func(name, variant string) []string {
+		return ids[name+variant]
+	})
+	assert.Equal("failed(compile_id) && success(push_id) && success(test_id)", resolved.String())
+
+	// the resolved expression can be parsed again
+	parsed, err := ParseDependencyExpression(resolved.String())
+	assert.NoError(err)
+	assert.Equal(resolved, parsed)
+
+	resolved = e.Resolve(func(name, variant string) []string { return nil })
+	assert.Nil(resolved)
+}
+
+func TestUnmetDependencies(t *testing.T) {
+	assert := assert.New(t)
+
+	task := Task{
+		Id: "t",
+		DependsOn: []Dependency{
+			{TaskId: "t1", Status: evergreen.TaskFailed},
+			{TaskId: "t2", Status: evergreen.TaskFailed},
+			{TaskId: "t3", Status: evergreen.TaskSucceeded},
+		},
+		DependsOnExpr: "failed(t1) || failed(t2)",
+	}
+	deps := map[string]Task{
+		"t1": {Id: "t1", Status: evergreen.TaskSucceeded},
+		"t2": {Id: "t2", Status: evergreen.TaskSucceeded},
+		"t3": {Id: "t3", Status: evergreen.TaskStarted},
+	}
+
+	unmet, err := task.UnmetDependencies(deps)
+	assert.NoError(err)
+	assert.Equal([]string{"failed(t1) || failed(t2)", "success(t3)"}, unmet)
+
+	deps["t2"] = Task{Id: "t2", Status: evergreen.TaskFailed}
+	deps["t3"] = Task{Id: "t3", Status: evergreen.TaskSucceeded}
+	unmet, err = task.UnmetDependencies(deps)
+	assert.NoError(err)
+	assert.Empty(unmet)
+
+	task.DependsOnExpr = "failed(t1"
+	_, err = task.UnmetDependencies(deps)
+	assert.Error(err)
+}
@@ -0,0 +1,169 @@
+package task
+
+import (
+	"sort"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// FlakinessRevisions is the number of mainline revisions whose test
+	// results are used to score the flakiness of tests, by default.
+	FlakinessRevisions = 50
+
+	// FlakyScoreThreshold is the flakiness score at which a test is
+	// considered flaky, by default.
+	FlakyScoreThreshold = 0.2
+
+	// flakyMinRuns is the fewest passes and failures that a test must have
+	// for its score to be meaningful
+	flakyMinRuns = 5
+)
+
+// TestFlakiness describes how often a test has changed between passing and
+// failing in a task's recent runs. Its score is the proportion of the
+// test's consecutive results, ordered by revision and then execution, that
+// differ, so that tests that flip on restarts of the same revision or
+// back and forth between revisions score highly, but a test that started
+// failing at one revision and kept failing does not.
+type TestFlakiness struct {
+	Project  string
+	Variant  string
+	TaskName string
+	TestFile string
+
+	// Runs counts the test's passes and failures, of which Failures failed.
+	Runs     int
+	Failures int
+	// Flips is how many times the test's result changed.
+	Flips int
+	// FlakyRevisions counts the revisions at which the test both passed
+	// and failed.
+	FlakyRevisions int
+	Score          float64
+}
+
+// IsFlaky returns true if the test has run enough times to be scored, and
+// its score is at least the threshold.
+func (f *TestFlakiness) IsFlaky(threshold float64) bool {
+	return f.Runs >= flakyMinRuns && f.Score >= threshold
+}
+
+type flakinessResult struct {
+	order     int
+	execution int
+	failed    bool
+}
+
+// ComputeTestFlakiness scores the flakiness of each test in the results, of
+// the tasks whose revision order numbers are given by task ID. Results that
+// are neither passes nor failures are ignored.
+func ComputeTestFlakiness(project, variant, taskName string, results []testresult.TestResult, orders map[string]int) []TestFlakiness {
+	byTest := map[string][]flakinessResult{}
+	for _, r := range results {
+		order, ok := orders[r.TaskID]
+		if !ok {
+			continue
+		}
+		if r.Status != evergreen.TestSucceededStatus && r.Status != evergreen.TestFailedStatus {
+			continue
+		}
+		byTest[r.TestFile] = append(byTest[r.TestFile], flakinessResult{
+			order:     order,
+			execution: r.Execution,
+			failed:    r.Status == evergreen.TestFailedStatus,
+		})
+	}
+
+	out := make([]TestFlakiness, 0, len(byTest))
+	for testFile, runs := range byTest {
+		sort.SliceStable(runs, func(i, j int) bool {
+			if runs[i].order != runs[j].order {
+				return runs[i].order < runs[j].order
+			}
+			return runs[i].execution < runs[j].execution
+		})
+
+		f := TestFlakiness{
+			Project:  project,
+			Variant:  variant,
+			TaskName: taskName,
+			TestFile: testFile,
+			Runs:     len(runs),
+		}
+		revisionFlaky := false
+		for i := range runs {
+			if runs[i].failed {
+				f.Failures++
+			}
+			if i == 0 {
+				continue
+			}
+			if runs[i].order != runs[i-1].order {
+				revisionFlaky = false
+			}
+			if runs[i].failed != runs[i-1].failed {
+				f.Flips++
+				if runs[i].order == runs[i-1].order && !revisionFlaky {
+					revisionFlaky = true
+					f.FlakyRevisions++
+				}
+			}
+		}
+		if f.Runs > 1 {
+			f.Score = float64(f.Flips) / float64(f.Runs-1)
+		}
+
+		out = append(out, f)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].TestFile < out[j].TestFile
+	})
+
+	return out
+}
+
+// FindTestFlakiness scores the flakiness of the tests of a task, from the
+// results of all executions of its most recent mainline runs.
+func FindTestFlakiness(project, variant, taskName string, revisions int) ([]TestFlakiness, error) {
+	if revisions <= 0 {
+		revisions = FlakinessRevisions
+	}
+
+	tasks, err := Find(db.Query(bson.M{
+		ProjectKey:      project,
+		BuildVariantKey: variant,
+		DisplayNameKey:  taskName,
+		RequesterKey:    evergreen.RepotrackerVersionRequester,
+		StatusKey:       bson.M{"$in": CompletedStatuses},
+	}).WithFields(IdKey, RevisionOrderNumberKey, DisplayOnlyKey).Sort([]string{"-" + RevisionOrderNumberKey}).Limit(revisions))
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding tasks")
+	}
+	if len(tasks) == 0 {
+		return []TestFlakiness{}, nil
+	}
+
+	orders := make(map[string]int, len(tasks))
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		orders[t.Id] = t.RevisionOrderNumber
+		ids = append(ids, t.Id)
+	}
+
+	results, err := testresult.Find(testresult.ByTaskIDs(ids).WithFields(testresult.TaskIDKey,
+		testresult.ExecutionKey, testresult.TestFileKey, testresult.StatusKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding test results")
+	}
+
+	return ComputeTestFlakiness(project, variant, taskName, results, orders), nil
+}
@@ -0,0 +1,73 @@
+package task
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTestFlakiness(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	orders := map[string]int{}
+	for i := 1; i <= 9; i++ {
+		orders[fmt.Sprintf("t%d", i)] = i
+	}
+	pass, fail := evergreen.TestSucceededStatus, evergreen.TestFailedStatus
+	results := []testresult.TestResult{
+		// flips on restarts of the same revisions
+		{TaskID: "t1", Execution: 0, TestFile: "flaky", Status: fail},
+		{TaskID: "t1", Execution: 1, TestFile: "flaky", Status: pass},
+		{TaskID: "t2", Execution: 0, TestFile: "flaky", Status: pass},
+		{TaskID: "t3", Execution: 1, TestFile: "flaky", Status: pass},
+		{TaskID: "t3", Execution: 0, TestFile: "flaky", Status: fail},
+		{TaskID: "t4", Execution: 0, TestFile: "flaky", Status: pass},
+		// started failing and kept failing
+		{TaskID: "t1", TestFile: "broken", Status: pass},
+		{TaskID: "t2", TestFile: "broken", Status: pass},
+		{TaskID: "t3", TestFile: "broken", Status: fail},
+		{TaskID: "t4", TestFile: "broken", Status: fail},
+		{TaskID: "t5", TestFile: "broken", Status: fail},
+		{TaskID: "t5", TestFile: "broken", Execution: 1, Status: evergreen.TestSkippedStatus},
+		{TaskID: "t6", TestFile: "broken", Status: fail},
+		{TaskID: "t7", TestFile: "broken", Status: fail},
+		{TaskID: "t8", TestFile: "broken", Status: fail},
+		{TaskID: "t9", TestFile: "broken", Status: fail},
+		// results of other tasks are ignored
+		{TaskID: "other", TestFile: "broken", Status: pass},
+	}
+
+	flakiness := ComputeTestFlakiness("proj", "bv", "test", results, orders)
+	require.Len(flakiness, 2)
+
+	flaky := flakiness[0]
+	assert.Equal("flaky", flaky.TestFile)
+	assert.Equal("proj", flaky.Project)
+	assert.Equal(6, flaky.Runs)
+	assert.Equal(2, flaky.Failures)
+	assert.Equal(3, flaky.Flips)
+	assert.Equal(2, flaky.FlakyRevisions)
+	assert.InDelta(0.6, flaky.Score, 0.001)
+	assert.True(flaky.IsFlaky(FlakyScoreThreshold))
+
+	broken := flakiness[1]
+	assert.Equal("broken", broken.TestFile)
+	assert.Equal(9, broken.Runs)
+	assert.Equal(7, broken.Failures)
+	assert.Equal(1, broken.Flips)
+	assert.Equal(0, broken.FlakyRevisions)
+	assert.InDelta(0.125, broken.Score, 0.001)
+	assert.False(broken.IsFlaky(FlakyScoreThreshold))
+	assert.True(broken.IsFlaky(0.1))
+
+	// too few runs to be scored
+	flakiness = ComputeTestFlakiness("proj", "bv", "test", results[:2], orders)
+	require.Len(flakiness, 1)
+	assert.Equal(1.0, flakiness[0].Score)
+	assert.False(flakiness[0].IsFlaky(FlakyScoreThreshold))
+}
@@ -83,6 +83,10 @@ type Task struct {
 	BuildVariant  string       `bson:"build_variant" json:"build_variant"`
 	DependsOn     []Dependency `bson:"depends_on" json:"depends_on"`
 	NumDependents int          `bson:"num_dependents,omitempty" json:"num_dependents,omitempty"`
+	// DependsOnExpr, if set, is a DependencyExpression over the ids of
+	// the task's dependencies that replaces the statuses required of the
+	// dependencies it refers to.
+	DependsOnExpr string `bson:"depends_on_expr,omitempty" json:"depends_on_expr,omitempty"`
 
 	// Human-readable name
 	DisplayName string `bson:"display_name" json:"display_name"`
@@ -146,6 +150,12 @@ type Dependency struct {
 	Status string `bson:"status" json:"status"`
 }
 
+// SatisfiedBy returns true if the status of the task depended on satisfies
+// the dependency.
+func (d *Dependency) SatisfiedBy(depTask *Task) bool {
+	return dependencyStatusMet(d.Status, depTask)
+}
+
 // VersionCost is service level model for representing cost data related to a version.
 // SumTimeTaken is the aggregation of time taken by all tasks associated with a version.
 type VersionCost struct {
@@ -255,19 +265,52 @@ func (t *Task) GetTaskCreatedTime() time.Time {
 func (t *Task) satisfiesDependency(depTask *Task) bool {
 	for _, dep := range t.DependsOn {
 		if dep.TaskId == depTask.Id {
-			switch dep.Status {
-			case evergreen.TaskSucceeded, "":
-				return depTask.Status == evergreen.TaskSucceeded
-			case evergreen.TaskFailed:
-				return depTask.Status == evergreen.TaskFailed
-			case AllStatuses:
-				return depTask.Status == evergreen.TaskFailed || depTask.Status == evergreen.TaskSucceeded
-			}
+			return dep.SatisfiedBy(depTask)
 		}
 	}
 	return false
 }
 
+// UnmetDependencies returns the requirements on the task's dependencies,
+// which are looked up by id in deps, that are not yet satisfied. If the
+// task has a dependency expression, these are the unmet clauses of the
+// expression, followed by any other dependencies that aren't satisfied.
+// Dependencies missing from deps are ignored unless they are part of the
+// expression.
+func (t *Task) UnmetDependencies(deps map[string]Task) ([]string, error) {
+	unmet := []string{}
+	inExpr := map[string]bool{}
+	if t.DependsOnExpr != "" {
+		expr, err := ParseDependencyExpression(t.DependsOnExpr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem parsing dependencies of task '%s'", t.Id)
+		}
+		for _, dep := range expr.Dependencies() {
+			inExpr[dep.Name] = true
+		}
+		unmet = append(unmet, expr.UnmetClauses(deps)...)
+	}
+
+	for _, dep := range t.DependsOn {
+		if inExpr[dep.TaskId] {
+			continue
+		}
+		depTask, ok := deps[dep.TaskId]
+		if !ok {
+			continue
+		}
+		if !t.satisfiesDependency(&depTask) {
+			d := DependencyExpression{Status: dep.Status, Name: dep.TaskId}
+			if d.Status == "" {
+				d.Status = evergreen.TaskSucceeded
+			}
+			unmet = append(unmet, d.String())
+		}
+	}
+
+	return unmet, nil
+}
+
 func (t *Task) IsPatchRequest() bool {
 	return util.StringSliceContains(evergreen.PatchRequesters, t.Requester)
 }
@@ -282,37 +325,36 @@ func (t *Task) DependenciesMet(depCaches map[string]Task) (bool, error) {
 		return true, nil
 	}
 
-	deps := make([]Task, 0, len(t.DependsOn))
+	deps := make(map[string]Task, len(t.DependsOn))
 
 	depIdsToQueryFor := make([]string, 0, len(t.DependsOn))
 	for _, dep := range t.DependsOn {
 		if cachedDep, ok := depCaches[dep.TaskId]; !ok {
 			depIdsToQueryFor = append(depIdsToQueryFor, dep.TaskId)
 		} else {
-			deps = append(deps, cachedDep)
+			deps[cachedDep.Id] = cachedDep
 		}
 	}
 
 	if len(depIdsToQueryFor) > 0 {
-		newDeps, err := Find(ByIds(depIdsToQueryFor).WithFields(StatusKey))
+		newDeps, err := Find(ByIds(depIdsToQueryFor).WithFields(StatusKey, DetailsKey))
 		if err != nil {
 			return false, err
 		}
 
 		// add queried dependencies to the cache
 		for _, newDep := range newDeps {
-			deps = append(deps, newDep)
+			deps[newDep.Id] = newDep
 			depCaches[newDep.Id] = newDep
 		}
 	}
 
-	for _, depTask := range deps {
-		if !t.satisfiesDependency(&depTask) {
-			return false, nil
-		}
+	unmet, err := t.UnmetDependencies(deps)
+	if err != nil {
+		return false, errors.WithStack(err)
 	}
 
-	return true, nil
+	return len(unmet) == 0, nil
 }
 
 // AllDependenciesSatisfied inspects the tasks first-order
@@ -327,13 +369,13 @@ func (t *Task) AllDependenciesSatisfied(cache map[string]Task) (bool, error) {
 	}
 
 	catcher := grip.NewBasicCatcher()
-	deps := []Task{}
+	deps := map[string]Task{}
 	for _, dep := range t.DependsOn {
 		if cachedDep, ok := cache[dep.TaskId]; !ok {
 			catcher.Add(errors.Errorf("cannot resolve task %s", dep.TaskId))
 			continue
 		} else {
-			deps = append(deps, cachedDep)
+			deps[cachedDep.Id] = cachedDep
 		}
 	}
 
@@ -341,13 +383,12 @@ func (t *Task) AllDependenciesSatisfied(cache map[string]Task) (bool, error) {
 		return false, catcher.Resolve()
 	}
 
-	for _, depTask := range deps {
-		if !t.satisfiesDependency(&depTask) {
-			return false, nil
-		}
+	unmet, err := t.UnmetDependencies(deps)
+	if err != nil {
+		return false, errors.WithStack(err)
 	}
 
-	return true, nil
+	return len(unmet) == 0, nil
 }
 
 // HasFailedTests iterates through a tasks' tests and returns true if
@@ -1286,8 +1327,21 @@ func FindSchedulable(distroID string) ([]Task, error) {
 	return Find(db.Query(query))
 }
 
+// FindRunnable returns the schedulable tasks whose dependencies are met.
+// The tasks' direct dependencies are looked up in the same aggregation,
+// and the dependencies, including any dependency expression, are checked
+// against them.
 func FindRunnable(distroID string) ([]Task, error) {
-	expectedStatuses := []string{evergreen.TaskSucceeded, evergreen.TaskFailed, ""}
+	finishedStatuses := []string{
+		evergreen.TaskSucceeded,
+		evergreen.TaskFailed,
+		evergreen.TaskSetupFailed,
+		evergreen.TaskSystemFailed,
+		evergreen.TaskSystemTimedOut,
+		evergreen.TaskSystemUnresponse,
+		evergreen.TaskTestTimedOut,
+		"",
+	}
 
 	match := scheduleableTasksQuery()
 	if distroID != "" {
@@ -1299,55 +1353,6 @@ func FindRunnable(distroID string) ([]Task, error) {
 		"$match": match,
 	}
 
-	graphLookupTaskDeps := bson.M{
-		"$graphLookup": bson.M{
-			"from":             Collection,
-			"startWith":        "$" + DependsOnKey + "." + IdKey,
-			"connectFromField": DependsOnKey + "." + IdKey,
-			"connectToField":   IdKey,
-			"as":               edgesKey,
-			// restrict graphLookup to only direct dependencies
-			"maxDepth": 0,
-			"restrictSearchWithMatch": bson.M{
-				StatusKey: bson.M{
-					"$in": expectedStatuses,
-				},
-			},
-		},
-	}
-
-	reshapeTasksAndEdges := bson.M{
-		"$project": bson.M{
-			edgesKey + "." + IdKey:     1,
-			edgesKey + "." + StatusKey: 1,
-			taskKey:                    "$$ROOT",
-		},
-	}
-
-	removeEdgesFromTask := bson.M{
-		"$project": bson.M{
-			taskKey + "." + edgesKey: 0,
-		},
-	}
-
-	redactUnrunnableTasks := bson.M{
-		"$redact": bson.M{
-			"$cond": bson.M{
-				"if": bson.M{
-					"$setEquals": []string{"$" + taskKey + "." + DependsOnKey, "$" + edgesKey},
-				},
-				"then": "$$KEEP",
-				"else": "$$PRUNE",
-			},
-		},
-	}
-
-	replaceRoot := bson.M{
-		"$replaceRoot": bson.M{
-			"newRoot": "$" + taskKey,
-		},
-	}
-
 	joinProjectRef := bson.M{
 		"$lookup": bson.M{
 			"from":         "project_ref",
@@ -1380,24 +1385,83 @@ func FindRunnable(distroID string) ([]Task, error) {
 		},
 	}
 
+	graphLookupTaskDeps := bson.M{
+		"$graphLookup": bson.M{
+			"from":             Collection,
+			"startWith":        "$" + DependsOnKey + "." + IdKey,
+			"connectFromField": DependsOnKey + "." + IdKey,
+			"connectToField":   IdKey,
+			"as":               edgesKey,
+			// restrict graphLookup to only direct dependencies
+			"maxDepth": 0,
+			"restrictSearchWithMatch": bson.M{
+				StatusKey: bson.M{
+					"$in": finishedStatuses,
+				},
+			},
+		},
+	}
+
+	reshapeTasksAndEdges := bson.M{
+		"$project": bson.M{
+			edgesKey + "." + IdKey:      1,
+			edgesKey + "." + StatusKey:  1,
+			edgesKey + "." + DetailsKey: 1,
+			taskKey:                     "$$ROOT",
+		},
+	}
+
+	removeEdgesFromTask := bson.M{
+		"$project": bson.M{
+			taskKey + "." + edgesKey: 0,
+		},
+	}
+
 	pipeline := []bson.M{
 		matchActivatedUndispatchedTasks,
-		graphLookupTaskDeps,
-		reshapeTasksAndEdges,
-		removeEdgesFromTask,
-		redactUnrunnableTasks,
-		replaceRoot,
 		joinProjectRef,
 		filterDisabledProejcts,
 		filterPatchingDisabledProjects,
 		removeProjectRef,
+		graphLookupTaskDeps,
+		reshapeTasksAndEdges,
+		removeEdgesFromTask,
 	}
 
-	runnableTasks := []Task{}
-	if err := Aggregate(pipeline, &runnableTasks); err != nil {
+	candidates := []struct {
+		Task  Task   `bson:"task"`
+		Edges []Task `bson:"edges"`
+	}{}
+	if err := Aggregate(pipeline, &candidates); err != nil {
 		return nil, errors.Wrap(err, "failed to fetch runnable tasks")
 	}
 
+	runnableTasks := []Task{}
+	for _, candidate := range candidates {
+		deps := make(map[string]Task, len(candidate.Task.DependsOn))
+		// dependencies that haven't finished aren't looked up, and
+		// can't satisfy any requirement until they do
+		for _, dep := range candidate.Task.DependsOn {
+			deps[dep.TaskId] = Task{Id: dep.TaskId, Status: evergreen.TaskUndispatched}
+		}
+		for _, edge := range candidate.Edges {
+			deps[edge.Id] = edge
+		}
+
+		unmet, err := candidate.Task.UnmetDependencies(deps)
+		if err != nil {
+			grip.Warning(message.WrapError(err, message.Fields{
+				"message": "error checking dependencies for task",
+				"outcome": "skipping",
+				"task":    candidate.Task.Id,
+			}))
+			continue
+		}
+		if len(unmet) == 0 {
+			runnableTasks = append(runnableTasks, candidate.Task)
+		}
+	}
+
 	return runnableTasks, nil
 }
 
@@ -1554,3 +1618,54 @@ func (t *Task) GetJQL(searchProjects []string) string {
 
 	return fmt.Sprintf(jqlBFQuery, strings.Join(searchProjects, ", "), jqlClause)
 }
+
+// GetProjectTimeOnDistro returns a map of project identifier -> the total
+// time that tasks for the project have run on the distro since the cutoff,
+// counting both tasks that finished since then and tasks still running.
+func GetProjectTimeOnDistro(distroId string, cutoff time.Time) (map[string]time.Duration, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			DistroIdKey:   distroId,
+			StatusKey:     bson.M{"$in": evergreen.CompletedStatuses},
+			FinishTimeKey: bson.M{"$gte": cutoff},
+		}},
+		{"$group": bson.M{
+			"_id":            "$" + ProjectKey,
+			"sum_time_taken": bson.M{"$sum": "$" + TimeTakenKey},
+		}},
+	}
+
+	var results []struct {
+		Project      string        `bson:"_id"`
+		SumTimeTaken time.Duration `bson:"sum_time_taken"`
+	}
+	if err := db.Aggregate(Collection, pipeline, &results); err != nil {
+		return nil, errors.Wrapf(err, "problem aggregating time taken on distro '%s'", distroId)
+	}
+
+	projectTimes := make(map[string]time.Duration, len(results))
+	for _, res := range results {
+		projectTimes[res.Project] = res.SumTimeTaken
+	}
+
+	running, err := Find(db.Query(bson.M{
+		DistroIdKey: distroId,
+		StatusKey:   bson.M{"$in": evergreen.AbortableStatuses},
+	}).WithFields(ProjectKey, StartTimeKey))
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding running tasks on distro '%s'", distroId)
+	}
+	now := time.Now()
+	for _, t := range running {
+		if util.IsZeroTime(t.StartTime) || t.StartTime.After(now) {
+			continue
+		}
+		start := t.StartTime
+		if start.Before(cutoff) {
+			start = cutoff
+		}
+		projectTimes[t.Project] += now.Sub(start)
+	}
+
+	return projectTimes, nil
+}
@@ -24,6 +24,7 @@ type TaskConfig struct {
 	Redacted        map[string]bool
 	WorkDir         string
 	GithubPatchData patch.GithubPatch
+	GitlabPatchData patch.GitlabPatch
 	Timeout         *Timeout
 
 	mu sync.RWMutex
@@ -87,6 +88,7 @@ func NewTaskConfig(d *distro.Distro, v *version.Version, p *Project, t *task.Tas
 	}
 	if patchDoc != nil {
 		taskConfig.GithubPatchData = patchDoc.GithubPatchData
+		taskConfig.GitlabPatchData = patchDoc.GitlabPatchData
 	}
 
 	taskConfig.Timeout = &Timeout{}
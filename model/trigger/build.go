@@ -243,7 +243,8 @@ func (t *buildTriggers) makeData(sub *event.Subscription, pastTenseOverride stri
 		PastTenseStatus: t.data.Status,
 		apiModel:        &api,
 	}
-	if t.build.Requester == evergreen.GithubPRRequester && t.build.Status == t.data.Status {
+	isPRBuild := t.build.Requester == evergreen.GithubPRRequester || t.build.Requester == evergreen.GitlabMRRequester
+	if isPRBuild && t.build.Status == t.data.Status {
 		data.githubContext = fmt.Sprintf("evergreen/%s", t.build.BuildVariant)
 		data.githubState = message.GithubStateFailure
 		data.githubDescription = taskStatusToDesc(t.build)
@@ -15,6 +15,7 @@ const (
 	selectorStatus    = "status"
 	selectorInVersion = "in-version"
 	selectorInBuild   = "in-build"
+	selectorDistro    = "distro"
 
 	triggerOutcome                = "outcome"
 	triggerFailure                = "failure"
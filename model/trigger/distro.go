@@ -0,0 +1,136 @@
+package trigger
+
+import (
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	registry.registerEventHandler(event.ResourceTypeDistro, event.EventDistroAdded, makeDistroTriggers)
+	registry.registerEventHandler(event.ResourceTypeDistro, event.EventDistroModified, makeDistroTriggers)
+	registry.registerEventHandler(event.ResourceTypeDistro, event.EventDistroRemoved, makeDistroTriggers)
+}
+
+const (
+	triggerDistroAdded    = "added"
+	triggerDistroModified = "modified"
+	triggerDistroRemoved  = "removed"
+)
+
+// distroTriggers notify about changes that users make to distros.
+type distroTriggers struct {
+	event    *event.EventLogEntry
+	data     *event.DistroEventData
+	uiConfig evergreen.UIConfig
+
+	base
+}
+
+func makeDistroTriggers() eventHandler {
+	t := &distroTriggers{}
+	t.base.triggers = map[string]trigger{
+		triggerDistroAdded:    t.distroAdded,
+		triggerDistroModified: t.distroModified,
+		triggerDistroRemoved:  t.distroRemoved,
+	}
+	return t
+}
+
+func (t *distroTriggers) Fetch(e *event.EventLogEntry) error {
+	if err := t.uiConfig.Get(); err != nil {
+		return errors.Wrap(err, "Failed to fetch ui config")
+	}
+	var ok bool
+	t.data, ok = e.Data.(*event.DistroEventData)
+	if !ok {
+		return errors.Errorf("distro event for '%s' contains unexpected data with type '%T'", e.ResourceId, e.Data)
+	}
+	t.event = e
+
+	return nil
+}
+
+func (t *distroTriggers) Selectors() []event.Selector {
+	return []event.Selector{
+		{
+			Type: selectorID,
+			Data: t.event.ResourceId,
+		},
+		{
+			Type: selectorObject,
+			Data: objectDistro,
+		},
+		{
+			Type: selectorOwner,
+			Data: t.data.UserId,
+		},
+	}
+}
+
+func (t *distroTriggers) distroAdded(sub *event.Subscription) (*notification.Notification, error) {
+	if t.event.EventType != event.EventDistroAdded {
+		return nil, nil
+	}
+
+	return t.generate(sub, "been added")
+}
+
+func (t *distroTriggers) distroModified(sub *event.Subscription) (*notification.Notification, error) {
+	if t.event.EventType != event.EventDistroModified {
+		return nil, nil
+	}
+
+	return t.generate(sub, "been modified")
+}
+
+func (t *distroTriggers) distroRemoved(sub *event.Subscription) (*notification.Notification, error) {
+	if t.event.EventType != event.EventDistroRemoved {
+		return nil, nil
+	}
+
+	return t.generate(sub, "been removed")
+}
+
+func (t *distroTriggers) makeData(status string) (*commonTemplateData, error) {
+	api := restModel.APIDistroEvent{}
+	if err := api.BuildFromService(t.event); err != nil {
+		return nil, errors.Wrap(err, "error building json model")
+	}
+
+	data := commonTemplateData{
+		ID:              t.event.ResourceId,
+		DisplayName:     t.event.ResourceId,
+		Description:     fmt.Sprintf("Changed by %s.", t.data.UserId),
+		Object:          objectDistro,
+		URL:             fmt.Sprintf("%s/event_log/distro/%s", t.uiConfig.Url, t.event.ResourceId),
+		PastTenseStatus: status,
+		apiModel:        &api,
+	}
+	data.slack = append(data.slack, message.SlackAttachment{
+		Title:     "Distro Event Log",
+		TitleLink: data.URL,
+		Text:      data.Description,
+	})
+
+	return &data, nil
+}
+
+func (t *distroTriggers) generate(sub *event.Subscription, status string) (*notification.Notification, error) {
+	data, err := t.makeData(status)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to collect distro data")
+	}
+
+	payload, err := makeCommonPayload(sub, t.Selectors(), data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build notification")
+	}
+
+	return notification.New(t.event, sub.Trigger, &sub.Subscriber, payload)
+}
@@ -0,0 +1,76 @@
+package trigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestDistroTriggers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	makeTriggers := func(eventType string) *distroTriggers {
+		triggers := makeDistroTriggers().(*distroTriggers)
+		triggers.uiConfig.Url = "https://evergreen.example.com"
+		triggers.data = &event.DistroEventData{UserId: "me"}
+		triggers.event = &event.EventLogEntry{
+			ID:           bson.NewObjectId(),
+			ResourceType: event.ResourceTypeDistro,
+			ResourceId:   "distro",
+			EventType:    eventType,
+			Timestamp:    time.Now(),
+			Data:         triggers.data,
+		}
+		return triggers
+	}
+	sub := &event.Subscription{
+		ID:      bson.NewObjectId(),
+		Type:    event.ResourceTypeDistro,
+		Trigger: triggerDistroModified,
+		Subscriber: event.Subscriber{
+			Type:   event.SlackSubscriberType,
+			Target: "#distros",
+		},
+	}
+
+	triggers := makeTriggers(event.EventDistroModified)
+	n, err := triggers.distroModified(sub)
+	assert.NoError(err)
+	require.NotNil(n)
+	payload, ok := n.Payload.(*notification.SlackPayload)
+	require.True(ok)
+	assert.Equal("The distro <https://evergreen.example.com/event_log/distro/distro|distro> has been modified!", payload.Body)
+	require.Len(payload.Attachments, 1)
+	assert.Equal("Changed by me.", payload.Attachments[0].Text)
+
+	// each trigger only fires for its own event
+	n, err = triggers.distroAdded(sub)
+	assert.NoError(err)
+	assert.Nil(n)
+	n, err = triggers.distroRemoved(sub)
+	assert.NoError(err)
+	assert.Nil(n)
+
+	sub.Subscriber = event.Subscriber{
+		Type: event.EvergreenWebhookSubscriberType,
+		Target: &event.WebhookSubscriber{
+			URL:    "https://example.com",
+			Secret: []byte("secret"),
+		},
+	}
+	triggers = makeTriggers(event.EventDistroRemoved)
+	n, err = triggers.distroRemoved(sub)
+	assert.NoError(err)
+	require.NotNil(n)
+	webhook, ok := n.Payload.(*util.EvergreenWebhook)
+	require.True(ok)
+	assert.Contains(string(webhook.Body), `"event_type":"DISTRO_REMOVED"`)
+	assert.Contains(string(webhook.Body), `"user":"me"`)
+}
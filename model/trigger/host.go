@@ -3,6 +3,7 @@ package trigger
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 	"text/template"
 	"time"
 
@@ -16,15 +17,39 @@ import (
 
 func init() {
 	registry.registerEventHandler(event.ResourceTypeHost, event.EventHostExpirationWarningSent, makeHostTriggers)
+	registry.registerEventHandler(event.ResourceTypeHost, event.EventHostProvisionError, makeHostTriggers)
+	registry.registerEventHandler(event.ResourceTypeHost, event.EventHostProvisionFailed, makeHostTriggers)
+	registry.registerEventHandler(event.ResourceTypeHost, event.EventHostAgentDeployFailed, makeHostTriggers)
+	registry.registerEventHandler(event.ResourceTypeHost, event.EventHostStatusChanged, makeHostTriggers)
 }
 
 const (
-	objectHost        = "host"
-	triggerExpiration = "expiration"
+	objectHost                  = "host"
+	triggerExpiration           = "expiration"
+	triggerProvisionFailed      = "provision-failed"
+	triggerAgentDeployFailed    = "agent-deploy-failed"
+	triggerQuarantined          = "quarantined"
+	triggerDecommissioned       = "decommissioned"
+	triggerIdleTerminated       = "idle-terminated"
+	triggerTerminatedExternally = "terminated-externally"
+
+	// defaultAgentDeployFailures is how many times in a row an agent must
+	// fail to deploy to a host before subscribers are notified, unless the
+	// subscription sets its own number
+	defaultAgentDeployFailures = 3
+
+	// hostReasonLimit is the most bytes of a host event's logs that are
+	// included in notifications
+	hostReasonLimit = 1000
 
 	// notification templates
 	expiringHostTitle = `{{.Distro}} host termination reminder`
 	expiringHostBody  = `Your {{.Distro}} host with id {{.ID}} will be terminated at {{.ExpirationTime}}. Visit {{.URL}} to extend its lifetime.`
+
+	hostLifecycleTitle = `Evergreen: {{.Distro}} host {{.ID}} {{.Event}}`
+	hostLifecycleBody  = `The {{.Distro}} host {{.ID}} {{.Event}}.{{if .Reason}}
+{{.Reason}}{{end}}
+Visit {{.URL}} for details.`
 )
 
 type hostTemplateData struct {
@@ -32,12 +57,35 @@ type hostTemplateData struct {
 	Distro         string
 	ExpirationTime time.Time
 	URL            string
+	// Event and Reason describe what happened to the host, for notifications
+	// about its lifecycle.
+	Event  string
+	Reason string
+}
+
+// hostMessage is the text and formatting of a host notification.
+type hostMessage struct {
+	subject string
+	body    string
+
+	// attachmentTitle and color are used for the link to the host in chat
+	// messages.
+	attachmentTitle string
+	color           string
+	// plainText is set for messages whose body is not HTML.
+	plainText bool
 }
 
 func makeHostTriggers() eventHandler {
 	t := &hostTriggers{}
 	t.base.triggers = map[string]trigger{
-		triggerExpiration: t.hostExpiration,
+		triggerExpiration:           t.hostExpiration,
+		triggerProvisionFailed:      t.hostProvisionFailed,
+		triggerAgentDeployFailed:    t.hostAgentDeployFailed,
+		triggerQuarantined:          t.hostQuarantined,
+		triggerDecommissioned:       t.hostDecommissioned,
+		triggerIdleTerminated:       t.hostIdleTerminated,
+		triggerTerminatedExternally: t.hostTerminatedExternally,
 	}
 
 	return t
@@ -48,6 +96,7 @@ type hostTriggers struct {
 	data         *event.HostEventData
 	host         *host.Host
 	templateData hostTemplateData
+	uiURL        string
 
 	base
 }
@@ -73,6 +122,7 @@ func (t *hostTriggers) Fetch(e *event.EventLogEntry) error {
 		return errors.Wrap(err, "error retrieving settings")
 	}
 
+	t.uiURL = settings.Ui.Url
 	t.templateData = hostTemplateData{
 		ID:             t.host.Id,
 		Distro:         t.host.Distro.Id,
@@ -97,17 +147,23 @@ func (t *hostTriggers) Selectors() []event.Selector {
 			Type: selectorOwner,
 			Data: t.host.StartedBy,
 		},
+		{
+			Type: selectorDistro,
+			Data: t.host.Distro.Id,
+		},
 	}
 }
 
-func (t *hostTriggers) generate(sub *event.Subscription, subjectTempl, bodyTempl string) (*notification.Notification, error) {
+func (t *hostTriggers) generate(sub *event.Subscription, data hostTemplateData, msg hostMessage) (*notification.Notification, error) {
 	var payload interface{}
 	var err error
 	switch sub.Subscriber.Type {
 	case event.EmailSubscriberType:
-		payload, err = hostExpirationEmailPayload(t.templateData, subjectTempl, bodyTempl, sub.Selectors)
+		payload, err = hostEmailPayload(data, msg, sub.Selectors)
 	case event.SlackSubscriberType:
-		payload, err = hostExpirationSlackPayload(t.templateData, bodyTempl, sub.Selectors)
+		payload, err = hostSlackPayload(data, msg)
+	case event.MSTeamsSubscriberType, event.ChatWebhookSubscriberType:
+		payload, err = hostChatPayload(sub.Subscriber.Type, data, msg)
 	default:
 		return nil, nil
 	}
@@ -118,59 +174,187 @@ func (t *hostTriggers) generate(sub *event.Subscription, subjectTempl, bodyTempl
 	return notification.New(t.event, sub.Trigger, &sub.Subscriber, payload)
 }
 
-func hostExpirationEmailPayload(t hostTemplateData, subjectString, bodyString string, selectors []event.Selector) (*message.Email, error) {
-	subjectTemplate, err := template.New("subject").Parse(subjectString)
+func executeHostTemplate(name, text string, data hostTemplateData) (string, error) {
+	tmpl, err := template.New(name).Parse(text)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to parse subject template")
+		return "", errors.Wrapf(err, "failed to parse %s template", name)
 	}
 	buf := &bytes.Buffer{}
-	err = subjectTemplate.Execute(buf, t)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to execute subject template")
+	if err = tmpl.Execute(buf, data); err != nil {
+		return "", errors.Wrapf(err, "failed to execute %s template", name)
 	}
-	subject := buf.String()
 
-	bodyTemplate, err := template.New("subject").Parse(bodyString)
+	return buf.String(), nil
+}
+
+func hostEmailPayload(t hostTemplateData, msg hostMessage, selectors []event.Selector) (*message.Email, error) {
+	subject, err := executeHostTemplate("subject", msg.subject, t)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to parse body template")
+		return nil, errors.WithStack(err)
 	}
-	buf = &bytes.Buffer{}
-	err = bodyTemplate.Execute(buf, t)
+	body, err := executeHostTemplate("body", msg.body, t)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to execute body template")
+		return nil, errors.WithStack(err)
 	}
-	body := buf.String()
 
 	return &message.Email{
 		Subject:           subject,
 		Body:              body,
-		PlainTextContents: false,
+		PlainTextContents: msg.plainText,
 		Headers:           makeHeaders(selectors),
 	}, nil
 }
 
-func hostExpirationSlackPayload(t hostTemplateData, messageString string, selectors []event.Selector) (*notification.SlackPayload, error) {
-	messageTemplate, err := template.New("subject").Parse(messageString)
+func hostSlackPayload(t hostTemplateData, msg hostMessage) (*notification.SlackPayload, error) {
+	body, err := executeHostTemplate("slack", msg.body, t)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to parse slack template")
+		return nil, errors.WithStack(err)
 	}
-	buf := &bytes.Buffer{}
-	err = messageTemplate.Execute(buf, t)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to execute slack template")
-	}
-	msg := buf.String()
 
 	return &notification.SlackPayload{
-		Body: msg,
+		Body: body,
 		Attachments: []message.SlackAttachment{{
-			Title:     "Spawnhost Page",
+			Title:     msg.attachmentTitle,
 			TitleLink: t.URL,
-			Color:     evergreenSuccessColor,
+			Color:     msg.color,
 		}},
 	}, nil
 }
 
+func hostChatPayload(subscriberType string, t hostTemplateData, msg hostMessage) (interface{}, error) {
+	slackPayload, err := hostSlackPayload(t, msg)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if subscriberType == event.ChatWebhookSubscriberType {
+		return &notification.ChatWebhookPayload{
+			Text:        slackPayload.Body,
+			Attachments: slackPayload.Attachments,
+		}, nil
+	}
+
+	subject, err := executeHostTemplate("subject", msg.subject, t)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return msTeamsCard(subject, slackPayload.Body, slackPayload.Attachments), nil
+}
+
 func (t *hostTriggers) hostExpiration(sub *event.Subscription) (*notification.Notification, error) {
-	return t.generate(sub, expiringHostTitle, expiringHostBody)
+	if t.event.EventType != event.EventHostExpirationWarningSent {
+		return nil, nil
+	}
+
+	return t.generate(sub, t.templateData, hostMessage{
+		subject:         expiringHostTitle,
+		body:            expiringHostBody,
+		attachmentTitle: "Spawnhost Page",
+		color:           evergreenSuccessColor,
+	})
+}
+
+// hostLifecycle notifies that something happened to the host, described by
+// the past tense phrase, with its event's logs as the reason.
+func (t *hostTriggers) hostLifecycle(sub *event.Subscription, description string) (*notification.Notification, error) {
+	data := t.templateData
+	data.URL = fmt.Sprintf("%s/host/%s", t.uiURL, t.host.Id)
+	data.Event = description
+	data.Reason, _ = truncateString(t.data.Logs, hostReasonLimit)
+
+	return t.generate(sub, data, hostMessage{
+		subject:         hostLifecycleTitle,
+		body:            hostLifecycleBody,
+		attachmentTitle: "Host Page",
+		color:           evergreenFailColor,
+		plainText:       true,
+	})
+}
+
+func (t *hostTriggers) statusChangedTo(status string) bool {
+	return t.event.EventType == event.EventHostStatusChanged && t.data.NewStatus == status
+}
+
+func (t *hostTriggers) hostProvisionFailed(sub *event.Subscription) (*notification.Notification, error) {
+	if t.event.EventType != event.EventHostProvisionFailed && t.event.EventType != event.EventHostProvisionError {
+		return nil, nil
+	}
+
+	return t.hostLifecycle(sub, "failed to provision")
+}
+
+// hostAgentDeployFailed notifies when an agent has failed to deploy to the
+// host a number of times in a row. Subscribers are notified once for each
+// streak of failures.
+func (t *hostTriggers) hostAgentDeployFailed(sub *event.Subscription) (*notification.Notification, error) {
+	if t.event.EventType != event.EventHostAgentDeployFailed {
+		return nil, nil
+	}
+
+	failures := defaultAgentDeployFailures
+	if val, err := strconv.Atoi(sub.TriggerData[event.AgentDeployFailuresKey]); err == nil && val > 0 {
+		failures = val
+	}
+
+	failedRepeatedly, err := agentDeployFailedRepeatedly(t.host.Id, failures)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if !failedRepeatedly {
+		return nil, nil
+	}
+
+	return t.hostLifecycle(sub, fmt.Sprintf("failed to start an agent %d times in a row", failures))
+}
+
+// agentDeployFailedRepeatedly returns true if exactly the most recent n
+// attempts to deploy an agent to the host failed.
+func agentDeployFailedRepeatedly(hostID string, n int) (bool, error) {
+	stat, err := event.GetRecentAgentDeployStatuses(hostID, n)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get recent agent deploys")
+	}
+	if stat.Count < n || !stat.AllAttemptsFailed() {
+		return false, nil
+	}
+
+	// one more failure means that this streak was already notified about
+	stat, err = event.GetRecentAgentDeployStatuses(hostID, n+1)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get recent agent deploys")
+	}
+
+	return stat.Count <= n || !stat.AllAttemptsFailed(), nil
+}
+
+func (t *hostTriggers) hostQuarantined(sub *event.Subscription) (*notification.Notification, error) {
+	if !t.statusChangedTo(evergreen.HostQuarantined) {
+		return nil, nil
+	}
+
+	return t.hostLifecycle(sub, "was quarantined")
+}
+
+func (t *hostTriggers) hostDecommissioned(sub *event.Subscription) (*notification.Notification, error) {
+	if !t.statusChangedTo(evergreen.HostDecommissioned) {
+		return nil, nil
+	}
+
+	return t.hostLifecycle(sub, "was decommissioned")
+}
+
+func (t *hostTriggers) hostIdleTerminated(sub *event.Subscription) (*notification.Notification, error) {
+	if !t.statusChangedTo(event.EventHostIdleTerminated) {
+		return nil, nil
+	}
+
+	return t.hostLifecycle(sub, "was terminated for being idle")
+}
+
+func (t *hostTriggers) hostTerminatedExternally(sub *event.Subscription) (*notification.Notification, error) {
+	if !t.statusChangedTo(event.EventHostTerminatedExternally) {
+		return nil, nil
+	}
+
+	return t.hostLifecycle(sub, "was terminated outside of Evergreen")
 }
@@ -7,9 +7,14 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/alertrecord"
+	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/notification"
 	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/mongodb/grip/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -89,14 +94,14 @@ func (s *hostSuite) SetupTest() {
 }
 
 func (s *hostSuite) TestEmailMessage() {
-	email, err := hostExpirationEmailPayload(s.testData, expiringHostTitle, expiringHostBody, s.t.Selectors())
+	email, err := hostEmailPayload(s.testData, hostMessage{subject: expiringHostTitle, body: expiringHostBody}, s.t.Selectors())
 	s.NoError(err)
 	s.Equal("myDistro host termination reminder", email.Subject)
 	s.Contains(email.Body, "Your myDistro host with id myHost will be terminated at")
 }
 
 func (s *hostSuite) TestSlackMessage() {
-	msg, err := hostExpirationSlackPayload(s.testData, expiringHostBody, s.t.Selectors())
+	msg, err := hostSlackPayload(s.testData, hostMessage{body: expiringHostBody})
 	s.NoError(err)
 	s.Contains(msg.Body, "Your myDistro host with id myHost will be terminated at")
 }
@@ -113,3 +118,85 @@ func (s *hostSuite) TestHostExpiration() {
 	s.NoError(err)
 	s.NotNil(n)
 }
+
+func (s *hostSuite) TestHostExpirationIgnoresOtherEvents() {
+	s.t.event.EventType = event.EventHostStatusChanged
+	s.t.data = &event.HostEventData{NewStatus: evergreen.HostQuarantined}
+	n, err := s.t.hostExpiration(&s.subs[0])
+	s.NoError(err)
+	s.Nil(n)
+}
+
+func TestHostLifecycleTriggers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	triggers := makeHostTriggers().(*hostTriggers)
+	triggers.host = &host.Host{
+		Id:     "host",
+		Distro: distro.Distro{Id: "distro"},
+	}
+	triggers.uiURL = "https://evergreen.example.com"
+	triggers.templateData = hostTemplateData{
+		ID:     triggers.host.Id,
+		Distro: triggers.host.Distro.Id,
+	}
+	triggers.event = &event.EventLogEntry{
+		ID:           bson.NewObjectId(),
+		ResourceType: event.ResourceTypeHost,
+		ResourceId:   triggers.host.Id,
+		EventType:    event.EventHostStatusChanged,
+	}
+	sub := &event.Subscription{
+		ID:      bson.NewObjectId(),
+		Type:    event.ResourceTypeHost,
+		Trigger: triggerQuarantined,
+		Subscriber: event.Subscriber{
+			Type:   event.SlackSubscriberType,
+			Target: "#hosts",
+		},
+	}
+
+	triggers.data = &event.HostEventData{NewStatus: evergreen.HostDecommissioned}
+	n, err := triggers.hostQuarantined(sub)
+	assert.NoError(err)
+	assert.Nil(n)
+	n, err = triggers.hostProvisionFailed(sub)
+	assert.NoError(err)
+	assert.Nil(n)
+
+	triggers.data = &event.HostEventData{NewStatus: evergreen.HostQuarantined, Logs: "too many system failures"}
+	n, err = triggers.hostQuarantined(sub)
+	assert.NoError(err)
+	require.NotNil(n)
+	payload, ok := n.Payload.(*notification.SlackPayload)
+	require.True(ok)
+	assert.Contains(payload.Body, "The distro host host was quarantined.")
+	assert.Contains(payload.Body, "too many system failures")
+	require.Len(payload.Attachments, 1)
+	assert.Equal("https://evergreen.example.com/host/host", payload.Attachments[0].TitleLink)
+	assert.Equal(evergreenFailColor, payload.Attachments[0].Color)
+
+	triggers.data = &event.HostEventData{NewStatus: event.EventHostIdleTerminated}
+	n, err = triggers.hostTerminatedExternally(sub)
+	assert.NoError(err)
+	assert.Nil(n)
+	n, err = triggers.hostIdleTerminated(sub)
+	assert.NoError(err)
+	assert.NotNil(n)
+
+	triggers.event.EventType = event.EventHostProvisionFailed
+	triggers.data = &event.HostEventData{Logs: "setup script failed"}
+	sub.Subscriber = event.Subscriber{
+		Type:   event.EmailSubscriberType,
+		Target: "distro-owners@example.com",
+	}
+	n, err = triggers.hostProvisionFailed(sub)
+	assert.NoError(err)
+	require.NotNil(n)
+	email, ok := n.Payload.(*message.Email)
+	require.True(ok)
+	assert.Equal("Evergreen: distro host host failed to provision", email.Subject)
+	assert.Contains(email.Body, "setup script failed")
+	assert.True(email.PlainTextContents)
+}
@@ -159,6 +159,14 @@ func (t *patchTriggers) makeData(sub *event.Subscription) (*commonTemplateData,
 		})
 	}
 
+	if t.patch.IsGitlabMRPatch() {
+		data.slack = append(data.slack, message.SlackAttachment{
+			Title:     "Gitlab Merge Request",
+			TitleLink: t.patch.GitlabPatchData.WebURL,
+			Color:     slackColor,
+		})
+	}
+
 	data.slack = append(data.slack, message.SlackAttachment{
 		Title:     "Evergreen Patch",
 		TitleLink: data.URL,
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"strings"
 	ttemplate "text/template"
 
 	"github.com/evergreen-ci/evergreen"
@@ -29,6 +30,9 @@ const (
 	// or the link back to Github Pull Requests.
 	// This number MUST NOT exceed 100, and Slack recommends a limit of 10
 	slackAttachmentsLimit = 10
+
+	// jiraSummaryLimit is the longest summary that JIRA allows for an issue
+	jiraSummaryLimit = 254
 )
 
 type commonTemplateData struct {
@@ -49,14 +53,14 @@ type commonTemplateData struct {
 	githubDescription string
 }
 
-const emailSubjectTemplate string = `Evergreen: {{ .Object }} in '{{ .Project }}' has {{ .PastTenseStatus }}!`
+const emailSubjectTemplate string = `Evergreen: {{ .Object }}{{ if .Project }} in '{{ .Project }}'{{ end }} has {{ .PastTenseStatus }}!`
 const emailTemplate string = `<html>
 <head>
 </head>
 <body>
 <p>Hi,</p>
 
-<p>Your Evergreen {{ .Object }} in '{{ .Project }}' <a href="{{ .URL }}">{{ .DisplayName }}</a> has {{ .PastTenseStatus }}.</p>
+<p>Your Evergreen {{ .Object }}{{ if .Project }} in '{{ .Project }}'{{ end }} <a href="{{ .URL }}">{{ .DisplayName }}</a> has {{ .PastTenseStatus }}.</p>
 <p>{{ .Description }}</p>
 
 <span style="overflow:hidden; float:left; display:none !important; line-height:0px;">
@@ -71,11 +75,17 @@ const emailTemplate string = `<html>
 </html>
 `
 
-const jiraCommentTemplate string = `Evergreen {{ .Object }} [{{ .DisplayName }}|{{ .URL }}] in '{{ .Project }}' has {{ .PastTenseStatus }}!`
+// emailDigestEntryTemplate is the body of an email that is held to be sent
+// in a digest, which lists the bodies of the emails in it.
+const emailDigestEntryTemplate string = `The {{ .Object }}{{ if .Project }} in '{{ .Project }}'{{ end }} <a href="{{ .URL }}">{{ .DisplayName }}</a> has {{ .PastTenseStatus }}.`
+
+const jiraCommentTemplate string = `Evergreen {{ .Object }} [{{ .DisplayName }}|{{ .URL }}]{{ if .Project }} in '{{ .Project }}'{{ end }} has {{ .PastTenseStatus }}!`
 
-const jiraIssueTitle string = "Evergreen {{ .Object }} '{{ .DisplayName }}' in '{{ .Project }}' has {{ .PastTenseStatus }}"
+const jiraIssueTitle string = "Evergreen {{ .Object }} '{{ .DisplayName }}'{{ if .Project }} in '{{ .Project }}'{{ end }} has {{ .PastTenseStatus }}"
 
-const slackTemplate string = `The {{ .Object }} <{{ .URL }}|{{ .DisplayName }}> in '{{ .Project }}' has {{ .PastTenseStatus }}!`
+const slackTemplate string = `The {{ .Object }} <{{ .URL }}|{{ .DisplayName }}>{{ if .Project }} in '{{ .Project }}'{{ end }} has {{ .PastTenseStatus }}!`
+
+const msTeamsTemplate string = `The {{ .Object }} [{{ .DisplayName }}]({{ .URL }}){{ if .Project }} in '{{ .Project }}'{{ end }} has {{ .PastTenseStatus }}!`
 
 func makeHeaders(selectors []event.Selector) http.Header {
 	headers := http.Header{}
@@ -122,6 +132,24 @@ func emailPayload(t *commonTemplateData) (*message.Email, error) {
 	return &m, nil
 }
 
+// emailDigestEntry creates an email to be held and sent in a digest.
+func emailDigestEntry(t *commonTemplateData) (*message.Email, error) {
+	bodyTmpl, err := template.New("emailDigestEntry").Parse(emailDigestEntryTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse digest entry template")
+	}
+	buf := &bytes.Buffer{}
+	if err = bodyTmpl.Execute(buf, t); err != nil {
+		return nil, errors.Wrap(err, "failed to execute digest entry template")
+	}
+
+	return &message.Email{
+		Body:              buf.String(),
+		PlainTextContents: false,
+		Headers:           t.Headers,
+	}, nil
+}
+
 func webhookPayload(api restModel.Model, headers http.Header) (*util.EvergreenWebhook, error) {
 	bytes, err := json.Marshal(api)
 	if err != nil {
@@ -150,8 +178,6 @@ func jiraComment(t *commonTemplateData) (*string, error) {
 }
 
 func jiraIssue(t *commonTemplateData) (*message.JiraIssue, error) {
-	const maxSummary = 254
-
 	comment, err := jiraComment(t)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to make jira issue")
@@ -166,7 +192,7 @@ func jiraIssue(t *commonTemplateData) (*message.JiraIssue, error) {
 	if err = issueTmpl.Execute(buf, t); err != nil {
 		return nil, errors.Wrap(err, "failed to make jira issue")
 	}
-	title, remainder := truncateString(buf.String(), maxSummary)
+	title, remainder := truncateString(buf.String(), jiraSummaryLimit)
 	desc := *comment
 	if len(remainder) != 0 {
 		desc = fmt.Sprintf("...\n%s\n%s", remainder, desc)
@@ -230,6 +256,16 @@ func makeCommonPayload(sub *event.Subscription, selectors []event.Selector,
 
 	data.Headers = makeHeaders(selectors)
 
+	payload, err := defaultPayload(sub, data)
+	if err != nil {
+		return nil, err
+	}
+	applyTemplate(sub, data, payload)
+
+	return payload, nil
+}
+
+func defaultPayload(sub *event.Subscription, data *commonTemplateData) (interface{}, error) {
 	switch sub.Subscriber.Type {
 	case event.GithubPullRequestSubscriberType:
 		if len(data.githubDescription) == 0 {
@@ -246,6 +282,17 @@ func makeCommonPayload(sub *event.Subscription, selectors []event.Selector,
 		}
 		return msg, nil
 
+	case event.GitlabMergeRequestSubscriberType:
+		if len(data.githubDescription) == 0 {
+			return nil, errors.Errorf("Gitlab subscriber not supported for trigger: '%s'", sub.Trigger)
+		}
+		return &util.GitlabStatus{
+			Name:        data.githubContext,
+			State:       gitlabState(data.githubState),
+			URL:         data.URL,
+			Description: data.githubDescription,
+		}, nil
+
 	case event.JIRAIssueSubscriberType:
 		return jiraIssue(data)
 
@@ -256,15 +303,89 @@ func makeCommonPayload(sub *event.Subscription, selectors []event.Selector,
 		return webhookPayload(data.apiModel, data.Headers)
 
 	case event.EmailSubscriberType:
+		if sub.Digest != nil {
+			return emailDigestEntry(data)
+		}
 		return emailPayload(data)
 
 	case event.SlackSubscriberType:
 		return slack(data)
+
+	case event.MSTeamsSubscriberType:
+		return msTeams(data)
+
+	case event.ChatWebhookSubscriberType:
+		return chatWebhook(data)
 	}
 
 	return nil, errors.Errorf("unknown type: '%s'", sub.Subscriber.Type)
 }
 
+func msTeams(t *commonTemplateData) (*notification.MSTeamsPayload, error) {
+	textTmpl, err := ttemplate.New("ms-teams").Parse(msTeamsTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse ms teams template")
+	}
+	buf := &bytes.Buffer{}
+	if err = textTmpl.Execute(buf, t); err != nil {
+		return nil, errors.Wrap(err, "failed to make ms teams message")
+	}
+	text := buf.String()
+
+	titleTmpl, err := ttemplate.New("ms-teams-title").Parse(jiraIssueTitle)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse ms teams title template")
+	}
+	buf = &bytes.Buffer{}
+	if err = titleTmpl.Execute(buf, t); err != nil {
+		return nil, errors.Wrap(err, "failed to make ms teams title")
+	}
+	title := buf.String()
+
+	return msTeamsCard(title, text, t.slack), nil
+}
+
+// msTeamsCard creates a Microsoft Teams message card, with the links and
+// text of Slack attachments as buttons and facts.
+func msTeamsCard(title, text string, attachments []message.SlackAttachment) *notification.MSTeamsPayload {
+	card := &notification.MSTeamsPayload{
+		Summary: title,
+		Title:   title,
+		Text:    text,
+	}
+	for _, attachment := range attachments {
+		if card.ThemeColor == "" {
+			card.ThemeColor = strings.TrimPrefix(attachment.Color, "#")
+		}
+		if attachment.TitleLink != "" {
+			card.Links = append(card.Links, notification.MSTeamsLink{
+				Name: attachment.Title,
+				URL:  attachment.TitleLink,
+			})
+		}
+		if attachment.Text != "" {
+			card.Facts = append(card.Facts, notification.MSTeamsFact{
+				Name:  attachment.Title,
+				Value: attachment.Text,
+			})
+		}
+	}
+
+	return card
+}
+
+func chatWebhook(t *commonTemplateData) (*notification.ChatWebhookPayload, error) {
+	payload, err := slack(t)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make chat webhook message")
+	}
+
+	return &notification.ChatWebhookPayload{
+		Text:        payload.Body,
+		Attachments: payload.Attachments,
+	}, nil
+}
+
 func taskLink(ui *evergreen.UIConfig, taskID string, execution int) string {
 	if execution < 0 {
 		return fmt.Sprintf("%s/task/%s", ui.Url, taskID)
@@ -279,3 +400,16 @@ func buildLink(ui *evergreen.UIConfig, buildID string) string {
 func versionLink(ui *evergreen.UIConfig, versionID string) string {
 	return fmt.Sprintf("%s/version/%s/", ui.Url, versionID)
 }
+
+// gitlabState converts the state of a github status to the state of a
+// gitlab commit status.
+func gitlabState(state message.GithubState) string {
+	switch state {
+	case message.GithubStateSuccess:
+		return util.GitlabStateSuccess
+	case message.GithubStateFailure, message.GithubStateError:
+		return util.GitlabStateFailed
+	default:
+		return util.GitlabStatePending
+	}
+}
@@ -2,9 +2,13 @@ package trigger
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip/message"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -89,6 +93,45 @@ func (s *payloadSuite) TestSlack() {
 	s.Empty(m.Attachments)
 }
 
+func (s *payloadSuite) TestEmailDigestEntry() {
+	m, err := emailDigestEntry(&s.t)
+	s.NoError(err)
+	s.Require().NotNil(m)
+
+	s.Empty(m.Subject)
+	s.Equal(`The patch in 'test' <a href="`+s.url+`">display-1234</a> has failed.`, m.Body)
+}
+
+func (s *payloadSuite) TestMSTeams() {
+	s.t.slack = []message.SlackAttachment{
+		{
+			Title:     "Evergreen Patch",
+			TitleLink: s.url,
+			Text:      "a description",
+			Color:     evergreenFailColor,
+		},
+	}
+	m, err := msTeams(&s.t)
+	s.NoError(err)
+	s.Require().NotNil(m)
+
+	s.Equal("Evergreen patch 'display-1234' in 'test' has failed", m.Title)
+	s.Equal(m.Title, m.Summary)
+	s.Equal("The patch [display-1234](https://example.com/patch/1234) in 'test' has failed!", m.Text)
+	s.Equal("ce3c3e", m.ThemeColor)
+	s.Equal([]notification.MSTeamsLink{{Name: "Evergreen Patch", URL: s.url}}, m.Links)
+	s.Equal([]notification.MSTeamsFact{{Name: "Evergreen Patch", Value: "a description"}}, m.Facts)
+}
+
+func (s *payloadSuite) TestChatWebhook() {
+	m, err := chatWebhook(&s.t)
+	s.NoError(err)
+	s.Require().NotNil(m)
+
+	s.Equal("The patch <https://example.com/patch/1234|display-1234> in 'test' has failed!", m.Text)
+	s.Empty(m.Attachments)
+}
+
 func TestTruncateString(t *testing.T) {
 	assert := assert.New(t)
 
@@ -124,3 +167,71 @@ func TestTruncateString(t *testing.T) {
 	assert.Len(head, 0)
 	assert.Equal("12345", tail)
 }
+
+func (s *payloadSuite) TestTemplate() {
+	sub := &event.Subscription{
+		Trigger: "outcome",
+		Subscriber: event.Subscriber{
+			Type: event.EmailSubscriberType,
+		},
+		Template: &event.Template{
+			Subject: "{{ .Object }} {{ .DisplayName }} {{ .PastTenseStatus }}",
+			Body:    `<a href="{{ .URL }}">{{ .DisplayName }}</a> <b>{{ .Description }}</b>`,
+		},
+	}
+	s.t.Description = "<script>"
+
+	payload, err := makeCommonPayload(sub, nil, &s.t)
+	s.NoError(err)
+	email, ok := payload.(*message.Email)
+	s.Require().True(ok)
+	s.Equal("patch display-1234 failed", email.Subject)
+	s.Equal(`<a href="https://example.com/patch/1234">display-1234</a> <b>&lt;script&gt;</b>`, email.Body)
+
+	// templates for other subscribers are plain text
+	sub.Subscriber.Type = event.SlackSubscriberType
+	payload, err = makeCommonPayload(sub, nil, &s.t)
+	s.NoError(err)
+	slack, ok := payload.(*notification.SlackPayload)
+	s.Require().True(ok)
+	s.Equal(`<a href="https://example.com/patch/1234">display-1234</a> <b><script></b>`, slack.Body)
+
+	// subjects too long for JIRA are truncated
+	sub.Subscriber.Type = event.JIRAIssueSubscriberType
+	sub.Template = &event.Template{Subject: strings.Repeat("a", 300)}
+	payload, err = makeCommonPayload(sub, nil, &s.t)
+	s.NoError(err)
+	issue, ok := payload.(*message.JiraIssue)
+	s.Require().True(ok)
+	s.Len(issue.Summary, jiraSummaryLimit)
+	s.True(strings.HasPrefix(issue.Description, "...\n"+strings.Repeat("a", 49)+"\n"))
+
+	// templates that fail to render fall back to the default
+	sub.Subscriber.Type = event.MSTeamsSubscriberType
+	sub.Template = &event.Template{Body: "{{ .Nonexistent }}"}
+	payload, err = makeCommonPayload(sub, nil, &s.t)
+	s.NoError(err)
+	card, ok := payload.(*notification.MSTeamsPayload)
+	s.Require().True(ok)
+	s.Equal("The patch [display-1234](https://example.com/patch/1234) in 'test' has failed!", card.Text)
+}
+
+func TestValidateTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(ValidateTemplate(nil, event.EmailSubscriberType))
+	assert.NoError(ValidateTemplate(&event.Template{
+		Subject: "{{ .Object }} in {{ .Project }} {{ .PastTenseStatus }}",
+		Body:    "{{ if eq .PastTenseStatus \"failed\" }}{{ .URL }}{{ end }}",
+	}, event.SlackSubscriberType))
+
+	for _, tmpl := range []event.Template{
+		{Subject: "{{ .Object "},
+		{Body: "{{ .Missing }}"},
+		{Body: "{{ range .Object }}{{ end }}"},
+		{Body: strings.Repeat("a", templateSizeLimit+1)},
+		{Body: "{{ range $i, $h := .Headers }}{{ end }}" + strings.Repeat("{{ .Description }}", 5000)},
+	} {
+		assert.Error(ValidateTemplate(&tmpl, event.EmailSubscriberType), tmpl)
+	}
+}
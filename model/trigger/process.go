@@ -1,10 +1,14 @@
 package trigger
 
 import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/notification"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
 )
 
 // NotificationsFromEvent takes an event, processes all of its triggers, and returns
@@ -35,15 +39,142 @@ func NotificationsFromEvent(e *event.EventLogEntry) ([]notification.Notification
 	notifications := make([]notification.Notification, 0, len(subscriptions))
 
 	catcher := grip.NewSimpleCatcher()
+	now := time.Now()
 	for i := range subscriptions {
 		n, err := h.Process(&subscriptions[i])
 		catcher.Add(err)
 		if n == nil {
 			continue
 		}
+		n.SubscriptionID = subscriptions[i].ID.Hex()
+		if subscriptions[i].Digest != nil {
+			n.Hold(&subscriptions[i], digestGroup(subscriptions[i].Digest, h.Selectors()), now)
+		}
 
 		notifications = append(notifications, *n)
 	}
 
 	return notifications, catcher.Resolve()
 }
+
+// urgentTriggers are the triggers, by resource type, whose notifications are
+// sent even during their subscribers' quiet hours.
+var urgentTriggers = map[string][]string{
+	event.ResourceTypeHost: {triggerExpiration},
+}
+
+// IsUrgent returns true if the subscription's notifications shouldn't wait
+// for its owner's quiet hours to end.
+func IsUrgent(sub *event.Subscription) bool {
+	for _, trigger := range urgentTriggers[sub.Type] {
+		if sub.Trigger == trigger {
+			return true
+		}
+	}
+
+	return false
+}
+
+// previewEventsLimit is the most recent events that are searched for one
+// to preview a subscription with.
+const previewEventsLimit = 50
+
+// previewTriggerSubstitutes are triggers that save alert records when they
+// are processed, so previews use the outcome trigger in their place.
+var previewTriggerSubstitutes = map[string]string{
+	triggerTaskFirstFailureInBuild:           triggerOutcome,
+	triggerTaskFirstFailureInVersion:         triggerOutcome,
+	triggerTaskFirstFailureInVersionWithName: triggerOutcome,
+	triggerRegression:                        triggerOutcome,
+	triggerTaskRegressionByTest:              triggerOutcome,
+	triggerTestFlaky:                         triggerFailure,
+}
+
+// Preview creates the notification that a subscription would send for an
+// event, without saving it. If eventID is empty, the most recent event that
+// the subscription would have been notified of is used.
+func Preview(sub *event.Subscription, eventID string) (*notification.Notification, *event.EventLogEntry, error) {
+	if err := ValidateTemplate(sub.Template, sub.Subscriber.Type); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid template")
+	}
+	if substitute, ok := previewTriggerSubstitutes[sub.Trigger]; ok && sub.Type == event.ResourceTypeTask {
+		subCopy := *sub
+		subCopy.Trigger = substitute
+		sub = &subCopy
+	}
+
+	var query db.Q
+	if len(eventID) != 0 {
+		if !bson.IsObjectIdHex(eventID) {
+			return nil, nil, errors.Errorf("'%s' is not a valid event ID", eventID)
+		}
+		query = event.EventByID(bson.ObjectIdHex(eventID))
+	} else {
+		query = event.RecentEvents(sub.Type, previewEventsLimit)
+	}
+	events, err := event.Find(event.AllLogCollection, query)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error finding events")
+	}
+
+	catcher := grip.NewSimpleCatcher()
+	for i := range events {
+		e := &events[i]
+		if e.ResourceType != sub.Type {
+			catcher.Add(errors.Errorf("event '%s' is for a %s, not a %s", e.ID.Hex(), e.ResourceType, sub.Type))
+			continue
+		}
+		h := registry.eventHandler(e.ResourceType, e.EventType)
+		if h == nil {
+			continue
+		}
+		if err = h.Fetch(e); err != nil {
+			catcher.Add(errors.Wrapf(err, "error fetching data for event '%s'", e.ID.Hex()))
+			continue
+		}
+		if !sub.Matches(h.Selectors()) {
+			continue
+		}
+
+		n, err := h.Process(sub)
+		catcher.Add(err)
+		if n != nil {
+			return n, e, nil
+		}
+	}
+
+	return nil, nil, catcher.Resolve()
+}
+
+// digestGroup returns the version or build that an event is in, for digests
+// that are sent separately for each, or an empty string if the event isn't
+// in one.
+func digestGroup(digest *event.Digest, selectors []event.Selector) string {
+	var inSelector, object string
+	switch digest.GroupBy {
+	case event.DigestGroupByVersion:
+		inSelector, object = selectorInVersion, objectVersion
+	case event.DigestGroupByBuild:
+		inSelector, object = selectorInBuild, objectBuild
+	default:
+		return ""
+	}
+
+	isObject := false
+	id := ""
+	for _, s := range selectors {
+		switch s.Type {
+		case inSelector:
+			return s.Data
+		case selectorObject:
+			isObject = s.Data == object
+		case selectorID:
+			id = s.Data
+		}
+	}
+	if isObject {
+		return id
+	}
+
+	return ""
+}
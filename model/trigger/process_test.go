@@ -0,0 +1,40 @@
+package trigger
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigestGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	taskSelectors := []event.Selector{
+		{Type: selectorID, Data: "t1"},
+		{Type: selectorObject, Data: objectTask},
+		{Type: selectorInVersion, Data: "v1"},
+		{Type: selectorInBuild, Data: "b1"},
+	}
+	buildSelectors := []event.Selector{
+		{Type: selectorID, Data: "b1"},
+		{Type: selectorObject, Data: objectBuild},
+		{Type: selectorInVersion, Data: "v1"},
+	}
+	versionSelectors := []event.Selector{
+		{Type: selectorID, Data: "v1"},
+		{Type: selectorObject, Data: objectVersion},
+	}
+
+	byVersion := &event.Digest{IntervalMinutes: 30, GroupBy: event.DigestGroupByVersion}
+	assert.Equal("v1", digestGroup(byVersion, taskSelectors))
+	assert.Equal("v1", digestGroup(byVersion, buildSelectors))
+	assert.Equal("v1", digestGroup(byVersion, versionSelectors))
+
+	byBuild := &event.Digest{IntervalMinutes: 30, GroupBy: event.DigestGroupByBuild}
+	assert.Equal("b1", digestGroup(byBuild, taskSelectors))
+	assert.Equal("b1", digestGroup(byBuild, buildSelectors))
+	assert.Equal("", digestGroup(byBuild, versionSelectors))
+
+	assert.Equal("", digestGroup(&event.Digest{IntervalMinutes: 30}, taskSelectors))
+}
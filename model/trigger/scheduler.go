@@ -0,0 +1,214 @@
+package trigger
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	registry.registerEventHandler(event.ResourceTypeScheduler, event.EventSchedulerRun, makeSchedulerTriggers)
+}
+
+const (
+	objectDistro = "distro"
+
+	triggerQueueLength  = "queue-length"
+	triggerTaskWaitTime = "task-wait-time"
+	triggerPoolSizeCap  = "pool-size-cap"
+)
+
+// schedulerTriggers notify about the state of a distro's queue and hosts.
+// Each trigger fires when its condition becomes true, rather than for every
+// event while it stays true, by comparing each event to the distro's
+// previous event of the same type.
+type schedulerTriggers struct {
+	event    *event.EventLogEntry
+	data     *event.SchedulerEventData
+	uiConfig evergreen.UIConfig
+
+	previous        *event.SchedulerEventData
+	fetchedPrevious bool
+
+	base
+}
+
+func makeSchedulerTriggers() eventHandler {
+	t := &schedulerTriggers{}
+	t.base.triggers = map[string]trigger{
+		triggerQueueLength:  t.schedulerQueueLength,
+		triggerTaskWaitTime: t.schedulerTaskWaitTime,
+		triggerPoolSizeCap:  t.schedulerPoolSizeCap,
+	}
+	return t
+}
+
+func (t *schedulerTriggers) Fetch(e *event.EventLogEntry) error {
+	if err := t.uiConfig.Get(); err != nil {
+		return errors.Wrap(err, "Failed to fetch ui config")
+	}
+	var ok bool
+	t.data, ok = e.Data.(*event.SchedulerEventData)
+	if !ok {
+		return errors.Errorf("scheduler event for '%s' contains unexpected data with type '%T'", e.ResourceId, e.Data)
+	}
+	t.event = e
+
+	return nil
+}
+
+func (t *schedulerTriggers) Selectors() []event.Selector {
+	return []event.Selector{
+		{
+			Type: selectorID,
+			Data: t.data.DistroId,
+		},
+		{
+			Type: selectorObject,
+			Data: objectDistro,
+		},
+		{
+			Type: selectorDistro,
+			Data: t.data.DistroId,
+		},
+	}
+}
+
+// previousData returns the data of the distro's last event of the same type
+// before this one, or nil if there wasn't one.
+func (t *schedulerTriggers) previousData() (*event.SchedulerEventData, error) {
+	if t.fetchedPrevious {
+		return t.previous, nil
+	}
+
+	events, err := event.Find(event.AllLogCollection, event.PreviousSchedulerEvent(t.data.DistroId, t.event.EventType, t.event.Timestamp))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch previous scheduler event")
+	}
+	if len(events) != 0 {
+		data, ok := events[0].Data.(*event.SchedulerEventData)
+		if !ok {
+			return nil, errors.Errorf("scheduler event '%s' contains unexpected data with type '%T'", events[0].ID.Hex(), events[0].Data)
+		}
+		t.previous = data
+	}
+	t.fetchedPrevious = true
+
+	return t.previous, nil
+}
+
+func thresholdFromTriggerData(sub *event.Subscription, key string) (int, error) {
+	thresholdString, ok := sub.TriggerData[key]
+	if !ok {
+		return 0, errors.Errorf("subscription %s has no %s", sub.ID.Hex(), key)
+	}
+	threshold, err := strconv.Atoi(thresholdString)
+	if err != nil {
+		return 0, errors.Errorf("subscription %s has an invalid %s", sub.ID.Hex(), key)
+	}
+
+	return threshold, nil
+}
+
+func (t *schedulerTriggers) schedulerQueueLength(sub *event.Subscription) (*notification.Notification, error) {
+	threshold, err := thresholdFromTriggerData(sub, event.DistroQueueLengthKey)
+	if err != nil {
+		return nil, err
+	}
+	if t.data.TaskQueueInfo.TaskQueueLength <= threshold {
+		return nil, nil
+	}
+	previous, err := t.previousData()
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil && previous.TaskQueueInfo.TaskQueueLength > threshold {
+		return nil, nil
+	}
+
+	return t.generate(sub, fmt.Sprintf("exceeded a queue length of %d tasks", threshold),
+		fmt.Sprintf("%d tasks are waiting to run.", t.data.TaskQueueInfo.TaskQueueLength))
+}
+
+func (t *schedulerTriggers) schedulerTaskWaitTime(sub *event.Subscription) (*notification.Notification, error) {
+	minutes, err := thresholdFromTriggerData(sub, event.TaskWaitMinutesKey)
+	if err != nil {
+		return nil, err
+	}
+	threshold := time.Duration(minutes) * time.Minute
+	if t.data.TaskQueueInfo.MaxWaitTime <= threshold {
+		return nil, nil
+	}
+	previous, err := t.previousData()
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil && previous.TaskQueueInfo.MaxWaitTime > threshold {
+		return nil, nil
+	}
+
+	return t.generate(sub, fmt.Sprintf("had a task wait for over %d minutes", minutes),
+		fmt.Sprintf("A task has been waiting to run for %s.", t.data.TaskQueueInfo.MaxWaitTime.Round(time.Second)))
+}
+
+func (t *schedulerTriggers) schedulerPoolSizeCap(sub *event.Subscription) (*notification.Notification, error) {
+	if !t.data.TaskQueueInfo.AtPoolSizeCap {
+		return nil, nil
+	}
+	previous, err := t.previousData()
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil && previous.TaskQueueInfo.AtPoolSizeCap {
+		return nil, nil
+	}
+
+	return t.generate(sub, fmt.Sprintf("reached its maximum of %d hosts", t.data.TaskQueueInfo.PoolSize),
+		fmt.Sprintf("%d tasks are waiting to run, and no more hosts can be started for them.", t.data.TaskQueueInfo.TaskQueueLength))
+}
+
+func (t *schedulerTriggers) makeData(status, description string) (*commonTemplateData, error) {
+	api := restModel.APISchedulerEvent{}
+	if err := api.BuildFromService(t.event); err != nil {
+		return nil, errors.Wrap(err, "error building json model")
+	}
+
+	data := commonTemplateData{
+		ID:              t.data.DistroId,
+		DisplayName:     t.data.DistroId,
+		Description:     description,
+		Object:          objectDistro,
+		URL:             fmt.Sprintf("%s/scheduler/distro/%s", t.uiConfig.Url, t.data.DistroId),
+		PastTenseStatus: status,
+		apiModel:        &api,
+	}
+	data.slack = append(data.slack, message.SlackAttachment{
+		Title:     "Scheduler Page",
+		TitleLink: data.URL,
+		Text:      description,
+		Color:     evergreenFailColor,
+	})
+
+	return &data, nil
+}
+
+func (t *schedulerTriggers) generate(sub *event.Subscription, status, description string) (*notification.Notification, error) {
+	data, err := t.makeData(status, description)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to collect scheduler data")
+	}
+
+	payload, err := makeCommonPayload(sub, t.Selectors(), data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build notification")
+	}
+
+	return notification.New(t.event, sub.Trigger, &sub.Subscriber, payload)
+}
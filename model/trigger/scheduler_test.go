@@ -0,0 +1,123 @@
+package trigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestSchedulerTriggers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	makeTriggers := func(info, previous *event.TaskQueueInfo) *schedulerTriggers {
+		triggers := makeSchedulerTriggers().(*schedulerTriggers)
+		triggers.uiConfig.Url = "https://evergreen.example.com"
+		triggers.data = &event.SchedulerEventData{
+			DistroId:      "distro",
+			TaskQueueInfo: *info,
+		}
+		triggers.event = &event.EventLogEntry{
+			ID:           bson.NewObjectId(),
+			ResourceType: event.ResourceTypeScheduler,
+			ResourceId:   "distro",
+			EventType:    event.EventSchedulerRun,
+			Timestamp:    time.Now(),
+			Data:         triggers.data,
+		}
+		triggers.fetchedPrevious = true
+		if previous != nil {
+			triggers.previous = &event.SchedulerEventData{
+				DistroId:      "distro",
+				TaskQueueInfo: *previous,
+			}
+		}
+		return triggers
+	}
+	sub := &event.Subscription{
+		ID:      bson.NewObjectId(),
+		Type:    event.ResourceTypeScheduler,
+		Trigger: triggerQueueLength,
+		TriggerData: map[string]string{
+			event.DistroQueueLengthKey: "10",
+			event.TaskWaitMinutesKey:   "30",
+		},
+		Subscriber: event.Subscriber{
+			Type:   event.SlackSubscriberType,
+			Target: "#distros",
+		},
+	}
+
+	// the queue grows past the threshold
+	triggers := makeTriggers(&event.TaskQueueInfo{TaskQueueLength: 11}, &event.TaskQueueInfo{TaskQueueLength: 10})
+	n, err := triggers.schedulerQueueLength(sub)
+	assert.NoError(err)
+	require.NotNil(n)
+	payload, ok := n.Payload.(*notification.SlackPayload)
+	require.True(ok)
+	assert.Equal("The distro <https://evergreen.example.com/scheduler/distro/distro|distro> has exceeded a queue length of 10 tasks!", payload.Body)
+	require.Len(payload.Attachments, 1)
+	assert.Equal("11 tasks are waiting to run.", payload.Attachments[0].Text)
+
+	// and stays past it
+	triggers = makeTriggers(&event.TaskQueueInfo{TaskQueueLength: 12}, &event.TaskQueueInfo{TaskQueueLength: 11})
+	n, err = triggers.schedulerQueueLength(sub)
+	assert.NoError(err)
+	assert.Nil(n)
+
+	// the first event for a distro can notify
+	triggers = makeTriggers(&event.TaskQueueInfo{TaskQueueLength: 12, MaxWaitTime: time.Hour}, nil)
+	n, err = triggers.schedulerQueueLength(sub)
+	assert.NoError(err)
+	assert.NotNil(n)
+	n, err = triggers.schedulerTaskWaitTime(sub)
+	assert.NoError(err)
+	assert.NotNil(n)
+	n, err = triggers.schedulerPoolSizeCap(sub)
+	assert.NoError(err)
+	assert.Nil(n)
+
+	triggers = makeTriggers(&event.TaskQueueInfo{MaxWaitTime: time.Hour}, &event.TaskQueueInfo{MaxWaitTime: 45 * time.Minute})
+	n, err = triggers.schedulerTaskWaitTime(sub)
+	assert.NoError(err)
+	assert.Nil(n)
+
+	triggers = makeTriggers(&event.TaskQueueInfo{TaskQueueLength: 20, PoolSize: 5, AtPoolSizeCap: true}, &event.TaskQueueInfo{TaskQueueLength: 15, PoolSize: 5})
+	n, err = triggers.schedulerQueueLength(sub)
+	assert.NoError(err)
+	assert.Nil(n)
+	n, err = triggers.schedulerPoolSizeCap(sub)
+	assert.NoError(err)
+	require.NotNil(n)
+	payload, ok = n.Payload.(*notification.SlackPayload)
+	require.True(ok)
+	assert.Contains(payload.Body, "has reached its maximum of 5 hosts!")
+
+	delete(sub.TriggerData, event.DistroQueueLengthKey)
+	triggers = makeTriggers(&event.TaskQueueInfo{TaskQueueLength: 12}, nil)
+	n, err = triggers.schedulerQueueLength(sub)
+	assert.Error(err)
+	assert.Nil(n)
+
+	// webhooks get the scheduler event
+	sub.Subscriber = event.Subscriber{
+		Type: event.EvergreenWebhookSubscriberType,
+		Target: &event.WebhookSubscriber{
+			URL:    "https://example.com",
+			Secret: []byte("secret"),
+		},
+	}
+	triggers = makeTriggers(&event.TaskQueueInfo{TaskQueueLength: 20, PoolSize: 5, AtPoolSizeCap: true}, nil)
+	n, err = triggers.schedulerPoolSizeCap(sub)
+	assert.NoError(err)
+	require.NotNil(n)
+	webhook, ok := n.Payload.(*util.EvergreenWebhook)
+	require.True(ok)
+	assert.Contains(string(webhook.Body), `"at_pool_size_cap":true`)
+}
@@ -3,6 +3,7 @@ package trigger
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -28,6 +29,7 @@ const (
 	triggerTaskFirstFailureInVersion         = "first-failure-in-version"
 	triggerTaskFirstFailureInVersionWithName = "first-failure-in-version-with-name"
 	triggerTaskRegressionByTest              = "regression-by-test"
+	triggerTestFlaky                         = "test-flaky"
 )
 
 func makeTaskTriggers() eventHandler {
@@ -45,6 +47,7 @@ func makeTaskTriggers() eventHandler {
 		triggerRuntimeChangeByPercent:            t.taskRuntimeChange,
 		triggerRegression:                        t.taskRegression,
 		triggerTaskRegressionByTest:              t.taskRegressionByTest,
+		triggerTestFlaky:                         t.taskTestFlaky,
 	}
 
 	return t
@@ -112,6 +115,9 @@ type taskTriggers struct {
 	uiConfig evergreen.UIConfig
 
 	oldTestResults map[string]*task.TestResult
+	// flakiness is the flakiness of the task's tests, by test file, which
+	// is found when a trigger first needs it
+	flakiness map[string]task.TestFlakiness
 
 	base
 }
@@ -317,6 +323,16 @@ func (t *taskTriggers) taskRegression(sub *event.Subscription) (*notification.No
 	if !shouldNotify {
 		return nil, nil
 	}
+	if suppressFlakyTests(sub) {
+		var onlyFlaky bool
+		onlyFlaky, err = t.failedOnlyFlakyTests(flakinessThreshold(sub))
+		if err != nil {
+			return nil, err
+		}
+		if onlyFlaky {
+			return nil, nil
+		}
+	}
 	n, err := t.generate(sub, "")
 	if err != nil {
 		return nil, err
@@ -540,8 +556,21 @@ func (t *taskTriggers) taskRegressionByTest(sub *event.Subscription) (*notificat
 			t.oldTestResults = mapTestResultsByTestFile(previousCompleteTask)
 		}
 
+		suppressFlaky := suppressFlakyTests(sub)
+		threshold := flakinessThreshold(sub)
 		testsToAlert := []task.TestResult{}
 		for i := range t.task.LocalTestResults {
+			if suppressFlaky && t.task.LocalTestResults[i].Status == evergreen.TestFailedStatus {
+				var flaky bool
+				flaky, err = t.isFlakyTest(t.task.LocalTestResults[i].TestFile, threshold)
+				if err != nil {
+					catcher.Add(err)
+					continue
+				}
+				if flaky {
+					continue
+				}
+			}
 			var shouldInclude bool
 			shouldInclude, err = t.shouldIncludeTest(previousCompleteTask, &t.task.LocalTestResults[i])
 			if err != nil {
@@ -566,6 +595,139 @@ func (t *taskTriggers) taskRegressionByTest(sub *event.Subscription) (*notificat
 	return n, catcher.Resolve()
 }
 
+// suppressFlakyTests returns true if a regression subscription ignores
+// flaky tests.
+func suppressFlakyTests(sub *event.Subscription) bool {
+	suppress, err := strconv.ParseBool(sub.TriggerData[event.SuppressFlakyTestsKey])
+	return err == nil && suppress
+}
+
+// flakinessThreshold returns the flakiness score at which the subscription
+// considers tests flaky.
+func flakinessThreshold(sub *event.Subscription) float64 {
+	threshold, err := strconv.ParseFloat(sub.TriggerData[event.TestFlakinessThresholdKey], 64)
+	if err != nil || threshold < 0 || threshold > 1 {
+		return task.FlakyScoreThreshold
+	}
+
+	return threshold
+}
+
+func (t *taskTriggers) testFlakiness() (map[string]task.TestFlakiness, error) {
+	if t.flakiness != nil {
+		return t.flakiness, nil
+	}
+
+	scores, err := task.FindTestFlakiness(t.task.Project, t.task.BuildVariant, t.task.DisplayName, task.FlakinessRevisions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to score test flakiness")
+	}
+	t.flakiness = make(map[string]task.TestFlakiness, len(scores))
+	for _, score := range scores {
+		t.flakiness[score.TestFile] = score
+	}
+
+	return t.flakiness, nil
+}
+
+func (t *taskTriggers) isFlakyTest(testFile string, threshold float64) (bool, error) {
+	flakiness, err := t.testFlakiness()
+	if err != nil {
+		return false, err
+	}
+	score, ok := flakiness[testFile]
+
+	return ok && score.IsFlaky(threshold), nil
+}
+
+// failedOnlyFlakyTests returns true if the task has failed tests, and all of
+// them are flaky.
+func (t *taskTriggers) failedOnlyFlakyTests(threshold float64) (bool, error) {
+	failed := 0
+	for i := range t.task.LocalTestResults {
+		if t.task.LocalTestResults[i].Status != evergreen.TestFailedStatus {
+			continue
+		}
+		failed++
+		flaky, err := t.isFlakyTest(t.task.LocalTestResults[i].TestFile, threshold)
+		if err != nil {
+			return false, err
+		}
+		if !flaky {
+			return false, nil
+		}
+	}
+
+	return failed > 0, nil
+}
+
+// taskTestFlaky notifies when a mainline task fails tests that are flaky.
+// Each flaky test is only notified about again once the revisions that its
+// flakiness was scored from have passed.
+func (t *taskTriggers) taskTestFlaky(sub *event.Subscription) (*notification.Notification, error) {
+	if t.task.Requester != evergreen.RepotrackerVersionRequester {
+		return nil, nil
+	}
+
+	threshold := flakinessThreshold(sub)
+	catcher := grip.NewBasicCatcher()
+	flakyTests := []task.TestFlakiness{}
+	for i := range t.task.LocalTestResults {
+		test := t.task.LocalTestResults[i]
+		if test.Status != evergreen.TestFailedStatus {
+			continue
+		}
+		flaky, err := t.isFlakyTest(test.TestFile, threshold)
+		if err != nil {
+			return nil, err
+		}
+		if !flaky {
+			continue
+		}
+
+		record, err := alertrecord.FindByLastTestFlaky(test.TestFile, t.task.DisplayName, t.task.BuildVariant, t.task.Project)
+		if err != nil {
+			catcher.Add(errors.Wrap(err, "failed to fetch alert record"))
+			continue
+		}
+		if record != nil && t.task.RevisionOrderNumber-record.RevisionOrderNumber < task.FlakinessRevisions {
+			continue
+		}
+		catcher.Add(alertrecord.InsertNewTestFlakyRecord(test.TestFile, t.task.DisplayName, t.task.BuildVariant, t.task.Project, t.task.RevisionOrderNumber))
+		flakyTests = append(flakyTests, t.flakiness[test.TestFile])
+	}
+	if len(flakyTests) == 0 {
+		return nil, catcher.Resolve()
+	}
+
+	data, err := t.makeData(sub, "failed flaky tests")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to collect task data")
+	}
+	descriptions := make([]string, 0, len(flakyTests))
+	for _, test := range flakyTests {
+		description := fmt.Sprintf("%s (flakiness %.2f, %d of %d runs failed)", test.TestFile, test.Score, test.Failures, test.Runs)
+		descriptions = append(descriptions, description)
+		if len(data.slack) < slackAttachmentsLimit {
+			data.slack = append(data.slack, message.SlackAttachment{
+				Title: test.TestFile,
+				Text:  description,
+				Color: evergreenFailColor,
+			})
+		}
+	}
+	data.Description = "Flaky tests: " + strings.Join(descriptions, ", ")
+
+	payload, err := makeCommonPayload(sub, t.Selectors(), data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build notification")
+	}
+	n, err := notification.New(t.event, sub.Trigger, &sub.Subscriber, payload)
+	catcher.Add(err)
+
+	return n, catcher.Resolve()
+}
+
 // mapTestResultsByTestFile creates map of test file to TestResult struct. If
 // multiple tests of the same name exist, this function will return a
 // failing test if one existed, otherwise it may return any test with
@@ -776,6 +776,47 @@ func TestMapTestResultsByTestFile(t *testing.T) {
 	}
 }
 
+func TestFailedOnlyFlakyTests(t *testing.T) {
+	assert := assert.New(t)
+
+	sub := &event.Subscription{TriggerData: map[string]string{}}
+	assert.Equal(task.FlakyScoreThreshold, flakinessThreshold(sub))
+	assert.False(suppressFlakyTests(sub))
+	sub.TriggerData[event.TestFlakinessThresholdKey] = "0.5"
+	sub.TriggerData[event.SuppressFlakyTestsKey] = "true"
+	assert.Equal(0.5, flakinessThreshold(sub))
+	assert.True(suppressFlakyTests(sub))
+
+	triggers := taskTriggers{
+		task: &task.Task{
+			LocalTestResults: []task.TestResult{
+				{TestFile: "flaky", Status: evergreen.TestFailedStatus},
+				{TestFile: "stable", Status: evergreen.TestSucceededStatus},
+			},
+		},
+		flakiness: map[string]task.TestFlakiness{
+			"flaky":  {TestFile: "flaky", Runs: 10, Score: 0.4},
+			"stable": {TestFile: "stable", Runs: 10},
+		},
+	}
+	flaky, err := triggers.failedOnlyFlakyTests(0.2)
+	assert.NoError(err)
+	assert.True(flaky)
+	flaky, err = triggers.failedOnlyFlakyTests(0.5)
+	assert.NoError(err)
+	assert.False(flaky)
+
+	triggers.task.LocalTestResults[1].Status = evergreen.TestFailedStatus
+	flaky, err = triggers.failedOnlyFlakyTests(0.2)
+	assert.NoError(err)
+	assert.False(flaky)
+
+	triggers.task.LocalTestResults = nil
+	flaky, err = triggers.failedOnlyFlakyTests(0.2)
+	assert.NoError(err)
+	assert.False(flaky)
+}
+
 func (s *taskSuite) TestTaskExceedsTime() {
 	now := time.Now()
 	// task that exceeds time should generate
@@ -0,0 +1,187 @@
+package trigger
+
+import (
+	"bytes"
+	"fmt"
+	htemplate "html/template"
+	"net/http"
+	ttemplate "text/template"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	// templateSizeLimit is the most bytes that a user's subject or body
+	// template can have
+	templateSizeLimit = 16 * 1024
+
+	// templateOutputLimit is the most bytes that a user's template can
+	// render, so that a template with loops can't create huge notifications
+	templateOutputLimit = 64 * 1024
+)
+
+// templateSample is the data that templates are checked against when they
+// are saved.
+var templateSample = commonTemplateData{
+	ID:              "sample_id",
+	DisplayName:     "sample",
+	Object:          objectTask,
+	Project:         "sample_project",
+	Description:     "sample description",
+	URL:             "https://evergreen.example.com/task/sample_id",
+	PastTenseStatus: "failed",
+	Headers:         http.Header{},
+}
+
+// limitedBuffer is a buffer that refuses writes beyond its limit.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errors.Errorf("template output is longer than %d bytes", b.limit)
+	}
+
+	return b.Buffer.Write(p)
+}
+
+// renderTemplate executes a user's template against the data. Templates for
+// HTML are escaped as such; all others are rendered as plain text.
+func renderTemplate(name, text string, html bool, data *commonTemplateData) (string, error) {
+	if len(text) > templateSizeLimit {
+		return "", errors.Errorf("%s template is longer than %d bytes", name, templateSizeLimit)
+	}
+
+	buf := &limitedBuffer{limit: templateOutputLimit}
+	if html {
+		tmpl, err := htemplate.New(name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse %s template", name)
+		}
+		if err = tmpl.Execute(buf, data); err != nil {
+			return "", errors.Wrapf(err, "failed to execute %s template", name)
+		}
+	} else {
+		tmpl, err := ttemplate.New(name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse %s template", name)
+		}
+		if err = tmpl.Execute(buf, data); err != nil {
+			return "", errors.Wrapf(err, "failed to execute %s template", name)
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// ValidateTemplate checks that a subscription's template parses, and renders
+// against sample data.
+func ValidateTemplate(tmpl *event.Template, subscriberType string) error {
+	if tmpl == nil {
+		return nil
+	}
+
+	catcher := grip.NewBasicCatcher()
+	if len(tmpl.Subject) != 0 {
+		_, err := renderTemplate("subject", tmpl.Subject, false, &templateSample)
+		catcher.Add(err)
+	}
+	if len(tmpl.Body) != 0 {
+		_, err := renderTemplate("body", tmpl.Body, subscriberType == event.EmailSubscriberType, &templateSample)
+		catcher.Add(err)
+	}
+
+	return catcher.Resolve()
+}
+
+// applyTemplate replaces the text of a payload with the subscription's
+// template. If the template fails to render, the payload is left as it was,
+// so that a broken template doesn't stop its subscriber being notified.
+func applyTemplate(sub *event.Subscription, data *commonTemplateData, payload interface{}) {
+	if sub.Template == nil {
+		return
+	}
+
+	subject, body, err := renderSubscriptionTemplate(sub, data)
+	if err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"source":          "notifications",
+			"message":         "failed to render subscription template, using the default",
+			"subscription_id": sub.ID,
+			"subscriber_type": sub.Subscriber.Type,
+			"trigger":         sub.Trigger,
+		}))
+		return
+	}
+
+	switch p := payload.(type) {
+	case *message.Email:
+		if len(subject) != 0 {
+			p.Subject = subject
+		}
+		if len(body) != 0 {
+			p.Body = body
+		}
+
+	case *message.JiraIssue:
+		if len(body) != 0 {
+			p.Description = body
+		}
+		if len(subject) != 0 {
+			title, remainder := truncateString(subject, jiraSummaryLimit)
+			p.Summary = title
+			if len(remainder) != 0 {
+				p.Description = fmt.Sprintf("...\n%s\n%s", remainder, p.Description)
+			}
+		}
+
+	case *string:
+		if len(body) != 0 {
+			*p = body
+		}
+
+	case *notification.SlackPayload:
+		if len(body) != 0 {
+			p.Body = body
+		}
+
+	case *notification.MSTeamsPayload:
+		if len(subject) != 0 {
+			p.Title = subject
+			p.Summary = subject
+		}
+		if len(body) != 0 {
+			p.Text = body
+		}
+
+	case *notification.ChatWebhookPayload:
+		if len(body) != 0 {
+			p.Text = body
+		}
+	}
+}
+
+func renderSubscriptionTemplate(sub *event.Subscription, data *commonTemplateData) (string, string, error) {
+	var subject, body string
+	var err error
+	if len(sub.Template.Subject) != 0 {
+		subject, err = renderTemplate("subject", sub.Template.Subject, false, data)
+		if err != nil {
+			return "", "", errors.WithStack(err)
+		}
+	}
+	if len(sub.Template.Body) != 0 {
+		body, err = renderTemplate("body", sub.Template.Body, sub.Subscriber.Type == event.EmailSubscriberType, data)
+		if err != nil {
+			return "", "", errors.WithStack(err)
+		}
+	}
+
+	return subject, body, nil
+}
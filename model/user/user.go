@@ -6,6 +6,7 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/mongodb/anser/bsonutil"
+	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -42,6 +43,78 @@ type UserSettings struct {
 	GithubUser    GithubUser              `json:"github_user" bson:"github_user,omitempty"`
 	SlackUsername string                  `bson:"slack_username,omitempty" json:"slack_username,omitempty"`
 	Notifications NotificationPreferences `bson:"notifications,omitempty" json:"notifications,omitempty"`
+	QuietHours    QuietHours              `bson:"quiet_hours,omitempty" json:"quiet_hours,omitempty"`
+}
+
+// QuietHours are the hours of the day, in the user's time zone, during which
+// notifications that aren't urgent are held until the user's day starts at
+// EndHour. Quiet hours that start after they end span midnight.
+type QuietHours struct {
+	Enabled   bool `bson:"enabled" json:"enabled"`
+	StartHour int  `bson:"start_hour" json:"start_hour"`
+	EndHour   int  `bson:"end_hour" json:"end_hour"`
+}
+
+// Validate checks that enabled quiet hours start and end at different hours
+// of the day.
+func (q *QuietHours) Validate() error {
+	if !q.Enabled {
+		return nil
+	}
+	catcher := grip.NewBasicCatcher()
+	if q.StartHour < 0 || q.StartHour > 23 {
+		catcher.Add(errors.Errorf("quiet hours start hour %d is not between 0 and 23", q.StartHour))
+	}
+	if q.EndHour < 0 || q.EndHour > 23 {
+		catcher.Add(errors.Errorf("quiet hours end hour %d is not between 0 and 23", q.EndHour))
+	}
+	if q.StartHour == q.EndHour {
+		catcher.Add(errors.New("quiet hours can't start and end at the same hour"))
+	}
+
+	return catcher.Resolve()
+}
+
+// Location returns the user's time zone, or UTC if it isn't set or isn't
+// valid.
+func (s *UserSettings) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// QuietUntil returns the time that the user's quiet hours end, if the time is
+// during them, and the zero time otherwise.
+func (s *UserSettings) QuietUntil(now time.Time) time.Time {
+	q := s.QuietHours
+	if !q.Enabled || q.StartHour == q.EndHour {
+		return time.Time{}
+	}
+
+	local := now.In(s.Location())
+	hour := local.Hour()
+	var quiet bool
+	if q.StartHour < q.EndHour {
+		quiet = hour >= q.StartHour && hour < q.EndHour
+	} else {
+		quiet = hour >= q.StartHour || hour < q.EndHour
+	}
+	if !quiet {
+		return time.Time{}
+	}
+
+	end := time.Date(local.Year(), local.Month(), local.Day(), q.EndHour, 0, 0, 0, local.Location())
+	if hour >= q.EndHour {
+		end = time.Date(local.Year(), local.Month(), local.Day()+1, q.EndHour, 0, 0, 0, local.Location())
+	}
+
+	return end
 }
 
 type NotificationPreferences struct {
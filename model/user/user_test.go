@@ -6,6 +6,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -137,3 +138,50 @@ func (s *UserTestSuite) TestFindByGithubUID() {
 	s.NoError(err)
 	s.Nil(u)
 }
+
+func TestQuietUntil(t *testing.T) {
+	assert := assert.New(t)
+
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(err)
+
+	settings := UserSettings{
+		Timezone: "America/New_York",
+		QuietHours: QuietHours{
+			Enabled:   true,
+			StartHour: 22,
+			EndHour:   7,
+		},
+	}
+	assert.NoError(settings.QuietHours.Validate())
+
+	// before midnight, quiet hours end the next morning
+	until := settings.QuietUntil(time.Date(2018, 6, 1, 23, 30, 0, 0, ny))
+	assert.True(until.Equal(time.Date(2018, 6, 2, 7, 0, 0, 0, ny)))
+
+	// after midnight, they end the same morning
+	until = settings.QuietUntil(time.Date(2018, 6, 2, 3, 0, 0, 0, ny).UTC())
+	assert.True(until.Equal(time.Date(2018, 6, 2, 7, 0, 0, 0, ny)))
+
+	assert.True(settings.QuietUntil(time.Date(2018, 6, 2, 7, 0, 0, 0, ny)).IsZero())
+	assert.True(settings.QuietUntil(time.Date(2018, 6, 2, 12, 0, 0, 0, ny)).IsZero())
+
+	// quiet hours during the day
+	settings.QuietHours.StartHour = 12
+	settings.QuietHours.EndHour = 14
+	until = settings.QuietUntil(time.Date(2018, 6, 2, 13, 0, 0, 0, ny))
+	assert.True(until.Equal(time.Date(2018, 6, 2, 14, 0, 0, 0, ny)))
+	assert.True(settings.QuietUntil(time.Date(2018, 6, 2, 11, 0, 0, 0, ny)).IsZero())
+
+	// an invalid time zone is UTC
+	settings.Timezone = "nowhere"
+	until = settings.QuietUntil(time.Date(2018, 6, 2, 13, 0, 0, 0, time.UTC))
+	assert.True(until.Equal(time.Date(2018, 6, 2, 14, 0, 0, 0, time.UTC)))
+
+	settings.QuietHours.Enabled = false
+	assert.True(settings.QuietUntil(time.Date(2018, 6, 2, 13, 0, 0, 0, time.UTC)).IsZero())
+
+	assert.Error((&QuietHours{Enabled: true, StartHour: 7, EndHour: 7}).Validate())
+	assert.Error((&QuietHours{Enabled: true, StartHour: 24, EndHour: 7}).Validate())
+	assert.NoError((&QuietHours{StartHour: 7, EndHour: 7}).Validate())
+}
@@ -50,6 +50,10 @@ type Version struct {
 	// AuthorID is an optional reference to the Evergreen user that authored
 	// this comment, if they can be identified
 	AuthorID string `bson:"author_id,omitempty" json:"author_id,omitempty"`
+
+	// SkippedTasks are the tasks that weren't created because the version
+	// doesn't change any of the paths that they run for
+	SkippedTasks []SkippedTask `bson:"skipped_tasks,omitempty" json:"skipped_tasks,omitempty"`
 }
 
 func (v *Version) LastSuccessful() (*Version, error) {
@@ -84,6 +88,12 @@ type BuildStatus struct {
 	BuildId      string    `bson:"build_id,omitempty" json:"build_id,omitempty"`
 }
 
+// SkippedTask is a task that a version skipped because of its path filters.
+type SkippedTask struct {
+	BuildVariant string `bson:"build_variant" json:"build_variant"`
+	Task         string `bson:"task" json:"task"`
+}
+
 var (
 	BuildStatusVariantKey    = bsonutil.MustHaveTag(BuildStatus{}, "BuildVariant")
 	BuildStatusActivatedKey  = bsonutil.MustHaveTag(BuildStatus{}, "Activated")
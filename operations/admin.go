@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"text/tabwriter"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -27,6 +29,7 @@ func Admin() cli.Command {
 			listEvents(),
 			revert(),
 			fetchAllProjectConfigs(),
+			explainQueue(),
 		},
 	}
 }
@@ -343,3 +346,105 @@ func revert() cli.Command {
 		},
 	}
 }
+
+func explainQueue() cli.Command {
+	const (
+		distroFlagName = "distro"
+		jsonFlagName   = "json"
+	)
+
+	return cli.Command{
+		Name:  "explain-queue",
+		Usage: "show how the scheduler would plan a distro's queue, without changing it",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(distroFlagName, "d"),
+				Usage: "name of an evergreen distro",
+			},
+			cli.BoolFlag{
+				Name:  jsonFlagName,
+				Usage: "print the explanation as json",
+			},
+		},
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(distroFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			distro := c.String(distroFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			explanation, err := client.ExplainDistroQueue(ctx, distro)
+			if err != nil {
+				return errors.Wrap(err, "problem explaining distro queue")
+			}
+
+			if c.Bool(jsonFlagName) {
+				explanationPretty, err := json.MarshalIndent(explanation, " ", " ")
+				if err != nil {
+					return errors.Wrap(err, "problem marshalling explanation")
+				}
+				grip.Info(explanationPretty)
+				return nil
+			}
+
+			return printQueueExplanation(explanation)
+		},
+	}
+}
+
+func printQueueExplanation(e *model.APIDistroQueueExplanation) error {
+	fmt.Printf("distro %s: task finder '%s', task prioritizer '%s', host allocator '%s'\n",
+		model.FromAPIString(e.Distro), model.FromAPIString(e.TaskFinder),
+		model.FromAPIString(e.TaskPrioritizer), model.FromAPIString(e.HostAllocator))
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 1, '\t', 0)
+
+	fmt.Printf("\n%d tasks in queue, expected to take %s:\n", len(e.Queue), e.ExpectedDuration.ToDuration())
+	fmt.Fprintln(w, "\tposition\ttask\tproject\tsorted in\tahead of\tdecided by\t")
+	for i, item := range e.Queue {
+		decidedBy := model.FromAPIString(item.DecidedBy)
+		if decidedBy == "" && model.FromAPIString(item.AheadOf) != "" {
+			decidedBy = "(tie)"
+		}
+		fmt.Fprintf(w, "\t%d\t%s\t%s\t%s\t%s\t%s\t\n", i+1, model.FromAPIString(item.Id),
+			model.FromAPIString(item.Project), model.FromAPIString(item.SortedIn),
+			model.FromAPIString(item.AheadOf), decidedBy)
+	}
+	if err := w.Flush(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	fmt.Printf("\n%d tasks filtered out of queue:\n", len(e.FilteredTasks))
+	fmt.Fprintln(w, "\ttask\tproject\treason\t")
+	for _, t := range e.FilteredTasks {
+		fmt.Fprintf(w, "\t%s\t%s\t%s\t\n", model.FromAPIString(t.Id),
+			model.FromAPIString(t.Project), model.FromAPIString(t.Reason))
+	}
+	if err := w.Flush(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if len(e.ProjectUsage) > 0 {
+		fmt.Println("\nrecent time used by project:")
+		for project, usage := range e.ProjectUsage {
+			fmt.Fprintf(w, "\t%s\t%s\t\n", project, usage.ToDuration())
+		}
+		if err := w.Flush(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	fmt.Printf("\n%d existing hosts, %d free; %d new hosts needed\n",
+		e.NumExistingHosts, e.NumFreeHosts, e.NewHostsNeeded)
+
+	return nil
+}
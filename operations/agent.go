@@ -25,6 +25,7 @@ func Agent() cli.Command {
 		logPrefixFlagName        = "log_prefix"
 		statusPortFlagName       = "status_port"
 		cleanupFlagName          = "cleanup"
+		pluginDirectoryFlagName  = "command_plugin_directory"
 	)
 
 	return cli.Command{
@@ -61,6 +62,10 @@ func Agent() cli.Command {
 				Name:  cleanupFlagName,
 				Usage: "clean up working directory and processes (do not set for smoke tests)",
 			},
+			cli.StringFlag{
+				Name:  pluginDirectoryFlagName,
+				Usage: "directory of executables to register as command plugins",
+			},
 		},
 		Before: mergeBeforeFuncs(
 			func(c *cli.Context) error {
@@ -86,6 +91,12 @@ func Agent() cli.Command {
 				return errors.Wrapf(err, "problem creating working directory '%s'", opts.WorkingDirectory)
 			}
 
+			grip.Error(message.WrapError(command.RegisterExternalCommands(c.String(pluginDirectoryFlagName)),
+				message.Fields{
+					"message": "problem registering command plugins",
+					"dir":     c.String(pluginDirectoryFlagName),
+				}))
+
 			grip.Info(message.Fields{
 				"message":  "starting agent",
 				"commands": command.RegisteredCommandNames(),
@@ -0,0 +1,236 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func RunLocal() cli.Command {
+	const (
+		taskFlagName       = "task"
+		variantFlagName    = "variant"
+		workDirFlagName    = "working_directory"
+		outputFlagName     = "output"
+		expansionFlagName  = "expansion"
+		expansionsFlagName = "expansions_file"
+		stepFlagName       = "step"
+	)
+
+	return cli.Command{
+		Name:  "run-local",
+		Usage: "run a task from a project configuration on this machine, without an evergreen service",
+		Flags: addPathFlag(
+			cli.StringFlag{
+				Name:  joinFlagNames(taskFlagName, "t"),
+				Usage: "name of the task to run",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(variantFlagName, "bv"),
+				Usage: "name of the build variant to run the task on",
+			},
+			cli.StringFlag{
+				Name:  workDirFlagName,
+				Usage: "working directory for the task (defaults to the current directory)",
+			},
+			cli.StringFlag{
+				Name:  outputFlagName,
+				Value: "evergreen-local",
+				Usage: "directory to write logs, test results, artifacts and json data to",
+			},
+			cli.StringSliceFlag{
+				Name:  joinFlagNames(expansionFlagName, "e"),
+				Usage: "set an expansion, as 'key=value'; may specify more than once",
+			},
+			cli.StringFlag{
+				Name:  expansionsFlagName,
+				Usage: "path to a yaml file of expansions",
+			},
+			cli.BoolFlag{
+				Name:  stepFlagName,
+				Usage: "prompt before running each command",
+			}),
+		Before: mergeBeforeFuncs(
+			requirePathFlag,
+			requireStringFlag(taskFlagName),
+			requireStringFlag(variantFlagName),
+		),
+		Action: func(c *cli.Context) error {
+			workDir := c.String(workDirFlagName)
+			if workDir == "" {
+				var err error
+				workDir, err = os.Getwd()
+				if err != nil {
+					return errors.WithStack(err)
+				}
+			}
+
+			expansions, err := parseLocalExpansions(c.StringSlice(expansionFlagName), c.String(expansionsFlagName))
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			configBytes, err := ioutil.ReadFile(c.String(pathFlagName))
+			if err != nil {
+				return errors.Wrap(err, "error reading project config")
+			}
+
+			comm, err := client.NewLocal(c.String(outputFlagName))
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			defer comm.Close()
+
+			runner, err := newLocalTaskRunner(configBytes, c.String(taskFlagName), c.String(variantFlagName), workDir, comm)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			runner.conf.Expansions.Update(expansions)
+			if c.Bool(stepFlagName) {
+				runner.opts.Confirm = func(name string) bool {
+					return confirm(fmt.Sprintf("Run command %s? [Y/n]", name), true)
+				}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			err = runner.run(ctx)
+			grip.Infof("Task output written to '%s'", comm.Dir())
+			return err
+		},
+	}
+}
+
+// localTaskRunner runs the pre, task and post commands of a project
+// task with the agent's command loop, using a Communicator that
+// records task output locally.
+type localTaskRunner struct {
+	conf   *model.TaskConfig
+	comm   client.Communicator
+	logger client.LoggerProducer
+	opts   agent.LocalCommandOptions
+}
+
+func newLocalTaskRunner(configBytes []byte, taskName, variantName, workDir string, comm *client.Local) (*localTaskRunner, error) {
+	project := &model.Project{}
+	if err := model.LoadProjectInto(configBytes, "", project); err != nil {
+		return nil, errors.Wrap(err, "error loading project")
+	}
+
+	bv := project.FindBuildVariant(variantName)
+	if bv == nil {
+		return nil, errors.Errorf("build variant '%s' is not defined in the project", variantName)
+	}
+	if project.FindTaskForVariant(taskName, variantName) == nil {
+		return nil, errors.Errorf("task '%s' does not run on build variant '%s'", taskName, variantName)
+	}
+
+	workDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	t := &task.Task{
+		Id:           fmt.Sprintf("local_%s_%s", variantName, taskName),
+		DisplayName:  taskName,
+		BuildVariant: variantName,
+		Project:      project.Identifier,
+		Requester:    evergreen.RepotrackerVersionRequester,
+	}
+	v := &version.Version{
+		Branch:     project.Branch,
+		Requester:  evergreen.RepotrackerVersionRequester,
+		CreateTime: time.Now(),
+	}
+	d := &distro.Distro{Id: "local", WorkDir: workDir}
+	ref := &model.ProjectRef{Identifier: project.Identifier, Branch: project.Branch}
+
+	conf, err := model.NewTaskConfig(d, v, project, t, ref, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem creating task configuration")
+	}
+
+	return &localTaskRunner{
+		conf:   conf,
+		comm:   comm,
+		logger: comm.GetLoggerProducer(context.Background(), client.TaskData{ID: t.Id}),
+		opts:   agent.LocalCommandOptions{CacheDirectory: comm.Dir()},
+	}, nil
+}
+
+// run executes the pre commands, the task's commands and the post
+// commands, returning an error if the task's commands failed. As on
+// the agent, failures in pre and post commands are only logged.
+func (r *localTaskRunner) run(ctx context.Context) error {
+	defer func() { grip.Warning(r.logger.Close()) }()
+
+	project := r.conf.Project
+	projectTask := project.FindProjectTask(r.conf.Task.DisplayName)
+	if projectTask == nil {
+		return errors.Errorf("task '%s' is not defined in the project", r.conf.Task.DisplayName)
+	}
+	runner, err := agent.NewLocalCommandRunner(r.comm, r.logger, r.conf, r.opts)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if project.Pre != nil {
+		r.logger.Execution().Info("Running pre-task commands.")
+		grip.Warning(runner.RunCommands(ctx, project.Pre.List(), false))
+	}
+
+	r.logger.Execution().Info("Running task commands.")
+	start := time.Now()
+	err = runner.RunCommands(ctx, projectTask.Commands, true)
+	if err != nil {
+		r.logger.Task().Errorf("Task failed: %v", err)
+	} else {
+		r.logger.Task().Info("Task succeeded.")
+	}
+	r.logger.Execution().Infof("Finished running task commands in %s.", time.Since(start))
+
+	if project.Post != nil {
+		r.logger.Execution().Info("Running post-task commands.")
+		grip.Warning(runner.RunCommands(ctx, project.Post.List(), false))
+	}
+
+	return errors.Wrap(err, "task failed")
+}
+
+// parseLocalExpansions merges expansions from a yaml file with
+// expansions given as key=value pairs, which take precedence.
+func parseLocalExpansions(pairs []string, file string) (map[string]string, error) {
+	expansions := util.NewExpansions(map[string]string{})
+	if file != "" {
+		if err := expansions.UpdateFromYaml(file); err != nil {
+			return nil, errors.Wrapf(err, "problem reading expansions from '%s'", file)
+		}
+	}
+
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("expansion '%s' must be of the form 'key=value'", pair)
+		}
+		expansions.Put(parts[0], parts[1])
+	}
+
+	return expansions.Map(), nil
+}
@@ -0,0 +1,104 @@
+package operations
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const localRunnerTestProject = `
+pre:
+  - command: shell.exec
+    params:
+      script: echo pre > pre.txt
+functions:
+  write:
+    command: shell.exec
+    params:
+      script: echo ${greeting} > ${file}
+tasks:
+  - name: passes
+    commands:
+      - func: write
+        vars:
+          file: out.txt
+  - name: fails
+    commands:
+      - command: shell.exec
+        params:
+          script: exit 1
+      - func: write
+        vars:
+          file: never.txt
+buildvariants:
+  - name: bv
+    expansions:
+      greeting: hello
+    tasks:
+      - name: passes
+      - name: fails
+`
+
+func TestLocalTaskRunner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test project requires a posix shell")
+	}
+
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "run-local")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	comm, err := client.NewLocal(filepath.Join(dir, "output"))
+	require.NoError(err)
+
+	_, err = newLocalTaskRunner([]byte(localRunnerTestProject), "passes", "missing", dir, comm)
+	assert.Error(err)
+	_, err = newLocalTaskRunner([]byte(localRunnerTestProject), "missing", "bv", dir, comm)
+	assert.Error(err)
+
+	runner, err := newLocalTaskRunner([]byte(localRunnerTestProject), "passes", "bv", dir, comm)
+	require.NoError(err)
+	assert.NoError(runner.run(ctx))
+	out, err := ioutil.ReadFile(filepath.Join(dir, "out.txt"))
+	assert.NoError(err)
+	assert.Equal("hello\n", string(out))
+	_, err = os.Stat(filepath.Join(dir, "pre.txt"))
+	assert.NoError(err)
+
+	require.NoError(os.Remove(filepath.Join(dir, "out.txt")))
+	runner, err = newLocalTaskRunner([]byte(localRunnerTestProject), "passes", "bv", dir, comm)
+	require.NoError(err)
+	runner.opts.Confirm = func(string) bool { return false }
+	assert.NoError(runner.run(ctx))
+	_, err = os.Stat(filepath.Join(dir, "out.txt"))
+	assert.True(os.IsNotExist(err))
+
+	runner, err = newLocalTaskRunner([]byte(localRunnerTestProject), "fails", "bv", dir, comm)
+	require.NoError(err)
+	assert.Error(runner.run(ctx))
+	_, err = os.Stat(filepath.Join(dir, "never.txt"))
+	assert.True(os.IsNotExist(err))
+}
+
+func TestParseLocalExpansions(t *testing.T) {
+	assert := assert.New(t)
+
+	expansions, err := parseLocalExpansions([]string{"a=b", "c=d=e"}, "")
+	assert.NoError(err)
+	assert.Equal(map[string]string{"a": "b", "c": "d=e"}, expansions)
+
+	_, err = parseLocalExpansions([]string{"novalue"}, "")
+	assert.Error(err)
+}
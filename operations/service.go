@@ -84,6 +84,7 @@ func startSystemCronJobs(ctx context.Context, env evergreen.Environment) {
 		units.PopulateBackgroundStatsJobs(env, 0),
 		units.PopulateLastContainerFinishTimeJobs(),
 		units.PopulateParentDecommissionJobs(),
+		units.PopulateCommitQueueJobs(env),
 		units.PopulatePeriodicNotificationJobs(1)))
 
 	amboy.IntervalQueueOperation(ctx, env.RemoteQueue(), 15*time.Second, time.Now(), opts, amboy.GroupQueueOperationFactory(
@@ -99,7 +100,8 @@ func startSystemCronJobs(ctx context.Context, env evergreen.Environment) {
 
 	amboy.IntervalQueueOperation(ctx, env.RemoteQueue(), 15*time.Minute, time.Now(), opts, amboy.GroupQueueOperationFactory(
 		units.PopulateCatchupJobs(30),
-		units.PopulateHostAlertJobs(20)))
+		units.PopulateHostAlertJobs(20),
+		units.PopulateEventArchiveJobs()))
 
 	////////////////////////////////////////////////////////////////////////
 	//
@@ -2,10 +2,37 @@ package operations
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
 
+	"github.com/evergreen-ci/evergreen/model/event"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	subscriptionOwnerFlagName          = "owner"
+	subscriptionOwnerTypeFlagName      = "owner-type"
+	subscriptionResourceTypeFlagName   = "resource-type"
+	subscriptionTriggerFlagName        = "trigger"
+	subscriptionSelectorFlagName       = "selector"
+	subscriptionRegexSelectorFlagName  = "regex-selector"
+	subscriptionTriggerDataFlagName    = "trigger-data"
+	subscriptionSubscriberTypeFlagName = "subscriber-type"
+	subscriptionTargetFlagName         = "target"
+	subscriptionDryRunFlagName         = "dry-run"
+	subscriptionIncludeSecretsFlagName = "include-secrets"
+
+	// redactedSecret replaces the secrets of webhook subscriptions in
+	// exported files, unless they're exported with --include-secrets.
+	redactedSecret = "{REDACTED}"
 )
 
 func Subscriptions() cli.Command {
@@ -15,14 +42,50 @@ func Subscriptions() cli.Command {
 		Before: setPlainLogger,
 		Subcommands: []cli.Command{
 			subscriptionsList(),
+			subscriptionsAdd(),
+			subscriptionsRemove(),
+			subscriptionsExport(),
+			subscriptionsImport(),
 		},
 	}
 }
 
+func addSubscriptionOwnerFlags(flags ...cli.Flag) []cli.Flag {
+	return append(flags,
+		cli.StringFlag{
+			Name:  subscriptionOwnerFlagName,
+			Usage: "the user or project that owns the subscriptions (defaults to the current user)",
+		},
+		cli.StringFlag{
+			Name:  subscriptionOwnerTypeFlagName,
+			Usage: "the type of owner, 'person' or 'project'",
+			Value: string(event.OwnerTypePerson),
+		})
+}
+
+// subscriptionOwner returns the owner of the subscriptions that a command
+// manages, from its flags.
+func subscriptionOwner(c *cli.Context, conf *ClientSettings) (string, event.OwnerType, error) {
+	ownerType := c.String(subscriptionOwnerTypeFlagName)
+	if !event.IsValidOwnerType(ownerType) {
+		return "", "", errors.Errorf("'%s' is not a valid owner type", ownerType)
+	}
+	owner := c.String(subscriptionOwnerFlagName)
+	if owner == "" {
+		if ownerType != string(event.OwnerTypePerson) {
+			return "", "", errors.Errorf("must specify the owner of %s subscriptions", ownerType)
+		}
+		owner = conf.User
+	}
+
+	return owner, event.OwnerType(ownerType), nil
+}
+
 func subscriptionsList() cli.Command {
 	return cli.Command{
 		Name:  "list",
-		Usage: "list subscriptions belonging to a user",
+		Usage: "list subscriptions belonging to a user or project",
+		Flags: addSubscriptionOwnerFlags(),
 		Action: func(c *cli.Context) error {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -32,9 +95,15 @@ func subscriptionsList() cli.Command {
 			if err != nil {
 				return errors.Wrap(err, "problem loading configuration")
 			}
+			owner, ownerType, err := subscriptionOwner(c, conf)
+			if err != nil {
+				return errors.WithStack(err)
+			}
 
 			com := conf.GetRestCommunicator(ctx)
-			subs, err := com.GetSubscriptions(ctx)
+			defer com.Close()
+
+			subs, err := com.GetOwnerSubscriptions(ctx, owner, ownerType)
 			if err != nil {
 				return errors.Wrap(err, "error fetching subscriptions")
 			}
@@ -51,3 +120,531 @@ func subscriptionsList() cli.Command {
 		},
 	}
 }
+
+func subscriptionsAdd() cli.Command {
+	return cli.Command{
+		Name:  "add",
+		Usage: "add a subscription",
+		Flags: addSubscriptionOwnerFlags(
+			cli.StringFlag{
+				Name:  subscriptionResourceTypeFlagName,
+				Usage: "the type of resource to subscribe to, e.g. 'TASK' or 'VERSION'",
+			},
+			cli.StringFlag{
+				Name:  subscriptionTriggerFlagName,
+				Usage: "the trigger to subscribe to, e.g. 'outcome'",
+			},
+			cli.StringSliceFlag{
+				Name:  subscriptionSelectorFlagName,
+				Usage: "a selector of the form 'type:data', which may be repeated",
+			},
+			cli.StringSliceFlag{
+				Name:  subscriptionRegexSelectorFlagName,
+				Usage: "a regex selector of the form 'type:regex', which may be repeated",
+			},
+			cli.StringSliceFlag{
+				Name:  subscriptionTriggerDataFlagName,
+				Usage: "trigger data of the form 'key=value', which may be repeated",
+			},
+			cli.StringFlag{
+				Name:  subscriptionSubscriberTypeFlagName,
+				Usage: "the type of subscriber to notify, e.g. 'email' or 'slack'",
+			},
+			cli.StringFlag{
+				Name:  subscriptionTargetFlagName,
+				Usage: "the address, channel or issue to notify",
+			},
+		),
+		Before: mergeBeforeFuncs(
+			requireStringFlag(subscriptionResourceTypeFlagName),
+			requireStringFlag(subscriptionTriggerFlagName),
+			requireStringFlag(subscriptionSubscriberTypeFlagName),
+			requireStringFlag(subscriptionTargetFlagName),
+		),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			owner, ownerType, err := subscriptionOwner(c, conf)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			sub := event.Subscription{
+				Type:      c.String(subscriptionResourceTypeFlagName),
+				Trigger:   c.String(subscriptionTriggerFlagName),
+				Owner:     owner,
+				OwnerType: ownerType,
+				Subscriber: event.Subscriber{
+					Type:   c.String(subscriptionSubscriberTypeFlagName),
+					Target: c.String(subscriptionTargetFlagName),
+				},
+			}
+			if sub.Selectors, err = parseSelectors(c.StringSlice(subscriptionSelectorFlagName)); err != nil {
+				return errors.WithStack(err)
+			}
+			if sub.RegexSelectors, err = parseSelectors(c.StringSlice(subscriptionRegexSelectorFlagName)); err != nil {
+				return errors.WithStack(err)
+			}
+			if sub.TriggerData, err = parseTriggerData(c.StringSlice(subscriptionTriggerDataFlagName)); err != nil {
+				return errors.WithStack(err)
+			}
+			if err = sub.Validate(); err != nil {
+				return errors.Wrap(err, "invalid subscription")
+			}
+
+			com := conf.GetRestCommunicator(ctx)
+			defer com.Close()
+
+			if err = com.SaveSubscriptions(ctx, []event.Subscription{sub}); err != nil {
+				return errors.Wrap(err, "error adding subscription")
+			}
+			grip.Infof("added subscription: %s", sub.String())
+
+			return nil
+		},
+	}
+}
+
+func subscriptionsRemove() cli.Command {
+	return cli.Command{
+		Name:      "remove",
+		Usage:     "remove subscriptions by ID",
+		ArgsUsage: "<id>...",
+		Before: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return errors.New("must specify the ID of at least one subscription to remove")
+			}
+			return nil
+		},
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			com := conf.GetRestCommunicator(ctx)
+			defer com.Close()
+
+			for _, id := range c.Args() {
+				if err = com.DeleteSubscription(ctx, id); err != nil {
+					return errors.Wrap(err, "error removing subscription")
+				}
+				grip.Infof("removed subscription '%s'", id)
+			}
+
+			return nil
+		},
+	}
+}
+
+func subscriptionsExport() cli.Command {
+	return cli.Command{
+		Name:  "export",
+		Usage: "write the subscriptions of a user or project to a YAML file, or to stdout",
+		Flags: addSubscriptionOwnerFlags(append(addOutputPath(),
+			cli.BoolFlag{
+				Name:  subscriptionIncludeSecretsFlagName,
+				Usage: "write the secrets of webhook subscriptions, instead of redacting them",
+			})...),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			owner, ownerType, err := subscriptionOwner(c, conf)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			com := conf.GetRestCommunicator(ctx)
+			defer com.Close()
+
+			subs, err := com.GetOwnerSubscriptions(ctx, owner, ownerType)
+			if err != nil {
+				return errors.Wrap(err, "error fetching subscriptions")
+			}
+
+			out, err := subscriptionsToYAML(subs, c.Bool(subscriptionIncludeSecretsFlagName))
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			path := c.String(pathFlagName)
+			if path == "" {
+				_, err = os.Stdout.Write(out)
+				return errors.Wrap(err, "error writing subscriptions")
+			}
+
+			return errors.Wrapf(ioutil.WriteFile(path, out, 0644), "error writing subscriptions to '%s'", path)
+		},
+	}
+}
+
+func subscriptionsImport() cli.Command {
+	return cli.Command{
+		Name: "import",
+		Usage: "make the subscriptions of a user or project match a YAML file, " +
+			"creating those that are only in the file and removing those that are not in it. " +
+			"Webhook subscriptions with redacted or empty secrets keep the secrets of the existing subscriptions to the same URLs",
+		Flags: addSubscriptionOwnerFlags(
+			cli.StringFlag{
+				Name:  joinFlagNames(pathFlagName, "filename", "file", "f"),
+				Usage: "path to a subscriptions file, as written by export",
+			},
+			cli.BoolFlag{
+				Name:  subscriptionDryRunFlagName,
+				Usage: "show the subscriptions that would be created and removed, without changing them",
+			}),
+		Before: mergeBeforeFuncs(requirePathFlag, requireFileExists(pathFlagName)),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			owner, ownerType, err := subscriptionOwner(c, conf)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			path := c.String(pathFlagName)
+			in, err := ioutil.ReadFile(path)
+			if err != nil {
+				return errors.Wrapf(err, "error reading '%s'", path)
+			}
+			desired, err := subscriptionsFromYAML(in)
+			if err != nil {
+				return errors.Wrapf(err, "error parsing '%s'", path)
+			}
+			catcher := grip.NewBasicCatcher()
+			for i := range desired {
+				catcher.Add(setSubscriptionOwner(&desired[i], owner, ownerType))
+				catcher.Add(errors.Wrapf(desired[i].Validate(), "subscription %d is invalid", i+1))
+			}
+			if catcher.HasErrors() {
+				return catcher.Resolve()
+			}
+
+			com := conf.GetRestCommunicator(ctx)
+			defer com.Close()
+
+			existing, err := com.GetOwnerSubscriptions(ctx, owner, ownerType)
+			if err != nil {
+				return errors.Wrap(err, "error fetching subscriptions")
+			}
+
+			if err = restoreWebhookSecrets(existing, desired); err != nil {
+				return errors.WithStack(err)
+			}
+
+			create, remove, err := diffSubscriptions(existing, desired)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if len(create) == 0 && len(remove) == 0 {
+				grip.Infof("the subscriptions of %s '%s' are up to date", ownerType, owner)
+				return nil
+			}
+			for i := range create {
+				grip.Infof("+ %s", create[i].String())
+			}
+			for i := range remove {
+				grip.Infof("- %s", remove[i].String())
+			}
+			if c.Bool(subscriptionDryRunFlagName) {
+				grip.Infof("dry run: would create %d and remove %d subscriptions", len(create), len(remove))
+				return nil
+			}
+
+			if len(create) != 0 {
+				if err = com.SaveSubscriptions(ctx, create); err != nil {
+					return errors.Wrap(err, "error creating subscriptions")
+				}
+			}
+			for i := range remove {
+				if err = com.DeleteSubscription(ctx, remove[i].ID.Hex()); err != nil {
+					return errors.Wrap(err, "error removing subscription")
+				}
+			}
+			grip.Infof("created %d and removed %d subscriptions", len(create), len(remove))
+
+			return nil
+		},
+	}
+}
+
+// parseSelectors parses selectors of the form 'type:data'.
+func parseSelectors(flags []string) ([]event.Selector, error) {
+	selectors := make([]event.Selector, 0, len(flags))
+	for _, flag := range flags {
+		parts := strings.SplitN(flag, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("selector '%s' must be of the form 'type:data'", flag)
+		}
+		selectors = append(selectors, event.Selector{Type: parts[0], Data: parts[1]})
+	}
+
+	return selectors, nil
+}
+
+// parseTriggerData parses trigger data of the form 'key=value'.
+func parseTriggerData(flags []string) (map[string]string, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	data := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		parts := strings.SplitN(flag, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("trigger data '%s' must be of the form 'key=value'", flag)
+		}
+		data[parts[0]] = parts[1]
+	}
+
+	return data, nil
+}
+
+// setSubscriptionOwner sets the owner of an imported subscription, which
+// must not belong to anyone else.
+func setSubscriptionOwner(sub *event.Subscription, owner string, ownerType event.OwnerType) error {
+	if (sub.Owner != "" && sub.Owner != owner) || (sub.OwnerType != "" && sub.OwnerType != ownerType) {
+		return errors.Errorf("subscription '%s' belongs to %s '%s', not %s '%s'",
+			sub.String(), sub.OwnerType, sub.Owner, ownerType, owner)
+	}
+	sub.Owner = owner
+	sub.OwnerType = ownerType
+
+	return nil
+}
+
+// subscriptionsToYAML writes subscriptions in the form of the REST API,
+// without their IDs, so that the file only changes when they do. The
+// secrets of webhook subscriptions are redacted unless includeSecrets is
+// set.
+func subscriptionsToYAML(subs []event.Subscription, includeSecrets bool) ([]byte, error) {
+	docs := make([]interface{}, 0, len(subs))
+	for i := range subs {
+		apiSub, err := exportedSubscription(subs[i])
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if !includeSecrets {
+			redactWebhookSecret(apiSub)
+		}
+		var doc interface{}
+		if err = remarshal(json.Marshal, json.Unmarshal, apiSub, &doc); err != nil {
+			return nil, errors.Wrap(err, "failed to convert subscription")
+		}
+		docs = append(docs, doc)
+	}
+
+	out, err := yaml.Marshal(docs)
+	return out, errors.Wrap(err, "failed to write yaml")
+}
+
+// subscriptionsFromYAML reads subscriptions written by subscriptionsToYAML.
+func subscriptionsFromYAML(in []byte) ([]event.Subscription, error) {
+	docs := []interface{}{}
+	if err := yaml.Unmarshal(in, &docs); err != nil {
+		return nil, errors.Wrap(err, "failed to read yaml")
+	}
+
+	subs := make([]event.Subscription, 0, len(docs))
+	for i, doc := range docs {
+		apiSub := restModel.APISubscription{}
+		if err := remarshal(json.Marshal, json.Unmarshal, jsonCompatible(doc), &apiSub); err != nil {
+			return nil, errors.Wrapf(err, "subscription %d is malformed", i+1)
+		}
+		subInterface, err := apiSub.ToService()
+		if err != nil {
+			return nil, errors.Wrapf(err, "subscription %d is malformed", i+1)
+		}
+		sub, ok := subInterface.(event.Subscription)
+		if !ok {
+			return nil, errors.Errorf("subscription %d is malformed", i+1)
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, nil
+}
+
+func exportedSubscription(sub event.Subscription) (*restModel.APISubscription, error) {
+	apiSub := &restModel.APISubscription{}
+	if err := apiSub.BuildFromService(sub); err != nil {
+		return nil, errors.Wrap(err, "failed to convert subscription")
+	}
+	apiSub.ID = nil
+	if len(apiSub.TriggerData) == 0 {
+		apiSub.TriggerData = nil
+	}
+	for _, selectors := range [][]restModel.APISelector{apiSub.Selectors, apiSub.RegexSelectors} {
+		sort.SliceStable(selectors, func(i, j int) bool {
+			typeI, typeJ := restModel.FromAPIString(selectors[i].Type), restModel.FromAPIString(selectors[j].Type)
+			if typeI != typeJ {
+				return typeI < typeJ
+			}
+			return restModel.FromAPIString(selectors[i].Data) < restModel.FromAPIString(selectors[j].Data)
+		})
+	}
+
+	return apiSub, nil
+}
+
+func redactWebhookSecret(apiSub *restModel.APISubscription) {
+	webhook, ok := apiSub.Subscriber.Target.(restModel.APIWebhookSubscriber)
+	if !ok || restModel.FromAPIString(webhook.Secret) == "" {
+		return
+	}
+	webhook.Secret = restModel.ToAPIString(redactedSecret)
+	apiSub.Subscriber.Target = webhook
+}
+
+// restoreWebhookSecrets sets the secrets of desired webhook subscriptions
+// that are redacted or empty to the secrets of the existing subscriptions
+// to the same URLs.
+func restoreWebhookSecrets(existing, desired []event.Subscription) error {
+	secrets := map[string][]byte{}
+	for i := range existing {
+		if webhook, ok := webhookSubscriber(existing[i]); ok && len(webhook.Secret) != 0 {
+			secrets[webhook.URL] = webhook.Secret
+		}
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for i := range desired {
+		webhook, ok := webhookSubscriber(desired[i])
+		if !ok || (len(webhook.Secret) != 0 && string(webhook.Secret) != redactedSecret) {
+			continue
+		}
+		secret, ok := secrets[webhook.URL]
+		if !ok {
+			catcher.Add(errors.Errorf("webhook subscription %d has no secret, and no existing subscription to '%s' has one", i+1, webhook.URL))
+			continue
+		}
+		webhook.Secret = secret
+		desired[i].Subscriber.Target = webhook
+	}
+
+	return catcher.Resolve()
+}
+
+func webhookSubscriber(sub event.Subscription) (event.WebhookSubscriber, bool) {
+	if sub.Subscriber.Type != event.EvergreenWebhookSubscriberType {
+		return event.WebhookSubscriber{}, false
+	}
+	switch target := sub.Subscriber.Target.(type) {
+	case event.WebhookSubscriber:
+		return target, true
+	case *event.WebhookSubscriber:
+		if target != nil {
+			return *target, true
+		}
+	}
+
+	return event.WebhookSubscriber{}, false
+}
+
+// diffSubscriptions returns the desired subscriptions that don't exist yet,
+// and the existing subscriptions that aren't desired. Subscriptions are
+// compared by everything but their IDs, so a changed subscription is removed
+// and created again.
+func diffSubscriptions(existing, desired []event.Subscription) ([]event.Subscription, []event.Subscription, error) {
+	existingByKey := map[string][]event.Subscription{}
+	for i := range existing {
+		key, err := subscriptionKey(existing[i])
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		existingByKey[key] = append(existingByKey[key], existing[i])
+	}
+
+	create := []event.Subscription{}
+	for i := range desired {
+		key, err := subscriptionKey(desired[i])
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		if matches := existingByKey[key]; len(matches) != 0 {
+			existingByKey[key] = matches[1:]
+			continue
+		}
+		sub := desired[i]
+		sub.ID = ""
+		create = append(create, sub)
+	}
+
+	remove := []event.Subscription{}
+	for i := range existing {
+		key, err := subscriptionKey(existing[i])
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		if matches := existingByKey[key]; len(matches) != 0 && matches[0].ID == existing[i].ID {
+			existingByKey[key] = matches[1:]
+			remove = append(remove, existing[i])
+		}
+	}
+
+	return create, remove, nil
+}
+
+func subscriptionKey(sub event.Subscription) (string, error) {
+	apiSub, err := exportedSubscription(sub)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	key, err := json.Marshal(apiSub)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to compare subscription")
+	}
+
+	return string(key), nil
+}
+
+func remarshal(marshal func(interface{}) ([]byte, error), unmarshal func([]byte, interface{}) error, in, out interface{}) error {
+	bytes, err := marshal(in)
+	if err != nil {
+		return err
+	}
+
+	return unmarshal(bytes, out)
+}
+
+// jsonCompatible converts the maps that YAML is read into, which may have
+// keys of any type, into maps that can be written as JSON.
+func jsonCompatible(in interface{}) interface{} {
+	switch v := in.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			out[fmt.Sprint(key)] = jsonCompatible(value)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i := range v {
+			out[i] = jsonCompatible(v[i])
+		}
+		return out
+	default:
+		return v
+	}
+}
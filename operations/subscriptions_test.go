@@ -0,0 +1,213 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func testSubscriptions() []event.Subscription {
+	return []event.Subscription{
+		{
+			ID:      bson.NewObjectId(),
+			Type:    event.ResourceTypeTask,
+			Trigger: "outcome",
+			Selectors: []event.Selector{
+				{Type: "project", Data: "mci"},
+				{Type: "object", Data: "task"},
+			},
+			Subscriber: event.Subscriber{
+				Type:   event.EmailSubscriberType,
+				Target: "me@example.com",
+			},
+			Owner:     "me",
+			OwnerType: event.OwnerTypePerson,
+		},
+		{
+			ID:      bson.NewObjectId(),
+			Type:    event.ResourceTypeVersion,
+			Trigger: "exceeds-duration",
+			Selectors: []event.Selector{
+				{Type: "project", Data: "mci"},
+			},
+			RegexSelectors: []event.Selector{
+				{Type: "display-name", Data: "^release"},
+			},
+			Subscriber: event.Subscriber{
+				Type:   event.SlackSubscriberType,
+				Target: "#evergreen",
+			},
+			TriggerData: map[string]string{event.VersionDurationKey: "3600"},
+			Owner:       "me",
+			OwnerType:   event.OwnerTypePerson,
+		},
+	}
+}
+
+func TestSubscriptionsYAMLRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	subs := testSubscriptions()
+	out, err := subscriptionsToYAML(subs, false)
+	require.NoError(err)
+	assert.NotContains(string(out), subs[0].ID.Hex())
+
+	imported, err := subscriptionsFromYAML(out)
+	require.NoError(err)
+	require.Len(imported, len(subs))
+	for i := range subs {
+		assert.Empty(imported[i].ID)
+		assert.Equal(subs[i].Type, imported[i].Type)
+		assert.Equal(subs[i].Trigger, imported[i].Trigger)
+		assert.Len(imported[i].Selectors, len(subs[i].Selectors))
+		assert.Subset(imported[i].Selectors, subs[i].Selectors)
+		assert.Len(imported[i].RegexSelectors, len(subs[i].RegexSelectors))
+		assert.Subset(imported[i].RegexSelectors, subs[i].RegexSelectors)
+		assert.Equal(subs[i].Subscriber, imported[i].Subscriber)
+		assert.Equal(subs[i].Owner, imported[i].Owner)
+		assert.Equal(subs[i].OwnerType, imported[i].OwnerType)
+	}
+	assert.Equal(subs[1].TriggerData, imported[1].TriggerData)
+
+	again, err := subscriptionsToYAML(imported, false)
+	require.NoError(err)
+	assert.Equal(string(out), string(again))
+
+	_, err = subscriptionsFromYAML([]byte("- resource_type: [1, 2]"))
+	assert.Error(err)
+}
+
+func TestWebhookSecrets(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	existing := []event.Subscription{
+		{
+			ID:      bson.NewObjectId(),
+			Type:    event.ResourceTypeTask,
+			Trigger: "outcome",
+			Subscriber: event.Subscriber{
+				Type: event.EvergreenWebhookSubscriberType,
+				Target: event.WebhookSubscriber{
+					URL:    "https://example.com/hook",
+					Secret: []byte("hunter2"),
+				},
+			},
+			Owner:     "me",
+			OwnerType: event.OwnerTypePerson,
+		},
+	}
+
+	// secrets are redacted unless they're asked for
+	out, err := subscriptionsToYAML(existing, false)
+	require.NoError(err)
+	assert.NotContains(string(out), "hunter2")
+	assert.Contains(string(out), redactedSecret)
+	withSecrets, err := subscriptionsToYAML(existing, true)
+	require.NoError(err)
+	assert.Contains(string(withSecrets), "hunter2")
+
+	// redacted secrets are restored from the existing subscriptions, so
+	// importing an export changes nothing
+	desired, err := subscriptionsFromYAML(out)
+	require.NoError(err)
+	require.NoError(restoreWebhookSecrets(existing, desired))
+	create, remove, err := diffSubscriptions(existing, desired)
+	require.NoError(err)
+	assert.Empty(create)
+	assert.Empty(remove)
+
+	// as are empty secrets
+	desired[0].Subscriber.Target = event.WebhookSubscriber{URL: "https://example.com/hook"}
+	require.NoError(restoreWebhookSecrets(existing, desired))
+	webhook, ok := webhookSubscriber(desired[0])
+	require.True(ok)
+	assert.Equal("hunter2", string(webhook.Secret))
+
+	// a new secret replaces the existing one
+	desired[0].Subscriber.Target = event.WebhookSubscriber{URL: "https://example.com/hook", Secret: []byte("swordfish")}
+	require.NoError(restoreWebhookSecrets(existing, desired))
+	create, remove, err = diffSubscriptions(existing, desired)
+	require.NoError(err)
+	assert.Len(create, 1)
+	assert.Len(remove, 1)
+
+	// a webhook without an existing secret can't be imported
+	desired[0].Subscriber.Target = event.WebhookSubscriber{URL: "https://example.com/other", Secret: []byte(redactedSecret)}
+	assert.Error(restoreWebhookSecrets(existing, desired))
+}
+
+func TestDiffSubscriptions(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	existing := testSubscriptions()
+	desired := testSubscriptions()
+
+	create, remove, err := diffSubscriptions(existing, desired)
+	require.NoError(err)
+	assert.Empty(create)
+	assert.Empty(remove)
+
+	// selector order doesn't matter
+	desired[0].Selectors[0], desired[0].Selectors[1] = desired[0].Selectors[1], desired[0].Selectors[0]
+	create, remove, err = diffSubscriptions(existing, desired)
+	require.NoError(err)
+	assert.Empty(create)
+	assert.Empty(remove)
+
+	// a changed subscription is replaced
+	desired[1].Subscriber.Target = "#evergreen-alerts"
+	create, remove, err = diffSubscriptions(existing, desired)
+	require.NoError(err)
+	require.Len(create, 1)
+	assert.Equal("#evergreen-alerts", create[0].Subscriber.Target)
+	assert.Empty(create[0].ID)
+	require.Len(remove, 1)
+	assert.Equal(existing[1].ID, remove[0].ID)
+
+	// duplicates are matched one to one
+	existing = append(existing, existing[0])
+	existing[2].ID = bson.NewObjectId()
+	create, remove, err = diffSubscriptions(existing, desired[:1])
+	require.NoError(err)
+	assert.Empty(create)
+	require.Len(remove, 2)
+	assert.Equal(existing[1].ID, remove[0].ID)
+	assert.Equal(existing[2].ID, remove[1].ID)
+}
+
+func TestSetSubscriptionOwner(t *testing.T) {
+	assert := assert.New(t)
+
+	sub := event.Subscription{}
+	assert.NoError(setSubscriptionOwner(&sub, "me", event.OwnerTypePerson))
+	assert.Equal("me", sub.Owner)
+	assert.Equal(event.OwnerTypePerson, sub.OwnerType)
+
+	sub = event.Subscription{Owner: "you", OwnerType: event.OwnerTypePerson}
+	assert.Error(setSubscriptionOwner(&sub, "me", event.OwnerTypePerson))
+
+	sub = event.Subscription{Owner: "me", OwnerType: event.OwnerTypeProject}
+	assert.Error(setSubscriptionOwner(&sub, "me", event.OwnerTypePerson))
+}
+
+func TestParseSubscriptionFlags(t *testing.T) {
+	assert := assert.New(t)
+
+	selectors, err := parseSelectors([]string{"project:mci", "id:a:b"})
+	assert.NoError(err)
+	assert.Equal([]event.Selector{{Type: "project", Data: "mci"}, {Type: "id", Data: "a:b"}}, selectors)
+	_, err = parseSelectors([]string{"project"})
+	assert.Error(err)
+
+	data, err := parseTriggerData([]string{"threshold=10"})
+	assert.NoError(err)
+	assert.Equal(map[string]string{"threshold": "10"}, data)
+	_, err = parseTriggerData([]string{"=10"})
+	assert.Error(err)
+}
@@ -37,6 +37,7 @@ mciModule.controller('AdminSettingsController', ['$scope','$window', 'mciAdminRe
 
       $scope.tempPlugins = resp.data.plugins ? jsyaml.safeDump(resp.data.plugins) : ""
       $scope.tempContainerPools = resp.data.container_pools.pools ? jsyaml.safeDump(resp.data.container_pools.pools) : ""
+      $scope.tempProjectWeights = resp.data.scheduler.project_weights && resp.data.scheduler.project_weights.length ? jsyaml.safeDump(resp.data.scheduler.project_weights) : ""
 
       $scope.Settings = resp.data;
     }
@@ -120,6 +121,14 @@ mciModule.controller('AdminSettingsController', ['$scope','$window', 'mciAdminRe
 
     $scope.Settings.container_pools.pools = parsedContainerPools;
 
+    try {
+      var parsedProjectWeights = jsyaml.safeLoad($scope.tempProjectWeights);
+    } catch(e) {
+      notificationService.pushNotification("Error parsing project weights yaml: " + e, "errorHeader");
+      return;
+    }
+    $scope.Settings.scheduler.project_weights = parsedProjectWeights || [];
+
     if ($scope.tempPlugins === null || $scope.tempPlugins === undefined || $scope.tempPlugins == "") {
       $scope.Settings.plugins = {};
     }
@@ -143,6 +152,8 @@ mciModule.controller('AdminSettingsController', ['$scope','$window', 'mciAdminRe
     repotracker_disabled: "repotracker",
     scheduler_disabled: "scheduler",
     github_pr_testing_disabled: "github_pr_testing",
+    gitlab_mr_testing_disabled: "gitlab_mr_testing",
+    commit_queue_disabled: "commit_queue",
     repotracker_push_event_disabled: "repotracker_push_event",
     cli_updates_disabled: "cli_updates",
     background_stats_disabled: "background stats",
@@ -152,7 +163,8 @@ mciModule.controller('AdminSettingsController', ['$scope','$window', 'mciAdminRe
     slack_notifications_disabled: "slack_notifications",
     email_notifications_disabled: "email_notifications",
     webhook_notifications_disabled: "webhook_notifications",
-    github_status_api_disabled: "github_status_api"
+    github_status_api_disabled: "github_status_api",
+    gitlab_status_api_disabled: "gitlab_status_api"
   }
 
   timestamp = function(ts) {
@@ -34,6 +34,9 @@ mciModule.controller('DistrosCtrl', function($scope, $window, $location, $anchor
   }, {
     'id': 'vsphere',
     'display': 'VMware vSphere'
+  }, {
+    'id': 'kubernetes',
+    'display': 'Kubernetes'
   }];
 
   $scope.architectures = [{
@@ -78,6 +78,14 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
       resource_type: "TASK",
       label: "a previously passing test in a task fails",
     },
+    {
+      trigger: "test-flaky",
+      resource_type: "TASK",
+      label: "a flaky test fails",
+      extraFields: [
+        {text: "Flakiness threshold (0 to 1)", key: "test-flakiness-threshold", validator: validateFraction}
+      ]
+    },
   ];
 
   // refreshTrackedProjects will populate the list of projects that should be displayed
@@ -192,6 +200,7 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
           item = Object.assign({}, $scope.settingsFormData);
           item.setup_github_hook = false;
           item.pr_testing_enabled = false;
+          item.commit_queue = {};
           item.enabled = false;
           $http.post('/project/' + $scope.newProject.identifier, item).then(
             function(resp) {
@@ -272,6 +281,8 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
           branch_name: $scope.projectRef.branch_name || "master",
           owner_name: $scope.projectRef.owner_name,
           repo_name: $scope.projectRef.repo_name,
+          repo_kind: $scope.projectRef.repo_kind || "github",
+          repo_url: $scope.projectRef.repo_url,
           enabled: $scope.projectRef.enabled,
           private: $scope.projectRef.private,
           patching_disabled: $scope.projectRef.patching_disabled,
@@ -281,6 +292,7 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
           setup_github_hook: $scope.githubHookID != 0,
           tracks_push_events: data.ProjectRef.tracks_push_events || false,
           pr_testing_enabled: data.ProjectRef.pr_testing_enabled || false,
+          commit_queue: data.ProjectRef.commit_queue || {},
           notify_on_failure: $scope.projectRef.notify_on_failure,
           force_repotracker_run: false,
           delete_aliases: [],
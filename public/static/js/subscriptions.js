@@ -3,6 +3,8 @@ const SUBSCRIPTION_JIRA_ISSUE = 'jira-issue';
 const SUBSCRIPTION_SLACK = 'slack';
 const SUBSCRIPTION_EMAIL = 'email';
 const SUBSCRIPTION_EVERGREEN_WEBHOOK = 'evergreen-webhook';
+const SUBSCRIPTION_MS_TEAMS = 'ms-teams';
+const SUBSCRIPTION_CHAT_WEBHOOK = 'chat-webhook';
 const DEFAULT_SUBSCRIPTION_METHODS = [
     {
         value: SUBSCRIPTION_EMAIL,
@@ -12,6 +14,14 @@ const DEFAULT_SUBSCRIPTION_METHODS = [
         value: SUBSCRIPTION_SLACK,
         label: "sending a slack message",
     },
+    {
+        value: SUBSCRIPTION_MS_TEAMS,
+        label: "sending a Microsoft Teams message",
+    },
+    {
+        value: SUBSCRIPTION_CHAT_WEBHOOK,
+        label: "posting to a chat webhook (Mattermost, Rocket.Chat, ...)",
+    },
     {
         value: SUBSCRIPTION_JIRA_COMMENT,
         label: "making a comment on a JIRA issue",
@@ -41,6 +51,12 @@ function subscriberLabel(subscriber) {
     }else if (subscriber.type === SUBSCRIPTION_EMAIL) {
         return "Send an email to " + subscriber.target;
 
+    }else if (subscriber.type === SUBSCRIPTION_MS_TEAMS) {
+        return "Send a Microsoft Teams message to " + subscriber.target;
+
+    }else if (subscriber.type === SUBSCRIPTION_CHAT_WEBHOOK) {
+        return "Post to chat webhook " + subscriber.target;
+
     }else if (subscriber.type === SUBSCRIPTION_EVERGREEN_WEBHOOK) {
         return "Post to external server " + subscriber.target.url;
     }
@@ -163,6 +179,10 @@ function subCtrl($scope, $mdDialog, mciUserSettingsService) {
         }else if ($scope.method.value === SUBSCRIPTION_EMAIL) {
             return $scope.targets[SUBSCRIPTION_EMAIL].match(".+@.+") !== null
 
+        }else if ($scope.method.value === SUBSCRIPTION_MS_TEAMS ||
+                  $scope.method.value === SUBSCRIPTION_CHAT_WEBHOOK) {
+            return $scope.targets[$scope.method.value].match("https://.+") !== null
+
         }else if ($scope.method.value === SUBSCRIPTION_EVERGREEN_WEBHOOK) {
             if (!$scope.targets[SUBSCRIPTION_EVERGREEN_WEBHOOK]) {
                 return false;
@@ -194,6 +214,7 @@ function subCtrl($scope, $mdDialog, mciUserSettingsService) {
     $scope.targets = {};
     $scope.targets[SUBSCRIPTION_EVERGREEN_WEBHOOK] = {
             secret: $scope.generateSecret(),
+            retries: 3,
     };
     if ($scope.c.subscription) {
         $scope.targets[$scope.c.subscription.subscriber.type] = $scope.c.subscription.subscriber.target;
@@ -282,3 +303,12 @@ function validatePercentage(percent) {
   }
   return "";
 }
+function validateFraction(fraction) {
+  if (!isFinite(fraction)) {
+    return fraction + " must be a number";
+  }
+  if (+fraction < 0 || +fraction > 1) {
+    return fraction + " must be between 0 and 1";
+  }
+  return "";
+}
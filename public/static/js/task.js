@@ -464,6 +464,11 @@ mciModule.controller('TaskHistoryDrawerCtrl', function($scope, $window, $locatio
           if (dependency.status == dependency.required || dependency.required == "*") {
             return "met";
           }
+          if (dependency.status == "failed" && dependency.task_end_details &&
+              ((dependency.required == "setup-failed" && dependency.task_end_details.type == "setup") ||
+               (dependency.required == "system-failed" && dependency.task_end_details.type == "system"))) {
+            return "met";
+          }
           return "unmet";
         };
 
@@ -0,0 +1,338 @@
+package repotracker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/subprocess"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/pkg/errors"
+)
+
+const (
+	// gitLogFormat separates the fields of each commit with the unit
+	// separator, and the commits with the record separator, since commit
+	// messages may contain newlines.
+	gitLogFormat       = "--format=%H%x1f%an%x1f%ae%x1f%ct%x1f%B%x1e"
+	gitFieldSeparator  = "\x1f"
+	gitRecordSeparator = "\x1e"
+
+	gitFetchTimeout   = 10 * time.Minute
+	gitCommandTimeout = 30 * time.Second
+)
+
+var (
+	// gitRevisionRegexp matches full or abbreviated commit hashes, which
+	// can't be mistaken for options by git.
+	gitRevisionRegexp = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+)
+
+func validateGitRevision(revision string) error {
+	if !gitRevisionRegexp.MatchString(revision) {
+		return errors.Errorf("invalid revision '%s'", revision)
+	}
+	return nil
+}
+
+// GitRepositoryPoller is a struct that implements the behavior required of a
+// RepoPoller for a repository on any git remote. It clones the repository
+// into a local mirror, fetches the mirror once per repotracker run, and
+// reads revisions and files from the mirror.
+type GitRepositoryPoller struct {
+	ProjectRef *model.ProjectRef
+	// MirrorDirectory is the directory that contains the local mirrors of
+	// project repositories.
+	MirrorDirectory string
+
+	fetched bool
+}
+
+// NewGitRepositoryPoller constructs and returns a pointer to a
+// GitRepositoryPoller struct
+func NewGitRepositoryPoller(projectRef *model.ProjectRef, mirrorDirectory string) *GitRepositoryPoller {
+	return &GitRepositoryPoller{
+		ProjectRef:      projectRef,
+		MirrorDirectory: mirrorDirectory,
+	}
+}
+
+func (p *GitRepositoryPoller) mirrorPath() string {
+	return filepath.Join(p.MirrorDirectory, p.ProjectRef.Identifier+".git")
+}
+
+func (p *GitRepositoryPoller) branchRef() string {
+	return "refs/heads/" + p.ProjectRef.Branch
+}
+
+// runGit runs a git command in the directory, and returns its output.
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	env := map[string]string{
+		// never wait on a prompt for credentials
+		"GIT_TERMINAL_PROMPT": "0",
+		// only allow the transports that ValidateRepoURL accepts, so
+		// that repository URLs can't run commands with ext:: or similar
+		"GIT_ALLOW_PROTOCOL": "https:ssh:file",
+	}
+	cmd, err := subprocess.NewLocalExec("git", args, env, dir)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	if err = cmd.SetOutput(subprocess.OutputOptions{Output: stdout, Error: stderr}); err != nil {
+		return nil, errors.Wrap(err, "problem configuring git output")
+	}
+	if err = cmd.Run(ctx); err != nil {
+		return nil, errors.Wrapf(err, "problem running 'git %s': %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// fetch clones the mirror of the repository if it doesn't exist yet, or
+// otherwise fetches new commits into it.
+func (p *GitRepositoryPoller) fetch(ctx context.Context) error {
+	if p.fetched {
+		return nil
+	}
+	if err := model.ValidateRepoURL(p.ProjectRef.RepoURL); err != nil {
+		return errors.Wrapf(err, "invalid repository URL in project ref: %s", p.ProjectRef.Identifier)
+	}
+	if p.MirrorDirectory == "" {
+		return errors.New("no directory to mirror repositories in")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gitFetchTimeout)
+	defer cancel()
+
+	mirror := p.mirrorPath()
+	if _, err := os.Stat(mirror); os.IsNotExist(err) {
+		if err = os.MkdirAll(p.MirrorDirectory, 0755); err != nil {
+			return errors.Wrapf(err, "problem creating mirror directory '%s'", p.MirrorDirectory)
+		}
+
+		// clone into a temporary directory, so that a clone that fails
+		// partway through doesn't leave a broken mirror behind
+		tmp := mirror + ".tmp"
+		if err = os.RemoveAll(tmp); err != nil {
+			return errors.Wrapf(err, "problem removing '%s'", tmp)
+		}
+		if _, err = runGit(ctx, p.MirrorDirectory, "clone", "--mirror", "--quiet", "--", p.ProjectRef.RepoURL, tmp); err != nil {
+			_ = os.RemoveAll(tmp)
+			return errors.Wrapf(err, "problem cloning repository for project ref: %s", p.ProjectRef.Identifier)
+		}
+		if err = os.Rename(tmp, mirror); err != nil {
+			return errors.Wrapf(err, "problem saving mirror '%s'", mirror)
+		}
+	} else if err != nil {
+		return errors.Wrapf(err, "problem finding mirror '%s'", mirror)
+	} else {
+		// the repository may have moved since the mirror was cloned
+		if _, err = runGit(ctx, mirror, "remote", "set-url", "--", "origin", p.ProjectRef.RepoURL); err != nil {
+			return errors.WithStack(err)
+		}
+		if _, err = runGit(ctx, mirror, "fetch", "--prune", "--quiet", "origin"); err != nil {
+			return errors.Wrapf(err, "problem fetching repository for project ref: %s", p.ProjectRef.Identifier)
+		}
+	}
+
+	p.fetched = true
+	return nil
+}
+
+// log returns the commits that git log lists with the arguments, most recent
+// first.
+func (p *GitRepositoryPoller) log(ctx context.Context, args ...string) ([]model.Revision, error) {
+	out, err := runGit(ctx, p.mirrorPath(), append([]string{"log", gitLogFormat}, args...)...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return parseGitLog(out)
+}
+
+// parseGitLog converts the output of git log in gitLogFormat to revisions.
+func parseGitLog(out []byte) ([]model.Revision, error) {
+	revisions := []model.Revision{}
+	for _, record := range strings.Split(string(out), gitRecordSeparator) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		fields := strings.SplitN(record, gitFieldSeparator, 5)
+		if len(fields) != 5 {
+			return nil, errors.Errorf("git log returned a commit with %d fields", len(fields))
+		}
+		ts, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "git log returned an invalid commit time for '%s'", fields[0])
+		}
+
+		revisions = append(revisions, model.Revision{
+			Revision:        fields[0],
+			Author:          fields[1],
+			AuthorEmail:     fields[2],
+			CreateTime:      time.Unix(ts, 0).UTC(),
+			RevisionMessage: strings.TrimSpace(fields[4]),
+		})
+	}
+
+	return revisions, nil
+}
+
+// GetRemoteConfig reads the project's configuration file from the mirror of
+// the repository as at a given revision
+func (p *GitRepositoryPoller) GetRemoteConfig(ctx context.Context, projectFileRevision string) (*model.Project, error) {
+	if err := validateGitRevision(projectFileRevision); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := p.fetch(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gitCommandTimeout)
+	defer cancel()
+
+	object := fmt.Sprintf("%s:%s", projectFileRevision, p.ProjectRef.RemotePath)
+	if _, err := runGit(ctx, p.mirrorPath(), "cat-file", "-e", object); err != nil {
+		return nil, thirdparty.NewFileNotFoundError(object)
+	}
+	projectFileBytes, err := runGit(ctx, p.mirrorPath(), "cat-file", "blob", object)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	projectConfig := &model.Project{}
+	if err = model.LoadProjectInto(projectFileBytes, p.ProjectRef.Identifier, projectConfig); err != nil {
+		return nil, thirdparty.YAMLFormatError{Message: err.Error()}
+	}
+
+	return projectConfig, nil
+}
+
+// GetChangedFiles returns the files that a revision changed from its first
+// parent, or all of the files in the revision if it has no parents.
+func (p *GitRepositoryPoller) GetChangedFiles(ctx context.Context, commitRevision string) ([]string, error) {
+	if err := validateGitRevision(commitRevision); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := p.fetch(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gitCommandTimeout)
+	defer cancel()
+
+	out, err := runGit(ctx, p.mirrorPath(), "rev-list", "--parents", "--max-count=1", commitRevision)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading commit '%v'", commitRevision)
+	}
+	commits := strings.Fields(string(out))
+	if len(commits) == 0 {
+		return nil, errors.Errorf("commit '%v' not found", commitRevision)
+	}
+
+	args := []string{"diff-tree", "-r", "--name-only", "--no-commit-id"}
+	if len(commits) > 1 {
+		args = append(args, commits[1], commits[0])
+	} else {
+		args = append(args, "--root", commits[0])
+	}
+	out, err = runGit(ctx, p.mirrorPath(), args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading files changed by commit '%v'", commitRevision)
+	}
+
+	files := []string{}
+	for _, line := range strings.Split(string(out), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// GetRevisionsSince returns the commits on the project's branch that were
+// made after 'revision', searching through at most 'maxRevisionsToSearch'
+// commits for it.
+func (p *GitRepositoryPoller) GetRevisionsSince(revision string, maxRevisionsToSearch int) ([]model.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), gitFetchTimeout)
+	defer cancel()
+
+	if err := p.fetch(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	args := []string{}
+	if maxRevisionsToSearch > 0 {
+		args = append(args, fmt.Sprintf("--max-count=%d", maxRevisionsToSearch))
+	}
+	commits, err := p.log(ctx, append(args, p.branchRef())...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem listing commits for project ref: %s", p.ProjectRef.Identifier)
+	}
+
+	revisions := []model.Revision{}
+	for _, commit := range commits {
+		if commit.Revision == revision {
+			return revisions, nil
+		}
+		revisions = append(revisions, commit)
+	}
+
+	if len(revision) < 10 || validateGitRevision(revision) != nil {
+		return nil, errors.Errorf("invalid revision: %v", revision)
+	}
+
+	var revisionError error
+	revisionDetails := &model.RepositoryErrorDetails{
+		Exists:          true,
+		InvalidRevision: revision[:10],
+	}
+	out, err := runGit(ctx, p.mirrorPath(), "merge-base", revision, p.branchRef())
+	if err != nil {
+		// unable to get merge base commit so set projectRef revision details with a blank base revision
+		revisionError = errors.Wrapf(err,
+			"unable to find a suggested merge base commit for revision %v, must fix on projects settings page",
+			revision)
+	} else {
+		revisionDetails.MergeBaseRevision = strings.TrimSpace(string(out))
+		revisionError = errors.Errorf("base revision, %v not found, suggested base revision, %v found, must confirm on project settings page",
+			revision, revisionDetails.MergeBaseRevision)
+	}
+
+	p.ProjectRef.RepotrackerError = revisionDetails
+	if err = p.ProjectRef.Upsert(); err != nil {
+		return []model.Revision{}, errors.Wrap(err, "unable to update projectRef revision details")
+	}
+
+	return []model.Revision{}, revisionError
+}
+
+// GetRecentRevisions returns the most recent 'maxRevisions' commits on the
+// project's branch.
+func (p *GitRepositoryPoller) GetRecentRevisions(maxRevisions int) ([]model.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), gitFetchTimeout)
+	defer cancel()
+
+	if err := p.fetch(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	revisions, err := p.log(ctx, fmt.Sprintf("--max-count=%d", maxRevisions), p.branchRef())
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem listing commits for project ref: %s", p.ProjectRef.Identifier)
+	}
+
+	return revisions, nil
+}
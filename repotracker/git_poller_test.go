@@ -0,0 +1,186 @@
+package repotracker
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type gitTestRepo struct {
+	t      *testing.T
+	origin string
+	work   string
+}
+
+func newGitTestRepo(t *testing.T, dir string) *gitTestRepo {
+	r := &gitTestRepo{
+		t:      t,
+		origin: filepath.Join(dir, "origin.git"),
+		work:   filepath.Join(dir, "work"),
+	}
+	require.NoError(t, os.MkdirAll(r.work, 0755))
+	r.git(dir, "init", "--quiet", "--bare", r.origin)
+	r.git(r.work, "init", "--quiet")
+
+	return r
+}
+
+func (r *gitTestRepo) git(dir string, args ...string) string {
+	out, err := runGit(context.Background(), dir, args...)
+	require.NoError(r.t, err)
+
+	return string(out)
+}
+
+// commit commits the files to the work repository, pushes it to the
+// master branch of the origin, and returns the revision.
+func (r *gitTestRepo) commit(message string, files map[string]string) string {
+	for name, contents := range files {
+		require.NoError(r.t, ioutil.WriteFile(filepath.Join(r.work, name), []byte(contents), 0644))
+		r.git(r.work, "add", name)
+	}
+	r.git(r.work, "-c", "user.name=Evergreen", "-c", "user.email=evergreen@example.com",
+		"commit", "--quiet", "-m", message)
+	r.git(r.work, "push", "--quiet", r.origin, "HEAD:refs/heads/master")
+
+	return r.git(r.work, "rev-parse", "HEAD")[:40]
+}
+
+func TestGitRepositoryPoller(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "git-poller")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	repo := newGitTestRepo(t, dir)
+	first := repo.commit("first commit", map[string]string{
+		"evergreen.yml": "tasks:\n- name: compile\n",
+		"main.go":       "package main\n",
+	})
+	second := repo.commit("second commit\n\nwith a body", map[string]string{
+		"main.go": "package main\n\nfunc main() {}\n",
+		"bad.yml": "tasks: [",
+	})
+
+	ref := &model.ProjectRef{
+		Identifier: "mci",
+		RepoKind:   model.GitRepoType,
+		RepoURL:    repo.origin,
+		Branch:     "master",
+		RemotePath: "evergreen.yml",
+	}
+	mirrors := filepath.Join(dir, "mirrors")
+	poller := NewGitRepositoryPoller(ref, mirrors)
+
+	revisions, err := poller.GetRecentRevisions(10)
+	require.NoError(err)
+	require.Len(revisions, 2)
+	assert.Equal(second, revisions[0].Revision)
+	assert.Equal("second commit\n\nwith a body", revisions[0].RevisionMessage)
+	assert.Equal("Evergreen", revisions[0].Author)
+	assert.Equal("evergreen@example.com", revisions[0].AuthorEmail)
+	assert.False(revisions[0].CreateTime.IsZero())
+	assert.Equal(first, revisions[1].Revision)
+
+	revisions, err = poller.GetRecentRevisions(1)
+	require.NoError(err)
+	require.Len(revisions, 1)
+	assert.Equal(second, revisions[0].Revision)
+
+	files, err := poller.GetChangedFiles(ctx, first)
+	require.NoError(err)
+	assert.Equal([]string{"evergreen.yml", "main.go"}, files)
+	files, err = poller.GetChangedFiles(ctx, second)
+	require.NoError(err)
+	assert.Equal([]string{"bad.yml", "main.go"}, files)
+
+	project, err := poller.GetRemoteConfig(ctx, first)
+	require.NoError(err)
+	require.Len(project.Tasks, 1)
+	assert.Equal("compile", project.Tasks[0].Name)
+
+	ref.RemotePath = "missing.yml"
+	_, err = poller.GetRemoteConfig(ctx, first)
+	assert.True(thirdparty.IsFileNotFound(err))
+	ref.RemotePath = "bad.yml"
+	_, err = poller.GetRemoteConfig(ctx, second)
+	assert.IsType(thirdparty.YAMLFormatError{}, err)
+	ref.RemotePath = "evergreen.yml"
+
+	// a poller only fetches once, so a new commit is found by the next one
+	third := repo.commit("third commit", map[string]string{"main.go": "package main\n\nfunc main() { panic(1) }\n"})
+	revisions, err = poller.GetRevisionsSince(second, 10)
+	require.NoError(err)
+	assert.Empty(revisions)
+
+	poller = NewGitRepositoryPoller(ref, mirrors)
+	revisions, err = poller.GetRevisionsSince(first, 10)
+	require.NoError(err)
+	require.Len(revisions, 2)
+	assert.Equal(third, revisions[0].Revision)
+	assert.Equal(second, revisions[1].Revision)
+
+	_, err = poller.GetRevisionsSince("abc", 10)
+	assert.Error(err)
+	_, err = poller.GetRevisionsSince("--output=/tmp/evergreen", 10)
+	assert.Error(err)
+	_, err = poller.GetChangedFiles(ctx, "--output=/tmp/evergreen")
+	assert.Error(err)
+	_, err = poller.GetRemoteConfig(ctx, "-p")
+	assert.Error(err)
+
+	ref.RepoURL = ""
+	_, err = NewGitRepositoryPoller(ref, filepath.Join(dir, "other")).GetRecentRevisions(10)
+	assert.Error(err)
+
+	// a bare repository can be cloned by file URL as well as by path
+	ref.RepoURL = "file://" + repo.origin
+	revisions, err = NewGitRepositoryPoller(ref, filepath.Join(dir, "file")).GetRecentRevisions(10)
+	require.NoError(err)
+	require.Len(revisions, 3)
+	assert.Equal(third, revisions[0].Revision)
+
+	// projects can't clone relative paths, pass options to git or use
+	// other transports
+	pwned := filepath.Join(dir, "pwned")
+	for _, repoURL := range []string{"origin.git", "--upload-pack=touch " + pwned, "ext::sh -c touch% " + pwned} {
+		ref.RepoURL = repoURL
+		_, err = NewGitRepositoryPoller(ref, filepath.Join(dir, "other")).GetRecentRevisions(10)
+		assert.Error(err)
+	}
+	_, err = runGit(ctx, dir, "ls-remote", "ext::sh -c touch% "+pwned)
+	assert.Error(err)
+	_, err = os.Stat(pwned)
+	assert.True(os.IsNotExist(err))
+}
+
+func TestParseGitLog(t *testing.T) {
+	assert := assert.New(t)
+
+	revisions, err := parseGitLog([]byte("abc\x1fme\x1fme@example.com\x1f1500000000\x1fmessage\n\x1e\ndef\x1fyou\x1fyou@example.com\x1f1400000000\x1fother\n\x1e\n"))
+	assert.NoError(err)
+	assert.Len(revisions, 2)
+	assert.Equal("abc", revisions[0].Revision)
+	assert.Equal("message", revisions[0].RevisionMessage)
+	assert.EqualValues(1500000000, revisions[0].CreateTime.Unix())
+	assert.Equal("you@example.com", revisions[1].AuthorEmail)
+
+	revisions, err = parseGitLog([]byte{})
+	assert.NoError(err)
+	assert.Empty(revisions)
+
+	_, err = parseGitLog([]byte("abc\x1fme\x1e"))
+	assert.Error(err)
+	_, err = parseGitLog([]byte("abc\x1fme\x1fme@example.com\x1fnow\x1fmessage\x1e"))
+	assert.Error(err)
+}
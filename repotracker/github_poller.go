@@ -8,9 +8,14 @@ import (
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/google/go-github/github"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
 	"github.com/pkg/errors"
 )
 
+// githubCommitFilesLimit is the most files of a commit that GitHub lists.
+const githubCommitFilesLimit = 300
+
 // GithubRepositoryPoller is a struct that implements Github specific behavior
 // required of a RepoPoller
 type GithubRepositoryPoller struct {
@@ -103,6 +108,29 @@ func (gRepoPoller *GithubRepositoryPoller) GetChangedFiles(ctx context.Context,
 		return nil, errors.Wrapf(err, "error loading commit '%v'", commitRevision)
 	}
 
+	files, err := commitFiles(commit)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if files == nil {
+		grip.Info(message.Fields{
+			"message":  "commit changed too many files to list them",
+			"project":  projectRef.Identifier,
+			"revision": commitRevision,
+			"runner":   RunnerName,
+		})
+	}
+
+	return files, nil
+}
+
+// commitFiles returns the files that a commit changed, or nil if GitHub
+// didn't list all of them, since it lists at most githubCommitFilesLimit.
+func commitFiles(commit *github.RepositoryCommit) ([]string, error) {
+	if len(commit.Files) >= githubCommitFilesLimit {
+		return nil, nil
+	}
+
 	files := []string{}
 	for _, f := range commit.Files {
 		if f.Filename == nil {
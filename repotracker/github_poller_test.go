@@ -16,6 +16,7 @@ import (
 	"github.com/google/go-github/github"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/smartystreets/goconvey/convey/reporting"
+	"github.com/stretchr/testify/assert"
 )
 
 var (
@@ -302,6 +303,28 @@ func TestGetChangedFiles(t *testing.T) {
 	})
 }
 
+func TestCommitFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	commit := &github.RepositoryCommit{}
+	for i := 0; i < githubCommitFilesLimit-1; i++ {
+		commit.Files = append(commit.Files, github.CommitFile{Filename: github.String("file")})
+	}
+	files, err := commitFiles(commit)
+	assert.NoError(err)
+	assert.Len(files, githubCommitFilesLimit-1)
+
+	// GitHub doesn't list every file of a large commit
+	commit.Files = append(commit.Files, github.CommitFile{Filename: github.String("file")})
+	files, err = commitFiles(commit)
+	assert.NoError(err)
+	assert.Nil(files)
+
+	files, err = commitFiles(&github.RepositoryCommit{Files: []github.CommitFile{{}}})
+	assert.Error(err)
+	assert.Nil(files)
+}
+
 func TestIsLastRevision(t *testing.T) {
 	Convey("When calling isLastRevision...", t, func() {
 		Convey("it should not panic on nil SHA hash", func() {
@@ -46,7 +46,9 @@ type RepoPoller interface {
 	// the given revision.
 	GetRemoteConfig(ctx context.Context, revision string) (*model.Project, error)
 
-	// Fetches a list of all filepaths modified by a given revision.
+	// Fetches a list of all filepaths modified by a given revision. The list
+	// is empty if the files can't all be listed, in which case no files are
+	// ignored and no tasks are skipped for the revision.
 	GetChangedFiles(ctx context.Context, revision string) ([]string, error)
 
 	// Fetches all changes since the 'revision' specified - with the most recent
@@ -320,11 +322,13 @@ func (repoTracker *RepoTracker) StoreRevisions(ctx context.Context, revisions []
 		}
 		v.Config = string(projectYamlBytes)
 
-		// "Ignore" a version if all changes are to ignored files
-		if len(project.Ignore) > 0 {
-			filenames, err := repoTracker.GetChangedFiles(ctx, revision)
+		// "Ignore" a version if all changes are to ignored files, and skip
+		// the tasks that don't run for the changed files
+		var filenames []string
+		if len(project.Ignore) > 0 || project.HasPathFilters() {
+			filenames, err = repoTracker.GetChangedFiles(ctx, revision)
 			if err != nil {
-				return nil, errors.Wrap(err, "error checking GitHub for ignored files")
+				return nil, errors.Wrap(err, "error getting the files changed by the revision")
 			}
 			if project.IgnoresAllFiles(filenames) {
 				v.Ignored = true
@@ -332,7 +336,7 @@ func (repoTracker *RepoTracker) StoreRevisions(ctx context.Context, revisions []
 		}
 
 		// We rebind newestVersion each iteration, so the last binding will be the newest version
-		err = errors.Wrapf(createVersionItems(v, ref, project),
+		err = errors.Wrapf(createVersionItems(v, ref, project, filenames),
 			"Error creating version items for %s in project %s",
 			v.Id, ref.Identifier)
 		if err != nil {
@@ -492,18 +496,44 @@ func NewVersionFromRevision(ref *model.ProjectRef, rev model.Revision) (*version
 }
 
 // createVersionItems populates and stores all the tasks and builds for a version according to
-// the given project config.
-func createVersionItems(v *version.Version, ref *model.ProjectRef, project *model.Project) error {
+// the given project config. Tasks whose path filters don't match the changed files are skipped.
+func createVersionItems(v *version.Version, ref *model.ProjectRef, project *model.Project, changedFiles []string) error {
 	// generate all task Ids so that we can easily reference them for dependencies
 	taskIds := model.NewTaskIdTable(project, v)
 
+	var selected model.TVPairSet
+	if project.HasPathFilters() {
+		var skipped model.TVPairSet
+		selected, skipped = project.SelectTasksByPaths(versionTVPairs(project), changedFiles)
+		// dependencies on skipped tasks are dropped along with their ids
+		for _, pair := range skipped {
+			delete(taskIds.ExecutionTasks, pair)
+		}
+		if len(skipped) > 0 {
+			v.SkippedTasks = skipped.ToSkippedTasks()
+		}
+	}
+
 	// create all builds for the version
 	for _, buildvariant := range project.BuildVariants {
 		if buildvariant.Disabled {
 			continue
 		}
 
-		buildId, err := model.CreateBuildFromVersion(project, v, taskIds, buildvariant.Name, false, nil, nil, "")
+		var taskNames, displayNames []string
+		if len(v.SkippedTasks) > 0 {
+			taskNames = selected.TaskNames(buildvariant.Name)
+			if len(taskNames) == 0 {
+				continue
+			}
+			for _, dt := range buildvariant.DisplayTasks {
+				if len(util.StringSliceIntersection(dt.ExecutionTasks, taskNames)) > 0 {
+					displayNames = append(displayNames, dt.Name)
+				}
+			}
+		}
+
+		buildId, err := model.CreateBuildFromVersion(project, v, taskIds, buildvariant.Name, false, taskNames, displayNames, "")
 		if err != nil {
 			return errors.WithStack(err)
 		}
@@ -554,6 +584,12 @@ func createVersionItems(v *version.Version, ref *model.ProjectRef, project *mode
 		})
 	}
 
+	// a version whose tasks were all skipped has nothing left to run
+	if len(v.BuildIds) == 0 && len(v.SkippedTasks) > 0 {
+		v.Status = evergreen.VersionSucceeded
+		v.FinishTime = v.CreateTime
+	}
+
 	err := v.Insert()
 	if err != nil && !db.IsDuplicateKey(err) {
 		grip.Error(message.WrapError(err, message.Fields{
@@ -576,6 +612,27 @@ func createVersionItems(v *version.Version, ref *model.ProjectRef, project *mode
 	return nil
 }
 
+// versionTVPairs returns the tasks of all of the enabled build variants of
+// the project, with task groups expanded to their tasks.
+func versionTVPairs(project *model.Project) model.TVPairSet {
+	pairs := model.TVPairSet{}
+	for _, bv := range project.BuildVariants {
+		if bv.Disabled {
+			continue
+		}
+		for _, t := range bv.Tasks {
+			if tg := project.FindTaskGroup(t.Name); tg != nil {
+				for _, groupTask := range tg.Tasks {
+					pairs = append(pairs, model.TVPair{Variant: bv.Name, TaskName: groupTask})
+				}
+				continue
+			}
+			pairs = append(pairs, model.TVPair{Variant: bv.Name, TaskName: t.Name})
+		}
+	}
+	return pairs
+}
+
 func addBuildBreakSubscriptions(v *version.Version, projectRef *model.ProjectRef) error {
 	if !projectRef.NotifyOnBuildFailure {
 		return nil
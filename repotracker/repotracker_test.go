@@ -10,6 +10,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/task"
 	modelutil "github.com/evergreen-ci/evergreen/model/testutil"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/model/version"
@@ -18,6 +19,7 @@ import (
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
@@ -512,6 +514,76 @@ func createTestProject(override1, override2 *int) *model.Project {
 	}
 }
 
+func TestCreateVersionItemsWithPathFilters(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	dropTestDB(t)
+	defer dropTestDB(t)
+
+	project := createTestProject(nil, nil)
+	project.Identifier = "testproject"
+	project.Tasks = append(project.Tasks, model.ProjectTask{
+		Name:  "docs",
+		Paths: []string{"docs/"},
+	})
+	project.BuildVariants[0].Tasks = append(project.BuildVariants[0].Tasks, model.BuildVariantTaskUnit{
+		Name:    "docs",
+		Distros: []string{"test-distro-one"},
+	})
+	project.BuildVariants[1].Paths = []string{"src/"}
+	ref := &model.ProjectRef{Identifier: "testproject"}
+
+	// a docs change skips the variant that only runs for source changes
+	v := &version.Version{
+		Id:         "v1",
+		Identifier: "testproject",
+		Revision:   "foo",
+		CreateTime: time.Now(),
+		Requester:  evergreen.RepotrackerVersionRequester,
+	}
+	require.NoError(createVersionItems(v, ref, project, []string{"docs/index.md"}))
+	require.Len(v.BuildVariants, 1)
+	assert.Equal("bv1", v.BuildVariants[0].BuildVariant)
+	assert.Equal([]version.SkippedTask{{BuildVariant: "bv2", Task: "Unabhaengigkeitserklaerungen"}}, v.SkippedTasks)
+	tasks, err := task.Find(task.ByVersion(v.Id))
+	assert.NoError(err)
+	assert.Len(tasks, 2)
+
+	dbVersion, err := version.FindOne(version.ById(v.Id))
+	assert.NoError(err)
+	require.NotNil(dbVersion)
+	assert.Equal(v.SkippedTasks, dbVersion.SkippedTasks)
+
+	// a source change skips the docs task
+	v = &version.Version{
+		Id:         "v2",
+		Identifier: "testproject",
+		Revision:   "bar",
+		CreateTime: time.Now(),
+		Requester:  evergreen.RepotrackerVersionRequester,
+	}
+	require.NoError(createVersionItems(v, ref, project, []string{"src/main.go"}))
+	assert.Len(v.BuildVariants, 2)
+	assert.Equal([]version.SkippedTask{{BuildVariant: "bv1", Task: "docs"}}, v.SkippedTasks)
+	tasks, err = task.Find(task.ByVersion(v.Id))
+	assert.NoError(err)
+	assert.Len(tasks, 2)
+
+	// a version that skips all of its tasks is done
+	project.BuildVariants[0].Paths = []string{"src/"}
+	v = &version.Version{
+		Id:         "v3",
+		Identifier: "testproject",
+		Revision:   "baz",
+		CreateTime: time.Now(),
+		Requester:  evergreen.RepotrackerVersionRequester,
+	}
+	require.NoError(createVersionItems(v, ref, project, []string{"docs/index.md"}))
+	assert.Empty(v.BuildVariants)
+	assert.Len(v.SkippedTasks, 3)
+	assert.Equal(evergreen.VersionSucceeded, v.Status)
+}
+
 func TestBuildBreakSubscriptions(t *testing.T) {
 	assert := assert.New(t)
 	assert.NoError(db.Clear(user.Collection))
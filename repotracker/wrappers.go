@@ -2,6 +2,8 @@ package repotracker
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
@@ -18,9 +20,28 @@ const (
 	// githubAPILimitCeiling is arbitrary but corresponds to when we start logging errors in
 	// thirdparty/github.go/getGithubRateLimit
 	githubAPILimitCeiling = 20
+
+	// defaultMirrorDirectoryName is the directory in the system's temporary
+	// directory that mirrors repositories if the settings don't specify one
+	defaultMirrorDirectoryName = "evergreen-repotracker"
 )
 
 func getTracker(conf *evergreen.Settings, project model.ProjectRef) (*RepoTracker, error) {
+	tracker := &RepoTracker{
+		Settings:   conf,
+		ProjectRef: &project,
+	}
+
+	if project.RepoKind == model.GitRepoType {
+		mirrorDir := conf.RepoTracker.MirrorDirectory
+		if mirrorDir == "" {
+			mirrorDir = filepath.Join(os.TempDir(), defaultMirrorDirectoryName)
+		}
+		tracker.RepoPoller = NewGitRepositoryPoller(&project, mirrorDir)
+
+		return tracker, nil
+	}
+
 	token, err := conf.GetGithubOauthToken()
 	if err != nil {
 		grip.Warning(message.Fields{
@@ -29,12 +50,7 @@ func getTracker(conf *evergreen.Settings, project model.ProjectRef) (*RepoTracke
 		})
 		return nil, errors.WithStack(err)
 	}
-
-	tracker := &RepoTracker{
-		Settings:   conf,
-		ProjectRef: &project,
-		RepoPoller: NewGithubRepositoryPoller(&project, token),
-	}
+	tracker.RepoPoller = NewGithubRepositoryPoller(&project, token)
 
 	return tracker, nil
 }
@@ -139,6 +139,9 @@ type Communicator interface {
 	// Fetch list of distributions evergreen can spawn
 	GetDistrosList(context.Context) ([]restmodel.APIDistro, error)
 
+	// Explain how the scheduler would plan the queue for a distro
+	ExplainDistroQueue(context.Context, string) (*restmodel.APIDistroQueueExplanation, error)
+
 	// Fetch the current authenticated user's public keys
 	GetCurrentUsersKeys(context.Context) ([]restmodel.APIPubKey, error)
 
@@ -156,4 +159,14 @@ type Communicator interface {
 	// GetSubscriptions fetches the subscriptions for the user defined
 	// in the local evergreen yaml
 	GetSubscriptions(context.Context) ([]event.Subscription, error)
+
+	// GetOwnerSubscriptions fetches the subscriptions that belong to a user
+	// or project
+	GetOwnerSubscriptions(context.Context, string, event.OwnerType) ([]event.Subscription, error)
+
+	// SaveSubscriptions creates subscriptions, or updates those with IDs
+	SaveSubscriptions(context.Context, []event.Subscription) error
+
+	// DeleteSubscription deletes the subscription with the ID
+	DeleteSubscription(context.Context, string) error
 }
@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	patchmodel "github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/logging"
+	"github.com/mongodb/grip/send"
+	"github.com/pkg/errors"
+)
+
+const (
+	localLogsDir        = "logs"
+	localTestLogsDir    = "test_logs"
+	localJSONDataDir    = "json"
+	localTestResultFile = "test_results.json"
+	localArtifactsFile  = "artifacts.json"
+	localGenerateFile   = "generate_tasks.json"
+)
+
+// Local is a Communicator for running tasks without an API server.
+// Rather than sending task output to the server, it writes logs, test
+// results, artifacts, and JSON data to files in a local directory.
+// Operations that have no local equivalent behave as they do for the
+// Mock communicator.
+type Local struct {
+	*Mock
+
+	dir string
+	mu  sync.Mutex
+}
+
+// NewLocal returns a Communicator that writes task output to the given
+// directory, creating it if necessary.
+func NewLocal(dir string) (*Local, error) {
+	for _, sub := range []string{localLogsDir, localTestLogsDir, localJSONDataDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, errors.Wrapf(err, "problem creating output directory '%s'", dir)
+		}
+	}
+
+	return &Local{
+		Mock: NewMock(""),
+		dir:  dir,
+	}, nil
+}
+
+// Dir returns the directory that task output is written to.
+func (c *Local) Dir() string { return c.dir }
+
+// GetLoggerProducer returns a LoggerProducer that writes each log
+// channel to a file in the output directory, as well as to the
+// process' global logger.
+func (c *Local) GetLoggerProducer(ctx context.Context, td TaskData) LoggerProducer {
+	local := grip.GetSender()
+
+	makeSender := func(name string) send.Sender {
+		fileSender, err := send.NewPlainFileLogger(name, filepath.Join(c.dir, localLogsDir, name+".log"), local.Level())
+		if err != nil {
+			grip.Warning(errors.Wrapf(err, "problem creating local '%s' log", name))
+			return local
+		}
+		grip.CatchWarning(fileSender.SetFormatter(send.MakeDefaultFormatter()))
+		return send.NewConfiguredMultiSender(local, fileSender)
+	}
+
+	return &logHarness{
+		execution: logging.MakeGrip(makeSender("execution")),
+		task:      logging.MakeGrip(makeSender("task")),
+		system:    logging.MakeGrip(makeSender("system")),
+	}
+}
+
+// SendTestResults appends the results to the test results file.
+func (c *Local) SendTestResults(ctx context.Context, td TaskData, results *task.LocalTestResults) error {
+	if results == nil || len(results.Results) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing := &task.LocalTestResults{}
+	if err := c.readJSON(localTestResultFile, existing); err != nil {
+		return errors.WithStack(err)
+	}
+	existing.Results = append(existing.Results, results.Results...)
+
+	return errors.WithStack(c.writeJSON(localTestResultFile, existing))
+}
+
+// SendTestLog writes the test log to the test logs directory and
+// returns the name of the file as the log's id.
+func (c *Local) SendTestLog(ctx context.Context, td TaskData, log *serviceModel.TestLog) (string, error) {
+	if log == nil {
+		return "", nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := sanitizeLocalFileName(log.Name)
+	if id == "" {
+		id = "test"
+	}
+	path := filepath.Join(c.dir, localTestLogsDir, id+".log")
+	for i := 1; localFileExists(path); i++ {
+		path = filepath.Join(c.dir, localTestLogsDir, fmt.Sprintf("%s-%d.log", id, i))
+	}
+
+	if err := ioutil.WriteFile(path, []byte(strings.Join(log.Lines, "\n")), 0644); err != nil {
+		return "", errors.Wrapf(err, "problem writing test log '%s'", log.Name)
+	}
+
+	return filepath.Base(path), nil
+}
+
+// AttachFiles appends the artifacts to the artifacts file.
+func (c *Local) AttachFiles(ctx context.Context, td TaskData, taskFiles []*artifact.File) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing := []*artifact.File{}
+	if err := c.readJSON(localArtifactsFile, &existing); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(c.writeJSON(localArtifactsFile, append(existing, taskFiles...)))
+}
+
+// PostJSONData writes the data to a file named for the path in the
+// JSON data directory.
+func (c *Local) PostJSONData(ctx context.Context, td TaskData, path string, data interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return errors.WithStack(c.writeJSON(filepath.Join(localJSONDataDir, sanitizeLocalFileName(path)+".json"), data))
+}
+
+// GetJSONData reads data previously written by PostJSONData for the
+// named task. Data from other variants or versions is not available
+// locally, so it only returns data posted during this run.
+func (c *Local) GetJSONData(ctx context.Context, td TaskData, taskName, dataName, variantName string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out, err := ioutil.ReadFile(filepath.Join(c.dir, localJSONDataDir, sanitizeLocalFileName(dataName)+".json"))
+	if os.IsNotExist(err) {
+		return nil, errors.Errorf("no local json data named '%s'", dataName)
+	}
+
+	return out, errors.WithStack(err)
+}
+
+// GenerateTasks writes the generate.tasks input to a file. Generated
+// tasks are not run locally.
+func (c *Local) GenerateTasks(ctx context.Context, td TaskData, jsonBytes []json.RawMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return errors.WithStack(c.writeJSON(localGenerateFile, jsonBytes))
+}
+
+// GetTaskPatch returns an error because local runs are not patches.
+func (c *Local) GetTaskPatch(ctx context.Context, td TaskData) (*patchmodel.Patch, error) {
+	return nil, errors.New("patches are not available when running a task locally")
+}
+
+// S3Copy returns an error because the copy is performed by the API
+// server.
+func (c *Local) S3Copy(ctx context.Context, td TaskData, req *apimodels.S3CopyRequest) error {
+	return errors.New("s3 copy is not available when running a task locally")
+}
+
+func (c *Local) readJSON(name string, out interface{}) error {
+	data, err := ioutil.ReadFile(filepath.Join(c.dir, name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "problem reading '%s'", name)
+	}
+
+	return errors.Wrapf(json.Unmarshal(data, out), "problem parsing '%s'", name)
+}
+
+func (c *Local) writeJSON(name string, data interface{}) error {
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "problem encoding '%s'", name)
+	}
+
+	return errors.Wrapf(ioutil.WriteFile(filepath.Join(c.dir, name), out, 0644), "problem writing '%s'", name)
+}
+
+func sanitizeLocalFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+func localFileExists(path string) bool {
+	_, err := os.Stat(path)
+	return !os.IsNotExist(err)
+}
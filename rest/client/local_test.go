@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalCommunicatorWritesTaskOutput(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "local-comm")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	comm, err := NewLocal(dir)
+	require.NoError(err)
+	td := TaskData{ID: "local"}
+
+	results := &task.LocalTestResults{Results: []task.TestResult{{TestFile: "one"}}}
+	assert.NoError(comm.SendTestResults(ctx, td, results))
+	assert.NoError(comm.SendTestResults(ctx, td, results))
+	saved := &task.LocalTestResults{}
+	require.NoError(comm.readJSON(localTestResultFile, saved))
+	assert.Len(saved.Results, 2)
+
+	id, err := comm.SendTestLog(ctx, td, &serviceModel.TestLog{Name: "a/b", Lines: []string{"line"}})
+	assert.NoError(err)
+	assert.Equal("a_b.log", id)
+	id, err = comm.SendTestLog(ctx, td, &serviceModel.TestLog{Name: "a/b"})
+	assert.NoError(err)
+	assert.Equal("a_b-1.log", id)
+
+	assert.NoError(comm.AttachFiles(ctx, td, []*artifact.File{{Name: "f", Link: "l"}}))
+	files := []*artifact.File{}
+	require.NoError(comm.readJSON(localArtifactsFile, &files))
+	assert.Len(files, 1)
+
+	assert.NoError(comm.PostJSONData(ctx, td, "perf", map[string]int{"ops": 1}))
+	data, err := comm.GetJSONData(ctx, td, "task", "perf", "bv")
+	assert.NoError(err)
+	out := map[string]int{}
+	assert.NoError(json.Unmarshal(data, &out))
+	assert.Equal(1, out["ops"])
+	_, err = comm.GetJSONData(ctx, td, "task", "missing", "bv")
+	assert.Error(err)
+
+	logger := comm.GetLoggerProducer(ctx, td)
+	logger.Task().Info("hello local")
+	assert.NoError(logger.Close())
+	logData, err := ioutil.ReadFile(filepath.Join(dir, localLogsDir, "task.log"))
+	assert.NoError(err)
+	assert.Contains(string(logData), "hello local")
+}
@@ -55,6 +55,8 @@ type Mock struct {
 	HeartbeatShouldErr     bool
 	TaskExecution          int
 	GetSubscriptionsFail   bool
+	SavedSubscriptions     []event.Subscription
+	DeletedSubscriptions   []string
 
 	AttachedFiles    map[string][]*artifact.File
 	LogID            string
@@ -461,6 +463,10 @@ func (c *Mock) GetDistrosList(ctx context.Context) ([]model.APIDistro, error) {
 	return mockDistros, nil
 }
 
+func (c *Mock) ExplainDistroQueue(ctx context.Context, distroID string) (*model.APIDistroQueueExplanation, error) {
+	return &model.APIDistroQueueExplanation{Distro: model.ToAPIString(distroID)}, nil
+}
+
 func (c *Mock) GetCurrentUsersKeys(ctx context.Context) ([]model.APIPubKey, error) {
 	return []model.APIPubKey{
 		{
@@ -533,3 +539,23 @@ func (c *Mock) GetSubscriptions(_ context.Context) ([]event.Subscription, error)
 		},
 	}, nil
 }
+
+func (c *Mock) GetOwnerSubscriptions(ctx context.Context, _ string, _ event.OwnerType) ([]event.Subscription, error) {
+	return c.GetSubscriptions(ctx)
+}
+
+func (c *Mock) SaveSubscriptions(_ context.Context, subs []event.Subscription) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.SavedSubscriptions = append(c.SavedSubscriptions, subs...)
+	return nil
+}
+
+func (c *Mock) DeleteSubscription(_ context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.DeletedSubscriptions = append(c.DeletedSubscriptions, id)
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -402,6 +403,35 @@ func (c *communicatorImpl) GetDistrosList(ctx context.Context) ([]model.APIDistr
 	return distros, nil
 }
 
+func (c *communicatorImpl) ExplainDistroQueue(ctx context.Context, distroID string) (*model.APIDistroQueueExplanation, error) {
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    fmt.Sprintf("distros/%s/queue/explain", distroID),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem explaining queue for distro '%s'", distroID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return nil, errors.Wrap(err, "problem explaining distro queue and parsing error message")
+		}
+		return nil, errors.Wrap(errMsg, "problem explaining distro queue")
+	}
+
+	explanation := &model.APIDistroQueueExplanation{}
+	if err = util.ReadJSONInto(resp.Body, explanation); err != nil {
+		return nil, errors.Wrap(err, "error parsing distro queue explanation")
+	}
+
+	return explanation, nil
+}
+
 func (c *communicatorImpl) GetCurrentUsersKeys(ctx context.Context) ([]model.APIPubKey, error) {
 	info := requestInfo{
 		method:  get,
@@ -554,8 +584,15 @@ func (c *communicatorImpl) GetClientConfig(ctx context.Context) (*evergreen.Clie
 }
 
 func (c *communicatorImpl) GetSubscriptions(ctx context.Context) ([]event.Subscription, error) {
+	return c.GetOwnerSubscriptions(ctx, c.apiUser, event.OwnerTypePerson)
+}
+
+func (c *communicatorImpl) GetOwnerSubscriptions(ctx context.Context, owner string, ownerType event.OwnerType) ([]event.Subscription, error) {
+	query := url.Values{}
+	query.Set("owner", owner)
+	query.Set("type", string(ownerType))
 	info := requestInfo{
-		path:    fmt.Sprintf("/subscriptions?owner=%s&type=person", c.apiUser),
+		path:    "/subscriptions?" + query.Encode(),
 		method:  get,
 		version: apiVersion2,
 	}
@@ -611,3 +648,62 @@ func (c *communicatorImpl) GetSubscriptions(ctx context.Context) ([]event.Subscr
 
 	return subs, nil
 }
+
+func (c *communicatorImpl) SaveSubscriptions(ctx context.Context, subs []event.Subscription) error {
+	info := requestInfo{
+		path:    "/subscriptions",
+		method:  post,
+		version: apiVersion2,
+	}
+
+	apiSubs := make([]model.APISubscription, len(subs))
+	for i := range subs {
+		if err := apiSubs[i].BuildFromService(subs[i]); err != nil {
+			return errors.Wrap(err, "failed to convert subscription")
+		}
+		// new subscriptions are given IDs by the server
+		if !subs[i].ID.Valid() {
+			apiSubs[i].ID = nil
+		}
+	}
+
+	resp, err := c.request(ctx, info, apiSubs)
+	if err != nil {
+		return errors.Wrap(err, "problem reaching evergreen API server")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "problem saving subscriptions and parsing error message")
+		}
+		return errors.Wrap(errMsg, "problem saving subscriptions")
+	}
+
+	return nil
+}
+
+func (c *communicatorImpl) DeleteSubscription(ctx context.Context, id string) error {
+	query := url.Values{}
+	query.Set("id", id)
+	info := requestInfo{
+		path:    "/subscriptions?" + query.Encode(),
+		method:  delete,
+		version: apiVersion2,
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrap(err, "problem reaching evergreen API server")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "problem deleting subscription and parsing error message")
+		}
+		return errors.Wrapf(errMsg, "problem deleting subscription '%s'", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/rest"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+)
+
+// DBCommitQueueConnector is a struct that implements the commit queue
+// related functions of the Connector interface through interactions with
+// the backing database and GitHub.
+type DBCommitQueueConnector struct{}
+
+// GetGitHubPR fetches a pull request from GitHub.
+func (pc *DBCommitQueueConnector) GetGitHubPR(ctx context.Context, owner, repo string, prNum int) (*github.PullRequest, error) {
+	token, err := evergreen.GetEnvironment().Settings().GetGithubOauthToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get github oauth token")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	pr, err := thirdparty.GetGithubPullRequest(ctx, token, owner, repo, prNum)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't get pull request %s/%s#%d", owner, repo, prNum)
+	}
+
+	return pr, nil
+}
+
+// FindCommitQueueProjectRef returns the project of a repository's branch
+// if it has the commit queue enabled, or nil otherwise.
+func (pc *DBCommitQueueConnector) FindCommitQueueProjectRef(owner, repo, branch string) (*model.ProjectRef, error) {
+	projectRef, err := model.FindOneProjectRefByRepoAndBranchWithPRTesting(owner, repo, branch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't find project for %s/%s:%s", owner, repo, branch)
+	}
+	if projectRef == nil || !projectRef.CommitQueue.Enabled {
+		return nil, nil
+	}
+
+	return projectRef, nil
+}
+
+// EnqueueItem adds an item to the end of a project's commit queue and
+// returns its position in the queue.
+func (pc *DBCommitQueueConnector) EnqueueItem(projectID string, item restModel.APICommitQueueItem) (int, error) {
+	q, err := item.ToService()
+	if err != nil {
+		return 0, errors.Wrap(err, "can't convert commit queue item")
+	}
+	serviceItem := q.(commitqueue.CommitQueueItem)
+	if cq, err := commitqueue.FindOneId(projectID); err == nil && cq != nil && cq.FindItem(serviceItem.Issue) >= 0 {
+		return 0, rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("item '%s' is already in the commit queue", serviceItem.Issue),
+		}
+	}
+
+	pos, err := commitqueue.Enqueue(projectID, serviceItem)
+	if err != nil {
+		return 0, errors.Wrapf(err, "can't enqueue item in the commit queue of '%s'", projectID)
+	}
+
+	return pos, nil
+}
+
+// FindCommitQueueByID returns the commit queue of a project, which is empty
+// if nothing was ever enqueued.
+func (pc *DBCommitQueueConnector) FindCommitQueueByID(projectID string) (*restModel.APICommitQueue, error) {
+	cq, err := commitqueue.FindOneId(projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't find the commit queue of '%s'", projectID)
+	}
+	if cq == nil {
+		cq = &commitqueue.CommitQueue{ProjectID: projectID}
+	}
+
+	apiCommitQueue := &restModel.APICommitQueue{}
+	if err = apiCommitQueue.BuildFromService(cq); err != nil {
+		return nil, errors.Wrap(err, "can't convert commit queue")
+	}
+
+	return apiCommitQueue, nil
+}
+
+// CommitQueueRemoveItem removes an item from a project's commit queue and
+// returns whether it was in the queue.
+func (pc *DBCommitQueueConnector) CommitQueueRemoveItem(projectID, item string) (bool, error) {
+	cq, err := commitqueue.FindOneId(projectID)
+	if err != nil {
+		return false, errors.Wrapf(err, "can't find the commit queue of '%s'", projectID)
+	}
+	if cq == nil {
+		return false, nil
+	}
+
+	return cq.Remove(item)
+}
+
+// IsAuthorizedToPatchAndMerge returns whether a GitHub user is a member of
+// the organization that is allowed to create patches from pull requests, and
+// has write access to the repository, so that evergreen doesn't merge pull
+// requests on behalf of users who couldn't merge them themselves.
+func (pc *DBCommitQueueConnector) IsAuthorizedToPatchAndMerge(ctx context.Context, settings *evergreen.Settings, owner, repo, username string) (bool, error) {
+	if settings.GithubPRCreatorOrg == "" {
+		return false, nil
+	}
+	token, err := settings.GetGithubOauthToken()
+	if err != nil {
+		return false, errors.Wrap(err, "can't get github oauth token")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	isMember, err := thirdparty.GithubUserInOrganization(ctx, token, settings.GithubPRCreatorOrg, username)
+	if err != nil {
+		return false, errors.Wrapf(err, "can't check if '%s' is a member of '%s'", username, settings.GithubPRCreatorOrg)
+	}
+	if !isMember {
+		return false, nil
+	}
+
+	canWrite, err := thirdparty.GithubUserHasWritePermission(ctx, token, owner, repo, username)
+	if err != nil {
+		return false, errors.Wrapf(err, "can't check if '%s' can write to %s/%s", username, owner, repo)
+	}
+
+	return canWrite, nil
+}
+
+// MockCommitQueueConnector is a struct that implements the commit queue
+// related functions of the Connector interface without a database or
+// GitHub.
+type MockCommitQueueConnector struct {
+	Queue           map[string][]restModel.APICommitQueueItem
+	CachedPRs       map[int]*github.PullRequest
+	CachedProjects  []model.ProjectRef
+	AuthorizedUsers []string
+}
+
+func (pc *MockCommitQueueConnector) GetGitHubPR(ctx context.Context, owner, repo string, prNum int) (*github.PullRequest, error) {
+	pr, ok := pc.CachedPRs[prNum]
+	if !ok {
+		return nil, errors.Errorf("can't get pull request %s/%s#%d", owner, repo, prNum)
+	}
+
+	return pr, nil
+}
+
+func (pc *MockCommitQueueConnector) FindCommitQueueProjectRef(owner, repo, branch string) (*model.ProjectRef, error) {
+	for i := range pc.CachedProjects {
+		p := pc.CachedProjects[i]
+		if p.Owner == owner && p.Repo == repo && p.Branch == branch && p.Enabled && p.PRTestingEnabled && p.CommitQueue.Enabled {
+			return &p, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (pc *MockCommitQueueConnector) EnqueueItem(projectID string, item restModel.APICommitQueueItem) (int, error) {
+	if pc.Queue == nil {
+		pc.Queue = make(map[string][]restModel.APICommitQueueItem)
+	}
+	for _, queued := range pc.Queue[projectID] {
+		if restModel.FromAPIString(queued.Issue) == restModel.FromAPIString(item.Issue) {
+			return 0, rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("item '%s' is already in the commit queue", restModel.FromAPIString(item.Issue)),
+			}
+		}
+	}
+
+	pc.Queue[projectID] = append(pc.Queue[projectID], item)
+	return len(pc.Queue[projectID]) - 1, nil
+}
+
+func (pc *MockCommitQueueConnector) FindCommitQueueByID(projectID string) (*restModel.APICommitQueue, error) {
+	return &restModel.APICommitQueue{
+		ProjectID: restModel.ToAPIString(projectID),
+		Queue:     pc.Queue[projectID],
+	}, nil
+}
+
+func (pc *MockCommitQueueConnector) CommitQueueRemoveItem(projectID, item string) (bool, error) {
+	queue := pc.Queue[projectID]
+	for i := range queue {
+		if restModel.FromAPIString(queue[i].Issue) == item {
+			pc.Queue[projectID] = append(queue[:i], queue[i+1:]...)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (pc *MockCommitQueueConnector) IsAuthorizedToPatchAndMerge(ctx context.Context, settings *evergreen.Settings, owner, repo, username string) (bool, error) {
+	for _, u := range pc.AuthorizedUsers {
+		if u == username {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
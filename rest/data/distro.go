@@ -1,14 +1,18 @@
 package data
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/scheduler"
 	"github.com/pkg/errors"
 )
 
@@ -73,6 +77,37 @@ func (tc *DBDistroConnector) ClearTaskQueue(distroId string) error {
 	return model.ClearTaskQueue(distroId)
 }
 
+// ExplainDistroQueue runs the scheduler for a distro with the current
+// scheduler settings, without saving the queue or spawning hosts.
+func (tc *DBDistroConnector) ExplainDistroQueue(ctx context.Context, distroId string) (*scheduler.DistroQueueExplanation, error) {
+	_, err := distro.FindOne(distro.ById(distroId))
+	if err != nil && !db.ResultsNotFound(err) {
+		return nil, errors.Wrapf(err, "problem finding distro '%s'", distroId)
+	}
+	if err != nil {
+		return nil, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("distro '%s' not found", distroId),
+		}
+	}
+
+	settings, err := evergreen.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving scheduler settings")
+	}
+
+	conf := scheduler.Configuration{
+		DistroID:         distroId,
+		TaskFinder:       settings.Scheduler.TaskFinder,
+		HostAllocator:    settings.Scheduler.HostAllocator,
+		TaskPrioritizer:  settings.Scheduler.TaskPrioritizer,
+		ProjectWeights:   settings.Scheduler.GetProjectWeights(),
+		FreeHostFraction: settings.Scheduler.FreeHostFraction,
+	}
+
+	return scheduler.ExplainDistro(ctx, conf, settings)
+}
+
 // MockDistroConnector is a struct that implements mock versions of
 // Distro-related methods for testing.
 type MockDistroConnector struct {
@@ -126,3 +161,7 @@ func (mdc *MockDistroConnector) FindCostByDistroId(distroId string,
 func (mdc *MockDistroConnector) ClearTaskQueue(distroId string) error {
 	return errors.New("ClearTaskQueue unimplemented for mock")
 }
+
+func (mdc *MockDistroConnector) ExplainDistroQueue(ctx context.Context, distroId string) (*scheduler.DistroQueueExplanation, error) {
+	return nil, errors.New("ExplainDistroQueue unimplemented for mock")
+}
@@ -0,0 +1,91 @@
+package data
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// DBEventConnector is a struct that implements the event log related
+// functions of the Connector interface through interactions with the
+// backing database.
+type DBEventConnector struct{}
+
+// FindEvents returns up to limit events that match the filter, from the
+// event with the ID key. Unless newer is true, they are the event with the
+// key and the events before it, newest first; otherwise they are the events
+// after it, oldest first.
+func (ec *DBEventConnector) FindEvents(filter event.EventFilter, key string, limit int, newer bool) ([]event.EventLogEntry, error) {
+	if key != "" && !bson.IsObjectIdHex(key) {
+		return nil, rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "'" + key + "' is not a valid event ID",
+		}
+	}
+
+	events, err := event.Find(event.LogCollection(filter.ResourceType), event.FilteredEvents(filter, key, newer, limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding events")
+	}
+
+	return events, nil
+}
+
+// MockEventConnector is a struct that implements the event log related
+// functions of the Connector interface without a database.
+type MockEventConnector struct {
+	CachedEvents []event.EventLogEntry
+}
+
+func (ec *MockEventConnector) FindEvents(filter event.EventFilter, key string, limit int, newer bool) ([]event.EventLogEntry, error) {
+	if key != "" && !bson.IsObjectIdHex(key) {
+		return nil, rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "'" + key + "' is not a valid event ID",
+		}
+	}
+
+	events := []event.EventLogEntry{}
+	for _, e := range ec.CachedEvents {
+		if filter.ResourceType != "" && e.ResourceType != filter.ResourceType {
+			continue
+		}
+		if filter.ResourceID != "" && e.ResourceId != filter.ResourceID {
+			continue
+		}
+		if filter.EventType != "" && e.EventType != filter.EventType {
+			continue
+		}
+		if !filter.StartTime.IsZero() && e.Timestamp.Before(filter.StartTime) {
+			continue
+		}
+		if !filter.EndTime.IsZero() && !e.Timestamp.Before(filter.EndTime) {
+			continue
+		}
+		if key != "" {
+			if newer && e.ID.Hex() <= key {
+				continue
+			}
+			if !newer && e.ID.Hex() > key {
+				continue
+			}
+		}
+		events = append(events, e)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if newer {
+			return events[i].ID.Hex() < events[j].ID.Hex()
+		}
+		return events[i].ID.Hex() > events[j].ID.Hex()
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
@@ -0,0 +1,184 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/pkg/errors"
+)
+
+// DBGithubPRConnector is a struct that implements the functions of the
+// Connector interface that act on the patches of GitHub pull requests,
+// through interactions with the backing database and GitHub.
+type DBGithubPRConnector struct{}
+
+// RetryGithubPRPatch restarts the failed tasks of the latest patch of a pull
+// request, and returns the patch's version.
+func (pc *DBGithubPRConnector) RetryGithubPRPatch(owner, repo string, prNum int, caller string) (string, error) {
+	p, err := findLatestGithubPRPatch(owner, repo, prNum)
+	if err != nil {
+		return "", err
+	}
+
+	tasks, err := task.Find(task.ByVersionAndStatus(p.Version, []string{evergreen.TaskFailed}).WithFields(task.IdKey))
+	if err != nil {
+		return "", errors.Wrapf(err, "can't find the failed tasks of version '%s'", p.Version)
+	}
+	if len(tasks) == 0 {
+		return "", rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "the latest patch has no failed tasks",
+		}
+	}
+	taskIds := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		taskIds = append(taskIds, t.Id)
+	}
+	if err = model.RestartVersion(p.Version, taskIds, false, caller); err != nil {
+		return "", errors.Wrapf(err, "can't restart the failed tasks of version '%s'", p.Version)
+	}
+
+	return p.Version, nil
+}
+
+// AbortGithubPRPatch aborts the latest patch of a pull request, and returns
+// the patch's version.
+func (pc *DBGithubPRConnector) AbortGithubPRPatch(owner, repo string, prNum int, caller string) (string, error) {
+	p, err := findLatestGithubPRPatch(owner, repo, prNum)
+	if err != nil {
+		return "", err
+	}
+	if err = model.CancelPatch(p, caller); err != nil {
+		return "", errors.Wrapf(err, "can't abort patch '%s'", p.Id.Hex())
+	}
+
+	return p.Version, nil
+}
+
+// AddVariantsToGithubPRPatch adds build variants to the latest patch of a
+// pull request, and returns the patch's version.
+func (pc *DBGithubPRConnector) AddVariantsToGithubPRPatch(owner, repo string, prNum int, variants []string) (string, error) {
+	p, err := findLatestGithubPRPatch(owner, repo, prNum)
+	if err != nil {
+		return "", err
+	}
+	if err = model.AddVariantsToPatch(p, variants); err != nil {
+		return "", rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	return p.Version, nil
+}
+
+// PostGithubPRComment posts a comment on a pull request.
+func (pc *DBGithubPRConnector) PostGithubPRComment(ctx context.Context, owner, repo string, prNum int, comment string) error {
+	token, err := evergreen.GetEnvironment().Settings().GetGithubOauthToken()
+	if err != nil {
+		return errors.Wrap(err, "can't get github oauth token")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return thirdparty.PostCommentToPullRequest(ctx, token, owner, repo, prNum, comment)
+}
+
+// findLatestGithubPRPatch returns the most recent patch of a pull request,
+// which must have been finalized.
+func findLatestGithubPRPatch(owner, repo string, prNum int) (*patch.Patch, error) {
+	p, err := patch.FindOne(patch.ByGithubPR(owner, repo, prNum))
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't find patches for %s/%s#%d", owner, repo, prNum)
+	}
+	if p == nil {
+		return nil, rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("no patch found for %s/%s#%d", owner, repo, prNum),
+		}
+	}
+	if p.Version == "" {
+		return nil, rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "the latest patch has not been finalized",
+		}
+	}
+
+	return p, nil
+}
+
+// MockGithubPRConnector is a struct that implements the functions of the
+// Connector interface that act on the patches of GitHub pull requests
+// without a database or GitHub.
+type MockGithubPRConnector struct {
+	// CachedVersions maps pull request numbers to the version of their
+	// latest patch
+	CachedVersions map[int]string
+	Retried        []string
+	Aborted        []string
+	AddedVariants  map[string][]string
+	Comments       map[int][]string
+}
+
+func (pc *MockGithubPRConnector) findVersion(owner, repo string, prNum int) (string, error) {
+	v, ok := pc.CachedVersions[prNum]
+	if !ok {
+		return "", rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("no patch found for %s/%s#%d", owner, repo, prNum),
+		}
+	}
+
+	return v, nil
+}
+
+func (pc *MockGithubPRConnector) RetryGithubPRPatch(owner, repo string, prNum int, caller string) (string, error) {
+	v, err := pc.findVersion(owner, repo, prNum)
+	if err != nil {
+		return "", err
+	}
+	pc.Retried = append(pc.Retried, v)
+
+	return v, nil
+}
+
+func (pc *MockGithubPRConnector) AbortGithubPRPatch(owner, repo string, prNum int, caller string) (string, error) {
+	v, err := pc.findVersion(owner, repo, prNum)
+	if err != nil {
+		return "", err
+	}
+	pc.Aborted = append(pc.Aborted, v)
+
+	return v, nil
+}
+
+func (pc *MockGithubPRConnector) AddVariantsToGithubPRPatch(owner, repo string, prNum int, variants []string) (string, error) {
+	v, err := pc.findVersion(owner, repo, prNum)
+	if err != nil {
+		return "", err
+	}
+	if pc.AddedVariants == nil {
+		pc.AddedVariants = make(map[string][]string)
+	}
+	pc.AddedVariants[v] = append(pc.AddedVariants[v], variants...)
+
+	return v, nil
+}
+
+func (pc *MockGithubPRConnector) PostGithubPRComment(ctx context.Context, owner, repo string, prNum int, comment string) error {
+	if pc.Comments == nil {
+		pc.Comments = make(map[int][]string)
+	}
+	pc.Comments[prNum] = append(pc.Comments[prNum], comment)
+
+	return nil
+}
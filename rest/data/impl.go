@@ -30,6 +30,9 @@ type DBConnector struct {
 	DBSubscriptionConnector
 	NotificationConnector
 	DBCreateHostConnector
+	DBEventConnector
+	DBCommitQueueConnector
+	DBGithubPRConnector
 }
 
 func (ctx *DBConnector) GetSuperUsers() []string   { return ctx.superUsers }
@@ -65,6 +68,9 @@ type MockConnector struct {
 	MockSubscriptionConnector
 	MockNotificationConnector
 	MockCreateHostConnector
+	MockEventConnector
+	MockCommitQueueConnector
+	MockGithubPRConnector
 }
 
 func (ctx *MockConnector) GetSuperUsers() []string   { return ctx.superUsers }
@@ -17,6 +17,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/model/version"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/scheduler"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/google/go-github/github"
 	"github.com/mongodb/amboy"
@@ -86,6 +87,10 @@ type Connector interface {
 	// limit, and sort to provide additional control over the results.
 	FindTestsByTaskId(string, string, string, int, int, int) ([]testresult.TestResult, error)
 
+	// GetTestFlakiness scores the flakiness of the tests of a task, given
+	// its project, variant and name, in the number of recent revisions.
+	GetTestFlakiness(string, string, string, int) ([]task.TestFlakiness, error)
+
 	// FindUserById is a method to find a specific user given its ID.
 	FindUserById(string) (gimlet.User, error)
 
@@ -124,6 +129,10 @@ type Connector interface {
 	// ClearTaskQueue deletes all tasks from the task queue for a distro
 	ClearTaskQueue(string) error
 
+	// ExplainDistroQueue returns how the scheduler would plan the queue for
+	// a distro, without saving it.
+	ExplainDistroQueue(context.Context, string) (*scheduler.DistroQueueExplanation, error)
+
 	// FindVersionById returns version given its ID.
 	FindVersionById(string) (*version.Version, error)
 
@@ -144,6 +153,10 @@ type Connector interface {
 	// AbortPatchesFromPullRequest aborts patches with the same PR Number,
 	// in the same repository, at the pull request's close time
 	AbortPatchesFromPullRequest(*github.PullRequestEvent) error
+	// AbortPatchesFromMergeRequest aborts patches with the same MR number,
+	// in the same GitLab project, that were created before the merge
+	// request was closed or merged
+	AbortPatchesFromMergeRequest(*patch.GitlabMergeRequestEvent) error
 
 	// RestartVersion restarts all completed tasks of a version given its ID and the caller.
 	RestartVersion(string, string) error
@@ -202,10 +215,47 @@ type Connector interface {
 	// GetSubscriptions returns the subscriptions that belong to a user
 	GetSubscriptions(string, event.OwnerType) ([]restModel.APISubscription, error)
 	DeleteSubscription(id string) error
+	// PreviewSubscription renders the notification that a subscription
+	// would send for an event, or for its most recent event if the event
+	// ID is empty
+	PreviewSubscription(event.Subscription, string) (*restModel.APISubscriptionPreview, error)
 
 	// Notifications
 	GetNotificationsStats() (*restModel.APIEventStats, error)
+	// GetFailedWebhooks returns the most recent webhook notifications that
+	// failed and won't be retried, for a subscription if its ID is given.
+	GetFailedWebhooks(string, int) ([]restModel.APINotification, error)
+	// ReplayNotifications queues a failed webhook notification, or if the
+	// notification ID is empty, all of a subscription's failed webhooks,
+	// to be sent again.
+	ReplayNotifications(amboy.Queue, string, string) ([]restModel.APINotification, error)
+
+	// FindEvents returns events from the event log that match the filter,
+	// paging from the event with the ID
+	FindEvents(event.EventFilter, string, int, bool) ([]event.EventLogEntry, error)
 
 	// ListHostsForTask lists running hosts scoped to the task or the task's build.
 	ListHostsForTask(string) ([]host.Host, error)
+
+	// GetGitHubPR fetches a pull request from GitHub
+	GetGitHubPR(context.Context, string, string, int) (*github.PullRequest, error)
+	// FindCommitQueueProjectRef returns the project of a repository's
+	// branch if it has the commit queue enabled
+	FindCommitQueueProjectRef(string, string, string) (*model.ProjectRef, error)
+	// EnqueueItem adds an item to a project's commit queue and returns
+	// its position in the queue
+	EnqueueItem(string, restModel.APICommitQueueItem) (int, error)
+	FindCommitQueueByID(string) (*restModel.APICommitQueue, error)
+	CommitQueueRemoveItem(string, string) (bool, error)
+	// IsAuthorizedToPatchAndMerge returns whether a GitHub user may
+	// create patches from and merge pull requests into a repository
+	IsAuthorizedToPatchAndMerge(context.Context, *evergreen.Settings, string, string, string) (bool, error)
+
+	// RetryGithubPRPatch, AbortGithubPRPatch, and AddVariantsToGithubPRPatch
+	// act on the latest patch of a pull request and return its version
+	RetryGithubPRPatch(string, string, int, string) (string, error)
+	AbortGithubPRPatch(string, string, int, string) (string, error)
+	AddVariantsToGithubPRPatch(string, string, int, []string) (string, error)
+	// PostGithubPRComment posts a comment on a pull request
+	PostGithubPRComment(context.Context, string, string, int, string) error
 }
@@ -1,12 +1,18 @@
 package data
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/notification"
 	"github.com/evergreen-ci/evergreen/rest"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/units"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 )
 
@@ -34,18 +40,106 @@ func (c *NotificationConnector) GetNotificationsStats() (*restModel.APIEventStat
 		return nil, errors.Wrap(err, "failed to collect notification stats")
 	}
 
+	heldStats, err := notification.CollectHeldNotificationStats()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to collect held notification stats")
+	}
+
 	if err = stats.BuildFromService(nStats); err != nil {
 		return nil, &rest.APIError{
 			Message:    "failed to build stats response",
 			StatusCode: http.StatusInternalServerError,
 		}
 	}
+	if err = stats.HeldNotificationsByType.BuildFromService(heldStats); err != nil {
+		return nil, &rest.APIError{
+			Message:    "failed to build stats response",
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
 
 	return &stats, nil
 }
 
+// replayLimit is the most failed notifications of a subscription that are
+// replayed at once.
+const replayLimit = 500
+
+func (c *NotificationConnector) GetFailedWebhooks(subscriptionID string, limit int) ([]restModel.APINotification, error) {
+	notifications, err := notification.FindFailedWebhooks(subscriptionID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find failed webhooks")
+	}
+
+	return buildAPINotifications(notifications)
+}
+
+func (c *NotificationConnector) ReplayNotifications(q amboy.Queue, notificationID, subscriptionID string) ([]restModel.APINotification, error) {
+	var notifications []notification.Notification
+	if len(notificationID) != 0 {
+		n, err := notification.Find(notificationID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find notification")
+		}
+		if n == nil {
+			return nil, &rest.APIError{
+				StatusCode: http.StatusNotFound,
+				Message:    fmt.Sprintf("notification '%s' not found", notificationID),
+			}
+		}
+		if !n.IsRetried() || n.SentAt.IsZero() {
+			return nil, &rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("notification '%s' can't be replayed", notificationID),
+			}
+		}
+		notifications = []notification.Notification{*n}
+
+	} else {
+		var err error
+		notifications, err = notification.FindFailedWebhooks(subscriptionID, replayLimit)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find failed webhooks")
+		}
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	catcher := grip.NewBasicCatcher()
+	for i := range notifications {
+		if err := notifications[i].PrepareReplay(); err != nil {
+			catcher.Add(err)
+			continue
+		}
+		catcher.Add(q.Put(units.NewEventNotificationReplayJob(notifications[i].ID, ts)))
+	}
+	if catcher.HasErrors() {
+		return nil, errors.Wrap(catcher.Resolve(), "failed to replay notifications")
+	}
+
+	return buildAPINotifications(notifications)
+}
+
+func buildAPINotifications(notifications []notification.Notification) ([]restModel.APINotification, error) {
+	out := make([]restModel.APINotification, len(notifications))
+	for i := range notifications {
+		if err := out[i].BuildFromService(&notifications[i]); err != nil {
+			return nil, errors.Wrap(err, "failed to build notification")
+		}
+	}
+
+	return out, nil
+}
+
 type MockNotificationConnector struct{}
 
 func (c *MockNotificationConnector) GetNotificationsStats() (*restModel.APIEventStats, error) {
 	return nil, errors.New("not implemented")
 }
+
+func (c *MockNotificationConnector) GetFailedWebhooks(_ string, _ int) ([]restModel.APINotification, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *MockNotificationConnector) ReplayNotifications(_ amboy.Queue, _, _ string) ([]restModel.APINotification, error) {
+	return nil, errors.New("not implemented")
+}
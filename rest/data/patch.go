@@ -107,6 +107,23 @@ func (p *DBPatchConnector) AbortPatchesFromPullRequest(event *github.PullRequest
 	return nil
 }
 
+func (p *DBPatchConnector) AbortPatchesFromMergeRequest(event *patch.GitlabMergeRequestEvent) error {
+	if err := verifyMergeRequestEventForAbort(event); err != nil {
+		return err
+	}
+
+	err := model.AbortPatchesWithGitlabPatchData(time.Now(), event.Project.PathWithNamespace,
+		event.ObjectAttributes.IID)
+	if err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "error aborting patches",
+		}
+	}
+
+	return nil
+}
+
 // MockPatchConnector is a struct that implements the Patch related methods
 // from the Connector through interactions with he backing database.
 type MockPatchConnector struct {
@@ -254,3 +271,19 @@ func verifyPullRequestEventForAbort(event *github.PullRequestEvent) (string, str
 
 	return baseRepo[0], baseRepo[1], nil
 }
+
+func (c *MockPatchConnector) AbortPatchesFromMergeRequest(event *patch.GitlabMergeRequestEvent) error {
+	return verifyMergeRequestEventForAbort(event)
+}
+
+func verifyMergeRequestEventForAbort(event *patch.GitlabMergeRequestEvent) error {
+	if event == nil || event.ObjectAttributes.IID == 0 ||
+		len(strings.Split(event.Project.PathWithNamespace, "/")) < 2 {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "merge request data is malformed",
+		}
+	}
+
+	return nil
+}
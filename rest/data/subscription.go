@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/trigger"
 	"github.com/evergreen-ci/evergreen/rest"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/mongodb/grip"
@@ -49,6 +50,32 @@ func (dc *DBSubscriptionConnector) DeleteSubscription(id string) error {
 	return event.RemoveSubscriptionID(id)
 }
 
+func (dc *DBSubscriptionConnector) PreviewSubscription(sub event.Subscription, eventID string) (*restModel.APISubscriptionPreview, error) {
+	n, e, err := trigger.Preview(&sub, eventID)
+	if err != nil {
+		return nil, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "failed to preview subscription").Error(),
+		}
+	}
+	if n == nil {
+		return nil, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    "no recent event would notify this subscription",
+		}
+	}
+
+	preview := &restModel.APISubscriptionPreview{}
+	if err = preview.BuildFromService(e); err != nil {
+		return nil, errors.Wrap(err, "failed to build preview")
+	}
+	if err = preview.BuildFromService(n); err != nil {
+		return nil, errors.Wrap(err, "failed to build preview")
+	}
+
+	return preview, nil
+}
+
 type MockSubscriptionConnector struct {
 	MockSubscriptions []event.Subscription
 }
@@ -64,3 +91,7 @@ func (mc *MockSubscriptionConnector) SaveSubscriptions(subscriptions []event.Sub
 func (dc *MockSubscriptionConnector) DeleteSubscription(id string) error {
 	return errors.New("MockSubscriptionConnector unimplemented")
 }
+
+func (dc *MockSubscriptionConnector) PreviewSubscription(sub event.Subscription, eventID string) (*restModel.APISubscriptionPreview, error) {
+	return nil, errors.New("MockSubscriptionConnector unimplemented")
+}
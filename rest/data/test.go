@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/pkg/errors"
 )
 
 // DBTestConnector is a struct that implements the Test related methods
@@ -36,11 +38,21 @@ func (tc *DBTestConnector) FindTestsByTaskId(taskId, testId, status string, limi
 	return res, nil
 }
 
+func (tc *DBTestConnector) GetTestFlakiness(project, variant, taskName string, revisions int) ([]task.TestFlakiness, error) {
+	flakiness, err := task.FindTestFlakiness(project, variant, taskName, revisions)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem scoring flakiness of tests of task '%s' on '%s' in '%s'", taskName, variant, project)
+	}
+
+	return flakiness, nil
+}
+
 // MockTaskConnector stores a cached set of tests that are queried against by the
 // implementations of the Connector interface's Test related functions.
 type MockTestConnector struct {
-	CachedTests []testresult.TestResult
-	StoredError error
+	CachedTests     []testresult.TestResult
+	CachedFlakiness []task.TestFlakiness
+	StoredError     error
 }
 
 func (mtc *MockTestConnector) FindTestsByTaskId(taskId, testId, status string, limit,
@@ -72,3 +84,18 @@ func (mtc *MockTestConnector) FindTestsByTaskId(taskId, testId, status string, l
 	}
 	return nil, nil
 }
+
+func (mtc *MockTestConnector) GetTestFlakiness(project, variant, taskName string, _ int) ([]task.TestFlakiness, error) {
+	if mtc.StoredError != nil {
+		return nil, mtc.StoredError
+	}
+
+	flakiness := []task.TestFlakiness{}
+	for _, f := range mtc.CachedFlakiness {
+		if f.Project == project && f.Variant == variant && f.TaskName == taskName {
+			flakiness = append(flakiness, f)
+		}
+	}
+
+	return flakiness, nil
+}
@@ -18,13 +18,16 @@ func NewConfigModel() *APIAdminSettings {
 		AuthConfig:     &APIAuthConfig{},
 		ContainerPools: &APIContainerPoolsConfig{},
 		Credentials:    map[string]string{},
+		EventRetention: &APIEventRetentionConfig{},
 		Expansions:     map[string]string{},
+		Gitlab:         &APIGitlabConfig{},
 		HostInit:       &APIHostInitConfig{},
 		Jira:           &APIJiraConfig{},
 		Keys:           map[string]string{},
 		LoggerConfig:   &APILoggerConfig{},
 		Notify:         &APINotifyConfig{},
 		Plugins:        map[string]map[string]interface{}{},
+		PriceTable:     &APIPriceTableConfig{},
 		Providers:      &APICloudProviders{},
 		RepoTracker:    &APIRepoTrackerConfig{},
 		Scheduler:      &APISchedulerConfig{},
@@ -48,8 +51,10 @@ type APIAdminSettings struct {
 	ConfigDir          APIString                         `json:"configdir,omitempty"`
 	Credentials        map[string]string                 `json:"credentials,omitempty"`
 	ContainerPools     *APIContainerPoolsConfig          `json:"container_pools,omitempty"`
+	EventRetention     *APIEventRetentionConfig          `json:"event_retention,omitempty"`
 	Expansions         map[string]string                 `json:"expansions,omitempty"`
 	GithubPRCreatorOrg APIString                         `json:"github_pr_creator_org,omitempty"`
+	Gitlab             *APIGitlabConfig                  `json:"gitlab,omitempty"`
 	HostInit           *APIHostInitConfig                `json:"hostinit,omitempty"`
 	IsNonProd          *bool                             `json:"isnonprod,omitempty"`
 	Jira               *APIJiraConfig                    `json:"jira,omitempty"`
@@ -59,6 +64,7 @@ type APIAdminSettings struct {
 	Notify             *APINotifyConfig                  `json:"notify,omitempty"`
 	Plugins            map[string]map[string]interface{} `json:"plugins,omitempty"`
 	PprofPort          APIString                         `json:"pprof_port,omitempty"`
+	PriceTable         *APIPriceTableConfig              `json:"price_table,omitempty"`
 	Providers          *APICloudProviders                `json:"providers,omitempty"`
 	RepoTracker        *APIRepoTrackerConfig             `json:"repotracker,omitempty"`
 	Scheduler          *APISchedulerConfig               `json:"scheduler,omitempty"`
@@ -679,11 +685,12 @@ func (a *APINotifyConfig) ToService() (interface{}, error) {
 }
 
 type APICloudProviders struct {
-	AWS       *APIAWSConfig       `json:"aws"`
-	Docker    *APIDockerConfig    `json:"docker"`
-	GCE       *APIGCEConfig       `json:"gce"`
-	OpenStack *APIOpenStackConfig `json:"openstack"`
-	VSphere   *APIVSphereConfig   `json:"vsphere"`
+	AWS        *APIAWSConfig        `json:"aws"`
+	Docker     *APIDockerConfig     `json:"docker"`
+	GCE        *APIGCEConfig        `json:"gce"`
+	Kubernetes *APIKubernetesConfig `json:"kubernetes"`
+	OpenStack  *APIOpenStackConfig  `json:"openstack"`
+	VSphere    *APIVSphereConfig    `json:"vsphere"`
 }
 
 func (a *APICloudProviders) BuildFromService(h interface{}) error {
@@ -692,6 +699,7 @@ func (a *APICloudProviders) BuildFromService(h interface{}) error {
 		a.AWS = &APIAWSConfig{}
 		a.Docker = &APIDockerConfig{}
 		a.GCE = &APIGCEConfig{}
+		a.Kubernetes = &APIKubernetesConfig{}
 		a.OpenStack = &APIOpenStackConfig{}
 		a.VSphere = &APIVSphereConfig{}
 		if err := a.AWS.BuildFromService(v.AWS); err != nil {
@@ -703,6 +711,9 @@ func (a *APICloudProviders) BuildFromService(h interface{}) error {
 		if err := a.GCE.BuildFromService(v.GCE); err != nil {
 			return err
 		}
+		if err := a.Kubernetes.BuildFromService(v.Kubernetes); err != nil {
+			return err
+		}
 		if err := a.OpenStack.BuildFromService(v.OpenStack); err != nil {
 			return err
 		}
@@ -728,6 +739,10 @@ func (a *APICloudProviders) ToService() (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	kubernetes, err := a.Kubernetes.ToService()
+	if err != nil {
+		return nil, err
+	}
 	openstack, err := a.OpenStack.ToService()
 	if err != nil {
 		return nil, err
@@ -737,11 +752,12 @@ func (a *APICloudProviders) ToService() (interface{}, error) {
 		return nil, err
 	}
 	return evergreen.CloudProviders{
-		AWS:       aws.(evergreen.AWSConfig),
-		Docker:    docker.(evergreen.DockerConfig),
-		GCE:       gce.(evergreen.GCEConfig),
-		OpenStack: openstack.(evergreen.OpenStackConfig),
-		VSphere:   vsphere.(evergreen.VSphereConfig),
+		AWS:        aws.(evergreen.AWSConfig),
+		Docker:     docker.(evergreen.DockerConfig),
+		GCE:        gce.(evergreen.GCEConfig),
+		Kubernetes: kubernetes.(evergreen.KubernetesConfig),
+		OpenStack:  openstack.(evergreen.OpenStackConfig),
+		VSphere:    vsphere.(evergreen.VSphereConfig),
 	}, nil
 }
 
@@ -810,6 +826,56 @@ func (a *APIContainerPool) ToService() (interface{}, error) {
 	}, nil
 }
 
+type APIPriceTableConfig struct {
+	Distros        []APIHourlyPrice `json:"distros"`
+	InstanceTypes  []APIHourlyPrice `json:"instance_types"`
+	ContainerPools []APIHourlyPrice `json:"container_pools"`
+}
+
+type APIHourlyPrice struct {
+	Id    APIString `json:"id"`
+	Price float64   `json:"price"`
+}
+
+func (a *APIPriceTableConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.PriceTableConfig:
+		a.Distros = buildAPIHourlyPrices(v.Distros)
+		a.InstanceTypes = buildAPIHourlyPrices(v.InstanceTypes)
+		a.ContainerPools = buildAPIHourlyPrices(v.ContainerPools)
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIPriceTableConfig) ToService() (interface{}, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return evergreen.PriceTableConfig{
+		Distros:        hourlyPricesToService(a.Distros),
+		InstanceTypes:  hourlyPricesToService(a.InstanceTypes),
+		ContainerPools: hourlyPricesToService(a.ContainerPools),
+	}, nil
+}
+
+func buildAPIHourlyPrices(prices []evergreen.HourlyPrice) []APIHourlyPrice {
+	out := []APIHourlyPrice{}
+	for _, p := range prices {
+		out = append(out, APIHourlyPrice{Id: ToAPIString(p.Id), Price: p.Price})
+	}
+	return out
+}
+
+func hourlyPricesToService(prices []APIHourlyPrice) []evergreen.HourlyPrice {
+	out := []evergreen.HourlyPrice{}
+	for _, p := range prices {
+		out = append(out, evergreen.HourlyPrice{Id: FromAPIString(p.Id), Price: p.Price})
+	}
+	return out
+}
+
 type APIAWSConfig struct {
 	Secret APIString `json:"aws_secret"`
 	Id     APIString `json:"aws_id"`
@@ -882,6 +948,35 @@ func (a *APIGCEConfig) ToService() (interface{}, error) {
 	}, nil
 }
 
+type APIKubernetesConfig struct {
+	APIServer APIString `json:"api_server"`
+	Token     APIString `json:"token"`
+	CACert    APIString `json:"ca_cert"`
+	Namespace APIString `json:"namespace"`
+}
+
+func (a *APIKubernetesConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.KubernetesConfig:
+		a.APIServer = ToAPIString(v.APIServer)
+		a.Token = ToAPIString(v.Token)
+		a.CACert = ToAPIString(v.CACert)
+		a.Namespace = ToAPIString(v.Namespace)
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIKubernetesConfig) ToService() (interface{}, error) {
+	return evergreen.KubernetesConfig{
+		APIServer: FromAPIString(a.APIServer),
+		Token:     FromAPIString(a.Token),
+		CACert:    FromAPIString(a.CACert),
+		Namespace: FromAPIString(a.Namespace),
+	}, nil
+}
+
 type APIOpenStackConfig struct {
 	IdentityEndpoint APIString `json:"identity_endpoint"`
 
@@ -949,10 +1044,86 @@ func (a *APIVSphereConfig) ToService() (interface{}, error) {
 	}, nil
 }
 
+type APIEventRetentionConfig struct {
+	DefaultTTLDays int                       `json:"default_ttl_days"`
+	Policies       []APIEventRetentionPolicy `json:"policies"`
+	Archive        APIString                 `json:"archive"`
+	ArchivePath    APIString                 `json:"archive_path"`
+	BatchSize      int                       `json:"batch_size"`
+}
+
+type APIEventRetentionPolicy struct {
+	ResourceType APIString `json:"resource_type"`
+	TTLDays      int       `json:"ttl_days"`
+}
+
+func (a *APIEventRetentionConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.EventRetentionConfig:
+		a.DefaultTTLDays = v.DefaultTTLDays
+		a.Archive = ToAPIString(v.Archive)
+		a.ArchivePath = ToAPIString(v.ArchivePath)
+		a.BatchSize = v.BatchSize
+		a.Policies = []APIEventRetentionPolicy{}
+		for _, policy := range v.Policies {
+			a.Policies = append(a.Policies, APIEventRetentionPolicy{
+				ResourceType: ToAPIString(policy.ResourceType),
+				TTLDays:      policy.TTLDays,
+			})
+		}
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIEventRetentionConfig) ToService() (interface{}, error) {
+	config := evergreen.EventRetentionConfig{
+		DefaultTTLDays: a.DefaultTTLDays,
+		Archive:        FromAPIString(a.Archive),
+		ArchivePath:    FromAPIString(a.ArchivePath),
+		BatchSize:      a.BatchSize,
+	}
+	for _, policy := range a.Policies {
+		config.Policies = append(config.Policies, evergreen.EventRetentionPolicy{
+			ResourceType: FromAPIString(policy.ResourceType),
+			TTLDays:      policy.TTLDays,
+		})
+	}
+	return config, nil
+}
+
+type APIGitlabConfig struct {
+	URL           APIString `json:"url"`
+	Token         APIString `json:"token"`
+	WebhookSecret APIString `json:"webhook_secret"`
+}
+
+func (a *APIGitlabConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.GitlabConfig:
+		a.URL = ToAPIString(v.URL)
+		a.Token = ToAPIString(v.Token)
+		a.WebhookSecret = ToAPIString(v.WebhookSecret)
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIGitlabConfig) ToService() (interface{}, error) {
+	return evergreen.GitlabConfig{
+		URL:           FromAPIString(a.URL),
+		Token:         FromAPIString(a.Token),
+		WebhookSecret: FromAPIString(a.WebhookSecret),
+	}, nil
+}
+
 type APIRepoTrackerConfig struct {
 	NumNewRepoRevisionsToFetch int `json:"revs_to_fetch"`
 	MaxRepoRevisionsToSearch   int `json:"max_revs_to_search"`
-	MaxConcurrentRequests      int `json:"max_con_requests"`
+	MaxConcurrentRequests      int       `json:"max_con_requests"`
+	MirrorDirectory            APIString `json:"mirror_dir"`
 }
 
 func (a *APIRepoTrackerConfig) BuildFromService(h interface{}) error {
@@ -961,6 +1132,7 @@ func (a *APIRepoTrackerConfig) BuildFromService(h interface{}) error {
 		a.NumNewRepoRevisionsToFetch = v.NumNewRepoRevisionsToFetch
 		a.MaxConcurrentRequests = v.MaxConcurrentRequests
 		a.MaxRepoRevisionsToSearch = v.MaxRepoRevisionsToSearch
+		a.MirrorDirectory = ToAPIString(v.MirrorDirectory)
 	default:
 		return errors.Errorf("%T is not a supported type", h)
 	}
@@ -972,13 +1144,21 @@ func (a *APIRepoTrackerConfig) ToService() (interface{}, error) {
 		NumNewRepoRevisionsToFetch: a.NumNewRepoRevisionsToFetch,
 		MaxConcurrentRequests:      a.MaxConcurrentRequests,
 		MaxRepoRevisionsToSearch:   a.MaxRepoRevisionsToSearch,
+		MirrorDirectory:            FromAPIString(a.MirrorDirectory),
 	}, nil
 }
 
 type APISchedulerConfig struct {
-	TaskFinder       APIString `json:"task_finder"`
-	HostAllocator    APIString `json:"host_allocator"`
-	FreeHostFraction float64   `json:"free_host_fraction"`
+	TaskFinder       APIString          `json:"task_finder"`
+	HostAllocator    APIString          `json:"host_allocator"`
+	FreeHostFraction float64            `json:"free_host_fraction"`
+	TaskPrioritizer  APIString          `json:"task_prioritizer"`
+	ProjectWeights   []APIProjectWeight `json:"project_weights"`
+}
+
+type APIProjectWeight struct {
+	Project APIString `json:"project"`
+	Weight  float64   `json:"weight"`
 }
 
 func (a *APISchedulerConfig) BuildFromService(h interface{}) error {
@@ -987,6 +1167,14 @@ func (a *APISchedulerConfig) BuildFromService(h interface{}) error {
 		a.TaskFinder = ToAPIString(v.TaskFinder)
 		a.HostAllocator = ToAPIString(v.HostAllocator)
 		a.FreeHostFraction = v.FreeHostFraction
+		a.TaskPrioritizer = ToAPIString(v.TaskPrioritizer)
+		a.ProjectWeights = []APIProjectWeight{}
+		for _, w := range v.ProjectWeights {
+			a.ProjectWeights = append(a.ProjectWeights, APIProjectWeight{
+				Project: ToAPIString(w.Project),
+				Weight:  w.Weight,
+			})
+		}
 	default:
 		return errors.Errorf("%T is not a supported type", h)
 	}
@@ -994,11 +1182,19 @@ func (a *APISchedulerConfig) BuildFromService(h interface{}) error {
 }
 
 func (a *APISchedulerConfig) ToService() (interface{}, error) {
-	return evergreen.SchedulerConfig{
+	config := evergreen.SchedulerConfig{
 		TaskFinder:       FromAPIString(a.TaskFinder),
 		HostAllocator:    FromAPIString(a.HostAllocator),
 		FreeHostFraction: a.FreeHostFraction,
-	}, nil
+		TaskPrioritizer:  FromAPIString(a.TaskPrioritizer),
+	}
+	for _, w := range a.ProjectWeights {
+		config.ProjectWeights = append(config.ProjectWeights, evergreen.ProjectWeight{
+			Project: FromAPIString(w.Project),
+			Weight:  w.Weight,
+		})
+	}
+	return config, nil
 }
 
 // APIServiceFlags is a public structure representing the admin service flags
@@ -1011,6 +1207,8 @@ type APIServiceFlags struct {
 	RepotrackerDisabled          bool `json:"repotracker_disabled"`
 	SchedulerDisabled            bool `json:"scheduler_disabled"`
 	GithubPRTestingDisabled      bool `json:"github_pr_testing_disabled"`
+	GitlabMRTestingDisabled      bool `json:"gitlab_mr_testing_disabled"`
+	CommitQueueDisabled          bool `json:"commit_queue_disabled"`
 	RepotrackerPushEventDisabled bool `json:"repotracker_push_event_disabled"`
 	CLIUpdatesDisabled           bool `json:"cli_updates_disabled"`
 	BackgroundStatsDisabled      bool `json:"background_stats_disabled"`
@@ -1023,6 +1221,7 @@ type APIServiceFlags struct {
 	EmailNotificationsDisabled   bool `json:"email_notifications_disabled"`
 	WebhookNotificationsDisabled bool `json:"webhook_notifications_disabled"`
 	GithubStatusAPIDisabled      bool `json:"github_status_api_disabled"`
+	GitlabStatusAPIDisabled      bool `json:"gitlab_status_api_disabled"`
 }
 
 type APISlackConfig struct {
@@ -1204,6 +1403,8 @@ func (as *APIServiceFlags) BuildFromService(h interface{}) error {
 		as.RepotrackerDisabled = v.RepotrackerDisabled
 		as.SchedulerDisabled = v.SchedulerDisabled
 		as.GithubPRTestingDisabled = v.GithubPRTestingDisabled
+		as.GitlabMRTestingDisabled = v.GitlabMRTestingDisabled
+		as.CommitQueueDisabled = v.CommitQueueDisabled
 		as.RepotrackerPushEventDisabled = v.RepotrackerPushEventDisabled
 		as.CLIUpdatesDisabled = v.CLIUpdatesDisabled
 		as.EventProcessingDisabled = v.EventProcessingDisabled
@@ -1212,6 +1413,7 @@ func (as *APIServiceFlags) BuildFromService(h interface{}) error {
 		as.EmailNotificationsDisabled = v.EmailNotificationsDisabled
 		as.WebhookNotificationsDisabled = v.WebhookNotificationsDisabled
 		as.GithubStatusAPIDisabled = v.GithubStatusAPIDisabled
+		as.GitlabStatusAPIDisabled = v.GitlabStatusAPIDisabled
 		as.BackgroundStatsDisabled = v.BackgroundStatsDisabled
 		as.TaskLoggingDisabled = v.TaskLoggingDisabled
 	default:
@@ -1231,6 +1433,8 @@ func (as *APIServiceFlags) ToService() (interface{}, error) {
 		RepotrackerDisabled:          as.RepotrackerDisabled,
 		SchedulerDisabled:            as.SchedulerDisabled,
 		GithubPRTestingDisabled:      as.GithubPRTestingDisabled,
+		GitlabMRTestingDisabled:      as.GitlabMRTestingDisabled,
+		CommitQueueDisabled:          as.CommitQueueDisabled,
 		RepotrackerPushEventDisabled: as.RepotrackerPushEventDisabled,
 		CLIUpdatesDisabled:           as.CLIUpdatesDisabled,
 		EventProcessingDisabled:      as.EventProcessingDisabled,
@@ -1239,6 +1443,7 @@ func (as *APIServiceFlags) ToService() (interface{}, error) {
 		EmailNotificationsDisabled:   as.EmailNotificationsDisabled,
 		WebhookNotificationsDisabled: as.WebhookNotificationsDisabled,
 		GithubStatusAPIDisabled:      as.GithubStatusAPIDisabled,
+		GitlabStatusAPIDisabled:      as.GitlabStatusAPIDisabled,
 		BackgroundStatsDisabled:      as.BackgroundStatsDisabled,
 		TaskLoggingDisabled:          as.TaskLoggingDisabled,
 	}, nil
@@ -61,6 +61,11 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.ContainerPools.Pools[0].MaxContainers, apiSettings.ContainerPools.Pools[0].MaxContainers)
 	assert.EqualValues(testSettings.ContainerPools.Pools[0].Port, apiSettings.ContainerPools.Pools[0].Port)
 	assert.EqualValues(testSettings.AuthConfig.Github.ClientId, FromAPIString(apiSettings.AuthConfig.Github.ClientId))
+	assert.EqualValues(testSettings.EventRetention.DefaultTTLDays, apiSettings.EventRetention.DefaultTTLDays)
+	assert.EqualValues(testSettings.EventRetention.Policies[0].ResourceType, FromAPIString(apiSettings.EventRetention.Policies[0].ResourceType))
+	assert.EqualValues(testSettings.EventRetention.Archive, FromAPIString(apiSettings.EventRetention.Archive))
+	assert.EqualValues(testSettings.Gitlab.URL, FromAPIString(apiSettings.Gitlab.URL))
+	assert.EqualValues(testSettings.Gitlab.WebhookSecret, FromAPIString(apiSettings.Gitlab.WebhookSecret))
 	assert.Equal(len(testSettings.AuthConfig.Github.Users), len(apiSettings.AuthConfig.Github.Users))
 	assert.EqualValues(testSettings.HostInit.SSHTimeoutSeconds, apiSettings.HostInit.SSHTimeoutSeconds)
 	assert.EqualValues(testSettings.Jira.Username, FromAPIString(apiSettings.Jira.Username))
@@ -69,13 +74,19 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.Notify.SMTP.From, FromAPIString(apiSettings.Notify.SMTP.From))
 	assert.EqualValues(testSettings.Notify.SMTP.Port, apiSettings.Notify.SMTP.Port)
 	assert.Equal(len(testSettings.Notify.SMTP.AdminEmail), len(apiSettings.Notify.SMTP.AdminEmail))
+	assert.EqualValues(testSettings.PriceTable.Distros[0].Id, FromAPIString(apiSettings.PriceTable.Distros[0].Id))
+	assert.EqualValues(testSettings.PriceTable.Distros[0].Price, apiSettings.PriceTable.Distros[0].Price)
 	assert.EqualValues(testSettings.Providers.AWS.Id, FromAPIString(apiSettings.Providers.AWS.Id))
 	assert.EqualValues(testSettings.Providers.Docker.APIVersion, FromAPIString(apiSettings.Providers.Docker.APIVersion))
 	assert.EqualValues(testSettings.Providers.GCE.ClientEmail, FromAPIString(apiSettings.Providers.GCE.ClientEmail))
+	assert.EqualValues(testSettings.Providers.Kubernetes.APIServer, FromAPIString(apiSettings.Providers.Kubernetes.APIServer))
 	assert.EqualValues(testSettings.Providers.OpenStack.IdentityEndpoint, FromAPIString(apiSettings.Providers.OpenStack.IdentityEndpoint))
 	assert.EqualValues(testSettings.Providers.VSphere.Host, FromAPIString(apiSettings.Providers.VSphere.Host))
 	assert.EqualValues(testSettings.RepoTracker.MaxConcurrentRequests, apiSettings.RepoTracker.MaxConcurrentRequests)
+	assert.EqualValues(testSettings.RepoTracker.MirrorDirectory, FromAPIString(apiSettings.RepoTracker.MirrorDirectory))
 	assert.EqualValues(testSettings.Scheduler.TaskFinder, FromAPIString(apiSettings.Scheduler.TaskFinder))
+	assert.EqualValues(testSettings.Scheduler.TaskPrioritizer, FromAPIString(apiSettings.Scheduler.TaskPrioritizer))
+	assert.EqualValues(testSettings.Scheduler.ProjectWeights[0].Weight, apiSettings.Scheduler.ProjectWeights[0].Weight)
 	assert.EqualValues(testSettings.ServiceFlags.HostinitDisabled, apiSettings.ServiceFlags.HostinitDisabled)
 	assert.EqualValues(testSettings.Slack.Level, FromAPIString(apiSettings.Slack.Level))
 	assert.EqualValues(testSettings.Slack.Options.Channel, FromAPIString(apiSettings.Slack.Options.Channel))
@@ -100,6 +111,8 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.ContainerPools.Pools[0].Id, dbSettings.ContainerPools.Pools[0].Id)
 	assert.EqualValues(testSettings.ContainerPools.Pools[0].MaxContainers, dbSettings.ContainerPools.Pools[0].MaxContainers)
 	assert.EqualValues(testSettings.ContainerPools.Pools[0].Port, dbSettings.ContainerPools.Pools[0].Port)
+	assert.EqualValues(testSettings.EventRetention, dbSettings.EventRetention)
+	assert.EqualValues(testSettings.Gitlab, dbSettings.Gitlab)
 	assert.EqualValues(testSettings.HostInit.SSHTimeoutSeconds, dbSettings.HostInit.SSHTimeoutSeconds)
 	assert.EqualValues(testSettings.Jira.Username, dbSettings.Jira.Username)
 	assert.EqualValues(testSettings.LoggerConfig.DefaultLevel, dbSettings.LoggerConfig.DefaultLevel)
@@ -107,13 +120,18 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.Notify.SMTP.From, dbSettings.Notify.SMTP.From)
 	assert.EqualValues(testSettings.Notify.SMTP.Port, dbSettings.Notify.SMTP.Port)
 	assert.Equal(len(testSettings.Notify.SMTP.AdminEmail), len(dbSettings.Notify.SMTP.AdminEmail))
+	assert.EqualValues(testSettings.PriceTable, dbSettings.PriceTable)
 	assert.EqualValues(testSettings.Providers.AWS.Id, dbSettings.Providers.AWS.Id)
 	assert.EqualValues(testSettings.Providers.Docker.APIVersion, dbSettings.Providers.Docker.APIVersion)
 	assert.EqualValues(testSettings.Providers.GCE.ClientEmail, dbSettings.Providers.GCE.ClientEmail)
+	assert.EqualValues(testSettings.Providers.Kubernetes.APIServer, dbSettings.Providers.Kubernetes.APIServer)
 	assert.EqualValues(testSettings.Providers.OpenStack.IdentityEndpoint, dbSettings.Providers.OpenStack.IdentityEndpoint)
 	assert.EqualValues(testSettings.Providers.VSphere.Host, dbSettings.Providers.VSphere.Host)
 	assert.EqualValues(testSettings.RepoTracker.MaxConcurrentRequests, dbSettings.RepoTracker.MaxConcurrentRequests)
+	assert.EqualValues(testSettings.RepoTracker.MirrorDirectory, dbSettings.RepoTracker.MirrorDirectory)
 	assert.EqualValues(testSettings.Scheduler.TaskFinder, dbSettings.Scheduler.TaskFinder)
+	assert.EqualValues(testSettings.Scheduler.TaskPrioritizer, dbSettings.Scheduler.TaskPrioritizer)
+	assert.EqualValues(testSettings.Scheduler.ProjectWeights, dbSettings.Scheduler.ProjectWeights)
 	assert.EqualValues(testSettings.ServiceFlags.HostinitDisabled, dbSettings.ServiceFlags.HostinitDisabled)
 	assert.EqualValues(testSettings.Slack.Level, dbSettings.Slack.Level)
 	assert.EqualValues(testSettings.Slack.Options.Channel, dbSettings.Slack.Options.Channel)
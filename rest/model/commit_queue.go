@@ -0,0 +1,93 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/pkg/errors"
+)
+
+// APICommitQueue is the model to be returned by the API whenever a
+// project's commit queue is fetched.
+type APICommitQueue struct {
+	ProjectID APIString            `json:"project_id"`
+	Queue     []APICommitQueueItem `json:"queue"`
+}
+
+// APICommitQueueItem is an item of an APICommitQueue.
+type APICommitQueueItem struct {
+	Issue       APIString `json:"issue"`
+	Version     APIString `json:"version"`
+	EnqueuedBy  APIString `json:"enqueued_by"`
+	EnqueueTime APITime   `json:"enqueue_time"`
+}
+
+// BuildFromService converts from a service level commit queue to an
+// APICommitQueue.
+func (cq *APICommitQueue) BuildFromService(h interface{}) error {
+	var v *commitqueue.CommitQueue
+	switch q := h.(type) {
+	case commitqueue.CommitQueue:
+		v = &q
+	case *commitqueue.CommitQueue:
+		v = q
+	default:
+		return errors.Errorf("incorrect type when converting commit queue type")
+	}
+
+	cq.ProjectID = ToAPIString(v.ProjectID)
+	cq.Queue = make([]APICommitQueueItem, 0, len(v.Queue))
+	for _, item := range v.Queue {
+		apiItem := APICommitQueueItem{}
+		if err := apiItem.BuildFromService(item); err != nil {
+			return err
+		}
+		cq.Queue = append(cq.Queue, apiItem)
+	}
+
+	return nil
+}
+
+// ToService returns a service layer commit queue using the data from
+// APICommitQueue.
+func (cq *APICommitQueue) ToService() (interface{}, error) {
+	queue := commitqueue.CommitQueue{
+		ProjectID: FromAPIString(cq.ProjectID),
+		Queue:     make([]commitqueue.CommitQueueItem, 0, len(cq.Queue)),
+	}
+	for _, apiItem := range cq.Queue {
+		item, err := apiItem.ToService()
+		if err != nil {
+			return nil, err
+		}
+		queue.Queue = append(queue.Queue, item.(commitqueue.CommitQueueItem))
+	}
+
+	return queue, nil
+}
+
+// BuildFromService converts from a service level commit queue item to an
+// APICommitQueueItem.
+func (item *APICommitQueueItem) BuildFromService(h interface{}) error {
+	v, ok := h.(commitqueue.CommitQueueItem)
+	if !ok {
+		return errors.Errorf("incorrect type when converting commit queue item type")
+	}
+	item.Issue = ToAPIString(v.Issue)
+	item.Version = ToAPIString(v.Version)
+	item.EnqueuedBy = ToAPIString(v.EnqueuedBy)
+	item.EnqueueTime = NewTime(v.EnqueueTime)
+
+	return nil
+}
+
+// ToService returns a service layer commit queue item using the data from
+// APICommitQueueItem.
+func (item *APICommitQueueItem) ToService() (interface{}, error) {
+	return commitqueue.CommitQueueItem{
+		Issue:       FromAPIString(item.Issue),
+		Version:     FromAPIString(item.Version),
+		EnqueuedBy:  FromAPIString(item.EnqueuedBy),
+		EnqueueTime: time.Time(item.EnqueueTime),
+	}, nil
+}
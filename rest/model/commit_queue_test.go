@@ -0,0 +1,42 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitQueueBuildFromService(t *testing.T) {
+	assert := assert.New(t)
+	cq := commitqueue.CommitQueue{
+		ProjectID: "mci",
+		Queue: []commitqueue.CommitQueueItem{
+			{Issue: "1", Version: "version", HeadHash: "abcdef", EnqueuedBy: "octocat", EnqueueTime: time.Now().Truncate(time.Millisecond)},
+			{Issue: "2"},
+		},
+	}
+
+	apiQueue := &APICommitQueue{}
+	assert.NoError(apiQueue.BuildFromService(cq))
+	assert.Equal("mci", FromAPIString(apiQueue.ProjectID))
+	require.Len(t, apiQueue.Queue, 2)
+	assert.Equal("1", FromAPIString(apiQueue.Queue[0].Issue))
+	assert.Equal("version", FromAPIString(apiQueue.Queue[0].Version))
+	assert.Equal("octocat", FromAPIString(apiQueue.Queue[0].EnqueuedBy))
+	assert.True(cq.Queue[0].EnqueueTime.Equal(time.Time(apiQueue.Queue[0].EnqueueTime)))
+	assert.Equal("2", FromAPIString(apiQueue.Queue[1].Issue))
+
+	assert.Error(apiQueue.BuildFromService("mci"))
+
+	out, err := apiQueue.ToService()
+	assert.NoError(err)
+	roundTrip, ok := out.(commitqueue.CommitQueue)
+	require.True(t, ok)
+	assert.Equal("mci", roundTrip.ProjectID)
+	require.Len(t, roundTrip.Queue, 2)
+	assert.Equal("1", roundTrip.Queue[0].Issue)
+	assert.Equal("octocat", roundTrip.Queue[0].EnqueuedBy)
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/scheduler"
 	"github.com/pkg/errors"
 )
 
@@ -28,3 +29,101 @@ func (apiDistro *APIDistro) BuildFromService(h interface{}) error {
 func (apiDistro *APIDistro) ToService() (interface{}, error) {
 	return nil, errors.Errorf("ToService() is not impelemented for APIDistro")
 }
+
+// APIDistroQueueExplanation is the model to be returned by the API when
+// explaining how the scheduler would plan a distro's queue.
+type APIDistroQueueExplanation struct {
+	Distro           APIString                    `json:"distro"`
+	TaskFinder       APIString                    `json:"task_finder"`
+	TaskPrioritizer  APIString                    `json:"task_prioritizer"`
+	HostAllocator    APIString                    `json:"host_allocator"`
+	Queue            []APIQueueItemExplanation    `json:"queue"`
+	FilteredTasks    []APIFilteredTaskExplanation `json:"filtered_tasks"`
+	ProjectUsage     map[string]APIDuration       `json:"project_usage,omitempty"`
+	ExpectedDuration APIDuration                  `json:"expected_duration_ms"`
+	NumExistingHosts int                          `json:"num_existing_hosts"`
+	NumFreeHosts     int                          `json:"num_free_hosts"`
+	NewHostsNeeded   int                          `json:"new_hosts_needed"`
+}
+
+// APIQueueItemExplanation is a task in the explained queue, with the
+// comparator that placed it ahead of the next task.
+type APIQueueItemExplanation struct {
+	Id               APIString   `json:"id"`
+	DisplayName      APIString   `json:"display_name"`
+	BuildVariant     APIString   `json:"build_variant"`
+	Project          APIString   `json:"project"`
+	Requester        APIString   `json:"requester"`
+	Priority         int64       `json:"priority"`
+	ExpectedDuration APIDuration `json:"expected_duration_ms"`
+	SortedIn         APIString   `json:"sorted_in"`
+	AheadOf          APIString   `json:"ahead_of"`
+	DecidedBy        APIString   `json:"decided_by"`
+}
+
+// APIFilteredTaskExplanation is a task that was left out of the explained
+// queue, with the reason why.
+type APIFilteredTaskExplanation struct {
+	Id          APIString `json:"id"`
+	DisplayName APIString `json:"display_name"`
+	Project     APIString `json:"project"`
+	Reason      APIString `json:"reason"`
+}
+
+// BuildFromService converts from a scheduler explanation to an
+// APIDistroQueueExplanation.
+func (e *APIDistroQueueExplanation) BuildFromService(h interface{}) error {
+	v, ok := h.(*scheduler.DistroQueueExplanation)
+	if !ok {
+		return errors.Errorf("incorrect type when converting distro queue explanation (%T)", h)
+	}
+
+	e.Distro = ToAPIString(v.DistroID)
+	e.TaskFinder = ToAPIString(v.TaskFinder)
+	e.TaskPrioritizer = ToAPIString(v.TaskPrioritizer)
+	e.HostAllocator = ToAPIString(v.HostAllocator)
+	e.ExpectedDuration = NewAPIDuration(v.ExpectedDuration)
+	e.NumExistingHosts = v.NumExistingHosts
+	e.NumFreeHosts = v.NumFreeHosts
+	e.NewHostsNeeded = v.NewHostsNeeded
+
+	e.Queue = []APIQueueItemExplanation{}
+	for _, item := range v.Queue {
+		e.Queue = append(e.Queue, APIQueueItemExplanation{
+			Id:               ToAPIString(item.Item.Id),
+			DisplayName:      ToAPIString(item.Item.DisplayName),
+			BuildVariant:     ToAPIString(item.Item.BuildVariant),
+			Project:          ToAPIString(item.Item.Project),
+			Requester:        ToAPIString(item.Item.Requester),
+			Priority:         item.Item.Priority,
+			ExpectedDuration: NewAPIDuration(item.Item.ExpectedDuration),
+			SortedIn:         ToAPIString(item.SortedIn),
+			AheadOf:          ToAPIString(item.AheadOf),
+			DecidedBy:        ToAPIString(item.DecidedBy),
+		})
+	}
+
+	e.FilteredTasks = []APIFilteredTaskExplanation{}
+	for _, t := range v.FilteredTasks {
+		e.FilteredTasks = append(e.FilteredTasks, APIFilteredTaskExplanation{
+			Id:          ToAPIString(t.TaskId),
+			DisplayName: ToAPIString(t.DisplayName),
+			Project:     ToAPIString(t.Project),
+			Reason:      ToAPIString(t.Reason),
+		})
+	}
+
+	if len(v.ProjectUsage) > 0 {
+		e.ProjectUsage = make(map[string]APIDuration, len(v.ProjectUsage))
+		for project, usage := range v.ProjectUsage {
+			e.ProjectUsage[project] = NewAPIDuration(usage)
+		}
+	}
+
+	return nil
+}
+
+// ToService is not implemented for APIDistroQueueExplanation.
+func (e *APIDistroQueueExplanation) ToService() (interface{}, error) {
+	return nil, errors.Errorf("ToService() is not implemented for APIDistroQueueExplanation")
+}
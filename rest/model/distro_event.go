@@ -0,0 +1,44 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/pkg/errors"
+)
+
+// APIDistroEvent is a change that a user made to a distro.
+type APIDistroEvent struct {
+	Timestamp time.Time `json:"ts"`
+	EventType APIString `json:"event_type"`
+	DistroID  APIString `json:"distro_id"`
+	User      APIString `json:"user"`
+}
+
+func (e *APIDistroEvent) BuildFromService(h interface{}) error {
+	var entry *event.EventLogEntry
+	switch v := h.(type) {
+	case event.EventLogEntry:
+		entry = &v
+	case *event.EventLogEntry:
+		entry = v
+	default:
+		return fmt.Errorf("%T is not the correct event type", h)
+	}
+
+	data, ok := entry.Data.(*event.DistroEventData)
+	if !ok {
+		return errors.New("unable to convert event type to distro event")
+	}
+	e.Timestamp = entry.Timestamp
+	e.EventType = ToAPIString(entry.EventType)
+	e.DistroID = ToAPIString(entry.ResourceId)
+	e.User = ToAPIString(data.UserId)
+
+	return nil
+}
+
+func (e *APIDistroEvent) ToService() (interface{}, error) {
+	return nil, errors.New("ToService not implemented for APIDistroEvent")
+}
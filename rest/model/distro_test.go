@@ -2,8 +2,11 @@ package model
 
 import (
 	"testing"
+	"time"
 
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/scheduler"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,3 +19,38 @@ func TestDistroBuildFromService(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, FromAPIString(apiDistro.Name), d.Id)
 }
+
+func TestDistroQueueExplanationBuildFromService(t *testing.T) {
+	explanation := &scheduler.DistroQueueExplanation{
+		DistroID:        "d1",
+		TaskPrioritizer: "fairshare",
+		Queue: []scheduler.QueueItemExplanation{
+			{
+				Item:      model.TaskQueueItem{Id: "t1", Project: "p1", ExpectedDuration: time.Minute},
+				SortedIn:  "patch",
+				AheadOf:   "t2",
+				DecidedBy: "byPriority",
+			},
+		},
+		FilteredTasks: []scheduler.FilteredTaskExplanation{
+			{TaskId: "t3", Project: "p2", Reason: "project disabled"},
+		},
+		ProjectUsage:   map[string]time.Duration{"p1": time.Hour},
+		NewHostsNeeded: 2,
+	}
+
+	apiExplanation := &APIDistroQueueExplanation{}
+	assert.NoError(t, apiExplanation.BuildFromService(explanation))
+	assert.Equal(t, "d1", FromAPIString(apiExplanation.Distro))
+	assert.Equal(t, "fairshare", FromAPIString(apiExplanation.TaskPrioritizer))
+	assert.Len(t, apiExplanation.Queue, 1)
+	assert.Equal(t, "t1", FromAPIString(apiExplanation.Queue[0].Id))
+	assert.Equal(t, time.Minute, apiExplanation.Queue[0].ExpectedDuration.ToDuration())
+	assert.Equal(t, "byPriority", FromAPIString(apiExplanation.Queue[0].DecidedBy))
+	assert.Len(t, apiExplanation.FilteredTasks, 1)
+	assert.Equal(t, "project disabled", FromAPIString(apiExplanation.FilteredTasks[0].Reason))
+	assert.Equal(t, time.Hour, apiExplanation.ProjectUsage["p1"].ToDuration())
+	assert.Equal(t, 2, apiExplanation.NewHostsNeeded)
+
+	assert.Error(t, apiExplanation.BuildFromService(*explanation))
+}
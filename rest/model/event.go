@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/pkg/errors"
+)
+
+// APIEvent is an entry in the event log.
+type APIEvent struct {
+	ID           APIString   `json:"id"`
+	ResourceType APIString   `json:"resource_type"`
+	ResourceID   APIString   `json:"resource_id"`
+	EventType    APIString   `json:"event_type"`
+	Timestamp    time.Time   `json:"timestamp"`
+	ProcessedAt  time.Time   `json:"processed_at"`
+	Data         interface{} `json:"data"`
+}
+
+func (e *APIEvent) BuildFromService(h interface{}) error {
+	var entry *event.EventLogEntry
+	switch v := h.(type) {
+	case event.EventLogEntry:
+		entry = &v
+	case *event.EventLogEntry:
+		entry = v
+	default:
+		return errors.Errorf("%T is not the correct event type", h)
+	}
+
+	e.ID = ToAPIString(entry.ID.Hex())
+	e.ResourceType = ToAPIString(entry.ResourceType)
+	e.ResourceID = ToAPIString(entry.ResourceId)
+	e.EventType = ToAPIString(entry.EventType)
+	e.Timestamp = entry.Timestamp
+	e.ProcessedAt = entry.ProcessedAt
+	e.Data = entry.Data
+
+	return nil
+}
+
+func (e *APIEvent) ToService() (interface{}, error) {
+	return nil, errors.New("ToService not implemented for APIEvent")
+}
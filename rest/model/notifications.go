@@ -11,6 +11,9 @@ type APIEventStats struct {
 	LastProcessedAt            time.Time            `json:"last_processed_at"`
 	NumUnprocessedEvents       int                  `json:"unprocessed_events"`
 	PendingNotificationsByType apiNotificationStats `json:"pending_notifications_by_type"`
+	// HeldNotificationsByType counts the notifications waiting to be sent
+	// in digests.
+	HeldNotificationsByType apiNotificationStats `json:"held_notifications_by_type"`
 }
 
 func (n *APIEventStats) BuildFromService(h interface{}) error {
@@ -33,6 +36,8 @@ type apiNotificationStats struct {
 	EvergreenWebhook  int `json:"evergreen_webhook"`
 	Email             int `json:"email"`
 	Slack             int `json:"slack"`
+	MSTeams           int `json:"ms_teams"`
+	ChatWebhook       int `json:"chat_webhook"`
 }
 
 func (n *apiNotificationStats) BuildFromService(h interface{}) error {
@@ -50,6 +55,8 @@ func (n *apiNotificationStats) BuildFromService(h interface{}) error {
 	n.EvergreenWebhook = data.EvergreenWebhook
 	n.Email = data.Email
 	n.Slack = data.Slack
+	n.MSTeams = data.MSTeams
+	n.ChatWebhook = data.ChatWebhook
 
 	return nil
 }
@@ -57,3 +64,57 @@ func (n *apiNotificationStats) BuildFromService(h interface{}) error {
 func (n *apiNotificationStats) ToService() (interface{}, error) {
 	return nil, errors.New("(*apiNotificationsStats) ToService not implemented")
 }
+
+// APINotification is a notification, with the history of attempts to send
+// it if it is a webhook.
+type APINotification struct {
+	ID             APIString            `json:"id"`
+	SubscriptionID APIString            `json:"subscription_id"`
+	SubscriberType APIString            `json:"subscriber_type"`
+	Target         APIString            `json:"target"`
+	SentAt         APITime              `json:"sent_at"`
+	Error          APIString            `json:"error"`
+	RetryAfter     APITime              `json:"retry_after"`
+	Attempts       []APIDeliveryAttempt `json:"attempts"`
+}
+
+type APIDeliveryAttempt struct {
+	Time   APITime   `json:"time"`
+	Error  APIString `json:"error"`
+	Replay bool      `json:"replay"`
+}
+
+func (n *APINotification) BuildFromService(h interface{}) error {
+	var v *notification.Notification
+	switch t := h.(type) {
+	case notification.Notification:
+		v = &t
+	case *notification.Notification:
+		v = t
+	default:
+		return errors.New("can't convert unknown type to APINotification")
+	}
+
+	n.ID = ToAPIString(v.ID)
+	n.SubscriptionID = ToAPIString(v.SubscriptionID)
+	n.SubscriberType = ToAPIString(v.Subscriber.Type)
+	// the subscriber's string includes its type, and never its secret
+	n.Target = ToAPIString(v.Subscriber.String())
+	n.SentAt = NewTime(v.SentAt)
+	n.Error = ToAPIString(v.Error)
+	n.RetryAfter = NewTime(v.RetryAfter)
+	n.Attempts = make([]APIDeliveryAttempt, 0, len(v.Attempts))
+	for _, attempt := range v.Attempts {
+		n.Attempts = append(n.Attempts, APIDeliveryAttempt{
+			Time:   NewTime(attempt.Time),
+			Error:  ToAPIString(attempt.Error),
+			Replay: attempt.Replay,
+		})
+	}
+
+	return nil
+}
+
+func (n *APINotification) ToService() (interface{}, error) {
+	return nil, errors.New("(*APINotification) ToService not implemented")
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/notification"
 	"github.com/stretchr/testify/assert"
 )
@@ -57,3 +58,38 @@ func TestNotificationStats(t *testing.T) {
 	assert.EqualError(err, "(*apiNotificationsStats) ToService not implemented")
 	assert.Implements((*Model)(nil), &stats)
 }
+
+func TestAPINotification(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	n := notification.Notification{
+		ID:             "n",
+		SubscriptionID: "s",
+		Subscriber: event.Subscriber{
+			Type: event.EvergreenWebhookSubscriberType,
+			Target: &event.WebhookSubscriber{
+				URL:    "https://example.com/hook",
+				Secret: []byte("secret"),
+			},
+		},
+		SentAt: now,
+		Error:  "503",
+		Attempts: []notification.DeliveryAttempt{
+			{Time: now, Error: "503"},
+			{Time: now, Error: "503", Replay: true},
+		},
+	}
+
+	apiNotification := APINotification{}
+	assert.NoError(apiNotification.BuildFromService(&n))
+	assert.Equal("n", FromAPIString(apiNotification.ID))
+	assert.Equal("s", FromAPIString(apiNotification.SubscriptionID))
+	assert.Equal("evergreen-webhook-https://example.com/hook", FromAPIString(apiNotification.Target))
+	assert.Equal("503", FromAPIString(apiNotification.Error))
+	if assert.Len(apiNotification.Attempts, 2) {
+		assert.True(apiNotification.Attempts[1].Replay)
+	}
+
+	assert.Error(apiNotification.BuildFromService(5))
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/pkg/errors"
+)
+
+// APISchedulerEvent is the state of a distro's queue and hosts, as logged by
+// the scheduler.
+type APISchedulerEvent struct {
+	Timestamp        time.Time   `json:"ts"`
+	EventType        APIString   `json:"event_type"`
+	DistroID         APIString   `json:"distro_id"`
+	TaskQueueLength  int         `json:"task_queue_length"`
+	ExpectedDuration APIDuration `json:"expected_duration_ms"`
+	MaxWaitTime      APIDuration `json:"max_wait_time_ms"`
+	NumHostsRunning  int         `json:"num_hosts_running"`
+	NumNewHosts      int         `json:"num_new_hosts"`
+	PoolSize         int         `json:"pool_size"`
+	AtPoolSizeCap    bool        `json:"at_pool_size_cap"`
+}
+
+func (e *APISchedulerEvent) BuildFromService(h interface{}) error {
+	var entry *event.EventLogEntry
+	switch v := h.(type) {
+	case event.EventLogEntry:
+		entry = &v
+	case *event.EventLogEntry:
+		entry = v
+	default:
+		return fmt.Errorf("%T is not the correct event type", h)
+	}
+
+	data, ok := entry.Data.(*event.SchedulerEventData)
+	if !ok {
+		return errors.New("unable to convert event type to scheduler event")
+	}
+	e.Timestamp = entry.Timestamp
+	e.EventType = ToAPIString(entry.EventType)
+	e.DistroID = ToAPIString(data.DistroId)
+	e.TaskQueueLength = data.TaskQueueInfo.TaskQueueLength
+	e.ExpectedDuration = NewAPIDuration(data.TaskQueueInfo.ExpectedDuration)
+	e.MaxWaitTime = NewAPIDuration(data.TaskQueueInfo.MaxWaitTime)
+	e.NumHostsRunning = data.TaskQueueInfo.NumHostsRunning
+	e.NumNewHosts = data.TaskQueueInfo.NumNewHosts
+	e.PoolSize = data.TaskQueueInfo.PoolSize
+	e.AtPoolSizeCap = data.TaskQueueInfo.AtPoolSizeCap
+
+	return nil
+}
+
+func (e *APISchedulerEvent) ToService() (interface{}, error) {
+	return nil, errors.New("ToService not implemented for APISchedulerEvent")
+}
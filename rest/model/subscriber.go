@@ -22,9 +22,17 @@ type APIGithubPRSubscriber struct {
 	Ref      APIString `json:"ref" mapstructure:"ref"`
 }
 
+type APIGitlabMRSubscriber struct {
+	ProjectPath APIString `json:"project_path" mapstructure:"project_path"`
+	MRNumber    int       `json:"mr_number" mapstructure:"mr_number"`
+	Ref         APIString `json:"ref" mapstructure:"ref"`
+}
+
 type APIWebhookSubscriber struct {
-	URL    APIString `json:"url" mapstructure:"url"`
-	Secret APIString `json:"secret" mapstructure:"secret"`
+	URL        APIString `json:"url" mapstructure:"url"`
+	Secret     APIString `json:"secret" mapstructure:"secret"`
+	Retries    int       `json:"retries" mapstructure:"retries"`
+	MinDelayMS int       `json:"min_delay_ms" mapstructure:"min_delay_ms"`
 }
 
 func (s *APISubscriber) BuildFromService(h interface{}) error {
@@ -44,6 +52,14 @@ func (s *APISubscriber) BuildFromService(h interface{}) error {
 			}
 			target = sub
 
+		case event.GitlabMergeRequestSubscriberType:
+			sub := APIGitlabMRSubscriber{}
+			err := sub.BuildFromService(v.Target)
+			if err != nil {
+				return err
+			}
+			target = sub
+
 		case event.EvergreenWebhookSubscriberType:
 			sub := APIWebhookSubscriber{}
 			err := sub.BuildFromService(v.Target)
@@ -53,7 +69,8 @@ func (s *APISubscriber) BuildFromService(h interface{}) error {
 			target = sub
 
 		case event.JIRAIssueSubscriberType, event.JIRACommentSubscriberType,
-			event.EmailSubscriberType, event.SlackSubscriberType:
+			event.EmailSubscriberType, event.SlackSubscriberType,
+			event.MSTeamsSubscriberType, event.ChatWebhookSubscriberType:
 			target = v.Target
 
 		default:
@@ -90,6 +107,19 @@ func (s *APISubscriber) ToService() (interface{}, error) {
 			return nil, err
 		}
 
+	case event.GitlabMergeRequestSubscriberType:
+		apiModel := APIGitlabMRSubscriber{}
+		if err := mapstructure.Decode(s.Target, &apiModel); err != nil {
+			return nil, rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "Subscriber target is malformed",
+			}
+		}
+		target, err = apiModel.ToService()
+		if err != nil {
+			return nil, err
+		}
+
 	case event.EvergreenWebhookSubscriberType:
 		apiModel := APIWebhookSubscriber{}
 		if err := mapstructure.Decode(s.Target, &apiModel); err != nil {
@@ -104,7 +134,8 @@ func (s *APISubscriber) ToService() (interface{}, error) {
 		}
 
 	case event.JIRAIssueSubscriberType, event.JIRACommentSubscriberType,
-		event.EmailSubscriberType, event.SlackSubscriberType:
+		event.EmailSubscriberType, event.SlackSubscriberType,
+		event.MSTeamsSubscriberType, event.ChatWebhookSubscriberType:
 		target = s.Target
 
 	default:
@@ -143,6 +174,32 @@ func (s *APIGithubPRSubscriber) ToService() (interface{}, error) {
 	}, nil
 }
 
+func (s *APIGitlabMRSubscriber) BuildFromService(h interface{}) error {
+	if v, ok := h.(event.GitlabMergeRequestSubscriber); ok {
+		h = &v
+	}
+
+	switch v := h.(type) {
+	case *event.GitlabMergeRequestSubscriber:
+		s.ProjectPath = ToAPIString(v.ProjectPath)
+		s.Ref = ToAPIString(v.Ref)
+		s.MRNumber = v.MRNumber
+
+	default:
+		return errors.New("unknown type for APIGitlabMRSubscriber")
+	}
+
+	return nil
+}
+
+func (s *APIGitlabMRSubscriber) ToService() (interface{}, error) {
+	return event.GitlabMergeRequestSubscriber{
+		ProjectPath: FromAPIString(s.ProjectPath),
+		Ref:         FromAPIString(s.Ref),
+		MRNumber:    s.MRNumber,
+	}, nil
+}
+
 func (s *APIWebhookSubscriber) BuildFromService(h interface{}) error {
 	if v, ok := h.(event.WebhookSubscriber); ok {
 		h = &v
@@ -152,6 +209,8 @@ func (s *APIWebhookSubscriber) BuildFromService(h interface{}) error {
 	case *event.WebhookSubscriber:
 		s.URL = ToAPIString(v.URL)
 		s.Secret = ToAPIString(string(v.Secret))
+		s.Retries = v.Retries
+		s.MinDelayMS = v.MinDelayMS
 
 	default:
 		return errors.New("unknown type for APIWebhookSubscriber")
@@ -162,7 +221,9 @@ func (s *APIWebhookSubscriber) BuildFromService(h interface{}) error {
 
 func (s *APIWebhookSubscriber) ToService() (interface{}, error) {
 	return event.WebhookSubscriber{
-		URL:    FromAPIString(s.URL),
-		Secret: []byte(FromAPIString(s.Secret)),
+		URL:        FromAPIString(s.URL),
+		Secret:     []byte(FromAPIString(s.Secret)),
+		Retries:    s.Retries,
+		MinDelayMS: s.MinDelayMS,
 	}, nil
 }
@@ -49,8 +49,10 @@ func TestSubscriberModelsWebhook(t *testing.T) {
 	webhookSubscriber := event.Subscriber{
 		Type: event.EvergreenWebhookSubscriberType,
 		Target: event.WebhookSubscriber{
-			URL:    "foo",
-			Secret: []byte("bar"),
+			URL:        "foo",
+			Secret:     []byte("bar"),
+			Retries:    3,
+			MinDelayMS: 500,
 		},
 	}
 	apiWebhookSubscriber := APISubscriber{}
@@ -65,8 +67,10 @@ func TestSubscriberModelsWebhook(t *testing.T) {
 	incoming := APISubscriber{
 		Type: ToAPIString(event.EvergreenWebhookSubscriberType),
 		Target: map[string]interface{}{
-			"url":    "foo",
-			"secret": "bar",
+			"url":          "foo",
+			"secret":       "bar",
+			"retries":      float64(3),
+			"min_delay_ms": float64(500),
 		},
 	}
 
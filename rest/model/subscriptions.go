@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -22,6 +23,18 @@ type APISubscription struct {
 	OwnerType      APIString         `json:"owner_type"`
 	Owner          APIString         `json:"owner"`
 	TriggerData    map[string]string `json:"trigger_data,omitempty"`
+	Digest         *APIDigest        `json:"digest,omitempty"`
+	Template       *APITemplate      `json:"template,omitempty"`
+}
+
+type APIDigest struct {
+	IntervalMinutes int       `json:"interval_minutes"`
+	GroupBy         APIString `json:"group_by"`
+}
+
+type APITemplate struct {
+	Subject APIString `json:"subject"`
+	Body    APIString `json:"body"`
 }
 
 func (s *APISelector) BuildFromService(h interface{}) error {
@@ -52,6 +65,18 @@ func (s *APISubscription) BuildFromService(h interface{}) error {
 		s.Owner = ToAPIString(v.Owner)
 		s.OwnerType = ToAPIString(string(v.OwnerType))
 		s.TriggerData = v.TriggerData
+		if v.Digest != nil {
+			s.Digest = &APIDigest{
+				IntervalMinutes: v.Digest.IntervalMinutes,
+				GroupBy:         ToAPIString(v.Digest.GroupBy),
+			}
+		}
+		if v.Template != nil {
+			s.Template = &APITemplate{
+				Subject: ToAPIString(v.Template.Subject),
+				Body:    ToAPIString(v.Template.Body),
+			}
+		}
 		err := s.Subscriber.BuildFromService(v.Subscriber)
 		if err != nil {
 			return err
@@ -100,6 +125,18 @@ func (s *APISubscription) ToService() (interface{}, error) {
 		RegexSelectors: []event.Selector{},
 		TriggerData:    s.TriggerData,
 	}
+	if s.Digest != nil {
+		out.Digest = &event.Digest{
+			IntervalMinutes: s.Digest.IntervalMinutes,
+			GroupBy:         FromAPIString(s.Digest.GroupBy),
+		}
+	}
+	if s.Template != nil {
+		out.Template = &event.Template{
+			Subject: FromAPIString(s.Template.Subject),
+			Body:    FromAPIString(s.Template.Body),
+		}
+	}
 	subscriberInterface, err := s.Subscriber.ToService()
 	if err != nil {
 		return nil, err
@@ -134,3 +171,35 @@ func (s *APISubscription) ToService() (interface{}, error) {
 
 	return out, nil
 }
+
+// APISubscriptionPreview is the notification that a subscription would
+// send for an event.
+type APISubscriptionPreview struct {
+	EventID        APIString   `json:"event_id"`
+	ResourceType   APIString   `json:"resource_type"`
+	EventType      APIString   `json:"event_type"`
+	Timestamp      APITime     `json:"timestamp"`
+	SubscriberType APIString   `json:"subscriber_type"`
+	Payload        interface{} `json:"payload"`
+}
+
+func (p *APISubscriptionPreview) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case *event.EventLogEntry:
+		p.EventID = ToAPIString(v.ID.Hex())
+		p.ResourceType = ToAPIString(v.ResourceType)
+		p.EventType = ToAPIString(v.EventType)
+		p.Timestamp = NewTime(v.Timestamp)
+	case *notification.Notification:
+		p.SubscriberType = ToAPIString(v.Subscriber.Type)
+		p.Payload = v.Payload
+	default:
+		return errors.New("unrecognized type for APISubscriptionPreview")
+	}
+
+	return nil
+}
+
+func (p *APISubscriptionPreview) ToService() (interface{}, error) {
+	return nil, errors.New("ToService not implemented for APISubscriptionPreview")
+}
@@ -46,3 +46,63 @@ func TestSubscriptionModels(t *testing.T) {
 	assert.NoError(err)
 	assert.EqualValues(subscription, origSubscription)
 }
+
+func TestSubscriptionDigest(t *testing.T) {
+	assert := assert.New(t)
+	subscription := event.Subscription{
+		ID:             bson.NewObjectId(),
+		Type:           "atype",
+		Trigger:        "atrigger",
+		Owner:          "me",
+		OwnerType:      event.OwnerTypePerson,
+		Selectors:      []event.Selector{},
+		RegexSelectors: []event.Selector{},
+		Subscriber: event.Subscriber{
+			Type:   event.EmailSubscriberType,
+			Target: "email message",
+		},
+		Digest: &event.Digest{
+			IntervalMinutes: 30,
+			GroupBy:         event.DigestGroupByBuild,
+		},
+	}
+
+	apiSubscription := APISubscription{}
+	assert.NoError(apiSubscription.BuildFromService(subscription))
+	assert.Equal(30, apiSubscription.Digest.IntervalMinutes)
+	assert.Equal(event.DigestGroupByBuild, FromAPIString(apiSubscription.Digest.GroupBy))
+
+	origSubscription, err := apiSubscription.ToService()
+	assert.NoError(err)
+	assert.EqualValues(subscription, origSubscription)
+}
+
+func TestSubscriptionTemplate(t *testing.T) {
+	assert := assert.New(t)
+	subscription := event.Subscription{
+		ID:             bson.NewObjectId(),
+		Type:           "atype",
+		Trigger:        "atrigger",
+		Owner:          "me",
+		OwnerType:      event.OwnerTypePerson,
+		Selectors:      []event.Selector{},
+		RegexSelectors: []event.Selector{},
+		Subscriber: event.Subscriber{
+			Type:   event.EmailSubscriberType,
+			Target: "email message",
+		},
+		Template: &event.Template{
+			Subject: "{{ .DisplayName }} {{ .PastTenseStatus }}",
+			Body:    "{{ .URL }}",
+		},
+	}
+
+	apiSubscription := APISubscription{}
+	assert.NoError(apiSubscription.BuildFromService(subscription))
+	assert.Equal("{{ .DisplayName }} {{ .PastTenseStatus }}", FromAPIString(apiSubscription.Template.Subject))
+	assert.Equal("{{ .URL }}", FromAPIString(apiSubscription.Template.Body))
+
+	origSubscription, err := apiSubscription.ToService()
+	assert.NoError(err)
+	assert.EqualValues(subscription, origSubscription)
+}
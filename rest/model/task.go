@@ -33,6 +33,8 @@ type APITask struct {
 	DistroId           APIString        `json:"distro_id"`
 	BuildVariant       APIString        `json:"build_variant"`
 	DependsOn          []string         `json:"depends_on"`
+	DependsOnExpr      APIString        `json:"depends_on_expr"`
+	UnmetDependencies  []string         `json:"unmet_dependencies,omitempty"`
 	DisplayName        APIString        `json:"display_name"`
 	HostId             APIString        `json:"host_id"`
 	Restarts           int              `json:"restarts"`
@@ -97,6 +99,7 @@ func (at *APITask) BuildFromService(t interface{}) error {
 			BuildId:       ToAPIString(v.BuildId),
 			DistroId:      ToAPIString(v.DistroId),
 			BuildVariant:  ToAPIString(v.BuildVariant),
+			DependsOnExpr: ToAPIString(v.DependsOnExpr),
 			DisplayName:   ToAPIString(v.DisplayName),
 			HostId:        ToAPIString(v.HostId),
 			Restarts:      v.Restarts,
@@ -157,6 +160,7 @@ func (ad *APITask) ToService() (interface{}, error) {
 		BuildId:             FromAPIString(ad.BuildId),
 		DistroId:            FromAPIString(ad.DistroId),
 		BuildVariant:        FromAPIString(ad.BuildVariant),
+		DependsOnExpr:       FromAPIString(ad.DependsOnExpr),
 		DisplayName:         FromAPIString(ad.DisplayName),
 		HostId:              FromAPIString(ad.HostId),
 		Restarts:            ad.Restarts,
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/util"
 )
@@ -69,3 +70,49 @@ func (at *APITest) ToService() (interface{}, error) {
 		EndTime:   util.ToPythonTime(time.Time(at.EndTime)),
 	}, nil
 }
+
+// APITestFlakiness is the flakiness score of a test in a task's recent
+// mainline runs.
+type APITestFlakiness struct {
+	Project        APIString `json:"project"`
+	Variant        APIString `json:"variant"`
+	TaskName       APIString `json:"task_name"`
+	TestFile       APIString `json:"test_file"`
+	Runs           int       `json:"runs"`
+	Failures       int       `json:"failures"`
+	Flips          int       `json:"flips"`
+	FlakyRevisions int       `json:"flaky_revisions"`
+	Score          float64   `json:"score"`
+	Flaky          bool      `json:"flaky"`
+}
+
+// BuildFromService converts a task.TestFlakiness into an APITestFlakiness.
+func (at *APITestFlakiness) BuildFromService(st interface{}) error {
+	var v *task.TestFlakiness
+	switch t := st.(type) {
+	case task.TestFlakiness:
+		v = &t
+	case *task.TestFlakiness:
+		v = t
+	default:
+		return fmt.Errorf("Incorrect type when creating APITestFlakiness")
+	}
+
+	at.Project = ToAPIString(v.Project)
+	at.Variant = ToAPIString(v.Variant)
+	at.TaskName = ToAPIString(v.TaskName)
+	at.TestFile = ToAPIString(v.TestFile)
+	at.Runs = v.Runs
+	at.Failures = v.Failures
+	at.Flips = v.Flips
+	at.FlakyRevisions = v.FlakyRevisions
+	at.Score = v.Score
+	at.Flaky = v.IsFlaky(task.FlakyScoreThreshold)
+
+	return nil
+}
+
+// ToService is not implemented for APITestFlakiness.
+func (at *APITestFlakiness) ToService() (interface{}, error) {
+	return nil, fmt.Errorf("ToService not implemented for APITestFlakiness")
+}
@@ -4,9 +4,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/util"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 )
 
 type testCompare struct {
@@ -65,3 +67,33 @@ func TestTestBuildFromService(t *testing.T) {
 		})
 	})
 }
+
+func TestTestFlakinessBuildFromService(t *testing.T) {
+	assert := assert.New(t)
+
+	flakiness := task.TestFlakiness{
+		Project:        "proj",
+		Variant:        "bv",
+		TaskName:       "test",
+		TestFile:       "file",
+		Runs:           10,
+		Failures:       3,
+		Flips:          4,
+		FlakyRevisions: 2,
+		Score:          4.0 / 9,
+	}
+	apiFlakiness := &APITestFlakiness{}
+	assert.NoError(apiFlakiness.BuildFromService(flakiness))
+	assert.Equal("proj", FromAPIString(apiFlakiness.Project))
+	assert.Equal("bv", FromAPIString(apiFlakiness.Variant))
+	assert.Equal("test", FromAPIString(apiFlakiness.TaskName))
+	assert.Equal("file", FromAPIString(apiFlakiness.TestFile))
+	assert.Equal(10, apiFlakiness.Runs)
+	assert.Equal(3, apiFlakiness.Failures)
+	assert.Equal(4, apiFlakiness.Flips)
+	assert.Equal(2, apiFlakiness.FlakyRevisions)
+	assert.Equal(flakiness.Score, apiFlakiness.Score)
+	assert.True(apiFlakiness.Flaky)
+
+	assert.Error(apiFlakiness.BuildFromService("file"))
+}
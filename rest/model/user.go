@@ -34,6 +34,7 @@ type APIUserSettings struct {
 	GithubUser    *APIGithubUser              `json:"github_user"`
 	SlackUsername APIString                   `json:"slack_username"`
 	Notifications *APINotificationPreferences `json:"notifications"`
+	QuietHours    *APIQuietHours              `json:"quiet_hours"`
 }
 
 func (s *APIUserSettings) BuildFromService(h interface{}) error {
@@ -51,6 +52,11 @@ func (s *APIUserSettings) BuildFromService(h interface{}) error {
 		if err != nil {
 			return err
 		}
+		s.QuietHours = &APIQuietHours{}
+		err = s.QuietHours.BuildFromService(v.QuietHours)
+		if err != nil {
+			return err
+		}
 	default:
 		return errors.Errorf("incorrect type for APIUserSettings")
 	}
@@ -74,14 +80,59 @@ func (s *APIUserSettings) ToService() (interface{}, error) {
 	if !ok {
 		return nil, errors.New("unable to convert NotificationPreferences")
 	}
+	quietHoursInterface, err := s.QuietHours.ToService()
+	if err != nil {
+		return nil, err
+	}
+	quietHours, ok := quietHoursInterface.(user.QuietHours)
+	if !ok {
+		return nil, errors.New("unable to convert QuietHours")
+	}
 	return user.UserSettings{
 		Timezone:      FromAPIString(s.Timezone),
 		SlackUsername: FromAPIString(s.SlackUsername),
 		GithubUser:    githubUser,
 		Notifications: preferences,
+		QuietHours:    quietHours,
 	}, nil
 }
 
+type APIQuietHours struct {
+	Enabled   bool `json:"enabled"`
+	StartHour int  `json:"start_hour"`
+	EndHour   int  `json:"end_hour"`
+}
+
+func (q *APIQuietHours) BuildFromService(h interface{}) error {
+	if q == nil {
+		return errors.New("APIQuietHours has not been instantiated")
+	}
+	switch v := h.(type) {
+	case user.QuietHours:
+		q.Enabled = v.Enabled
+		q.StartHour = v.StartHour
+		q.EndHour = v.EndHour
+	default:
+		return errors.Errorf("incorrect type for APIQuietHours")
+	}
+	return nil
+}
+
+func (q *APIQuietHours) ToService() (interface{}, error) {
+	if q == nil {
+		return user.QuietHours{}, nil
+	}
+	quietHours := user.QuietHours{
+		Enabled:   q.Enabled,
+		StartHour: q.StartHour,
+		EndHour:   q.EndHour,
+	}
+	if err := quietHours.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid quiet hours")
+	}
+	return quietHours, nil
+}
+
 type APIGithubUser struct {
 	UID         int       `json:"uid,omitempty"`
 	LastKnownAs APIString `json:"last_known_as,omitempty"`
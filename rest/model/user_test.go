@@ -19,11 +19,32 @@ func TestFullUserSettings(t *testing.T) {
 			BuildBreak:  user.PreferenceEmail,
 			PatchFinish: user.PreferenceSlack,
 		},
+		QuietHours: user.QuietHours{
+			Enabled:   true,
+			StartHour: 22,
+			EndHour:   7,
+		},
 	}
 
 	runTests(t, settings)
 }
 
+func TestInvalidQuietHours(t *testing.T) {
+	apiSettings := APIUserSettings{
+		QuietHours: &APIQuietHours{
+			Enabled:   true,
+			StartHour: 22,
+			EndHour:   22,
+		},
+	}
+	_, err := apiSettings.ToService()
+	assert.Error(t, err)
+
+	apiSettings.QuietHours.Enabled = false
+	_, err = apiSettings.ToService()
+	assert.NoError(t, err)
+}
+
 func TestEmptySettings(t *testing.T) {
 	settings := user.UserSettings{}
 
@@ -25,6 +25,8 @@ type APIVersion struct {
 	Errors   []APIString `json:"errors"`
 	Warnings []APIString `json:"warnings"`
 	Ignored  bool        `json:"ignored"`
+
+	SkippedTasks []skippedTask `json:"skipped_tasks"`
 }
 
 type buildDetail struct {
@@ -32,6 +34,11 @@ type buildDetail struct {
 	BuildId      APIString `json:"build_id"`
 }
 
+type skippedTask struct {
+	BuildVariant APIString `json:"build_variant"`
+	Task         APIString `json:"task"`
+}
+
 // BuildFromService converts from service level structs to an APIVersion.
 func (apiVersion *APIVersion) BuildFromService(h interface{}) error {
 	v, ok := h.(*version.Version)
@@ -61,6 +68,13 @@ func (apiVersion *APIVersion) BuildFromService(h interface{}) error {
 		apiVersion.BuildVariants = append(apiVersion.BuildVariants, bd)
 	}
 
+	for _, t := range v.SkippedTasks {
+		apiVersion.SkippedTasks = append(apiVersion.SkippedTasks, skippedTask{
+			BuildVariant: ToAPIString(t.BuildVariant),
+			Task:         ToAPIString(t.Task),
+		})
+	}
+
 	return nil
 }
 
@@ -51,6 +51,9 @@ func TestVersionBuildFromService(t *testing.T) {
 		Repo:          repo,
 		Branch:        branch,
 		BuildVariants: buildVariants,
+		SkippedTasks: []version.SkippedTask{
+			{BuildVariant: bv1, Task: "docs"},
+		},
 	}
 
 	apiVersion := &APIVersion{}
@@ -75,6 +78,10 @@ func TestVersionBuildFromService(t *testing.T) {
 	assert.Equal(bvs[0].BuildId, ToAPIString(bi1))
 	assert.Equal(bvs[1].BuildVariant, ToAPIString(bv2))
 	assert.Equal(bvs[1].BuildId, ToAPIString(bi2))
+
+	assert.Len(apiVersion.SkippedTasks, 1)
+	assert.Equal(apiVersion.SkippedTasks[0].BuildVariant, ToAPIString(bv1))
+	assert.Equal(apiVersion.SkippedTasks[0].Task, ToAPIString("docs"))
 }
 
 func TestVersionToService(t *testing.T) {
@@ -0,0 +1,111 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for a project's commit queue
+//
+//    /commit_queue/{project_id}
+
+func getCommitQueueRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchProjectContext},
+				Authenticator:     &ProjectAdminAuthenticator{},
+				RequestHandler:    &commitQueueGetHandler{},
+				MethodType:        http.MethodGet,
+			},
+		},
+	}
+}
+
+type commitQueueGetHandler struct {
+	project string
+}
+
+func (cq *commitQueueGetHandler) Handler() RequestHandler {
+	return &commitQueueGetHandler{}
+}
+
+func (cq *commitQueueGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	cq.project = gimlet.GetVars(r)["project_id"]
+	return nil
+}
+
+func (cq *commitQueueGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	commitQueue, err := sc.FindCommitQueueByID(cq.project)
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "can't get commit queue")
+	}
+
+	return ResponseData{
+		Result: []model.Model{commitQueue},
+	}, nil
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for removing an item from a project's commit queue
+//
+//    /commit_queue/{project_id}/{item}
+
+func getCommitQueueDeleteItemRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchProjectContext},
+				Authenticator:     &ProjectAdminAuthenticator{},
+				RequestHandler:    &commitQueueDeleteItemHandler{},
+				MethodType:        http.MethodDelete,
+			},
+		},
+	}
+}
+
+type commitQueueDeleteItemHandler struct {
+	project string
+	item    string
+}
+
+func (cq *commitQueueDeleteItemHandler) Handler() RequestHandler {
+	return &commitQueueDeleteItemHandler{}
+}
+
+func (cq *commitQueueDeleteItemHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	vars := gimlet.GetVars(r)
+	cq.project = vars["project_id"]
+	cq.item = vars["item"]
+
+	return nil
+}
+
+func (cq *commitQueueDeleteItemHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	found, err := sc.CommitQueueRemoveItem(cq.project, cq.item)
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "can't delete item")
+	}
+	if !found {
+		return ResponseData{}, rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("item '%s' not found in the commit queue", cq.item),
+		}
+	}
+
+	return ResponseData{}, nil
+}
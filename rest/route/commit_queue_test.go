@@ -0,0 +1,64 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommitQueueSuite struct {
+	sc *data.MockConnector
+	suite.Suite
+}
+
+func TestCommitQueueSuite(t *testing.T) {
+	suite.Run(t, new(CommitQueueSuite))
+}
+
+func (s *CommitQueueSuite) SetupTest() {
+	s.sc = &data.MockConnector{}
+	for _, issue := range []string{"1", "2", "3"} {
+		_, err := s.sc.EnqueueItem("mci", model.APICommitQueueItem{Issue: model.ToAPIString(issue)})
+		s.Require().NoError(err)
+	}
+}
+
+func (s *CommitQueueSuite) TestGetCommitQueue() {
+	route := &commitQueueGetHandler{project: "mci"}
+	response, err := route.Execute(context.Background(), s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+	cq, ok := response.Result[0].(*model.APICommitQueue)
+	s.Require().True(ok)
+	s.Equal("mci", model.FromAPIString(cq.ProjectID))
+	s.Require().Len(cq.Queue, 3)
+	s.Equal("1", model.FromAPIString(cq.Queue[0].Issue))
+
+	route = &commitQueueGetHandler{project: "other"}
+	response, err = route.Execute(context.Background(), s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+	cq, ok = response.Result[0].(*model.APICommitQueue)
+	s.Require().True(ok)
+	s.Empty(cq.Queue)
+}
+
+func (s *CommitQueueSuite) TestDeleteItem() {
+	route := &commitQueueDeleteItemHandler{project: "mci", item: "2"}
+	_, err := route.Execute(context.Background(), s.sc)
+	s.NoError(err)
+	s.Require().Len(s.sc.MockCommitQueueConnector.Queue["mci"], 2)
+	s.Equal("1", model.FromAPIString(s.sc.MockCommitQueueConnector.Queue["mci"][0].Issue))
+	s.Equal("3", model.FromAPIString(s.sc.MockCommitQueueConnector.Queue["mci"][1].Issue))
+
+	_, err = route.Execute(context.Background(), s.sc)
+	s.Require().Error(err)
+	apiErr, ok := err.(rest.APIError)
+	s.Require().True(ok)
+	s.Equal(http.StatusNotFound, apiErr.StatusCode)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
 	"github.com/pkg/errors"
 )
 
@@ -59,3 +60,62 @@ func (dgh *distroGetHandler) Execute(ctx context.Context, sc data.Connector) (Re
 type clearTaskQueueHandler struct {
 	distro string
 }
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for explaining how the scheduler would plan a distro's queue
+//
+//    /distros/{distro_id}/queue/explain
+
+type distroQueueExplainHandler struct {
+	distroId string
+}
+
+func getDistroQueueExplainRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				Authenticator:  &RequireUserAuthenticator{},
+				RequestHandler: &distroQueueExplainHandler{},
+				MethodType:     http.MethodGet,
+			},
+		},
+		Version: version,
+	}
+}
+
+func (h *distroQueueExplainHandler) Handler() RequestHandler {
+	return &distroQueueExplainHandler{}
+}
+
+func (h *distroQueueExplainHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.distroId = gimlet.GetVars(r)["distro_id"]
+	if h.distroId == "" {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify a distro",
+		}
+	}
+
+	return nil
+}
+
+func (h *distroQueueExplainHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	explanation, err := sc.ExplainDistroQueue(ctx, h.distroId)
+	if err != nil {
+		if _, ok := err.(*rest.APIError); !ok {
+			err = errors.Wrap(err, "problem explaining distro queue")
+		}
+		return ResponseData{}, err
+	}
+
+	explanationModel := &model.APIDistroQueueExplanation{}
+	if err = explanationModel.BuildFromService(explanation); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+
+	return ResponseData{
+		Result: []model.Model{explanationModel},
+	}, nil
+}
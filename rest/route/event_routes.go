@@ -0,0 +1,146 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/pkg/errors"
+)
+
+type eventsArgs struct {
+	filter event.EventFilter
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for querying the event log
+//
+//    /events
+
+func getEventsRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				MethodType:     http.MethodGet,
+				Authenticator:  &SuperUserAuthenticator{},
+				RequestHandler: (&eventsGetHandler{}).Handler(),
+			},
+		},
+	}
+}
+
+type eventsGetHandler struct {
+	PaginationExecutor
+}
+
+func (h *eventsGetHandler) Handler() RequestHandler {
+	return &eventsGetHandler{PaginationExecutor{
+		KeyQueryParam:   "start_at",
+		LimitQueryParam: "limit",
+		Paginator:       eventsPaginator,
+	}}
+}
+
+func (h *eventsGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	vals := r.URL.Query()
+	args := eventsArgs{
+		filter: event.EventFilter{
+			ResourceType: vals.Get("resource_type"),
+			ResourceID:   vals.Get("resource_id"),
+			EventType:    vals.Get("event_type"),
+		},
+	}
+
+	var err error
+	if args.filter.StartTime, err = parseEventTime(vals.Get("start_time"), "start_time"); err != nil {
+		return err
+	}
+	if args.filter.EndTime, err = parseEventTime(vals.Get("end_time"), "end_time"); err != nil {
+		return err
+	}
+	if !args.filter.StartTime.IsZero() && !args.filter.EndTime.IsZero() && !args.filter.EndTime.After(args.filter.StartTime) {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "end_time must be after start_time",
+		}
+	}
+	h.Args = args
+
+	return h.PaginationExecutor.ParseAndValidate(ctx, r)
+}
+
+func parseEventTime(value, param string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	ts, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("%s '%s' is not in RFC-3339 format", param, value),
+		}
+	}
+
+	return ts, nil
+}
+
+func eventsPaginator(key string, limit int, args interface{}, sc data.Connector) ([]model.Model, *PageResult, error) {
+	filter := args.(eventsArgs).filter
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	events, err := sc.FindEvents(filter, key, limit+1, false)
+	if err != nil {
+		if _, ok := err.(rest.APIError); !ok {
+			err = errors.Wrap(err, "database error")
+		}
+		return []model.Model{}, nil, err
+	}
+	prevEvents := []event.EventLogEntry{}
+	if key != "" {
+		prevEvents, err = sc.FindEvents(filter, key, limit, true)
+		if err != nil {
+			if _, ok := err.(rest.APIError); !ok {
+				err = errors.Wrap(err, "database error")
+			}
+			return []model.Model{}, nil, err
+		}
+	}
+
+	pages := &PageResult{}
+	if len(events) > limit {
+		pages.Next = &Page{
+			Relation: "next",
+			Key:      events[limit].ID.Hex(),
+			Limit:    limit,
+		}
+		events = events[:limit]
+	}
+	if len(prevEvents) > 0 {
+		pages.Prev = &Page{
+			Relation: "prev",
+			Key:      prevEvents[len(prevEvents)-1].ID.Hex(),
+			Limit:    len(prevEvents),
+		}
+	}
+
+	models := make([]model.Model, 0, len(events))
+	for i := range events {
+		apiEvent := &model.APIEvent{}
+		if err = apiEvent.BuildFromService(events[i]); err != nil {
+			return []model.Model{}, nil, errors.Wrap(err, "problem converting event")
+		}
+		models = append(models, apiEvent)
+	}
+
+	return models, pages, nil
+}
@@ -0,0 +1,92 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestEventsGetHandler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	now := time.Now().Truncate(time.Second)
+	events := []event.EventLogEntry{}
+	for i := 0; i < 5; i++ {
+		events = append(events, event.EventLogEntry{
+			ID:           bson.NewObjectId(),
+			ResourceType: event.ResourceTypeHost,
+			ResourceId:   "h1",
+			EventType:    event.EventHostCreated,
+			Timestamp:    now.Add(time.Duration(i) * time.Minute),
+			Data:         &event.HostEventData{},
+		})
+	}
+	events = append(events, event.EventLogEntry{
+		ID:           bson.NewObjectId(),
+		ResourceType: event.ResourceTypeHost,
+		ResourceId:   "h2",
+		EventType:    event.EventHostCreated,
+		Timestamp:    now,
+		Data:         &event.HostEventData{},
+	})
+	sc := &data.MockConnector{MockEventConnector: data.MockEventConnector{CachedEvents: events}}
+
+	h := (&eventsGetHandler{}).Handler()
+	r, err := http.NewRequest(http.MethodGet, "/events?resource_type=HOST&resource_id=h1&limit=2", nil)
+	require.NoError(err)
+	require.NoError(h.ParseAndValidate(context.Background(), r))
+	resp, err := h.Execute(context.Background(), sc)
+	require.NoError(err)
+	require.Len(resp.Result, 2)
+	assert.Equal(events[4].ID.Hex(), model.FromAPIString(resp.Result[0].(*model.APIEvent).ID))
+	assert.Equal(events[3].ID.Hex(), model.FromAPIString(resp.Result[1].(*model.APIEvent).ID))
+	pages := resp.Metadata.(*PaginationMetadata).Pages
+	require.NotNil(pages.Next)
+	assert.Equal(events[2].ID.Hex(), pages.Next.Key)
+	assert.Nil(pages.Prev)
+
+	// the second page links back to the first
+	r, err = http.NewRequest(http.MethodGet, "/events?resource_id=h1&limit=2&start_at="+events[2].ID.Hex(), nil)
+	require.NoError(err)
+	require.NoError(h.ParseAndValidate(context.Background(), r))
+	resp, err = h.Execute(context.Background(), sc)
+	require.NoError(err)
+	require.Len(resp.Result, 2)
+	assert.Equal(events[2].ID.Hex(), model.FromAPIString(resp.Result[0].(*model.APIEvent).ID))
+	pages = resp.Metadata.(*PaginationMetadata).Pages
+	require.NotNil(pages.Prev)
+	assert.Equal(events[4].ID.Hex(), pages.Prev.Key)
+	assert.Equal(2, pages.Prev.Limit)
+	require.NotNil(pages.Next)
+	assert.Equal(events[0].ID.Hex(), pages.Next.Key)
+
+	// time ranges are inclusive of the start and exclusive of the end
+	r, err = http.NewRequest(http.MethodGet, "/events?resource_id=h1&start_time="+
+		now.Add(time.Minute).Format(time.RFC3339)+"&end_time="+now.Add(3*time.Minute).Format(time.RFC3339), nil)
+	require.NoError(err)
+	require.NoError(h.ParseAndValidate(context.Background(), r))
+	resp, err = h.Execute(context.Background(), sc)
+	require.NoError(err)
+	require.Len(resp.Result, 2)
+	assert.Equal(events[2].ID.Hex(), model.FromAPIString(resp.Result[0].(*model.APIEvent).ID))
+	assert.Equal(events[1].ID.Hex(), model.FromAPIString(resp.Result[1].(*model.APIEvent).ID))
+
+	r, err = http.NewRequest(http.MethodGet, "/events?start_time=yesterday", nil)
+	require.NoError(err)
+	assert.Error(h.ParseAndValidate(context.Background(), r))
+
+	r, err = http.NewRequest(http.MethodGet, "/events?start_at=nope", nil)
+	require.NoError(err)
+	require.NoError(h.ParseAndValidate(context.Background(), r))
+	_, err = h.Execute(context.Background(), sc)
+	assert.Error(err)
+}
@@ -0,0 +1,272 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/google/go-github/github"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// Pull request comments that start with the command prefix control the
+// patches of the pull request, e.g. "evergreen patch --alias lint".
+const (
+	githubPRCommandPrefix = "evergreen"
+
+	githubPRCommandMerge = "merge"
+	githubPRCommandRetry = "retry"
+	githubPRCommandAbort = "abort"
+	githubPRCommandPatch = "patch"
+	githubPRCommandAdd   = "add"
+	githubPRCommandHelp  = "help"
+
+	githubPRCommandUsage = "Usage:\n" +
+		"* `evergreen merge`: add the pull request to the commit queue\n" +
+		"* `evergreen retry`: restart the failed tasks of the latest patch\n" +
+		"* `evergreen abort`: abort the latest patch\n" +
+		"* `evergreen patch [--alias <alias>]`: create a new patch, optionally running the tasks of an alias\n" +
+		"* `evergreen add <variant>...`: add build variants to the latest patch\n" +
+		"* `evergreen help`: show this message"
+)
+
+// githubPRCommand is a command parsed from a pull request comment.
+type githubPRCommand struct {
+	name     string
+	alias    string
+	variants []string
+}
+
+// parseGithubPRCommand parses the command on the first line of a pull
+// request comment. It returns nil without an error if the comment isn't a
+// command.
+func parseGithubPRCommand(comment string) (*githubPRCommand, error) {
+	line := strings.TrimSpace(comment)
+	if i := strings.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != githubPRCommandPrefix {
+		return nil, nil
+	}
+
+	cmd := &githubPRCommand{name: fields[1]}
+	args := fields[2:]
+	switch cmd.name {
+	case githubPRCommandMerge, githubPRCommandRetry, githubPRCommandAbort, githubPRCommandHelp:
+		if len(args) > 0 {
+			return nil, errors.Errorf("`evergreen %s` takes no arguments", cmd.name)
+		}
+	case githubPRCommandPatch:
+		for i := 0; i < len(args); i++ {
+			switch {
+			case args[i] == "--alias" || args[i] == "-a":
+				if i+1 == len(args) {
+					return nil, errors.Errorf("`%s` requires an alias", args[i])
+				}
+				i++
+				cmd.alias = args[i]
+			case strings.HasPrefix(args[i], "--alias="):
+				cmd.alias = strings.TrimPrefix(args[i], "--alias=")
+			default:
+				return nil, errors.Errorf("unknown argument '%s' to `evergreen patch`", args[i])
+			}
+		}
+	case githubPRCommandAdd:
+		for _, arg := range args {
+			for _, variant := range strings.Split(arg, ",") {
+				if variant != "" {
+					cmd.variants = append(cmd.variants, variant)
+				}
+			}
+		}
+		if len(cmd.variants) == 0 {
+			return nil, errors.New("`evergreen add` requires at least one build variant")
+		}
+	default:
+		// comments that happen to start with "evergreen" aren't commands
+		return nil, nil
+	}
+
+	return cmd, nil
+}
+
+// handlePRCommand runs a command from a pull request comment, if the
+// commenter is authorized to, and replies to the comment with the result.
+// Comments from users who aren't authorized are only logged.
+func (gh *githubHookApi) handlePRCommand(ctx context.Context, sc data.Connector, event *github.IssueCommentEvent, cmd *githubPRCommand, parseErr error) error {
+	owner := event.Repo.GetOwner().GetLogin()
+	repo := event.Repo.GetName()
+	prNum := event.Issue.GetNumber()
+	user := event.Sender.GetLogin()
+	msg := message.Fields{
+		"source":    "github hook",
+		"msg_id":    gh.msgID,
+		"event":     gh.eventType,
+		"repo":      owner + "/" + repo,
+		"pr_number": prNum,
+		"user":      user,
+	}
+
+	// only authorized users get replies, so that commenting on a pull
+	// request doesn't let anyone make evergreen post on it
+	authorized, err := sc.IsAuthorizedToPatchAndMerge(ctx, evergreen.GetEnvironment().Settings(), owner, repo, user)
+	if err != nil {
+		grip.Error(message.WrapError(err, msg))
+		return err
+	}
+	if !authorized {
+		msg["message"] = "user is not authorized to run pull request commands"
+		grip.Info(msg)
+		return nil
+	}
+
+	if parseErr != nil {
+		return gh.replyToPR(ctx, sc, owner, repo, prNum, user, fmt.Sprintf("%s\n\n%s", parseErr.Error(), githubPRCommandUsage))
+	}
+	msg["command"] = cmd.name
+	if cmd.name == githubPRCommandHelp {
+		return gh.replyToPR(ctx, sc, owner, repo, prNum, user, githubPRCommandUsage)
+	}
+
+	reply, err := gh.runPRCommand(ctx, sc, event, cmd)
+	if err != nil {
+		grip.Error(message.WrapError(err, msg))
+		reply = githubPRCommandErrorReply(cmd.name, err)
+	} else {
+		msg["message"] = "ran pull request command"
+		grip.Info(msg)
+	}
+
+	return gh.replyToPR(ctx, sc, owner, repo, prNum, user, reply)
+}
+
+func (gh *githubHookApi) runPRCommand(ctx context.Context, sc data.Connector, event *github.IssueCommentEvent, cmd *githubPRCommand) (string, error) {
+	owner := event.Repo.GetOwner().GetLogin()
+	repo := event.Repo.GetName()
+	prNum := event.Issue.GetNumber()
+	user := event.Sender.GetLogin()
+	caller := fmt.Sprintf("%s (%s)", evergreen.GithubPatchUser, user)
+
+	switch cmd.name {
+	case githubPRCommandMerge:
+		pr, err := sc.GetGitHubPR(ctx, owner, repo, prNum)
+		if err != nil {
+			return "", err
+		}
+		projectRef, err := sc.FindCommitQueueProjectRef(owner, repo, pr.GetBase().GetRef())
+		if err != nil {
+			return "", err
+		}
+		if projectRef == nil {
+			return fmt.Sprintf("the commit queue is not enabled for branch '%s'", pr.GetBase().GetRef()), nil
+		}
+		return gh.commitQueueEnqueue(sc, projectRef, pr, user)
+
+	case githubPRCommandRetry:
+		v, err := sc.RetryGithubPRPatch(owner, repo, prNum, caller)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("restarted the failed tasks of %s", versionURL(v)), nil
+
+	case githubPRCommandAbort:
+		v, err := sc.AbortGithubPRPatch(owner, repo, prNum, caller)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("aborted %s", versionURL(v)), nil
+
+	case githubPRCommandAdd:
+		v, err := sc.AddVariantsToGithubPRPatch(owner, repo, prNum, cmd.variants)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("added %s to %s", strings.Join(cmd.variants, ", "), versionURL(v)), nil
+
+	case githubPRCommandPatch:
+		pr, err := sc.GetGitHubPR(ctx, owner, repo, prNum)
+		if err != nil {
+			return "", err
+		}
+		// the new patch is created as if the pull request was pushed to
+		// now, so that it aborts the patches before it
+		repository := *event.Repo
+		repository.PushedAt = &github.Timestamp{Time: time.Now()}
+		prEvent := &github.PullRequestEvent{
+			Action:      github.String(githubActionSynchronize),
+			Number:      github.Int(prNum),
+			PullRequest: pr,
+			Repo:        &repository,
+			Sender:      event.Sender,
+		}
+
+		var intent patch.Intent
+		if cmd.alias == "" {
+			intent, err = patch.NewGithubIntent(gh.msgID, prEvent)
+		} else {
+			intent, err = patch.NewGithubIntentWithAlias(gh.msgID, cmd.alias, prEvent)
+		}
+		if err != nil {
+			return "", rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    err.Error(),
+			}
+		}
+		if err = sc.AddPatchIntent(intent, gh.queue); err != nil {
+			return "", err
+		}
+		if cmd.alias != "" {
+			return fmt.Sprintf("creating a new patch with alias '%s'", cmd.alias), nil
+		}
+		return "creating a new patch", nil
+	}
+
+	return "", errors.Errorf("unknown command '%s'", cmd.name)
+}
+
+// replyToPR posts a reply to a user on a pull request, unless the reply is
+// empty.
+func (gh *githubHookApi) replyToPR(ctx context.Context, sc data.Connector, owner, repo string, prNum int, user, reply string) error {
+	if reply == "" {
+		return nil
+	}
+
+	err := sc.PostGithubPRComment(ctx, owner, repo, prNum, fmt.Sprintf("@%s %s", user, reply))
+	grip.Error(message.WrapError(err, message.Fields{
+		"source":    "github hook",
+		"msg_id":    gh.msgID,
+		"event":     gh.eventType,
+		"repo":      owner + "/" + repo,
+		"pr_number": prNum,
+		"message":   "can't reply to pull request",
+	}))
+
+	return err
+}
+
+// githubPRCommandErrorReply returns the reply to a command that failed,
+// without exposing internal errors.
+func githubPRCommandErrorReply(name string, err error) string {
+	reason := "an internal error occurred"
+	switch apiErr := errors.Cause(err).(type) {
+	case rest.APIError:
+		reason = apiErr.Message
+	case *rest.APIError:
+		reason = apiErr.Message
+	}
+
+	return fmt.Sprintf("`evergreen %s` failed: %s", name, reason)
+}
+
+func versionURL(versionID string) string {
+	return fmt.Sprintf("%s/version/%s", evergreen.GetEnvironment().Settings().Ui.Url, versionID)
+}
@@ -0,0 +1,58 @@
+package route
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGithubPRCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, comment := range []string{"", "looks good", "evergreen", "evergreen is great", "\\evergreen merge"} {
+		cmd, err := parseGithubPRCommand(comment)
+		assert.NoError(err, comment)
+		assert.Nil(cmd, comment)
+	}
+
+	for _, name := range []string{githubPRCommandMerge, githubPRCommandRetry, githubPRCommandAbort, githubPRCommandHelp} {
+		cmd, err := parseGithubPRCommand("  evergreen " + name + "\nplease")
+		assert.NoError(err)
+		if assert.NotNil(cmd) {
+			assert.Equal(name, cmd.name)
+		}
+
+		cmd, err = parseGithubPRCommand("evergreen " + name + " now")
+		assert.Error(err)
+		assert.Nil(cmd)
+	}
+
+	cmd, err := parseGithubPRCommand("evergreen patch")
+	assert.NoError(err)
+	if assert.NotNil(cmd) {
+		assert.Equal(githubPRCommandPatch, cmd.name)
+		assert.Empty(cmd.alias)
+	}
+	for _, comment := range []string{"evergreen patch --alias lint", "evergreen patch -a lint", "evergreen patch --alias=lint"} {
+		cmd, err = parseGithubPRCommand(comment)
+		assert.NoError(err, comment)
+		if assert.NotNil(cmd, comment) {
+			assert.Equal("lint", cmd.alias, comment)
+		}
+	}
+	for _, comment := range []string{"evergreen patch --alias", "evergreen patch lint"} {
+		cmd, err = parseGithubPRCommand(comment)
+		assert.Error(err, comment)
+		assert.Nil(cmd, comment)
+	}
+
+	cmd, err = parseGithubPRCommand("evergreen add linux, windows,osx")
+	assert.NoError(err)
+	if assert.NotNil(cmd) {
+		assert.Equal(githubPRCommandAdd, cmd.name)
+		assert.Equal([]string{"linux", "windows", "osx"}, cmd.variants)
+	}
+	cmd, err = parseGithubPRCommand("evergreen add ,")
+	assert.Error(err)
+	assert.Nil(cmd)
+}
@@ -2,11 +2,17 @@ package route
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/evergreen-ci/evergreen"
+	dbModel "github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/google/go-github/github"
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/grip"
@@ -18,6 +24,8 @@ const (
 	githubActionOpened      = "opened"
 	githubActionSynchronize = "synchronize"
 	githubActionReopened    = "reopened"
+	githubActionLabeled     = "labeled"
+	githubActionCreated     = "created"
 )
 
 type githubHookApi struct {
@@ -27,6 +35,7 @@ type githubHookApi struct {
 	event     interface{}
 	eventType string
 	msgID     string
+	label     string
 }
 
 func getGithubHooksRouteManager(queue amboy.Queue, secret []byte) routeManagerFactory {
@@ -99,6 +108,17 @@ func (gh *githubHookApi) ParseAndValidate(ctx context.Context, r *http.Request)
 		}
 	}
 
+	// the vendored pull request event doesn't include the label that was
+	// added to the pull request
+	if gh.eventType == "pull_request" {
+		labelEvent := struct {
+			Label *github.Label `json:"label"`
+		}{}
+		if err = json.Unmarshal(body, &labelEvent); err == nil {
+			gh.label = labelEvent.Label.GetName()
+		}
+	}
+
 	return nil
 }
 
@@ -189,11 +209,91 @@ func (gh *githubHookApi) Execute(ctx context.Context, sc data.Connector) (Respon
 			}))
 
 			return ResponseData{}, err
+
+		} else if *event.Action == githubActionLabeled {
+			if event.PullRequest == nil || event.Repo == nil || gh.label == "" {
+				return ResponseData{}, nil
+			}
+
+			owner := event.Repo.GetOwner().GetLogin()
+			repo := event.Repo.GetName()
+			user := event.Sender.GetLogin()
+			// check the label before the user, which needs calls to GitHub
+			projectRef, err := sc.FindCommitQueueProjectRef(owner, repo, event.PullRequest.GetBase().GetRef())
+			if err != nil {
+				return ResponseData{}, err
+			}
+			if projectRef == nil || gh.label != projectRef.CommitQueue.EnqueueLabel {
+				return ResponseData{}, nil
+			}
+
+			authorized, err := sc.IsAuthorizedToPatchAndMerge(ctx, evergreen.GetEnvironment().Settings(), owner, repo, user)
+			if err != nil {
+				return ResponseData{}, err
+			}
+			if !authorized {
+				grip.Info(message.Fields{
+					"source":    "github hook",
+					"msg_id":    gh.msgID,
+					"event":     gh.eventType,
+					"project":   projectRef.Identifier,
+					"pr_number": event.PullRequest.GetNumber(),
+					"user":      user,
+					"message":   "user is not authorized to merge, not adding pull request to the commit queue",
+				})
+				return ResponseData{}, nil
+			}
+
+			reply, err := gh.commitQueueEnqueue(sc, projectRef, event.PullRequest, user)
+			if err != nil {
+				reply = githubPRCommandErrorReply(githubPRCommandMerge, err)
+			}
+
+			return ResponseData{}, gh.replyToPR(ctx, sc, owner, repo, event.PullRequest.GetNumber(), user, reply)
+		}
+
+	case *github.IssueCommentEvent:
+		if event.GetAction() != githubActionCreated || event.Issue == nil || !event.Issue.IsPullRequest() {
+			return ResponseData{}, nil
+		}
+
+		cmd, err := parseGithubPRCommand(event.Comment.GetBody())
+		if cmd == nil && err == nil {
+			return ResponseData{}, nil
 		}
 
+		return ResponseData{}, gh.handlePRCommand(ctx, sc, event, cmd, err)
+
 	case *github.PushEvent:
 		return ResponseData{}, sc.TriggerRepotracker(gh.queue, gh.msgID, event)
 	}
 
 	return ResponseData{}, nil
 }
+
+// commitQueueEnqueue adds a pull request to the project's commit queue.
+// Callers must check that the project has a commit queue and that the user
+// is authorized to merge pull requests. It returns the reply to post on the
+// pull request.
+func (gh *githubHookApi) commitQueueEnqueue(sc data.Connector, projectRef *dbModel.ProjectRef, pr *github.PullRequest, username string) (string, error) {
+	msg := message.Fields{
+		"source":    "github hook",
+		"msg_id":    gh.msgID,
+		"event":     gh.eventType,
+		"project":   projectRef.Identifier,
+		"pr_number": pr.GetNumber(),
+		"user":      username,
+	}
+	pos, err := sc.EnqueueItem(projectRef.Identifier, model.APICommitQueueItem{
+		Issue:      model.ToAPIString(strconv.Itoa(pr.GetNumber())),
+		EnqueuedBy: model.ToAPIString(username),
+	})
+	if err != nil {
+		return "", err
+	}
+	msg["message"] = "added pull request to the commit queue"
+	msg["position"] = pos
+	grip.Info(msg)
+
+	return fmt.Sprintf("added this pull request to the commit queue of '%s' at position %d", projectRef.Identifier, pos+1), nil
+}
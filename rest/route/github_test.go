@@ -13,6 +13,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/rest/data"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/google/go-github/github"
@@ -183,3 +184,200 @@ func (s *GithubWebhookRouteSuite) TestPushEventTriggersRepoTracker() {
 	s.NoError(err)
 	s.Empty(resp.Result)
 }
+
+func (s *GithubWebhookRouteSuite) commitQueueConnector() {
+	s.sc.MockCommitQueueConnector = data.MockCommitQueueConnector{
+		CachedPRs: map[int]*github.PullRequest{
+			1: &github.PullRequest{
+				Number: github.Int(1),
+				Base:   &github.PullRequestBranch{Ref: github.String("master")},
+			},
+		},
+		CachedProjects: []model.ProjectRef{
+			{
+				Identifier:       "mci",
+				Owner:            "evergreen-ci",
+				Repo:             "evergreen",
+				Branch:           "master",
+				Enabled:          true,
+				PRTestingEnabled: true,
+				CommitQueue: model.CommitQueueParams{
+					Enabled:      true,
+					EnqueueLabel: "merge-when-green",
+				},
+			},
+		},
+		AuthorizedUsers: []string{"octocat"},
+	}
+}
+
+func (s *GithubWebhookRouteSuite) makeCommentEvent(user, body string) *github.IssueCommentEvent {
+	return &github.IssueCommentEvent{
+		Action: github.String(githubActionCreated),
+		Issue: &github.Issue{
+			Number:           github.Int(1),
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+		Comment: &github.IssueComment{Body: github.String(body)},
+		Repo: &github.Repository{
+			Name:  github.String("evergreen"),
+			Owner: &github.User{Login: github.String("evergreen-ci")},
+		},
+		Sender: &github.User{Login: github.String(user)},
+	}
+}
+
+func (s *GithubWebhookRouteSuite) TestCommitQueueCommentEnqueues() {
+	s.commitQueueConnector()
+	s.h.event = s.makeCommentEvent("octocat", " evergreen merge\n")
+	s.h.msgID = "1"
+
+	ctx := context.Background()
+	_, err := s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Require().Len(s.sc.MockCommitQueueConnector.Queue["mci"], 1)
+	s.Equal("1", restModel.FromAPIString(s.sc.MockCommitQueueConnector.Queue["mci"][0].Issue))
+	s.Equal("octocat", restModel.FromAPIString(s.sc.MockCommitQueueConnector.Queue["mci"][0].EnqueuedBy))
+
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Len(s.sc.MockCommitQueueConnector.Queue["mci"], 1)
+
+	comments := s.sc.MockGithubPRConnector.Comments[1]
+	s.Require().Len(comments, 2)
+	s.Equal("@octocat added this pull request to the commit queue of 'mci' at position 1", comments[0])
+	s.Equal("@octocat `evergreen merge` failed: item '1' is already in the commit queue", comments[1])
+}
+
+func (s *GithubWebhookRouteSuite) TestCommitQueueCommentIgnored() {
+	s.commitQueueConnector()
+	ctx := context.Background()
+
+	s.h.event = s.makeCommentEvent("octocat", "looks good to me")
+	_, err := s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+
+	s.h.event = s.makeCommentEvent("someone", "evergreen merge")
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+
+	event := s.makeCommentEvent("octocat", "evergreen merge")
+	event.Issue.PullRequestLinks = nil
+	s.h.event = event
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+
+	s.Empty(s.sc.MockCommitQueueConnector.Queue["mci"])
+	s.Empty(s.sc.MockGithubPRConnector.Comments[1])
+}
+
+func (s *GithubWebhookRouteSuite) TestCommitQueueLabelEnqueues() {
+	s.commitQueueConnector()
+	event := &github.PullRequestEvent{
+		Action:      github.String(githubActionLabeled),
+		Number:      github.Int(1),
+		PullRequest: s.sc.MockCommitQueueConnector.CachedPRs[1],
+		Repo: &github.Repository{
+			Name:  github.String("evergreen"),
+			Owner: &github.User{Login: github.String("evergreen-ci")},
+		},
+		Sender: &github.User{Login: github.String("octocat")},
+	}
+	s.h.event = event
+	ctx := context.Background()
+
+	s.h.label = "needs-review"
+	_, err := s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Empty(s.sc.MockCommitQueueConnector.Queue["mci"])
+
+	s.h.label = "merge-when-green"
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Len(s.sc.MockCommitQueueConnector.Queue["mci"], 1)
+	s.Len(s.sc.MockGithubPRConnector.Comments[1], 1)
+}
+
+func (s *GithubWebhookRouteSuite) TestPRCommands() {
+	s.commitQueueConnector()
+	s.sc.MockGithubPRConnector.CachedVersions = map[int]string{1: "version"}
+	ctx := context.Background()
+
+	s.h.event = s.makeCommentEvent("octocat", "evergreen retry")
+	_, err := s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Equal([]string{"version"}, s.sc.MockGithubPRConnector.Retried)
+
+	s.h.event = s.makeCommentEvent("octocat", "evergreen add linux,windows osx")
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Equal([]string{"linux", "windows", "osx"}, s.sc.MockGithubPRConnector.AddedVariants["version"])
+
+	s.h.event = s.makeCommentEvent("octocat", "evergreen abort")
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Equal([]string{"version"}, s.sc.MockGithubPRConnector.Aborted)
+
+	s.h.event = s.makeCommentEvent("someone", "evergreen abort")
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Len(s.sc.MockGithubPRConnector.Aborted, 1)
+
+	s.h.event = s.makeCommentEvent("octocat", "evergreen patch --bogus")
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+
+	// unauthorized users don't get replies, even for usage
+	s.h.event = s.makeCommentEvent("someone", "evergreen patch --bogus")
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.h.event = s.makeCommentEvent("someone", "evergreen help")
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+
+	comments := s.sc.MockGithubPRConnector.Comments[1]
+	s.Require().Len(comments, 4)
+	s.Contains(comments[0], "@octocat restarted the failed tasks of ")
+	s.Contains(comments[0], "/version/version")
+	s.Contains(comments[1], "@octocat added linux, windows, osx to ")
+	s.Contains(comments[2], "@octocat aborted ")
+	s.Contains(comments[3], "unknown argument '--bogus'")
+	s.Contains(comments[3], githubPRCommandUsage)
+}
+
+func (s *GithubWebhookRouteSuite) TestPRCommandWithoutPatch() {
+	s.commitQueueConnector()
+	s.h.event = s.makeCommentEvent("octocat", "evergreen retry")
+	_, err := s.h.Execute(context.Background(), s.sc)
+	s.NoError(err)
+
+	comments := s.sc.MockGithubPRConnector.Comments[1]
+	s.Require().Len(comments, 1)
+	s.Equal("@octocat `evergreen retry` failed: no patch found for evergreen-ci/evergreen#1", comments[0])
+}
+
+func (s *GithubWebhookRouteSuite) TestPRCommandCreatesPatchWithAlias() {
+	s.commitQueueConnector()
+	pr := s.sc.MockCommitQueueConnector.CachedPRs[1]
+	pr.Title = github.String("title")
+	pr.Head = &github.PullRequestBranch{
+		SHA:  github.String("67da19930b1b18d346477e99a8e18094a672f48a"),
+		Repo: &github.Repository{FullName: github.String("octocat/evergreen")},
+	}
+	event := s.makeCommentEvent("octocat", "evergreen patch --alias lint")
+	event.Repo.FullName = github.String("evergreen-ci/evergreen")
+	event.Sender.ID = github.Int(1234)
+	s.h.event = event
+	s.h.msgID = "2"
+
+	_, err := s.h.Execute(context.Background(), s.sc)
+	s.NoError(err)
+
+	s.Require().Len(s.sc.MockPatchIntentConnector.CachedIntents, 1)
+	for _, intent := range s.sc.MockPatchIntentConnector.CachedIntents {
+		s.Equal("lint", intent.GetAlias())
+		s.Equal(1, intent.NewPatch().GithubPatchData.PRNumber)
+		s.Equal("octocat", intent.NewPatch().GithubPatchData.Author)
+	}
+	s.Equal([]string{"@octocat creating a new patch with alias 'lint'"}, s.sc.MockGithubPRConnector.Comments[1])
+}
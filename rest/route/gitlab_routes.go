@@ -0,0 +1,210 @@
+package route
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+)
+
+const (
+	gitlabEventMergeRequest = "Merge Request Hook"
+
+	gitlabActionOpen   = "open"
+	gitlabActionReopen = "reopen"
+	gitlabActionUpdate = "update"
+	gitlabActionClose  = "close"
+	gitlabActionMerge  = "merge"
+
+	// gitlabMaxWebhookSize is the largest webhook body that is read.
+	gitlabMaxWebhookSize = 10 * 1024 * 1024
+)
+
+type gitlabHookApi struct {
+	queue  amboy.Queue
+	secret []byte
+
+	event     *patch.GitlabMergeRequestEvent
+	eventType string
+	msgID     string
+}
+
+func getGitlabHooksRouteManager(queue amboy.Queue, secret []byte) routeManagerFactory {
+	return func(route string, version int) *RouteManager {
+		methods := []MethodHandler{}
+		if len(secret) > 0 {
+			methods = append(methods, MethodHandler{
+				Authenticator: &NoAuthAuthenticator{},
+				RequestHandler: &gitlabHookApi{
+					queue:  queue,
+					secret: secret,
+				},
+				MethodType: http.MethodPost,
+			})
+
+		} else {
+			grip.Warning("Gitlab webhook secret is empty! Gitlab webhooks have been disabled!")
+		}
+
+		return &RouteManager{
+			Route:   route,
+			Methods: methods,
+			Version: version,
+		}
+	}
+}
+
+func (gl *gitlabHookApi) Handler() RequestHandler {
+	return &gitlabHookApi{
+		queue:  gl.queue,
+		secret: gl.secret,
+	}
+}
+
+func (gl *gitlabHookApi) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	gl.eventType = r.Header.Get("X-Gitlab-Event")
+	gl.msgID = r.Header.Get("X-Gitlab-Event-UUID")
+
+	if len(gl.secret) == 0 || gl.queue == nil {
+		return rest.APIError{
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+
+	// GitLab sends the secret itself, rather than a signature of the body
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Gitlab-Token")), gl.secret) != 1 {
+		grip.Error(message.Fields{
+			"source":  "gitlab hook",
+			"message": "rejecting gitlab webhook with an invalid token",
+			"msg_id":  gl.msgID,
+			"event":   gl.eventType,
+		})
+		return rest.APIError{
+			StatusCode: http.StatusUnauthorized,
+			Message:    "invalid gitlab token",
+		}
+	}
+
+	if gl.eventType != gitlabEventMergeRequest {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, gitlabMaxWebhookSize))
+	if err != nil {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "failed to read request body",
+		}
+	}
+
+	gl.event = &patch.GitlabMergeRequestEvent{}
+	if err = json.Unmarshal(body, gl.event); err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"source":  "gitlab hook",
+			"msg_id":  gl.msgID,
+			"event":   gl.eventType,
+			"message": "rejecting gitlab webhook",
+		}))
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	// older versions of GitLab don't identify events, so identify the
+	// event by the revision that it's for
+	if gl.msgID == "" {
+		gl.msgID = fmt.Sprintf("%s-%d-%s", gl.event.Project.PathWithNamespace,
+			gl.event.ObjectAttributes.IID, gl.event.ObjectAttributes.LastCommit.ID)
+	}
+
+	return nil
+}
+
+func (gl *gitlabHookApi) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	if gl.event == nil {
+		grip.Info(message.Fields{
+			"source":  "gitlab hook",
+			"msg_id":  gl.msgID,
+			"event":   gl.eventType,
+			"message": "ignoring gitlab webhook",
+		})
+		return ResponseData{}, nil
+	}
+
+	attrs := gl.event.ObjectAttributes
+	switch attrs.Action {
+	case gitlabActionOpen, gitlabActionReopen, gitlabActionUpdate:
+		// updates that don't push new commits, like a new title, don't
+		// need a new patch
+		if attrs.Action == gitlabActionUpdate && attrs.OldRev == "" {
+			return ResponseData{}, nil
+		}
+
+		intent, err := patch.NewGitlabIntent(gl.msgID, gl.event)
+		if err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"source":  "gitlab hook",
+				"msg_id":  gl.msgID,
+				"event":   gl.eventType,
+				"action":  attrs.Action,
+				"message": "failed to create intent",
+			}))
+			return ResponseData{}, &rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    err.Error(),
+			}
+		}
+
+		grip.Info(message.Fields{
+			"source":    "gitlab hook",
+			"msg_id":    gl.msgID,
+			"event":     gl.eventType,
+			"action":    attrs.Action,
+			"message":   "mr accepted, attempting to queue",
+			"project":   gl.event.Project.PathWithNamespace,
+			"ref":       attrs.TargetBranch,
+			"mr_number": attrs.IID,
+			"creator":   gl.event.User.Username,
+			"hash":      attrs.LastCommit.ID,
+		})
+
+		if err = sc.AddPatchIntent(intent, gl.queue); err != nil {
+			return ResponseData{}, &rest.APIError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    err.Error(),
+			}
+		}
+
+	case gitlabActionClose, gitlabActionMerge:
+		grip.Info(message.Fields{
+			"source":  "gitlab hook",
+			"msg_id":  gl.msgID,
+			"event":   gl.eventType,
+			"action":  attrs.Action,
+			"message": "merge request closed; aborting patch",
+		})
+
+		err := sc.AbortPatchesFromMergeRequest(gl.event)
+		grip.ErrorWhen(err != nil, message.WrapError(err, message.Fields{
+			"source":  "gitlab hook",
+			"msg_id":  gl.msgID,
+			"event":   gl.eventType,
+			"action":  attrs.Action,
+			"message": "failed to abort patches",
+		}))
+
+		return ResponseData{}, err
+	}
+
+	return ResponseData{}, nil
+}
@@ -0,0 +1,171 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/mongodb/amboy/queue"
+	"github.com/stretchr/testify/suite"
+)
+
+type GitlabWebhookRouteSuite struct {
+	sc     *data.MockConnector
+	rm     *RouteManager
+	secret []byte
+	mrBody []byte
+	h      *gitlabHookApi
+	suite.Suite
+}
+
+func TestGitlabWebhookRouteSuite(t *testing.T) {
+	suite.Run(t, new(GitlabWebhookRouteSuite))
+}
+
+func (s *GitlabWebhookRouteSuite) SetupTest() {
+	s.secret = []byte("gitlab-secret")
+	s.rm = getGitlabHooksRouteManager(queue.NewLocalUnordered(1), s.secret)("", 2)
+	s.Require().Len(s.rm.Methods, 1)
+	s.sc = &data.MockConnector{MockPatchIntentConnector: data.MockPatchIntentConnector{
+		CachedIntents: map[data.MockPatchIntentKey]patch.Intent{},
+	}}
+
+	var err error
+	s.mrBody, err = ioutil.ReadFile(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "gitlab_merge_request.json"))
+	s.Require().NoError(err)
+
+	var ok bool
+	s.h, ok = s.rm.Methods[0].Handler().(*gitlabHookApi)
+	s.Require().True(ok)
+}
+
+func (s *GitlabWebhookRouteSuite) makeRequest(msgID, token string, body []byte) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, "http://example.com/rest/v2/hooks/gitlab", bytes.NewBuffer(body))
+	s.Require().NoError(err)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("X-Gitlab-Event", gitlabEventMergeRequest)
+	req.Header.Add("X-Gitlab-Token", token)
+	if msgID != "" {
+		req.Header.Add("X-Gitlab-Event-UUID", msgID)
+	}
+
+	return req
+}
+
+func (s *GitlabWebhookRouteSuite) TestNoRouteWithoutSecret() {
+	rm := getGitlabHooksRouteManager(queue.NewLocalUnordered(1), nil)("", 2)
+	s.Empty(rm.Methods)
+}
+
+func (s *GitlabWebhookRouteSuite) TestParseAndValidate() {
+	ctx := context.Background()
+	s.NoError(s.h.ParseAndValidate(ctx, s.makeRequest("1", string(s.secret), s.mrBody)))
+	s.Equal("1", s.h.msgID)
+	s.Require().NotNil(s.h.event)
+	s.Equal("evergreen-ci/evergreen", s.h.event.Project.PathWithNamespace)
+	s.Equal(7, s.h.event.ObjectAttributes.IID)
+	s.Equal("da1560886d4f094c3e6c9ef40349f7d38b5d27d7", s.h.event.ObjectAttributes.LastCommit.ID)
+}
+
+func (s *GitlabWebhookRouteSuite) TestParseAndValidateWithoutEventID() {
+	s.NoError(s.h.ParseAndValidate(context.Background(), s.makeRequest("", string(s.secret), s.mrBody)))
+	s.Equal("evergreen-ci/evergreen-7-da1560886d4f094c3e6c9ef40349f7d38b5d27d7", s.h.msgID)
+}
+
+func (s *GitlabWebhookRouteSuite) TestParseAndValidateFailsWithInvalidToken() {
+	ctx := context.Background()
+	err := s.h.ParseAndValidate(ctx, s.makeRequest("1", "wrong", s.mrBody))
+	s.Require().Error(err)
+	apiErr, ok := err.(rest.APIError)
+	s.True(ok)
+	s.Equal(http.StatusUnauthorized, apiErr.StatusCode)
+
+	req := s.makeRequest("1", "", s.mrBody)
+	req.Header.Del("X-Gitlab-Token")
+	s.Error(s.h.ParseAndValidate(ctx, req))
+}
+
+func (s *GitlabWebhookRouteSuite) TestParseAndValidateFailsWithMalformedBody() {
+	s.Error(s.h.ParseAndValidate(context.Background(), s.makeRequest("1", string(s.secret), []byte("{"))))
+}
+
+func (s *GitlabWebhookRouteSuite) TestOtherEventsAreIgnored() {
+	ctx := context.Background()
+	req := s.makeRequest("1", string(s.secret), []byte(`{"object_kind": "push"}`))
+	req.Header.Set("X-Gitlab-Event", "Push Hook")
+	s.NoError(s.h.ParseAndValidate(ctx, req))
+	s.Nil(s.h.event)
+
+	_, err := s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Empty(s.sc.MockPatchIntentConnector.CachedIntents)
+}
+
+func (s *GitlabWebhookRouteSuite) TestAddIntent() {
+	ctx := context.Background()
+	s.Require().NoError(s.h.ParseAndValidate(ctx, s.makeRequest("1", string(s.secret), s.mrBody)))
+
+	resp, err := s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Empty(resp.Result)
+	s.Require().Len(s.sc.MockPatchIntentConnector.CachedIntents, 1)
+	for _, intent := range s.sc.MockPatchIntentConnector.CachedIntents {
+		s.Equal(patch.GitlabIntentType, intent.GetType())
+		s.Equal("1", intent.ID())
+		s.Equal(7, intent.NewPatch().GitlabPatchData.MRNumber)
+	}
+
+	// the same event can't be queued twice
+	_, err = s.h.Execute(ctx, s.sc)
+	s.Error(err)
+	s.Len(s.sc.MockPatchIntentConnector.CachedIntents, 1)
+}
+
+func (s *GitlabWebhookRouteSuite) TestUpdates() {
+	ctx := context.Background()
+	s.Require().NoError(s.h.ParseAndValidate(ctx, s.makeRequest("1", string(s.secret), s.mrBody)))
+
+	// an update that doesn't push new commits doesn't create a patch
+	s.h.event.ObjectAttributes.Action = gitlabActionUpdate
+	_, err := s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Empty(s.sc.MockPatchIntentConnector.CachedIntents)
+
+	s.h.event.ObjectAttributes.OldRev = "4aa8f8b1d3f6b5c3b5a5d3f2e6a2c1b0a9f8e7d6"
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Len(s.sc.MockPatchIntentConnector.CachedIntents, 1)
+}
+
+func (s *GitlabWebhookRouteSuite) TestCloseAbortsPatches() {
+	ctx := context.Background()
+	s.Require().NoError(s.h.ParseAndValidate(ctx, s.makeRequest("1", string(s.secret), s.mrBody)))
+
+	for _, action := range []string{gitlabActionClose, gitlabActionMerge} {
+		s.h.event.ObjectAttributes.Action = action
+		_, err := s.h.Execute(ctx, s.sc)
+		s.NoError(err)
+	}
+	s.Empty(s.sc.MockPatchIntentConnector.CachedIntents)
+
+	s.h.event.ObjectAttributes.IID = 0
+	_, err := s.h.Execute(ctx, s.sc)
+	s.Error(err)
+}
+
+func (s *GitlabWebhookRouteSuite) TestInvalidMergeRequest() {
+	ctx := context.Background()
+	s.Require().NoError(s.h.ParseAndValidate(ctx, s.makeRequest("1", string(s.secret), s.mrBody)))
+
+	s.h.event.ObjectAttributes.LastCommit.ID = ""
+	_, err := s.h.Execute(ctx, s.sc)
+	s.Error(err)
+	s.Empty(s.sc.MockPatchIntentConnector.CachedIntents)
+}
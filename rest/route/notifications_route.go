@@ -3,9 +3,17 @@ package route
 import (
 	"context"
 	"net/http"
+	"strconv"
 
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/auth"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
 )
 
 func getNotificationsStatusRouteManager(route string, version int) *RouteManager {
@@ -40,3 +48,176 @@ func (gh *notificationsStatusHandler) Execute(ctx context.Context, sc data.Conne
 
 	return ResponseData{Result: []model.Model{stats}}, nil
 }
+
+// canManageSubscription returns true if the user owns the subscription, or
+// is a superuser.
+func canManageSubscription(ctx context.Context, sc data.Connector, subscriptionID string) (bool, error) {
+	u := MustHaveUser(ctx)
+	if auth.IsSuperUser(sc.GetSuperUsers(), u) {
+		return true, nil
+	}
+	if len(subscriptionID) == 0 {
+		return false, nil
+	}
+
+	sub, err := event.FindSubscriptionByIDString(subscriptionID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to find subscription")
+	}
+
+	return sub != nil && sub.OwnerType == event.OwnerTypePerson && sub.Owner == u.Username(), nil
+}
+
+func getFailedWebhooksRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				Authenticator:  &RequireUserAuthenticator{},
+				RequestHandler: &failedWebhooksHandler{},
+				MethodType:     http.MethodGet,
+			},
+		},
+		Version: version,
+	}
+}
+
+// failedWebhooksHandler lists the webhook notifications that failed and
+// won't be retried, for a subscription, or for all subscriptions if the
+// user is a superuser.
+type failedWebhooksHandler struct {
+	subscriptionID string
+	limit          int
+}
+
+func (h *failedWebhooksHandler) Handler() RequestHandler {
+	return &failedWebhooksHandler{}
+}
+
+func (h *failedWebhooksHandler) ParseAndValidate(_ context.Context, r *http.Request) error {
+	h.subscriptionID = r.URL.Query().Get("subscription_id")
+	h.limit = 100
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		var err error
+		h.limit, err = strconv.Atoi(limit)
+		if err != nil || h.limit <= 0 {
+			return rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "Invalid limit",
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *failedWebhooksHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	ok, err := canManageSubscription(ctx, sc, h.subscriptionID)
+	if err != nil {
+		return ResponseData{}, err
+	}
+	if !ok {
+		return ResponseData{}, rest.APIError{
+			StatusCode: http.StatusUnauthorized,
+			Message:    "Cannot view failed notifications of subscriptions that aren't yours",
+		}
+	}
+
+	notifications, err := sc.GetFailedWebhooks(h.subscriptionID, h.limit)
+	if err != nil {
+		return ResponseData{}, err
+	}
+
+	models := make([]model.Model, len(notifications))
+	for i := range notifications {
+		models[i] = &notifications[i]
+	}
+
+	return ResponseData{Result: models}, nil
+}
+
+func getNotificationReplayRouteManager(route string, version int) *RouteManager {
+	h := &notificationReplayHandler{}
+
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				Authenticator:  &RequireUserAuthenticator{},
+				RequestHandler: h.Handler(),
+				MethodType:     http.MethodPost,
+			},
+		},
+		Version: version,
+	}
+}
+
+// notificationReplayHandler sends a failed webhook notification, or all of
+// a subscription's failed webhooks, again.
+type notificationReplayHandler struct {
+	NotificationID string `json:"notification_id"`
+	SubscriptionID string `json:"subscription_id"`
+}
+
+func (h *notificationReplayHandler) Handler() RequestHandler {
+	return &notificationReplayHandler{}
+}
+
+func (h *notificationReplayHandler) ParseAndValidate(_ context.Context, r *http.Request) error {
+	if err := util.ReadJSONInto(r.Body, h); err != nil {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Error parsing request body: " + err.Error(),
+		}
+	}
+	if (len(h.NotificationID) == 0) == (len(h.SubscriptionID) == 0) {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Must specify either a notification ID or a subscription ID",
+		}
+	}
+
+	if len(h.NotificationID) != 0 {
+		n, err := notification.Find(h.NotificationID)
+		if err != nil {
+			return rest.APIError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    err.Error(),
+			}
+		}
+		if n == nil {
+			return rest.APIError{
+				StatusCode: http.StatusNotFound,
+				Message:    "Notification not found",
+			}
+		}
+		h.SubscriptionID = n.SubscriptionID
+	}
+
+	return nil
+}
+
+func (h *notificationReplayHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	ok, err := canManageSubscription(ctx, sc, h.SubscriptionID)
+	if err != nil {
+		return ResponseData{}, err
+	}
+	if !ok {
+		return ResponseData{}, rest.APIError{
+			StatusCode: http.StatusUnauthorized,
+			Message:    "Cannot replay notifications of subscriptions that aren't yours",
+		}
+	}
+
+	notifications, err := sc.ReplayNotifications(evergreen.GetEnvironment().RemoteQueue(), h.NotificationID, h.SubscriptionID)
+	if err != nil {
+		return ResponseData{}, err
+	}
+
+	models := make([]model.Model, len(notifications))
+	for i := range notifications {
+		models[i] = &notifications[i]
+	}
+
+	return ResponseData{Result: models}, nil
+}
@@ -9,18 +9,18 @@ import (
 // AttachHandler attaches the api's request handlers to the given mux router.
 // It builds a Connector then attaches each of the main functions for
 // the api to the router.
-func AttachHandler(app *gimlet.APIApp, queue amboy.Queue, URL string, superUsers []string, githubSecret []byte) {
+func AttachHandler(app *gimlet.APIApp, queue amboy.Queue, URL string, superUsers []string, githubSecret, gitlabSecret []byte) {
 	sc := &data.DBConnector{}
 
 	sc.SetURL(URL)
 	sc.SetSuperUsers(superUsers)
-	GetHandler(app, sc, queue, githubSecret)
+	GetHandler(app, sc, queue, githubSecret, gitlabSecret)
 }
 
 // GetHandler builds each of the functions that this api implements and then
 // registers them on the given router. It then returns the given router as an
 // http handler which can be given more functions.
-func GetHandler(app *gimlet.APIApp, sc data.Connector, queue amboy.Queue, githubSecret []byte) {
+func GetHandler(app *gimlet.APIApp, sc data.Connector, queue amboy.Queue, githubSecret, gitlabSecret []byte) {
 	routes := map[string]routeManagerFactory{
 		"/admin":                             getLegacyAdminSettingsManager,
 		"/admin/banner":                      getBannerRouteManager,
@@ -34,11 +34,16 @@ func GetHandler(app *gimlet.APIApp, sc data.Connector, queue amboy.Queue, github
 		"/builds/{build_id}/abort":           getBuildAbortRouteManager,
 		"/builds/{build_id}/restart":         getBuildRestartManager,
 		"/builds/{build_id}/tasks":           getTasksByBuildRouteManager,
+		"/commit_queue/{project_id}":         getCommitQueueRouteManager,
+		"/commit_queue/{project_id}/{item}":  getCommitQueueDeleteItemRouteManager,
 		"/cost/distro/{distro_id}":           getCostByDistroIdRouteManager,
 		"/cost/project/{project_id}/tasks":   getCostTaskByProjectRouteManager,
 		"/cost/version/{version_id}":         getCostByVersionIdRouteManager,
 		"/distros":                           getDistroRouteManager,
+		"/distros/{distro_id}/queue/explain": getDistroQueueExplainRouteManager,
+		"/events":                            getEventsRouteManager,
 		"/hooks/github":                      getGithubHooksRouteManager(queue, githubSecret),
+		"/hooks/gitlab":                      getGitlabHooksRouteManager(queue, gitlabSecret),
 		"/hosts":                             getHostRouteManager,
 		"/hosts/{host_id}":                   getHostIDRouteManager,
 		"/hosts/{host_id}/change_password":   getHostChangeRDPPasswordRouteManager,
@@ -53,11 +58,15 @@ func GetHandler(app *gimlet.APIApp, sc data.Connector, queue amboy.Queue, github
 		"/projects/{project_id}/patches":                       getPatchesByProjectManager,
 		"/projects/{project_id}/recent_versions":               getRecentVersionsManager,
 		"/projects/{project_id}/revisions/{commit_hash}/tasks": getTasksByProjectAndCommitRouteManager,
+		"/projects/{project_id}/test_flakiness":                getTestFlakinessRouteManager,
 		"/status/cli_version":                                  getCLIVersionRouteManager,
 		"/status/notifications":                                getNotificationsStatusRouteManager,
+		"/notifications/failed_webhooks":                       getFailedWebhooksRouteManager,
+		"/notifications/replay":                                getNotificationReplayRouteManager,
 		"/status/hosts/distros":                                getHostStatsByDistroManager,
 		"/status/recent_tasks":                                 getRecentTasksRouteManager,
 		"/subscriptions":                                       getSubscriptionRouteManager,
+		"/subscriptions/preview":                               getSubscriptionPreviewRouteManager,
 		"/tasks/{task_id}":                                     getTaskRouteManager,
 		"/tasks/{task_id}/abort":                               getTaskAbortManager,
 		"/tasks/{task_id}/generate":                            getGenerateManager,
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"net/http"
 
+	dbModel "github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/trigger"
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
 )
 
 func getSubscriptionRouteManager(route string, version int) *RouteManager {
@@ -98,6 +101,14 @@ func (s *subscriptionPostHandler) ParseAndValidate(ctx context.Context, r *http.
 			}
 		}
 
+		err = trigger.ValidateTemplate(dbSubscription.Template, dbSubscription.Subscriber.Type)
+		if err != nil {
+			return &rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "Error validating template: " + err.Error(),
+			}
+		}
+
 		s.dbSubscriptions = append(s.dbSubscriptions, dbSubscription)
 	}
 
@@ -211,7 +222,14 @@ func (s *subscriptionDeleteHandler) ParseAndValidate(ctx context.Context, r *htt
 			Message:    "Subscription not found",
 		}
 	}
-	if subscription.Owner != u.Username() {
+	canDelete, err := isSubscriptionOwner(subscription, u.Username())
+	if err != nil {
+		return rest.APIError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    err.Error(),
+		}
+	}
+	if !canDelete {
 		return rest.APIError{
 			StatusCode: http.StatusUnauthorized,
 			Message:    "Cannot delete subscriptions for someone other than yourself",
@@ -221,6 +239,24 @@ func (s *subscriptionDeleteHandler) ParseAndValidate(ctx context.Context, r *htt
 	return nil
 }
 
+// isSubscriptionOwner returns true if the subscription belongs to the user,
+// or to a project that the user is an admin of.
+func isSubscriptionOwner(subscription *event.Subscription, username string) (bool, error) {
+	if subscription.Owner == username {
+		return true, nil
+	}
+	if subscription.OwnerType != event.OwnerTypeProject {
+		return false, nil
+	}
+
+	projectRef, err := dbModel.FindOneProjectRef(subscription.Owner)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to find project '%s'", subscription.Owner)
+	}
+
+	return projectRef != nil && util.StringSliceContains(projectRef.Admins, username), nil
+}
+
 func (s *subscriptionDeleteHandler) Execute(_ context.Context, sc data.Connector) (ResponseData, error) {
 	err := sc.DeleteSubscription(s.id)
 	if err != nil {
@@ -229,3 +265,84 @@ func (s *subscriptionDeleteHandler) Execute(_ context.Context, sc data.Connector
 
 	return ResponseData{}, nil
 }
+
+func getSubscriptionPreviewRouteManager(route string, version int) *RouteManager {
+	h := &subscriptionPreviewHandler{}
+
+	postHandler := MethodHandler{
+		Authenticator:  &RequireUserAuthenticator{},
+		RequestHandler: h.Handler(),
+		MethodType:     http.MethodPost,
+	}
+
+	routeManager := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{postHandler},
+		Version: version,
+	}
+	return &routeManager
+}
+
+// subscriptionPreviewHandler renders the notification that a subscription
+// would send, so that its template can be checked before it is saved.
+type subscriptionPreviewHandler struct {
+	Subscription   model.APISubscription `json:"subscription"`
+	EventID        string                `json:"event_id"`
+	dbSubscription event.Subscription
+}
+
+func (s *subscriptionPreviewHandler) Handler() RequestHandler {
+	return &subscriptionPreviewHandler{}
+}
+
+func (s *subscriptionPreviewHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	u := MustHaveUser(ctx)
+	if err := util.ReadJSONInto(r.Body, s); err != nil {
+		return err
+	}
+
+	subscriptionInterface, err := s.Subscription.ToService()
+	if err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Error parsing request body: " + err.Error(),
+		}
+	}
+	dbSubscription, ok := subscriptionInterface.(event.Subscription)
+	if !ok {
+		return &rest.APIError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Error parsing subscription interface",
+		}
+	}
+	if dbSubscription.OwnerType == event.OwnerTypePerson && dbSubscription.Owner == "" {
+		dbSubscription.Owner = u.Username()
+	}
+
+	if err = dbSubscription.Validate(); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Error validating subscription: " + err.Error(),
+		}
+	}
+	if err = trigger.ValidateTemplate(dbSubscription.Template, dbSubscription.Subscriber.Type); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Error validating template: " + err.Error(),
+		}
+	}
+	s.dbSubscription = dbSubscription
+
+	return nil
+}
+
+func (s *subscriptionPreviewHandler) Execute(_ context.Context, sc data.Connector) (ResponseData, error) {
+	preview, err := sc.PreviewSubscription(s.dbSubscription, s.EventID)
+	if err != nil {
+		return ResponseData{}, err
+	}
+
+	return ResponseData{
+		Result: []model.Model{preview},
+	}, nil
+}
@@ -303,6 +303,29 @@ func (tgh *taskGetHandler) Execute(ctx context.Context, sc data.Connector) (Resp
 		return ResponseData{}, errors.Wrap(err, "error retrieving artifacts")
 	}
 
+	if len(foundTask.DependsOn) > 0 {
+		depIds := make([]string, 0, len(foundTask.DependsOn))
+		for _, dep := range foundTask.DependsOn {
+			depIds = append(depIds, dep.TaskId)
+		}
+		var deps []task.Task
+		deps, err = sc.FindTasksByIds(depIds)
+		if apiErr, ok := err.(*rest.APIError); ok && apiErr.StatusCode == http.StatusNotFound {
+			err = nil
+		}
+		if err != nil {
+			return ResponseData{}, errors.Wrap(err, "error retrieving dependencies")
+		}
+		depsById := make(map[string]task.Task, len(deps))
+		for _, dep := range deps {
+			depsById[dep.Id] = dep
+		}
+		taskModel.UnmetDependencies, err = foundTask.UnmetDependencies(depsById)
+		if err != nil {
+			return ResponseData{}, errors.Wrap(err, "error checking dependencies")
+		}
+	}
+
 	return ResponseData{
 		Result: []model.Model{taskModel},
 	}, nil
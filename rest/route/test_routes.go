@@ -2,13 +2,16 @@ package route
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 )
@@ -167,3 +170,88 @@ func makePrevTestsPage(tests []testresult.TestResult) *Page {
 	}
 	return prevPage
 }
+
+// getTestFlakinessRouteManager gets the route manager for
+// GET /projects/{project_id}/test_flakiness.
+func getTestFlakinessRouteManager(route string, version int) *RouteManager {
+	h := &testFlakinessHandler{}
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				Authenticator:  &RequireUserAuthenticator{},
+				RequestHandler: h.Handler(),
+				MethodType:     http.MethodGet,
+			},
+		},
+		Version: version,
+	}
+}
+
+// maxFlakinessRevisions is the largest number of revisions that can be
+// requested when scoring test flakiness.
+const maxFlakinessRevisions = 500
+
+// testFlakinessHandler returns the flakiness scores of the tests of a task
+// in a project, given by the "variant" and "task" query parameters, from
+// the number of recent revisions in the "revisions" parameter.
+type testFlakinessHandler struct {
+	project   string
+	variant   string
+	taskName  string
+	revisions int
+}
+
+func (h *testFlakinessHandler) Handler() RequestHandler {
+	return &testFlakinessHandler{}
+}
+
+func (h *testFlakinessHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.project = gimlet.GetVars(r)["project_id"]
+	h.variant = r.URL.Query().Get("variant")
+	h.taskName = r.URL.Query().Get("task")
+	if h.variant == "" || h.taskName == "" {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Must specify a variant and a task",
+		}
+	}
+
+	h.revisions = task.FlakinessRevisions
+	if revisions := r.URL.Query().Get("revisions"); revisions != "" {
+		var err error
+		h.revisions, err = strconv.Atoi(revisions)
+		if err != nil || h.revisions <= 0 {
+			return rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "Invalid revisions",
+			}
+		}
+		if h.revisions > maxFlakinessRevisions {
+			return rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("Cannot request more than %d revisions", maxFlakinessRevisions),
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *testFlakinessHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	flakiness, err := sc.GetTestFlakiness(h.project, h.variant, h.taskName, h.revisions)
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "Database error")
+	}
+
+	models := make([]model.Model, len(flakiness))
+	for i := range flakiness {
+		apiFlakiness := &model.APITestFlakiness{}
+		if err = apiFlakiness.BuildFromService(&flakiness[i]); err != nil {
+			return ResponseData{}, errors.Wrap(err, "API model error")
+		}
+		models[i] = apiFlakiness
+	}
+
+	return ResponseData{Result: models}, nil
+}
@@ -0,0 +1,45 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestFlakinessHandlerParseAndValidate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	parse := func(query string) (*testFlakinessHandler, error) {
+		h := (&testFlakinessHandler{}).Handler().(*testFlakinessHandler)
+		r, err := http.NewRequest(http.MethodGet, "/projects/p/test_flakiness?"+query, nil)
+		require.NoError(err)
+		return h, h.ParseAndValidate(context.Background(), r)
+	}
+
+	h, err := parse("variant=bv&task=t")
+	assert.NoError(err)
+	assert.Equal(task.FlakinessRevisions, h.revisions)
+
+	h, err = parse("variant=bv&task=t&revisions=500")
+	assert.NoError(err)
+	assert.Equal(500, h.revisions)
+
+	for _, query := range []string{
+		"task=t",
+		"variant=bv&task=t&revisions=0",
+		"variant=bv&task=t&revisions=abc",
+		"variant=bv&task=t&revisions=501",
+	} {
+		_, err = parse(query)
+		require.Error(err, query)
+		apiErr, ok := err.(rest.APIError)
+		require.True(ok, query)
+		assert.Equal(http.StatusBadRequest, apiErr.StatusCode, query)
+	}
+}
@@ -47,7 +47,15 @@ func (h *userSettingsPostHandler) Handler() RequestHandler {
 
 func (h *userSettingsPostHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
 	h.settings = model.APIUserSettings{}
-	return util.ReadJSONInto(r.Body, &h.settings)
+	if err := util.ReadJSONInto(r.Body, &h.settings); err != nil {
+		return err
+	}
+	if timezone := model.FromAPIString(h.settings.Timezone); timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return errors.Errorf("'%s' is not a valid time zone", timezone)
+		}
+	}
+	return nil
 }
 
 func (h *userSettingsPostHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
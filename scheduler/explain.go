@@ -0,0 +1,214 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+)
+
+// DistroQueueExplanation describes how the scheduler would plan a distro:
+// the queue it would create, the tasks it would leave out of the queue,
+// and the hosts it would start.
+type DistroQueueExplanation struct {
+	DistroID        string
+	TaskFinder      string
+	TaskPrioritizer string
+	HostAllocator   string
+
+	Queue         []QueueItemExplanation
+	FilteredTasks []FilteredTaskExplanation
+	// ProjectUsage is the time each project has used the distro recently,
+	// if the distro uses the fair share prioritizer.
+	ProjectUsage map[string]time.Duration
+
+	ExpectedDuration time.Duration
+	NumExistingHosts int
+	NumFreeHosts     int
+	NewHostsNeeded   int
+}
+
+// QueueItemExplanation is a task in the queue, with the comparator that
+// placed it ahead of the next task in the queue it was sorted in. Patch and
+// commit tasks are sorted separately and then interleaved, and high
+// priority tasks are sorted separately and put first.
+type QueueItemExplanation struct {
+	Item model.TaskQueueItem
+	// SortedIn is the queue the task was sorted in: "high_priority",
+	// "patch" or "repotracker".
+	SortedIn string
+	// AheadOf is the next task in the queue the task was sorted in.
+	AheadOf string
+	// DecidedBy is the comparator that decided that the task is more
+	// important than the next task, or empty if all comparators found
+	// them equally important.
+	DecidedBy string
+}
+
+// FilteredTaskExplanation is a schedulable task that was left out of the
+// queue, and the reason why.
+type FilteredTaskExplanation struct {
+	TaskId      string
+	DisplayName string
+	Project     string
+	Reason      string
+}
+
+// ExplainDistro runs the task finder, prioritizer and host allocator for
+// the distro as the scheduler would, without saving the queue, spawning
+// hosts, or otherwise modifying anything.
+func ExplainDistro(ctx context.Context, conf Configuration, s *evergreen.Settings) (*DistroQueueExplanation, error) {
+	now := time.Now()
+	distroSpec, err := distro.FindOne(distro.ById(conf.DistroID))
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding distro")
+	}
+
+	out := &DistroQueueExplanation{
+		DistroID:        conf.DistroID,
+		TaskFinder:      conf.TaskFinder,
+		TaskPrioritizer: getTaskPrioritizerName(conf, distroSpec),
+		HostAllocator:   conf.HostAllocator,
+	}
+
+	schedulableTasks, err := task.FindSchedulable(conf.DistroID)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding schedulable tasks")
+	}
+
+	tasks, err := GetTaskFinder(conf.TaskFinder)(conf.DistroID)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem calculating task finder")
+	}
+
+	out.FilteredTasks, err = explainFilteredTasks(schedulableTasks, tasks)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	runnableTasks, versions, err := filterTasksWithVersionCache(tasks)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting runnable tasks")
+	}
+	runnable := make(map[string]bool, len(runnableTasks))
+	for _, t := range runnableTasks {
+		runnable[t.Id] = true
+	}
+	for _, t := range tasks {
+		if !runnable[t.Id] {
+			out.FilteredTasks = append(out.FilteredTasks, newFilteredTaskExplanation(t, "version has not been created"))
+		}
+	}
+
+	prioritizer := &CmpBasedTaskPrioritizer{placements: map[string]taskPlacement{}}
+	prioritizedTasks, err := prioritizer.PrioritizeTasks(conf.DistroID, runnableTasks, versions)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error prioritizing tasks")
+	}
+	if out.TaskPrioritizer == "fairshare" {
+		out.ProjectUsage, err = task.GetProjectTimeOnDistro(conf.DistroID, now.Add(-fairShareUsageWindow))
+		if err != nil {
+			return nil, errors.Wrap(err, "problem finding time used by projects")
+		}
+		prioritizedTasks = interleaveByFairShare(prioritizedTasks, out.ProjectUsage, conf.ProjectWeights)
+	}
+
+	queue := newTaskQueueItems(prioritizedTasks)
+	for _, item := range queue {
+		placement := prioritizer.placements[item.Id]
+		out.Queue = append(out.Queue, QueueItemExplanation{
+			Item:      item,
+			SortedIn:  placement.queue,
+			AheadOf:   placement.aheadOf,
+			DecidedBy: placement.decidedBy,
+		})
+		out.ExpectedDuration += item.ExpectedDuration
+	}
+
+	distroHostsMap, err := findUsableHosts(conf.DistroID)
+	if err != nil {
+		return nil, errors.Wrap(err, "with host query")
+	}
+	out.NumExistingHosts = len(distroHostsMap[conf.DistroID])
+	for _, h := range distroHostsMap[conf.DistroID] {
+		if h.RunningTask == "" {
+			out.NumFreeHosts++
+		}
+	}
+
+	allocatorArgs, _, err := newHostAllocatorData(conf, distroSpec, queue, distroHostsMap, s, now)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	newHosts, err := GetHostAllocator(conf.HostAllocator)(ctx, allocatorArgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem calculating hosts needed")
+	}
+	out.NewHostsNeeded = newHosts[conf.DistroID]
+
+	return out, nil
+}
+
+// explainFilteredTasks returns the reasons that the schedulable tasks that
+// the task finder did not return were filtered out.
+func explainFilteredTasks(schedulableTasks, foundTasks []task.Task) ([]FilteredTaskExplanation, error) {
+	found := make(map[string]bool, len(foundTasks))
+	for _, t := range foundTasks {
+		found[t.Id] = true
+	}
+
+	projectRefCache, err := getProjectRefCache()
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding projects")
+	}
+
+	filtered := []FilteredTaskExplanation{}
+	dependencyCaches := make(map[string]task.Task)
+	for _, t := range schedulableTasks {
+		if found[t.Id] {
+			continue
+		}
+
+		reason := "filtered by task finder"
+		ref, ok := projectRefCache[t.Project]
+		switch {
+		case !ok:
+			reason = "could not find project for task"
+		case !ref.Enabled:
+			reason = "project disabled"
+		case t.IsPatchRequest() && ref.PatchingDisabled:
+			reason = "patch testing disabled"
+		default:
+			depsMet, err := t.DependenciesMet(dependencyCaches)
+			if err != nil {
+				reason = "error checking dependencies: " + err.Error()
+			} else if !depsMet {
+				reason = "dependencies not met"
+				if unmet, err := t.UnmetDependencies(dependencyCaches); err == nil && len(unmet) > 0 {
+					reason += ": " + strings.Join(unmet, ", ")
+				}
+			}
+		}
+
+		filtered = append(filtered, newFilteredTaskExplanation(t, reason))
+	}
+
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].TaskId < filtered[j].TaskId })
+
+	return filtered, nil
+}
+
+func newFilteredTaskExplanation(t task.Task, reason string) FilteredTaskExplanation {
+	return FilteredTaskExplanation{
+		TaskId:      t.Id,
+		DisplayName: t.DisplayName,
+		Project:     t.Project,
+		Reason:      reason,
+	}
+}
@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComparatorName(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("byPriority", comparatorName(byPriority))
+	assert.Equal("byNumDeps", comparatorName(byNumDeps))
+}
+
+func TestExplainPlacements(t *testing.T) {
+	assert := assert.New(t)
+
+	comparator := &CmpBasedTaskComparator{
+		tasks: []task.Task{
+			{Id: "t1", Priority: 2},
+			{Id: "t2", Priority: 1, NumDependents: 3},
+			{Id: "t3", Priority: 1, NumDependents: 1},
+			{Id: "t4", Priority: 1, NumDependents: 1},
+		},
+		comparators: []taskPriorityCmp{byPriority, byNumDeps},
+	}
+
+	placements := map[string]taskPlacement{}
+	assert.NoError(comparator.explainPlacements("patch", placements))
+	assert.Len(placements, 4)
+
+	assert.Equal(taskPlacement{queue: "patch", aheadOf: "t2", decidedBy: "byPriority"}, placements["t1"])
+	assert.Equal(taskPlacement{queue: "patch", aheadOf: "t3", decidedBy: "byNumDeps"}, placements["t2"])
+	// tasks that all comparators find equally important are ties
+	assert.Equal(taskPlacement{queue: "patch", aheadOf: "t4"}, placements["t3"])
+	// the last task isn't ahead of anything
+	assert.Equal(taskPlacement{queue: "patch"}, placements["t4"])
+}
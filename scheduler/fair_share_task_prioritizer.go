@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	// fairShareUsageWindow is how far back the time that projects have
+	// used a distro counts towards their share.
+	fairShareUsageWindow = 24 * time.Hour
+	// fairShareDefaultTaskDuration is the time charged to a project for a
+	// task that has no expected duration.
+	fairShareDefaultTaskDuration = 10 * time.Minute
+)
+
+// FairShareTaskPrioritizer orders each project's tasks the same way as
+// the CmpBasedTaskPrioritizer, then interleaves the projects so that each
+// gets a share of the distro in proportion to its weight. Projects that
+// have recently used more than their share of the distro are put behind
+// those that have used less, so one project with a large version can't
+// starve the others.
+type FairShareTaskPrioritizer struct {
+	// ProjectWeights maps project identifiers to their relative share of
+	// the distro. Projects without a weight have a weight of 1.
+	ProjectWeights map[string]float64
+}
+
+func (p *FairShareTaskPrioritizer) PrioritizeTasks(distroId string, tasks []task.Task, versions map[string]version.Version) ([]task.Task, error) {
+	prioritizedTasks, err := (&CmpBasedTaskPrioritizer{}).PrioritizeTasks(distroId, tasks, versions)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	usage, err := task.GetProjectTimeOnDistro(distroId, time.Now().Add(-fairShareUsageWindow))
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding time used by projects")
+	}
+
+	grip.Debug(message.Fields{
+		"message":   "interleaving projects by fair share",
+		"distro":    distroId,
+		"runner":    RunnerName,
+		"operation": "prioritize tasks",
+		"usage":     usage,
+		"weights":   p.ProjectWeights,
+	})
+
+	return interleaveByFairShare(prioritizedTasks, usage, p.ProjectWeights), nil
+}
+
+// interleaveByFairShare reorders the prioritized tasks so that projects
+// alternate according to their weights, preserving the order of tasks
+// within each project. High priority tasks stay at the front of the
+// queue. Each project starts with its recent usage of the distro divided
+// by its weight, and the project with the least weighted usage goes
+// next, adding the expected duration of its task divided by its weight.
+func interleaveByFairShare(tasks []task.Task, usage map[string]time.Duration, weights map[string]float64) []task.Task {
+	interleaved := make([]task.Task, 0, len(tasks))
+
+	projects := []string{}
+	projectTasks := map[string][]task.Task{}
+	for _, t := range tasks {
+		if t.Priority > evergreen.MaxTaskPriority {
+			interleaved = append(interleaved, t)
+			continue
+		}
+		if _, ok := projectTasks[t.Project]; !ok {
+			projects = append(projects, t.Project)
+		}
+		projectTasks[t.Project] = append(projectTasks[t.Project], t)
+	}
+
+	weight := func(project string) float64 {
+		if w, ok := weights[project]; ok && w > 0 {
+			return w
+		}
+		return 1
+	}
+
+	weightedUsage := make(map[string]float64, len(projects))
+	for _, project := range projects {
+		weightedUsage[project] = float64(usage[project]) / weight(project)
+	}
+
+	for len(interleaved) < len(tasks) {
+		// ties go to the project whose first task was prioritized highest
+		next := ""
+		for _, project := range projects {
+			if len(projectTasks[project]) == 0 {
+				continue
+			}
+			if next == "" || weightedUsage[project] < weightedUsage[next] {
+				next = project
+			}
+		}
+
+		t := projectTasks[next][0]
+		projectTasks[next] = projectTasks[next][1:]
+		interleaved = append(interleaved, t)
+
+		duration := t.ExpectedDuration
+		if duration <= 0 {
+			duration = fairShareDefaultTaskDuration
+		}
+		weightedUsage[next] += float64(duration) / weight(next)
+	}
+
+	return interleaved
+}
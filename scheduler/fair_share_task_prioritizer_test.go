@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func taskIds(tasks []task.Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.Id)
+	}
+	return ids
+}
+
+func TestGetTaskPrioritizer(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.IsType(&CmpBasedTaskPrioritizer{}, GetTaskPrioritizer("", nil))
+	assert.IsType(&CmpBasedTaskPrioritizer{}, GetTaskPrioritizer("comparator", nil))
+
+	weights := map[string]float64{"p1": 2}
+	prioritizer, ok := GetTaskPrioritizer("fairshare", weights).(*FairShareTaskPrioritizer)
+	assert.True(ok)
+	assert.Equal(weights, prioritizer.ProjectWeights)
+}
+
+func TestInterleaveByFairShare(t *testing.T) {
+	assert := assert.New(t)
+
+	tasks := []task.Task{
+		{Id: "a1", Project: "a", ExpectedDuration: time.Minute},
+		{Id: "a2", Project: "a", ExpectedDuration: time.Minute},
+		{Id: "a3", Project: "a", ExpectedDuration: time.Minute},
+		{Id: "a4", Project: "a", ExpectedDuration: time.Minute},
+		{Id: "b1", Project: "b", ExpectedDuration: time.Minute},
+		{Id: "b2", Project: "b", ExpectedDuration: time.Minute},
+	}
+
+	// with equal weights and no usage, projects alternate
+	assert.Equal([]string{"a1", "b1", "a2", "b2", "a3", "a4"},
+		taskIds(interleaveByFairShare(tasks, nil, nil)))
+
+	// a project with twice the weight gets twice as many turns
+	assert.Equal([]string{"a1", "b1", "a2", "a3", "b2", "a4"},
+		taskIds(interleaveByFairShare(tasks, nil, map[string]float64{"a": 2})))
+
+	// a project that has used the distro recently goes after one that hasn't
+	usage := map[string]time.Duration{"a": 2 * time.Minute}
+	assert.Equal([]string{"b1", "b2", "a1", "a2", "a3", "a4"},
+		taskIds(interleaveByFairShare(tasks, usage, nil)))
+
+	// the empty queue is still empty
+	assert.Empty(interleaveByFairShare([]task.Task{}, usage, nil))
+}
+
+func TestInterleaveByFairShareChargesExpectedDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	tasks := []task.Task{
+		{Id: "a1", Project: "a", ExpectedDuration: time.Hour},
+		{Id: "a2", Project: "a", ExpectedDuration: time.Hour},
+		{Id: "b1", Project: "b", ExpectedDuration: 20 * time.Minute},
+		{Id: "b2", Project: "b", ExpectedDuration: 20 * time.Minute},
+		{Id: "b3", Project: "b"},
+		{Id: "b4", Project: "b", ExpectedDuration: 20 * time.Minute},
+	}
+
+	// short tasks take turns until they add up to a long one, and tasks
+	// without an expected duration are charged the default
+	assert.Equal([]string{"a1", "b1", "b2", "b3", "b4", "a2"},
+		taskIds(interleaveByFairShare(tasks, nil, nil)))
+}
+
+func TestInterleaveByFairShareKeepsHighPriorityFirst(t *testing.T) {
+	assert := assert.New(t)
+
+	tasks := []task.Task{
+		{Id: "urgent", Project: "b", Priority: evergreen.MaxTaskPriority + 1},
+		{Id: "a1", Project: "a"},
+		{Id: "a2", Project: "a"},
+		{Id: "b1", Project: "b"},
+	}
+
+	assert.Equal([]string{"urgent", "a1", "b1", "a2"},
+		taskIds(interleaveByFairShare(tasks, map[string]time.Duration{}, nil)))
+}
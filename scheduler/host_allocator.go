@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
@@ -16,6 +17,7 @@ import (
 //  existingDistroHosts: a map of distro name -> currently running hosts on that distro
 //  projectTaskDurations: the expected duration of tasks by project and variant
 //  taskRunDistros: a map of task id -> distros the task is allowed to run on
+//  taskArrivals: a map of distro name -> counts of the tasks recently scheduled on that distro
 // Returns a map of distro name -> how many hosts need to be spun up for that distro.
 type HostAllocator func(context.Context, HostAllocatorData) (map[string]int, error)
 
@@ -28,6 +30,10 @@ type HostAllocatorData struct {
 	freeHostFraction    float64
 	usesContainers      bool
 	containerPool       *evergreen.ContainerPool
+	taskArrivals        map[string][]arrivalBucket
+	// now is the time the allocator runs at; if it is zero, the current
+	// time is used.
+	now time.Time
 }
 
 func GetHostAllocator(name string) HostAllocator {
@@ -38,6 +44,8 @@ func GetHostAllocator(name string) HostAllocator {
 		return DurationBasedHostAllocator
 	case "utilization":
 		return UtilizationBasedHostAllocator
+	case "predictive":
+		return PredictiveHostAllocator
 	default:
 		return DurationBasedHostAllocator
 	}
@@ -0,0 +1,271 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/suite"
+)
+
+type simulationSettings struct {
+	start       time.Time // when the simulation starts
+	measureFrom time.Time // when results start being recorded
+	end         time.Time // when the simulation ends
+
+	tick             time.Duration // how often the allocator runs
+	provisioningTime time.Duration // how long a new host takes to come up
+	idleTimeout      time.Duration // how long a host idles before it's terminated
+
+	baseRate      float64       // tasks arriving per minute
+	burstRate     float64       // tasks arriving per minute during a burst
+	burstStart    time.Duration // when the burst starts on weekdays, after midnight
+	burstEnd      time.Duration // when the burst ends on weekdays, after midnight
+	taskDurations []time.Duration
+	seed          int64
+}
+
+type simulatedTask struct {
+	id       string
+	arrival  time.Time
+	duration time.Duration
+	start    time.Time
+}
+
+type simulatedHost struct {
+	id        string
+	readyAt   time.Time
+	task      *simulatedTask
+	idleSince time.Time
+}
+
+type simulationResult struct {
+	numTasks  int
+	totalWait time.Duration
+	maxWait   time.Duration
+	hostTime  time.Duration
+}
+
+func (r simulationResult) meanWait() time.Duration {
+	if r.numTasks == 0 {
+		return 0
+	}
+	return r.totalWait / time.Duration(r.numTasks)
+}
+
+// HostAllocatorSimulationSuite runs each host allocator against the same
+// simulated workload, a steady trickle of tasks with a burst every weekday
+// morning, and compares how long tasks wait for a host against how many
+// host hours are used.
+type HostAllocatorSimulationSuite struct {
+	ctx        context.Context
+	distroName string
+	distro     distro.Distro
+	settings   simulationSettings
+	tasks      []*simulatedTask
+	results    map[string]simulationResult
+
+	suite.Suite
+}
+
+func TestHostAllocatorSimulation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping host allocator simulation in short mode")
+	}
+	suite.Run(t, &HostAllocatorSimulationSuite{})
+}
+
+func (s *HostAllocatorSimulationSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.distroName = "simulatedDistro"
+	s.distro = distro.Distro{
+		Id:       s.distroName,
+		PoolSize: 200,
+		Provider: evergreen.ProviderNameEc2Auto,
+	}
+	// run from a Monday through the Wednesday of the following week
+	s.settings = simulationSettings{
+		start:            time.Date(2018, time.June, 4, 0, 0, 0, 0, time.UTC),
+		measureFrom:      time.Date(2018, time.June, 13, 0, 0, 0, 0, time.UTC),
+		end:              time.Date(2018, time.June, 14, 0, 0, 0, 0, time.UTC),
+		tick:             time.Minute,
+		provisioningTime: 15 * time.Minute,
+		idleTimeout:      20 * time.Minute,
+		baseRate:         0.1,
+		burstRate:        2,
+		burstStart:       9 * time.Hour,
+		burstEnd:         10 * time.Hour,
+		taskDurations: []time.Duration{
+			5 * time.Minute,
+			10 * time.Minute,
+			15 * time.Minute,
+			20 * time.Minute,
+			25 * time.Minute,
+		},
+		seed: 1,
+	}
+	s.tasks = s.generateTasks()
+	s.results = map[string]simulationResult{}
+}
+
+// generateTasks returns the tasks that arrive over the simulation, in
+// order of arrival.
+func (s *HostAllocatorSimulationSuite) generateTasks() []*simulatedTask {
+	r := rand.New(rand.NewSource(s.settings.seed))
+	tasks := []*simulatedTask{}
+	for now := s.settings.start; now.Before(s.settings.end); {
+		rate := s.settings.baseRate
+		sinceMidnight := now.Sub(now.Truncate(24 * time.Hour))
+		if !isWeekend(now) && sinceMidnight >= s.settings.burstStart && sinceMidnight < s.settings.burstEnd {
+			rate = s.settings.burstRate
+		}
+
+		now = now.Add(time.Duration(r.ExpFloat64() / rate * float64(time.Minute)))
+		tasks = append(tasks, &simulatedTask{
+			id:       fmt.Sprintf("t%d", len(tasks)),
+			arrival:  now,
+			duration: s.settings.taskDurations[r.Intn(len(s.settings.taskDurations))],
+		})
+	}
+
+	return tasks
+}
+
+// simulate runs the allocator once a tick over the simulation, starting
+// the hosts it asks for and dispatching queued tasks to free hosts in the
+// order that they arrived.
+func (s *HostAllocatorSimulationSuite) simulate(allocator HostAllocator) simulationResult {
+	result := simulationResult{}
+	hosts := []*simulatedHost{}
+	queue := []*simulatedTask{}
+	arrivals := []arrivalBucket{}
+	numHosts := 0
+	next := 0
+
+	record := func(now time.Time, t *simulatedTask) {
+		if t.arrival.Before(s.settings.measureFrom) {
+			return
+		}
+		wait := now.Sub(t.arrival)
+		result.numTasks++
+		result.totalWait += wait
+		if wait > result.maxWait {
+			result.maxWait = wait
+		}
+	}
+
+	for now := s.settings.start; now.Before(s.settings.end); now = now.Add(s.settings.tick) {
+		// finish tasks, and terminate hosts that have been idle too long
+		running := []*simulatedHost{}
+		for _, h := range hosts {
+			if h.task != nil && !h.task.start.Add(h.task.duration).After(now) {
+				h.idleSince = h.task.start.Add(h.task.duration)
+				h.task = nil
+			}
+			if h.task == nil && !h.readyAt.After(now) && now.Sub(h.idleSince) >= s.settings.idleTimeout {
+				continue
+			}
+			running = append(running, h)
+		}
+		hosts = running
+
+		for ; next < len(s.tasks) && !s.tasks[next].arrival.After(now); next++ {
+			queue = append(queue, s.tasks[next])
+			arrivals = append(arrivals, arrivalBucket{
+				start:         s.tasks[next].arrival,
+				numTasks:      1,
+				numCommits:    1,
+				totalDuration: s.tasks[next].duration,
+			})
+		}
+
+		for _, h := range hosts {
+			if len(queue) == 0 {
+				break
+			}
+			if h.task != nil || h.readyAt.After(now) {
+				continue
+			}
+			h.task = queue[0]
+			h.task.start = now
+			queue = queue[1:]
+			record(now, h.task)
+		}
+
+		queueItems := []model.TaskQueueItem{}
+		for _, t := range queue {
+			queueItems = append(queueItems, model.TaskQueueItem{Id: t.id, ExpectedDuration: t.duration})
+		}
+		existingHosts := []host.Host{}
+		for _, h := range hosts {
+			existingHost := host.Host{Id: h.id}
+			if h.task != nil {
+				existingHost.RunningTask = h.task.id
+			}
+			existingHosts = append(existingHosts, existingHost)
+		}
+
+		newHosts, err := allocator(s.ctx, HostAllocatorData{
+			distros:             map[string]distro.Distro{s.distroName: s.distro},
+			taskQueueItems:      map[string][]model.TaskQueueItem{s.distroName: queueItems},
+			existingDistroHosts: map[string][]host.Host{s.distroName: existingHosts},
+			taskArrivals:        map[string][]arrivalBucket{s.distroName: arrivals},
+			// simulated tasks aren't in the database, so hosts running
+			// them can't be estimated to be soon to be free
+			freeHostFraction: 0,
+			now:              now,
+		})
+		s.Require().NoError(err)
+		for i := 0; i < newHosts[s.distroName]; i++ {
+			readyAt := now.Add(s.settings.provisioningTime)
+			hosts = append(hosts, &simulatedHost{
+				id:        fmt.Sprintf("h%d", numHosts),
+				readyAt:   readyAt,
+				idleSince: readyAt,
+			})
+			numHosts++
+		}
+
+		if !now.Before(s.settings.measureFrom) {
+			result.hostTime += time.Duration(len(hosts)) * s.settings.tick
+		}
+	}
+
+	// tasks that never started waited until the end of the simulation
+	for _, t := range queue {
+		record(s.settings.end, t)
+	}
+
+	return result
+}
+
+func (s *HostAllocatorSimulationSuite) TestCompareAllocators() {
+	allocators := map[string]HostAllocator{
+		"deficit":     DeficitBasedHostAllocator,
+		"utilization": UtilizationBasedHostAllocator,
+		"predictive":  PredictiveHostAllocator,
+	}
+
+	for name, allocator := range allocators {
+		result := s.simulate(allocator)
+		s.results[name] = result
+		s.T().Logf("%-12s tasks: %4d, mean wait: %10s, max wait: %10s, host hours: %.1f", name,
+			result.numTasks, result.meanWait(), result.maxWait, result.hostTime.Hours())
+	}
+
+	for name, result := range s.results {
+		s.NotZero(result.numTasks, name)
+		s.Equal(s.results["utilization"].numTasks, result.numTasks, name)
+	}
+
+	// forecasting the morning burst means fewer tasks wait on hosts being
+	// provisioned than when only reacting to the queue
+	s.True(s.results["predictive"].meanWait() < s.results["utilization"].meanWait())
+	s.True(s.results["predictive"].maxWait < s.results["utilization"].maxWait)
+}
@@ -0,0 +1,268 @@
+package scheduler
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	// predictionRecentLookback is how far back the recent arrival rate
+	// of tasks is measured.
+	predictionRecentLookback = 2 * time.Hour
+	// predictionHistoryDays is how many days back the arrival rate at
+	// the same time of day is measured.
+	predictionHistoryDays = 7
+	// predictionBucketSize is how finely the arrival history is counted.
+	// It divides the lookback and the length of the prediction windows,
+	// so each bucket is either entirely inside or outside of a window.
+	predictionBucketSize = 5 * time.Minute
+)
+
+// arrivalBucket counts the tasks that were scheduled on a distro in a
+// bucket of time in the past, used to forecast how many tasks will be
+// scheduled in the future.
+type arrivalBucket struct {
+	start         time.Time
+	numTasks      int
+	numCommits    int
+	totalDuration time.Duration
+}
+
+// predictionWindow is a span of time in which tasks arrived.
+type predictionWindow struct {
+	start time.Time
+	end   time.Time
+}
+
+func (w predictionWindow) contains(t time.Time) bool {
+	return !t.Before(w.start) && t.Before(w.end)
+}
+
+// PredictiveHostAllocator allocates hosts for the tasks in the queue the
+// same way as the UtilizationBasedHostAllocator, and additionally
+// pre-spawns hosts for the tasks it expects to arrive before the new
+// hosts would come up. The forecast is based on the rate at which tasks
+// and commits arrived recently and at the same time of day on previous
+// days, so that hosts for daily bursts of commits are started before the
+// burst.
+func PredictiveHostAllocator(ctx context.Context, hostAllocatorData HostAllocatorData) (map[string]int, error) {
+	now := hostAllocatorData.now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	newHostsNeeded := make(map[string]int)
+	for name, d := range hostAllocatorData.distros {
+		newHosts, err := evalPredictedHosts(ctx, d, hostAllocatorData.taskQueueItems[name],
+			hostAllocatorData.existingDistroHosts[name], hostAllocatorData.taskArrivals[name],
+			hostAllocatorData.freeHostFraction, hostAllocatorData.usesContainers,
+			hostAllocatorData.containerPool, now)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error calculating hosts for distro %s", name)
+		}
+		newHostsNeeded[name] = newHosts
+	}
+
+	return newHostsNeeded, nil
+}
+
+// evalPredictedHosts returns the number of hosts needed for the task queue,
+// plus the number needed for the tasks forecast to arrive in the next
+// interval that the hosts which are spare after the queue is served can't
+// absorb.
+func evalPredictedHosts(ctx context.Context, d distro.Distro, taskQueue []model.TaskQueueItem,
+	existingHosts []host.Host, arrivals []arrivalBucket, freeHostFraction float64,
+	usesContainers bool, containerPool *evergreen.ContainerPool, now time.Time) (int, error) {
+
+	if !d.IsEphemeral() {
+		return 0, nil
+	}
+
+	reactiveHosts, err := evalHostUtilization(ctx, d, taskQueue, existingHosts, freeHostFraction,
+		usesContainers, containerPool)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	maxDuration := MaxDurationPerDistroHost
+	if usesContainers {
+		maxDuration = MaxDurationPerDistroHostWithContainers
+	}
+
+	// determine how much of the free capacity is left once the queue is served
+	newTaskQueue, hostsForLongTasks := calcHostsForLongTasks(taskQueue, maxDuration)
+	queueHosts := float64(calcScheduledTasksDuration(newTaskQueue))/float64(maxDuration) +
+		float64(hostsForLongTasks)
+	numFreeHosts, err := calcExistingFreeHosts(existingHosts, freeHostFraction, maxDuration)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	spareHosts := math.Max(float64(numFreeHosts+reactiveHosts)-queueHosts, 0)
+
+	predictedHosts := forecastHostsNeeded(arrivals, now, maxDuration)
+	hostsForPrediction := int(math.Ceil(predictedHosts - spareHosts))
+	if hostsForPrediction < 0 {
+		hostsForPrediction = 0
+	}
+
+	numNewHosts := reactiveHosts + hostsForPrediction
+
+	// enforce the max hosts cap
+	if isMaxHostsCapacity(d, containerPool, numNewHosts, len(existingHosts)) {
+		numNewHosts = d.PoolSize - len(existingHosts)
+	}
+	if numNewHosts < 0 {
+		numNewHosts = 0
+	}
+
+	grip.Info(message.Fields{
+		"message":              "predictive allocator report",
+		"runner":               RunnerName,
+		"provider":             d.Provider,
+		"distro":               d.Id,
+		"pool_size":            d.PoolSize,
+		"reactive_hosts":       reactiveHosts,
+		"spare_hosts":          spareHosts,
+		"predicted_hosts":      predictedHosts,
+		"hosts_for_prediction": hostsForPrediction,
+		"new_hosts_needed":     numNewHosts,
+		"num_arrivals":         len(arrivals),
+	})
+
+	return numNewHosts, nil
+}
+
+// forecastHostsNeeded returns the fractional number of hosts needed to
+// run the tasks expected to arrive in the interval of length window
+// starting at now. The number of tasks expected is the greater of the
+// recent arrival rate and the average number of tasks that arrived in the
+// same interval on previous days that were, like today, a weekday or a
+// day of the weekend. Commits are forecast the same way, and are expected
+// to bring as many tasks as commits brought on average, so a burst of
+// commits is anticipated even while few of their tasks have been
+// scheduled yet.
+func forecastHostsNeeded(buckets []arrivalBucket, now time.Time, window time.Duration) float64 {
+	if len(buckets) == 0 || window <= 0 {
+		return 0
+	}
+
+	recent := predictionWindow{start: now.Add(-predictionRecentLookback), end: now}
+	history := historicalPredictionWindows(now, window)
+
+	var numTasks, numCommits, numRecentTasks, numRecentCommits int
+	var totalDuration time.Duration
+	historyTasks := make([]int, len(history))
+	historyCommits := make([]int, len(history))
+	for _, b := range buckets {
+		duration := b.totalDuration
+		// like calcHostsForLongTasks, a long task is worth at most one host
+		if maxDuration := time.Duration(b.numTasks) * window; duration > maxDuration {
+			duration = maxDuration
+		}
+		totalDuration += duration
+		numTasks += b.numTasks
+		numCommits += b.numCommits
+
+		if recent.contains(b.start) {
+			numRecentTasks += b.numTasks
+			numRecentCommits += b.numCommits
+		}
+		for i, w := range history {
+			if w.contains(b.start) {
+				historyTasks[i] += b.numTasks
+				historyCommits[i] += b.numCommits
+			}
+		}
+	}
+	if numTasks == 0 {
+		return 0
+	}
+
+	recentScale := float64(window) / float64(predictionRecentLookback)
+	expectedTasks := math.Max(float64(numRecentTasks)*recentScale, average(historyTasks))
+	expectedCommits := math.Max(float64(numRecentCommits)*recentScale, average(historyCommits))
+	if numCommits > 0 {
+		expectedTasks = math.Max(expectedTasks, expectedCommits*float64(numTasks)/float64(numCommits))
+	}
+
+	avgDuration := float64(totalDuration) / float64(numTasks)
+
+	return expectedTasks * avgDuration / float64(window)
+}
+
+func average(counts []int) float64 {
+	if len(counts) == 0 {
+		return 0
+	}
+
+	var total int
+	for _, n := range counts {
+		total += n
+	}
+	return float64(total) / float64(len(counts))
+}
+
+// historicalPredictionWindows returns the intervals of length window at
+// the same time of day as now on each of the previous days that, like
+// now, fall on a weekday or on the weekend.
+func historicalPredictionWindows(now time.Time, window time.Duration) []predictionWindow {
+	windows := []predictionWindow{}
+	for i := 1; i <= predictionHistoryDays; i++ {
+		start := now.AddDate(0, 0, -i)
+		if isWeekend(start) != isWeekend(now) {
+			continue
+		}
+		windows = append(windows, predictionWindow{start: start, end: start.Add(window)})
+	}
+
+	return windows
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// findTaskArrivals returns the counts of tasks scheduled on the distro
+// over the history that the PredictiveHostAllocator uses to forecast
+// demand.
+func findTaskArrivals(distroID string, now time.Time, usesContainers bool) ([]arrivalBucket, error) {
+	window := MaxDurationPerDistroHost
+	if usesContainers {
+		window = MaxDurationPerDistroHostWithContainers
+	}
+
+	// the buckets are counted back from now, so the prediction windows
+	// start on bucket boundaries
+	start := now.AddDate(0, 0, -predictionHistoryDays)
+	bounds := model.FrameBounds{
+		StartTime:     start,
+		EndTime:       now,
+		BucketSize:    predictionBucketSize,
+		NumberBuckets: int(now.Sub(start) / predictionBucketSize),
+	}
+	stats, err := model.TaskArrivalStatistics(distroID, bounds, window)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding tasks scheduled on distro '%s'", distroID)
+	}
+
+	buckets := make([]arrivalBucket, 0, len(stats))
+	for _, b := range stats {
+		buckets = append(buckets, arrivalBucket{
+			start:         b.Start,
+			numTasks:      b.NumberTasks,
+			numCommits:    b.NumberCommits,
+			totalDuration: b.TotalDuration,
+		})
+	}
+
+	return buckets, nil
+}
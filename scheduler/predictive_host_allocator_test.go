@@ -0,0 +1,228 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/suite"
+)
+
+type PredictiveAllocatorSuite struct {
+	ctx        context.Context
+	distroName string
+	distro     distro.Distro
+	// now is a Wednesday morning
+	now time.Time
+	suite.Suite
+}
+
+func TestPredictiveAllocatorSuite(t *testing.T) {
+	s := &PredictiveAllocatorSuite{}
+	suite.Run(t, s)
+}
+
+func (s *PredictiveAllocatorSuite) SetupSuite() {
+	s.distroName = "testDistro"
+	s.distro = distro.Distro{
+		Id:       s.distroName,
+		PoolSize: 50,
+		Provider: evergreen.ProviderNameEc2Auto,
+	}
+	s.now = time.Date(2018, time.June, 13, 9, 0, 0, 0, time.UTC)
+}
+
+func (s *PredictiveAllocatorSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+// arrivals returns n tasks of the given duration scheduled at t, each
+// for its own commit.
+func (s *PredictiveAllocatorSuite) arrivals(t time.Time, n int, duration time.Duration) []arrivalBucket {
+	return []arrivalBucket{{
+		start:         t,
+		numTasks:      n,
+		numCommits:    n,
+		totalDuration: time.Duration(n) * duration,
+	}}
+}
+
+func (s *PredictiveAllocatorSuite) TestHistoricalPredictionWindows() {
+	windows := historicalPredictionWindows(s.now, 30*time.Minute)
+	s.Len(windows, 5)
+	for _, w := range windows {
+		s.False(isWeekend(w.start))
+		s.Equal(9, w.start.Hour())
+		s.Equal(30*time.Minute, w.end.Sub(w.start))
+	}
+
+	saturday := time.Date(2018, time.June, 16, 9, 0, 0, 0, time.UTC)
+	windows = historicalPredictionWindows(saturday, 30*time.Minute)
+	s.Len(windows, 2)
+	for _, w := range windows {
+		s.True(isWeekend(w.start))
+	}
+}
+
+func (s *PredictiveAllocatorSuite) TestForecastWithoutHistory() {
+	s.Zero(forecastHostsNeeded(nil, s.now, 30*time.Minute))
+}
+
+func (s *PredictiveAllocatorSuite) TestForecastFromRecentArrivals() {
+	// 40 tasks of 15 minutes in the last 2 hours is 10 tasks per 30 minutes
+	arrivals := s.arrivals(s.now.Add(-time.Hour), 40, 15*time.Minute)
+	s.InDelta(5, forecastHostsNeeded(arrivals, s.now, 30*time.Minute), 0.001)
+
+	// tasks outside of the windows only count towards the average duration
+	arrivals = append(arrivals, s.arrivals(s.now.Add(-5*time.Hour), 40, 15*time.Minute)...)
+	s.InDelta(5, forecastHostsNeeded(arrivals, s.now, 30*time.Minute), 0.001)
+}
+
+func (s *PredictiveAllocatorSuite) TestForecastFromSameTimeOnPreviousDays() {
+	arrivals := []arrivalBucket{}
+	// a burst of 20 tasks each weekday at 9:10, and none on the weekend
+	for i := 1; i <= 7; i++ {
+		day := s.now.AddDate(0, 0, -i)
+		if !isWeekend(day) {
+			arrivals = append(arrivals, s.arrivals(day.Add(10*time.Minute), 20, 30*time.Minute)...)
+		}
+	}
+	s.InDelta(20, forecastHostsNeeded(arrivals, s.now, 30*time.Minute), 0.001)
+
+	// the burst is not expected before it starts
+	s.Zero(forecastHostsNeeded(arrivals, s.now.Add(-time.Hour), 30*time.Minute))
+
+	// nor on the weekend
+	saturday := time.Date(2018, time.June, 16, 9, 0, 0, 0, time.UTC)
+	s.Zero(forecastHostsNeeded(arrivals, saturday, 30*time.Minute))
+}
+
+func (s *PredictiveAllocatorSuite) TestForecastFromCommitCadence() {
+	arrivals := []arrivalBucket{}
+	// each weekday at 6:00, a commit brings 20 tasks
+	for i := 1; i <= 7; i++ {
+		day := s.now.AddDate(0, 0, -i)
+		if !isWeekend(day) {
+			arrivals = append(arrivals, arrivalBucket{
+				start:         day.Add(-3 * time.Hour),
+				numTasks:      20,
+				numCommits:    1,
+				totalDuration: 20 * 30 * time.Minute,
+			})
+		}
+	}
+	s.InDelta(0, forecastHostsNeeded(arrivals, s.now, 30*time.Minute), 0.001)
+
+	// 8 commits in the last 2 hours that have only scheduled a task each
+	// are 2 commits per 30 minutes, bringing 108 tasks per 13 commits
+	arrivals = append(arrivals, arrivalBucket{
+		start:         s.now.Add(-time.Hour),
+		numTasks:      8,
+		numCommits:    8,
+		totalDuration: 8 * 30 * time.Minute,
+	})
+	s.InDelta(2*108.0/13, forecastHostsNeeded(arrivals, s.now, 30*time.Minute), 0.001)
+}
+
+func (s *PredictiveAllocatorSuite) TestForecastCapsLongTasks() {
+	arrivals := s.arrivals(s.now.Add(-time.Hour), 4, 4*time.Hour)
+	s.InDelta(1, forecastHostsNeeded(arrivals, s.now, 30*time.Minute), 0.001)
+}
+
+func (s *PredictiveAllocatorSuite) TestEmptyQueueAndHistory() {
+	data := HostAllocatorData{
+		distros:          map[string]distro.Distro{s.distroName: s.distro},
+		freeHostFraction: 0.5,
+		now:              s.now,
+	}
+
+	hosts, err := PredictiveHostAllocator(s.ctx, data)
+	s.NoError(err)
+	s.Equal(0, hosts[s.distroName])
+}
+
+func (s *PredictiveAllocatorSuite) TestPreSpawnsForExpectedTasks() {
+	data := HostAllocatorData{
+		distros: map[string]distro.Distro{s.distroName: s.distro},
+		taskArrivals: map[string][]arrivalBucket{
+			s.distroName: s.arrivals(s.now.Add(-time.Hour), 40, 15*time.Minute),
+		},
+		freeHostFraction: 0.5,
+		now:              s.now,
+	}
+
+	hosts, err := PredictiveHostAllocator(s.ctx, data)
+	s.NoError(err)
+	s.Equal(5, hosts[s.distroName])
+
+	// free hosts absorb part of the expected tasks
+	data.existingDistroHosts = map[string][]host.Host{
+		s.distroName: {{Id: "h1"}, {Id: "h2"}},
+	}
+	hosts, err = PredictiveHostAllocator(s.ctx, data)
+	s.NoError(err)
+	s.Equal(3, hosts[s.distroName])
+}
+
+func (s *PredictiveAllocatorSuite) TestAddsToHostsForQueue() {
+	data := HostAllocatorData{
+		distros: map[string]distro.Distro{s.distroName: s.distro},
+		taskQueueItems: map[string][]model.TaskQueueItem{
+			s.distroName: {
+				{Id: "t1", ExpectedDuration: 20 * time.Minute},
+				{Id: "t2", ExpectedDuration: 20 * time.Minute},
+				{Id: "t3", ExpectedDuration: 20 * time.Minute},
+			},
+		},
+		taskArrivals: map[string][]arrivalBucket{
+			s.distroName: s.arrivals(s.now.Add(-time.Hour), 40, 15*time.Minute),
+		},
+		freeHostFraction: 0.5,
+		now:              s.now,
+	}
+
+	// the queue needs 2 hosts, and the expected tasks 5 more
+	hosts, err := PredictiveHostAllocator(s.ctx, data)
+	s.NoError(err)
+	s.Equal(7, hosts[s.distroName])
+}
+
+func (s *PredictiveAllocatorSuite) TestRespectsPoolSize() {
+	d := s.distro
+	d.PoolSize = 3
+	data := HostAllocatorData{
+		distros: map[string]distro.Distro{s.distroName: d},
+		existingDistroHosts: map[string][]host.Host{
+			s.distroName: {{Id: "h1"}},
+		},
+		taskArrivals: map[string][]arrivalBucket{
+			s.distroName: s.arrivals(s.now.Add(-time.Hour), 40, 15*time.Minute),
+		},
+		freeHostFraction: 0.5,
+		now:              s.now,
+	}
+
+	hosts, err := PredictiveHostAllocator(s.ctx, data)
+	s.NoError(err)
+	s.Equal(2, hosts[s.distroName])
+}
+
+func (s *PredictiveAllocatorSuite) TestStaticDistro() {
+	d := s.distro
+	d.Provider = evergreen.ProviderNameStatic
+	data := HostAllocatorData{
+		distros: map[string]distro.Distro{s.distroName: d},
+		taskArrivals: map[string][]arrivalBucket{
+			s.distroName: s.arrivals(s.now.Add(-time.Hour), 40, 15*time.Minute),
+		},
+		now: s.now,
+	}
+
+	hosts, err := PredictiveHostAllocator(s.ctx, data)
+	s.NoError(err)
+	s.Equal(0, hosts[s.distroName])
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/anser/bsonutil"
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/message"
@@ -78,8 +79,13 @@ func (s *distroSchedueler) scheduleDistro(distroId string, runnableTasksForDistr
 		return res
 	}
 
+	// the wait time is measured before tasks that weren't scheduled
+	// before are given a scheduled time
+	now := time.Now()
+	maxWaitTime := maxTaskWaitTime(prioritizedTasks, now)
+
 	// track scheduled time for prioritized tasks
-	err = task.SetTasksScheduledTime(prioritizedTasks, time.Now())
+	err = task.SetTasksScheduledTime(prioritizedTasks, now)
 	if err != nil {
 		res.err = errors.Wrapf(err,
 			"Error processing distro %s setting scheduled time for prioritized tasks",
@@ -97,6 +103,7 @@ func (s *distroSchedueler) scheduleDistro(distroId string, runnableTasksForDistr
 		TaskQueueLength:  len(queuedTasks),
 		NumHostsRunning:  0,
 		ExpectedDuration: totalDuration,
+		MaxWaitTime:      maxWaitTime,
 	}
 
 	// final sanity check
@@ -123,6 +130,22 @@ func (s *distroSchedueler) scheduleDistro(distroId string, runnableTasksForDistr
 	return res
 }
 
+// maxTaskWaitTime returns how long the task that has been scheduled for
+// the longest has waited to run.
+func maxTaskWaitTime(tasks []task.Task, now time.Time) time.Duration {
+	var maxWaitTime time.Duration
+	for _, t := range tasks {
+		if util.IsZeroTime(t.ScheduledTime) {
+			continue
+		}
+		if wait := now.Sub(t.ScheduledTime); wait > maxWaitTime {
+			maxWaitTime = wait
+		}
+	}
+
+	return maxWaitTime
+}
+
 // Call out to the embedded Manager to spawn hosts.  Takes in a map of
 // distro -> number of hosts to spawn for the distro.
 // Returns a map of distro -> hosts spawned, and an error if one occurs.
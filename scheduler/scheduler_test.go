@@ -14,6 +14,7 @@ import (
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/evergreen/util"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -570,3 +571,31 @@ func (s *SchedulerSuite) TestSpawnContainersStatic() {
 	s.NotEmpty(newHostsSpawned["distro"][1].ParentID)
 	s.NotEmpty(newHostsSpawned["distro"][2].ParentID)
 }
+
+func TestAtPoolSizeCap(t *testing.T) {
+	d := distro.Distro{
+		Id:       "d",
+		Provider: evergreen.ProviderNameEc2Auto,
+		PoolSize: 5,
+	}
+	assert := assert.New(t)
+	assert.False(atPoolSizeCap(d, 10, 4))
+	assert.True(atPoolSizeCap(d, 10, 5))
+	assert.False(atPoolSizeCap(d, 0, 5))
+
+	d.Provider = evergreen.ProviderNameStatic
+	assert.False(atPoolSizeCap(d, 10, 5))
+}
+
+func TestMaxTaskWaitTime(t *testing.T) {
+	now := time.Now()
+	tasks := []task.Task{
+		{Id: "one", ScheduledTime: now.Add(-time.Hour)},
+		{Id: "two", ScheduledTime: now.Add(-time.Minute)},
+		{Id: "three"},
+	}
+	assert := assert.New(t)
+	assert.Equal(time.Hour, maxTaskWaitTime(tasks, now))
+	assert.Zero(maxTaskWaitTime(tasks[2:], now))
+	assert.Zero(maxTaskWaitTime(nil, now))
+}
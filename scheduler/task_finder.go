@@ -135,7 +135,7 @@ func AlternateTaskFinder(distroID string) ([]task.Task, error) {
 		taskIds = append(taskIds, t)
 	}
 
-	tasksToCache, err := task.Find(task.ByIds(taskIds).WithFields(task.StatusKey))
+	tasksToCache, err := task.Find(task.ByIds(taskIds).WithFields(task.StatusKey, task.DetailsKey))
 	if err != nil {
 		return nil, errors.Wrap(err, "problem finding task dependencies")
 	}
@@ -231,7 +231,7 @@ func ParallelTaskFinder(distroID string) ([]task.Task, error) {
 		go func() {
 			defer wg.Done()
 			for id := range toLookup {
-				nt, err := task.FindOneIdWithFields(id, task.StatusKey)
+				nt, err := task.FindOneIdWithFields(id, task.StatusKey, task.DetailsKey)
 				catcher.Add(err)
 				if nt == nil {
 					continue
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/task"
@@ -143,6 +144,38 @@ func (s *TaskFinderSuite) TestTasksWithUnsatisfiedDependenciesNeverReturned() {
 	s.Len(runnableTasks, 2)
 }
 
+func (s *TaskFinderSuite) TestTasksWithDependencyExpressions() {
+	s.depTasks[0].Status = evergreen.TaskFailed
+	s.depTasks[0].Details = apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: "setup"}
+	s.depTasks[1].Status = evergreen.TaskStarted
+
+	deps := []task.Dependency{
+		{TaskId: s.depTasks[0].Id, Status: evergreen.TaskSucceeded},
+		{TaskId: s.depTasks[1].Id, Status: evergreen.TaskSucceeded},
+	}
+	// met by the first dependency having failed, though the second is
+	// still running
+	s.tasks[0].DependsOn = deps
+	s.tasks[0].DependsOnExpr = "failed(td1) || success(td2)"
+	// met by the first dependency's setup having failed
+	s.tasks[1].DependsOn = deps[:1]
+	s.tasks[1].DependsOnExpr = "setup-failed(td1)"
+	// not met until the second dependency finishes
+	s.tasks[2].DependsOn = deps
+	s.tasks[2].DependsOnExpr = "failed(td1) && success(td2)"
+
+	s.insertTasks()
+
+	runnableTasks, err := s.FindRunnableTasks("")
+	s.NoError(err)
+	ids := []string{}
+	for _, t := range runnableTasks {
+		ids = append(ids, t.Id)
+	}
+	sort.Strings(ids)
+	s.Equal([]string{s.tasks[0].Id, s.tasks[1].Id}, ids)
+}
+
 type TaskFinderComparisonSuite struct {
 	suite.Suite
 	tasksGenerator   func() []task.Task
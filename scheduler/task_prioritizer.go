@@ -2,7 +2,10 @@ package scheduler
 
 import (
 	"fmt"
+	"reflect"
+	"runtime"
 	"sort"
+	"strings"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/task"
@@ -21,6 +24,20 @@ type TaskPrioritizer interface {
 	PrioritizeTasks(distroId string, tasks []task.Task, versions map[string]version.Version) ([]task.Task, error)
 }
 
+// GetTaskPrioritizer returns the named task prioritizer, which defaults
+// to the CmpBasedTaskPrioritizer. The weights are the relative shares of
+// the distro that the fair share prioritizer gives each project.
+func GetTaskPrioritizer(name string, weights map[string]float64) TaskPrioritizer {
+	switch name {
+	case "fairshare":
+		return &FairShareTaskPrioritizer{ProjectWeights: weights}
+	case "comparator":
+		return &CmpBasedTaskPrioritizer{}
+	default:
+		return &CmpBasedTaskPrioritizer{}
+	}
+}
+
 // CmpBasedTaskComparator runs the tasks through a slice of comparator functions
 // determining which is more important.
 type CmpBasedTaskComparator struct {
@@ -71,7 +88,19 @@ func NewCmpBasedTaskComparator() *CmpBasedTaskComparator {
 	}
 }
 
-type CmpBasedTaskPrioritizer struct{}
+type CmpBasedTaskPrioritizer struct {
+	// placements, if not nil, is filled in with a map of task id -> why
+	// the task was placed ahead of the next task in its queue.
+	placements map[string]taskPlacement
+}
+
+// taskPlacement explains why a task was placed ahead of the next task in
+// the queue it was sorted in.
+type taskPlacement struct {
+	queue     string
+	aheadOf   string
+	decidedBy string
+}
 
 // PrioritizeTask prioritizes the tasks to run. First splits the tasks into slices based on
 // whether they are part of patch versions or automatically created versions.
@@ -91,7 +120,8 @@ func (prioritizer *CmpBasedTaskPrioritizer) PrioritizeTasks(distroId string, tas
 		"runner":    RunnerName,
 		"operation": "prioritize tasks",
 	})
-	for _, taskList := range [][]task.Task{taskQueues.RepotrackerTasks, taskQueues.PatchTasks, taskQueues.HighPriorityTasks} {
+	queueNames := []string{"repotracker", "patch", "high_priority"}
+	for i, taskList := range [][]task.Task{taskQueues.RepotrackerTasks, taskQueues.PatchTasks, taskQueues.HighPriorityTasks} {
 
 		comparator.tasks = taskList
 
@@ -122,6 +152,12 @@ func (prioritizer *CmpBasedTaskPrioritizer) PrioritizeTasks(distroId string, tas
 			return nil, errors.New(errString)
 		}
 
+		if prioritizer.placements != nil {
+			if err = comparator.explainPlacements(queueNames[i], prioritizer.placements); err != nil {
+				return nil, errors.Wrap(err, "Error explaining task placements")
+			}
+		}
+
 		prioritizedTaskLists = append(prioritizedTaskLists, comparator.tasks)
 	}
 	prioritizedTaskQueues := CmpBasedTaskQueues{
@@ -198,6 +234,36 @@ func (self *CmpBasedTaskComparator) taskMoreImportantThan(task1,
 	return false, nil
 }
 
+// explainPlacements records which comparator decided that each sorted task
+// is more important than the task after it.
+func (self *CmpBasedTaskComparator) explainPlacements(queue string, placements map[string]taskPlacement) error {
+	for i := range self.tasks {
+		placement := taskPlacement{queue: queue}
+		if i+1 < len(self.tasks) {
+			placement.aheadOf = self.tasks[i+1].Id
+			for _, cmp := range self.comparators {
+				ret, err := cmp(self.tasks[i], self.tasks[i+1], self)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				if ret != 0 {
+					placement.decidedBy = comparatorName(cmp)
+					break
+				}
+			}
+		}
+		placements[self.tasks[i].Id] = placement
+	}
+
+	return nil
+}
+
+// comparatorName returns the name of the comparator function.
+func comparatorName(cmp taskPriorityCmp) string {
+	name := runtime.FuncForPC(reflect.ValueOf(cmp).Pointer()).Name()
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
 // Functions that ensure the CmdBasedTaskPrioritizer implements sort.Interface
 
 func (self *CmpBasedTaskComparator) Len() int {
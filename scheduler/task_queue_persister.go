@@ -19,6 +19,15 @@ type DBTaskQueuePersister struct{}
 // PersistTaskQueue saves the task queue to the database.
 // Returns an error if the db call returns an error.
 func (self *DBTaskQueuePersister) PersistTaskQueue(distro string, tasks []task.Task) ([]model.TaskQueueItem, error) {
+	taskQueue := newTaskQueueItems(tasks)
+	queue := model.NewTaskQueue(distro, taskQueue)
+	err := queue.Save()
+
+	return taskQueue, errors.WithStack(err)
+}
+
+// newTaskQueueItems returns the task queue items for the tasks.
+func newTaskQueueItems(tasks []task.Task) []model.TaskQueueItem {
 	taskQueue := make([]model.TaskQueueItem, 0, len(tasks))
 	for _, t := range tasks {
 		taskQueue = append(taskQueue, model.TaskQueueItem{
@@ -38,8 +47,5 @@ func (self *DBTaskQueuePersister) PersistTaskQueue(distro string, tasks []task.T
 
 	}
 
-	queue := model.NewTaskQueue(distro, taskQueue)
-	err := queue.Save()
-
-	return taskQueue, errors.WithStack(err)
+	return taskQueue
 }
@@ -18,6 +18,8 @@ type Configuration struct {
 	DistroID         string
 	TaskFinder       string
 	HostAllocator    string
+	TaskPrioritizer  string
+	ProjectWeights   map[string]float64
 	FreeHostFraction float64
 }
 
@@ -48,7 +50,7 @@ func PlanDistro(ctx context.Context, conf Configuration, s *evergreen.Settings)
 	}
 
 	ds := &distroSchedueler{
-		TaskPrioritizer:    &CmpBasedTaskPrioritizer{},
+		TaskPrioritizer:    GetTaskPrioritizer(getTaskPrioritizerName(conf, distroSpec), conf.ProjectWeights),
 		TaskQueuePersister: &DBTaskQueuePersister{},
 	}
 
@@ -73,26 +75,9 @@ func PlanDistro(ctx context.Context, conf Configuration, s *evergreen.Settings)
 		return errors.Wrap(err, "with host query")
 	}
 
-	allocatorArgs := HostAllocatorData{
-		taskQueueItems: map[string][]model.TaskQueueItem{
-			conf.DistroID: res.taskQueueItem,
-		},
-		existingDistroHosts: distroHostsMap,
-		distros: map[string]distro.Distro{
-			conf.DistroID: distroSpec,
-		},
-		freeHostFraction: conf.FreeHostFraction,
-	}
-
-	// retrieve container pool information for container distros
-	var pool *evergreen.ContainerPool
-	if distroSpec.ContainerPool != "" {
-		pool = s.ContainerPools.GetContainerPool(distroSpec.ContainerPool)
-		if pool == nil {
-			return errors.Wrap(err, "problem retrieving container pool")
-		}
-		allocatorArgs.usesContainers = true
-		allocatorArgs.containerPool = pool
+	allocatorArgs, pool, err := newHostAllocatorData(conf, distroSpec, res.taskQueueItem, distroHostsMap, s, startAt)
+	if err != nil {
+		return errors.WithStack(err)
 	}
 
 	allocator := GetHostAllocator(conf.HostAllocator)
@@ -107,6 +92,12 @@ func PlanDistro(ctx context.Context, conf Configuration, s *evergreen.Settings)
 	}
 	hostList := hostsSpawned[conf.DistroID]
 
+	numExistingHosts := len(distroHostsMap[conf.DistroID])
+	res.schedulerEvent.NumHostsRunning = numExistingHosts
+	res.schedulerEvent.NumNewHosts = len(hostList)
+	res.schedulerEvent.PoolSize = distroSpec.PoolSize
+	res.schedulerEvent.AtPoolSizeCap = atPoolSizeCap(distroSpec, res.schedulerEvent.TaskQueueLength, numExistingHosts+len(hostList))
+
 	event.LogSchedulerEvent(event.SchedulerEventData{
 		TaskQueueInfo: res.schedulerEvent,
 		DistroId:      conf.DistroID,
@@ -136,3 +127,64 @@ func PlanDistro(ctx context.Context, conf Configuration, s *evergreen.Settings)
 
 	return nil
 }
+
+// atPoolSizeCap returns true if the distro has as many hosts as its pool size
+// allows, while tasks are still queued for it. Static distros are never at
+// their cap, since hosts are not allocated for them.
+func atPoolSizeCap(d distro.Distro, queueLength, numHosts int) bool {
+	if d.Provider == evergreen.ProviderNameStatic || queueLength == 0 {
+		return false
+	}
+
+	return numHosts >= d.PoolSize
+}
+
+// getTaskPrioritizerName returns the name of the distro's task
+// prioritizer, if it has one, or else the one in the configuration.
+func getTaskPrioritizerName(conf Configuration, distroSpec distro.Distro) string {
+	if distroSpec.TaskPrioritizer != "" {
+		return distroSpec.TaskPrioritizer
+	}
+	return conf.TaskPrioritizer
+}
+
+// newHostAllocatorData returns the data for the distro's host allocator,
+// and the distro's container pool, if it has one.
+func newHostAllocatorData(conf Configuration, distroSpec distro.Distro, queue []model.TaskQueueItem,
+	distroHostsMap map[string][]host.Host, s *evergreen.Settings, now time.Time) (HostAllocatorData, *evergreen.ContainerPool, error) {
+
+	allocatorArgs := HostAllocatorData{
+		taskQueueItems: map[string][]model.TaskQueueItem{
+			conf.DistroID: queue,
+		},
+		existingDistroHosts: distroHostsMap,
+		distros: map[string]distro.Distro{
+			conf.DistroID: distroSpec,
+		},
+		freeHostFraction: conf.FreeHostFraction,
+	}
+
+	// retrieve container pool information for container distros
+	var pool *evergreen.ContainerPool
+	if distroSpec.ContainerPool != "" {
+		pool = s.ContainerPools.GetContainerPool(distroSpec.ContainerPool)
+		if pool == nil {
+			return HostAllocatorData{}, nil, errors.Errorf("problem retrieving container pool '%s'", distroSpec.ContainerPool)
+		}
+		allocatorArgs.usesContainers = true
+		allocatorArgs.containerPool = pool
+	}
+
+	if conf.HostAllocator == "predictive" {
+		arrivals, err := findTaskArrivals(conf.DistroID, now, allocatorArgs.usesContainers)
+		if err != nil {
+			return HostAllocatorData{}, nil, errors.Wrap(err, "problem finding task arrival history")
+		}
+		allocatorArgs.taskArrivals = map[string][]arrivalBucket{
+			conf.DistroID: arrivals,
+		}
+		allocatorArgs.now = now
+	}
+
+	return allocatorArgs, pool, nil
+}
@@ -198,6 +198,8 @@ func (uis *UIServer) schedulePatch(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		projCtx.Patch.Activated = true
+		// the user picked these tasks, so none are skipped
+		projCtx.Patch.FilterByPaths = false
 		err = projCtx.Patch.SetVariantsTasks(tasks.TVPairsToVariantTasks())
 		if err != nil {
 			uis.LoggedError(w, r, http.StatusInternalServerError,
@@ -208,6 +210,8 @@ func (uis *UIServer) schedulePatch(w http.ResponseWriter, r *http.Request) {
 		requester := evergreen.PatchVersionRequester
 		if projCtx.Patch.IsGithubPRPatch() {
 			requester = evergreen.GithubPRRequester
+		} else if projCtx.Patch.IsGitlabMRPatch() {
+			requester = evergreen.GitlabMRRequester
 		}
 
 		ctx, cancel := context.WithCancel(r.Context())
@@ -227,6 +231,13 @@ func (uis *UIServer) schedulePatch(w http.ResponseWriter, r *http.Request) {
 					errors.Wrap(err, "Error adding github status update job to queue"))
 				return
 			}
+		} else if projCtx.Patch.IsGitlabMRPatch() {
+			job := units.NewGitlabStatusUpdateJobForNewPatch(projCtx.Patch.Id.Hex())
+			if err := uis.queue.Put(job); err != nil {
+				uis.LoggedError(w, r, http.StatusInternalServerError,
+					errors.Wrap(err, "Error adding gitlab status update job to queue"))
+				return
+			}
 		}
 
 		PushFlash(uis.CookieStore, r, w, NewSuccessFlash("Patch builds are scheduled."))
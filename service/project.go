@@ -184,24 +184,27 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 	}
 
 	responseRef := struct {
-		Identifier         string               `json:"id"`
-		DisplayName        string               `json:"display_name"`
-		RemotePath         string               `json:"remote_path"`
-		BatchTime          int                  `json:"batch_time"`
-		DeactivatePrevious bool                 `json:"deactivate_previous"`
-		Branch             string               `json:"branch_name"`
-		ProjVarsMap        map[string]string    `json:"project_vars"`
-		ProjectAliases     []model.ProjectAlias `json:"project_aliases"`
-		DeleteAliases      []string             `json:"delete_aliases"`
-		PrivateVars        map[string]bool      `json:"private_vars"`
-		Enabled            bool                 `json:"enabled"`
-		Private            bool                 `json:"private"`
-		Owner              string               `json:"owner_name"`
-		Repo               string               `json:"repo_name"`
-		Admins             []string             `json:"admins"`
-		TracksPushEvents   bool                 `json:"tracks_push_events"`
-		PRTestingEnabled   bool                 `json:"pr_testing_enabled"`
-		PatchingDisabled   bool                 `json:"patching_disabled"`
+		Identifier         string                  `json:"id"`
+		DisplayName        string                  `json:"display_name"`
+		RemotePath         string                  `json:"remote_path"`
+		BatchTime          int                     `json:"batch_time"`
+		DeactivatePrevious bool                    `json:"deactivate_previous"`
+		Branch             string                  `json:"branch_name"`
+		ProjVarsMap        map[string]string       `json:"project_vars"`
+		ProjectAliases     []model.ProjectAlias    `json:"project_aliases"`
+		DeleteAliases      []string                `json:"delete_aliases"`
+		PrivateVars        map[string]bool         `json:"private_vars"`
+		Enabled            bool                    `json:"enabled"`
+		Private            bool                    `json:"private"`
+		Owner              string                  `json:"owner_name"`
+		Repo               string                  `json:"repo_name"`
+		RepoKind           string                  `json:"repo_kind"`
+		RepoURL            string                  `json:"repo_url"`
+		Admins             []string                `json:"admins"`
+		TracksPushEvents   bool                    `json:"tracks_push_events"`
+		PRTestingEnabled   bool                    `json:"pr_testing_enabled"`
+		CommitQueue        model.CommitQueueParams `json:"commit_queue"`
+		PatchingDisabled   bool                    `json:"patching_disabled"`
 		AlertConfig        map[string][]struct {
 			Provider string                 `json:"provider"`
 			Settings map[string]interface{} `json:"settings"`
@@ -223,6 +226,23 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if responseRef.RepoKind == "" {
+		responseRef.RepoKind = projectRef.RepoKind
+	}
+	if responseRef.RepoKind == "" {
+		responseRef.RepoKind = model.GithubRepoType
+	}
+	if !util.StringSliceContains(model.ValidRepoTypes, responseRef.RepoKind) {
+		http.Error(w, fmt.Sprintf("'%s' is not a valid repo kind", responseRef.RepoKind), http.StatusBadRequest)
+		return
+	}
+	if responseRef.RepoKind == model.GitRepoType {
+		if err = model.ValidateRepoURL(responseRef.RepoURL); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
 	errs := []string{}
 	for i, pd := range responseRef.ProjectAliases {
 		if strings.TrimSpace(pd.Alias) == "" {
@@ -252,6 +272,16 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if responseRef.CommitQueue.Enabled {
+		if responseRef.CommitQueue.MergeMethod == "" {
+			responseRef.CommitQueue.MergeMethod = model.CommitQueueMergeMethodSquash
+		}
+		if !util.StringSliceContains(model.ValidCommitQueueMergeMethods, responseRef.CommitQueue.MergeMethod) {
+			http.Error(w, fmt.Sprintf("'%s' is not a valid merge method", responseRef.CommitQueue.MergeMethod), http.StatusBadRequest)
+			return
+		}
+	}
+
 	if responseRef.PRTestingEnabled {
 		var conflictingRefs []model.ProjectRef
 		conflictingRefs, err = model.FindProjectRefsByRepoAndBranch(responseRef.Owner, responseRef.Repo, responseRef.Branch)
@@ -276,10 +306,13 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 	projectRef.Owner = responseRef.Owner
 	projectRef.DeactivatePrevious = responseRef.DeactivatePrevious
 	projectRef.Repo = responseRef.Repo
+	projectRef.RepoKind = responseRef.RepoKind
+	projectRef.RepoURL = responseRef.RepoURL
 	projectRef.Admins = responseRef.Admins
 	projectRef.Identifier = id
 	projectRef.TracksPushEvents = responseRef.TracksPushEvents
 	projectRef.PRTestingEnabled = responseRef.PRTestingEnabled
+	projectRef.CommitQueue = responseRef.CommitQueue
 	projectRef.PatchingDisabled = responseRef.PatchingDisabled
 	projectRef.NotifyOnBuildFailure = responseRef.NotifyOnBuildFailure
 
@@ -297,6 +330,9 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 	if !projectRef.Enabled {
 		projectRef.PRTestingEnabled = false
 	}
+	if !projectRef.PRTestingEnabled {
+		projectRef.CommitQueue.Enabled = false
+	}
 
 	projectVars, err := model.FindOneProjectVars(id)
 	if err != nil {
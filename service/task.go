@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -59,6 +60,11 @@ type uiTaskData struct {
 	DependsOn        []uiDep                 `json:"depends_on"`
 	IngestTime       time.Time               `json:"ingest_time"`
 
+	// the dependency expression and its unmet clauses, with
+	// dependencies named by display name
+	DependsOnExpr     string   `json:"depends_on_expr,omitempty"`
+	UnmetDependencies []string `json:"unmet_dependencies,omitempty"`
+
 	// from the host doc (the dns name)
 	HostDNS string `json:"host_dns,omitempty"`
 	// from the host doc (the host id)
@@ -241,6 +247,11 @@ func (uis *UIServer) taskPage(w http.ResponseWriter, r *http.Request) {
 
 	uiTask.DependsOn = deps
 	uiTask.TaskWaiting = taskWaiting
+	uiTask.DependsOnExpr, uiTask.UnmetDependencies, err = describeTaskDependencies(projCtx.Task, deps)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	uiTask.MinQueuePos, err = model.FindMinimumQueuePositionForTask(uiTask.Id)
 	if err != nil {
 		uis.LoggedError(w, r, http.StatusInternalServerError, err)
@@ -372,7 +383,7 @@ func getTaskDependencies(t *task.Task) ([]uiDep, string, error) {
 		depIds = append(depIds, dep.TaskId)
 	}
 	dependencies, err := task.Find(task.ByIds(depIds).WithFields(task.DisplayNameKey, task.StatusKey,
-		task.ActivatedKey, task.BuildVariantKey, task.DetailsKey, task.DependsOnKey))
+		task.ActivatedKey, task.BuildVariantKey, task.DetailsKey, task.DependsOnKey, task.DependsOnExprKey))
 	if err != nil {
 		return nil, "", err
 	}
@@ -418,6 +429,35 @@ func getTaskDependencies(t *task.Task) ([]uiDep, string, error) {
 	return uiDeps, status, nil
 }
 
+// describeTaskDependencies returns the task's dependency expression and the
+// requirements on its dependencies that aren't met yet, with dependencies
+// named by their display name, and variant if it differs from the task's,
+// rather than by id.
+func describeTaskDependencies(t *task.Task, deps []uiDep) (string, []string, error) {
+	depTasks := make(map[string]task.Task, len(deps))
+	names := make([]string, 0, 2*len(deps))
+	for _, dep := range deps {
+		depTasks[dep.Id] = task.Task{Id: dep.Id, Status: dep.Status, Details: dep.Details}
+		name := dep.Name
+		if dep.BuildVariant != t.BuildVariant {
+			name = fmt.Sprintf("%s, %s", dep.Name, dep.BuildVariant)
+		}
+		names = append(names, dep.Id, name)
+	}
+
+	unmet, err := t.UnmetDependencies(depTasks)
+	if err != nil {
+		return "", nil, errors.WithStack(err)
+	}
+
+	replacer := strings.NewReplacer(names...)
+	for i := range unmet {
+		unmet[i] = replacer.Replace(unmet[i])
+	}
+
+	return replacer.Replace(t.DependsOnExpr), unmet, nil
+}
+
 // addRecDeps recursively finds all dependencies of tasks and adds them to tasks and uiDeps.
 // done is a hashtable of task IDs whose dependencies we have found.
 // TODO EVG-614: delete this function once Task.DependsOn includes all recursive dependencies.
@@ -438,7 +478,7 @@ func addRecDeps(tasks map[string]task.Task, uiDeps map[string]uiDep, done map[st
 	}
 
 	deps, err := task.Find(task.ByIds(depIds).WithFields(task.DisplayNameKey, task.StatusKey, task.ActivatedKey,
-		task.BuildVariantKey, task.DetailsKey, task.DependsOnKey))
+		task.BuildVariantKey, task.DetailsKey, task.DependsOnKey, task.DependsOnExprKey))
 
 	if err != nil {
 		return err
@@ -481,22 +521,53 @@ func addRecDeps(tasks map[string]task.Task, uiDeps map[string]uiDep, done map[st
 func setBlockedOrPending(t task.Task, tasks map[string]task.Task, uiDeps map[string]uiDep) string {
 	blocked := false
 	pending := false
+
+	// dependencies in the task's dependency expression only block the task
+	// once the expression can't be satisfied
+	var expr *task.DependencyExpression
+	inExpr := map[string]bool{}
+	exprPending := false
+	if t.DependsOnExpr != "" {
+		var err error
+		if expr, err = task.ParseDependencyExpression(t.DependsOnExpr); err == nil {
+			for _, dep := range expr.Dependencies() {
+				inExpr[dep.Name] = true
+			}
+		} else {
+			expr = nil
+		}
+	}
+
 	for _, dep := range t.DependsOn {
 		depTask := tasks[dep.TaskId]
 
 		uid := uiDeps[depTask.Id]
 		uid.TaskWaiting = setBlockedOrPending(depTask, tasks, uiDeps)
 		uiDeps[depTask.Id] = uid
+		finished := depTask.Status == evergreen.TaskSucceeded || depTask.Status == evergreen.TaskFailed
+		if inExpr[dep.TaskId] {
+			if uid.TaskWaiting != TaskBlocked && !finished {
+				exprPending = true
+			}
+			continue
+		}
 		if uid.TaskWaiting == TaskBlocked {
 			blocked = true
-		} else if depTask.Status == evergreen.TaskSucceeded || depTask.Status == evergreen.TaskFailed {
-			if depTask.Status != dep.Status && dep.Status != model.AllStatuses {
+		} else if finished {
+			if !dep.SatisfiedBy(&depTask) {
 				blocked = true
 			}
 		} else {
 			pending = true
 		}
 	}
+	if expr != nil && !expr.Satisfied(tasks) {
+		if exprPending {
+			pending = true
+		} else {
+			blocked = true
+		}
+	}
 	if blocked {
 		return TaskBlocked
 	}
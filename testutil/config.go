@@ -92,9 +92,22 @@ func MockConfig() *evergreen.Settings {
 				},
 			},
 		},
-		Credentials:        map[string]string{"k1": "v1"},
+		Credentials: map[string]string{"k1": "v1"},
+		EventRetention: evergreen.EventRetentionConfig{
+			DefaultTTLDays: 365,
+			Policies: []evergreen.EventRetentionPolicy{
+				{ResourceType: "SCHEDULER", TTLDays: 30},
+			},
+			Archive:   evergreen.EventArchiveCollection,
+			BatchSize: 500,
+		},
 		Expansions:         map[string]string{"k2": "v2"},
 		GithubPRCreatorOrg: "org",
+		Gitlab: evergreen.GitlabConfig{
+			URL:           "https://gitlab.example.com",
+			Token:         "token",
+			WebhookSecret: "secret",
+		},
 		HostInit: evergreen.HostInitConfig{
 			SSHTimeoutSeconds: 10,
 		},
@@ -120,6 +133,11 @@ func MockConfig() *evergreen.Settings {
 		},
 		Plugins:   map[string]map[string]interface{}{"k4": map[string]interface{}{"k5": "v5"}},
 		PprofPort: "port",
+		PriceTable: evergreen.PriceTableConfig{
+			Distros:        []evergreen.HourlyPrice{{Id: "static-distro", Price: 0.5}},
+			InstanceTypes:  []evergreen.HourlyPrice{{Id: "large", Price: 1.5}},
+			ContainerPools: []evergreen.HourlyPrice{{Id: "test-pool-1", Price: 0.1}},
+		},
 		Providers: evergreen.CloudProviders{
 			AWS: evergreen.AWSConfig{
 				Secret: "aws_secret",
@@ -134,6 +152,11 @@ func MockConfig() *evergreen.Settings {
 				PrivateKeyID: "gce_key_id",
 				TokenURI:     "gce_token",
 			},
+			Kubernetes: evergreen.KubernetesConfig{
+				APIServer: "https://kubernetes.example.com",
+				Token:     "kubernetes_token",
+				Namespace: "evergreen",
+			},
 			OpenStack: evergreen.OpenStackConfig{
 				IdentityEndpoint: "endpoint",
 				Username:         "username",
@@ -153,9 +176,14 @@ func MockConfig() *evergreen.Settings {
 			NumNewRepoRevisionsToFetch: 10,
 			MaxRepoRevisionsToSearch:   20,
 			MaxConcurrentRequests:      30,
+			MirrorDirectory:            "/srv/mirrors",
 		},
 		Scheduler: evergreen.SchedulerConfig{
-			TaskFinder: "legacy",
+			TaskFinder:      "legacy",
+			TaskPrioritizer: "fairshare",
+			ProjectWeights: []evergreen.ProjectWeight{
+				{Project: "mci", Weight: 2},
+			},
 		},
 		ServiceFlags: evergreen.ServiceFlags{
 			TaskDispatchDisabled:         true,
@@ -166,6 +194,8 @@ func MockConfig() *evergreen.Settings {
 			RepotrackerDisabled:          true,
 			SchedulerDisabled:            true,
 			GithubPRTestingDisabled:      true,
+			GitlabMRTestingDisabled:      true,
+			CommitQueueDisabled:          true,
 			RepotrackerPushEventDisabled: true,
 			CLIUpdatesDisabled:           true,
 			EventProcessingDisabled:      true,
@@ -174,6 +204,7 @@ func MockConfig() *evergreen.Settings {
 			EmailNotificationsDisabled:   true,
 			WebhookNotificationsDisabled: true,
 			GithubStatusAPIDisabled:      true,
+			GitlabStatusAPIDisabled:      true,
 		},
 		Slack: evergreen.SlackConfig{
 			Options: &send.SlackOptions{
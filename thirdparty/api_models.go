@@ -41,6 +41,12 @@ func (nfe FileNotFoundError) Error() string {
 	return fmt.Sprintf("Requested file at %v not found", nfe.filepath)
 }
 
+// NewFileNotFoundError returns an error for a file that doesn't exist at the
+// path, for repositories that aren't fetched from github.
+func NewFileNotFoundError(path string) FileNotFoundError {
+	return FileNotFoundError{filepath: path}
+}
+
 func IsFileNotFound(err error) bool {
 	_, ok := err.(FileNotFoundError)
 	return ok
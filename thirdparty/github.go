@@ -614,6 +614,28 @@ func GithubUserInOrganization(ctx context.Context, token, requiredOrganization,
 	return isMember, err
 }
 
+// GithubUserHasWritePermission returns true if the given github user has
+// write or admin permission on the repository.
+func GithubUserHasWritePermission(ctx context.Context, token, owner, repo, username string) (bool, error) {
+	httpClient, err := getGithubClient(token)
+	if err != nil {
+		return false, errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+
+	client := github.NewClient(httpClient)
+	level, _, err := client.Repositories.GetPermissionLevel(ctx, owner, repo, username)
+	if err != nil {
+		return false, errors.Wrapf(err, "can't get the permission of '%s' on %s/%s", username, owner, repo)
+	}
+	if level == nil {
+		return false, errors.New("empty data received from github")
+	}
+
+	permission := level.GetPermission()
+	return permission == "admin" || permission == "write", nil
+}
+
 // GetPullRequestMergeBase returns the merge base hash for the given PR.
 // This function will retry up to 5 times, regardless of error response (unless
 // error is the result of hitting an api limit)
@@ -722,3 +744,71 @@ func buildPatchURL(gp *patch.GithubPatch) string {
 
 	return url.String()
 }
+
+// GetGithubPullRequest fetches a pull request
+func GetGithubPullRequest(ctx context.Context, token, owner, repo string, number int) (*github.PullRequest, error) {
+	httpClient, err := getGithubClient(token)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	pr, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't get pull request %s/%s#%d", owner, repo, number)
+	}
+	if pr == nil {
+		return nil, errors.Errorf("pull request %s/%s#%d not found", owner, repo, number)
+	}
+
+	return pr, nil
+}
+
+// MergePullRequest merges a pull request with the merge method, but only if
+// its head is the revision with the SHA
+func MergePullRequest(ctx context.Context, token, owner, repo string, number int, sha, mergeMethod, commitTitle string) error {
+	httpClient, err := getGithubClient(token)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	res, _, err := client.PullRequests.Merge(ctx, owner, repo, number, "", &github.PullRequestOptions{
+		CommitTitle: commitTitle,
+		SHA:         sha,
+		MergeMethod: mergeMethod,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "can't merge pull request %s/%s#%d", owner, repo, number)
+	}
+	if res == nil || res.Merged == nil || !*res.Merged {
+		msg := ""
+		if res != nil && res.Message != nil {
+			msg = *res.Message
+		}
+		return errors.Errorf("pull request %s/%s#%d was not merged: %s", owner, repo, number, msg)
+	}
+
+	return nil
+}
+
+// PostCommentToPullRequest posts a comment on a pull request
+func PostCommentToPullRequest(ctx context.Context, token, owner, repo string, number int, comment string) error {
+	httpClient, err := getGithubClient(token)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	_, _, err = client.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{
+		Body: github.String(comment),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "can't comment on pull request %s/%s#%d", owner, repo, number)
+	}
+
+	return nil
+}
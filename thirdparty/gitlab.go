@@ -0,0 +1,202 @@
+package thirdparty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+const gitlabRequestTimeout = 30 * time.Second
+
+// GitlabMergeRequestChange is a file that a GitLab merge request changes,
+// with its diff. GitLab omits the diff of binary files and of files with
+// very large diffs.
+type GitlabMergeRequestChange struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	AMode       string `json:"a_mode"`
+	BMode       string `json:"b_mode"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+	Diff        string `json:"diff"`
+}
+
+// gitlabProjectURL returns the API URL of a GitLab project, which the API
+// identifies by its URL-encoded namespaced path.
+func gitlabProjectURL(baseURL, projectPath string) string {
+	return fmt.Sprintf("%s/api/v4/projects/%s", strings.TrimRight(baseURL, "/"), url.PathEscape(projectPath))
+}
+
+// gitlabRequest makes a GET request to the GitLab API, and returns the
+// response if its status is 200 OK. The caller must close the body of the
+// response.
+func gitlabRequest(ctx context.Context, token, requestURL string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gitlab request")
+	}
+	req.Header.Add("PRIVATE-TOKEN", token)
+	req = req.WithContext(ctx)
+
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch data from gitlab")
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return resp, errors.Errorf("Expected 200 OK from gitlab, got %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	return resp, nil
+}
+
+func getGitlabJSON(ctx context.Context, token, requestURL string, out interface{}) error {
+	resp, err := gitlabRequest(ctx, token, requestURL)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, patch.SizeLimit)).Decode(out); err != nil {
+		return errors.Wrap(err, "failed to parse gitlab response")
+	}
+
+	return nil
+}
+
+// GetGitlabMergeBase returns the best common ancestor of the base branch and
+// the head revision of a merge request in a GitLab project.
+func GetGitlabMergeBase(ctx context.Context, baseURL, token, projectPath, base, head string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitlabRequestTimeout)
+	defer cancel()
+
+	query := url.Values{}
+	query.Add("refs[]", base)
+	query.Add("refs[]", head)
+	commit := struct {
+		ID string `json:"id"`
+	}{}
+	requestURL := fmt.Sprintf("%s/repository/merge_base?%s", gitlabProjectURL(baseURL, projectPath), query.Encode())
+	if err := getGitlabJSON(ctx, token, requestURL, &commit); err != nil {
+		return "", errors.Wrapf(err, "can't find merge base of '%s' and '%s' in '%s'", base, head, projectPath)
+	}
+	if commit.ID == "" {
+		return "", errors.Errorf("gitlab returned no merge base of '%s' and '%s' in '%s'", base, head, projectPath)
+	}
+
+	return commit.ID, nil
+}
+
+// GetGitlabMergeRequestDiff returns the diff of a GitLab merge request from
+// its merge base, in the format of git diff, with summaries of its changes.
+func GetGitlabMergeRequestDiff(ctx context.Context, baseURL, token string, gl *patch.GitlabPatch) (string, []patch.Summary, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitlabRequestTimeout)
+	defer cancel()
+
+	mr := struct {
+		Changes []GitlabMergeRequestChange `json:"changes"`
+	}{}
+	requestURL := fmt.Sprintf("%s/merge_requests/%d/changes", gitlabProjectURL(baseURL, gl.ProjectPath), gl.MRNumber)
+	if err := getGitlabJSON(ctx, token, requestURL, &mr); err != nil {
+		return "", nil, errors.Wrap(err, "failed to fetch diff from gitlab")
+	}
+
+	diff := BuildGitlabDiff(mr.Changes)
+	if len(diff) == 0 || len(diff) > patch.SizeLimit {
+		return "", nil, errors.Errorf("Patch contents must be at least 1 byte and no greater than %d bytes; was %d bytes",
+			patch.SizeLimit, len(diff))
+	}
+
+	summaries, err := GetPatchSummaries(diff)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to get patch summary")
+	}
+
+	return diff, summaries, nil
+}
+
+// BuildGitlabDiff joins the changes of a merge request into one diff in the
+// format of git diff, since the GitLab API returns only the hunks of each
+// file.
+func BuildGitlabDiff(changes []GitlabMergeRequestChange) string {
+	diff := &bytes.Buffer{}
+	for _, change := range changes {
+		fmt.Fprintf(diff, "diff --git a/%s b/%s\n", change.OldPath, change.NewPath)
+
+		oldPath := "a/" + change.OldPath
+		newPath := "b/" + change.NewPath
+		switch {
+		case change.NewFile:
+			fmt.Fprintf(diff, "new file mode %s\n", gitlabFileMode(change.BMode))
+			oldPath = "/dev/null"
+		case change.DeletedFile:
+			fmt.Fprintf(diff, "deleted file mode %s\n", gitlabFileMode(change.AMode))
+			newPath = "/dev/null"
+		default:
+			if change.AMode != change.BMode && change.AMode != "" && change.BMode != "" {
+				fmt.Fprintf(diff, "old mode %s\nnew mode %s\n", change.AMode, change.BMode)
+			}
+			if change.RenamedFile {
+				fmt.Fprintf(diff, "rename from %s\nrename to %s\n", change.OldPath, change.NewPath)
+			}
+		}
+
+		if change.Diff == "" {
+			continue
+		}
+		fmt.Fprintf(diff, "--- %s\n+++ %s\n", oldPath, newPath)
+		diff.WriteString(change.Diff)
+		if !strings.HasSuffix(change.Diff, "\n") {
+			diff.WriteString("\n")
+		}
+	}
+
+	return diff.String()
+}
+
+func gitlabFileMode(mode string) string {
+	if mode == "" || mode == "0" {
+		return "100644"
+	}
+	return mode
+}
+
+// GetGitlabFile returns the contents of a file in a GitLab project at the
+// given revision.
+func GetGitlabFile(ctx context.Context, baseURL, token, projectPath, path, revision string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitlabRequestTimeout)
+	defer cancel()
+
+	requestURL := fmt.Sprintf("%s/repository/files/%s/raw?ref=%s", gitlabProjectURL(baseURL, projectPath),
+		url.PathEscape(path), url.QueryEscape(revision))
+	resp, err := gitlabRequest(ctx, token, requestURL)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, FileNotFoundError{filepath: path}
+	}
+	if err != nil {
+		return nil, APIResponseError{fmt.Sprintf("error querying '%s' for '%s': %v", projectPath, path, err)}
+	}
+	defer resp.Body.Close()
+
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, ResponseReadError{err.Error()}
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,137 @@
+package thirdparty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitlabStandIn returns a server that answers requests for the GitLab API
+// of the project group/repo, and records the tokens that it was sent.
+func newGitlabStandIn(t *testing.T, tokens *[]string) *httptest.Server {
+	mux := http.NewServeMux()
+	project := "/api/v4/projects/group%2Frepo"
+	mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+		*tokens = append(*tokens, r.Header.Get("PRIVATE-TOKEN"))
+
+		switch r.URL.EscapedPath() {
+		case project + "/repository/merge_base":
+			refs := r.URL.Query()["refs[]"]
+			if len(refs) != 2 || refs[0] != "master" || refs[1] != "abcdef" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]string{"id": "123456"}))
+
+		case project + "/merge_requests/7/changes":
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"changes": []GitlabMergeRequestChange{
+					{
+						OldPath: "main.go",
+						NewPath: "main.go",
+						AMode:   "100644",
+						BMode:   "100644",
+						Diff:    "@@ -1 +1,3 @@\n package main\n+\n+func main() {}\n",
+					},
+					{
+						OldPath: "README.md",
+						NewPath: "README.md",
+						BMode:   "100644",
+						NewFile: true,
+						Diff:    "@@ -0,0 +1 @@\n+# repo\n",
+					},
+				},
+			}))
+
+		case project + "/repository/files/dir%2Fevergreen.yml/raw":
+			if r.URL.Query().Get("ref") != "123456" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte("tasks:\n- name: compile\n"))
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestGitlabMergeBase(t *testing.T) {
+	assert := assert.New(t)
+	tokens := []string{}
+	server := newGitlabStandIn(t, &tokens)
+	defer server.Close()
+	ctx := context.Background()
+
+	hash, err := GetGitlabMergeBase(ctx, server.URL, "token", "group/repo", "master", "abcdef")
+	assert.NoError(err)
+	assert.Equal("123456", hash)
+	assert.Equal([]string{"token"}, tokens)
+
+	_, err = GetGitlabMergeBase(ctx, server.URL, "token", "group/repo", "master", "fedcba")
+	assert.Error(err)
+	_, err = GetGitlabMergeBase(ctx, server.URL, "token", "group/other", "master", "abcdef")
+	assert.Error(err)
+}
+
+func TestGitlabMergeRequestDiff(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	tokens := []string{}
+	server := newGitlabStandIn(t, &tokens)
+	defer server.Close()
+
+	diff, summaries, err := GetGitlabMergeRequestDiff(context.Background(), server.URL, "token", &patch.GitlabPatch{
+		ProjectPath: "group/repo",
+		MRNumber:    7,
+	})
+	require.NoError(err)
+	assert.Contains(diff, "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1 +1,3 @@\n")
+	assert.Contains(diff, "diff --git a/README.md b/README.md\nnew file mode 100644\n--- /dev/null\n+++ b/README.md\n")
+	require.Len(summaries, 2)
+	assert.Equal("main.go", summaries[0].Name)
+	assert.Equal(2, summaries[0].Additions)
+	assert.Equal("README.md", summaries[1].Name)
+	assert.Equal(1, summaries[1].Additions)
+
+	_, _, err = GetGitlabMergeRequestDiff(context.Background(), server.URL, "token", &patch.GitlabPatch{
+		ProjectPath: "group/repo",
+		MRNumber:    8,
+	})
+	assert.Error(err)
+}
+
+func TestBuildGitlabDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(BuildGitlabDiff(nil))
+	assert.Equal("diff --git a/old.go b/new.go\nrename from old.go\nrename to new.go\n",
+		BuildGitlabDiff([]GitlabMergeRequestChange{{OldPath: "old.go", NewPath: "new.go", AMode: "100644", BMode: "100644", RenamedFile: true}}))
+	assert.Equal("diff --git a/gone.go b/gone.go\ndeleted file mode 100644\n--- a/gone.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-package gone\n",
+		BuildGitlabDiff([]GitlabMergeRequestChange{{OldPath: "gone.go", NewPath: "gone.go", DeletedFile: true, Diff: "@@ -1 +0,0 @@\n-package gone"}}))
+	assert.Equal("diff --git a/run.sh b/run.sh\nold mode 100644\nnew mode 100755\n",
+		BuildGitlabDiff([]GitlabMergeRequestChange{{OldPath: "run.sh", NewPath: "run.sh", AMode: "100644", BMode: "100755"}}))
+}
+
+func TestGitlabFile(t *testing.T) {
+	assert := assert.New(t)
+	tokens := []string{}
+	server := newGitlabStandIn(t, &tokens)
+	defer server.Close()
+	ctx := context.Background()
+
+	contents, err := GetGitlabFile(ctx, server.URL, "token", "group/repo", "dir/evergreen.yml", "123456")
+	assert.NoError(err)
+	assert.Equal("tasks:\n- name: compile\n", string(contents))
+
+	_, err = GetGitlabFile(ctx, server.URL, "token", "group/repo", "dir/evergreen.yml", "abcdef")
+	assert.True(IsFileNotFound(err))
+}
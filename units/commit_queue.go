@@ -0,0 +1,393 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/google/go-github/github"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/level"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/mongodb/grip/sometimes"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	commitQueueJobName = "commit-queue"
+
+	// commitQueueStatusContext is the context of the statuses that the
+	// commit queue sets on pull requests, which is separate from the
+	// status of PR testing.
+	commitQueueStatusContext = "evergreen/commitqueue"
+
+	// commitQueueMaxProcessingTime is how long the item at the head of
+	// the queue may be tested before it's removed, so that a patch that
+	// never finishes doesn't block the rest of the queue.
+	commitQueueMaxProcessingTime = 6 * time.Hour
+)
+
+func init() {
+	registry.AddJobType(commitQueueJobName, func() amboy.Job { return makeCommitQueueJob() })
+}
+
+// commitQueueJob advances a project's commit queue. It tests the pull
+// request at the head of the queue on top of the project's branch, and once
+// the patch that tests it finishes, merges it if the patch passed and
+// removes it from the queue either way.
+type commitQueueJob struct {
+	job.Base  `bson:"job_base" json:"job_base" yaml:"job_base"`
+	ProjectID string `bson:"project_id" json:"project_id" yaml:"project_id"`
+
+	env     evergreen.Environment
+	sender  send.Sender
+	urlBase string
+}
+
+func makeCommitQueueJob() *commitQueueJob {
+	j := &commitQueueJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    commitQueueJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewCommitQueueJob creates a job to advance the commit queue of a project.
+func NewCommitQueueJob(env evergreen.Environment, projectID, id string) amboy.Job {
+	j := makeCommitQueueJob()
+	j.ProjectID = projectID
+	j.env = env
+
+	j.SetID(fmt.Sprintf("%s:%s-%s", commitQueueJobName, projectID, id))
+	return j
+}
+
+func (j *commitQueueJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving admin settings"))
+		return
+	}
+	if flags.CommitQueueDisabled {
+		grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+			"job":     commitQueueJobName,
+			"project": j.ProjectID,
+			"message": "commit queue is disabled",
+		})
+		return
+	}
+
+	projectRef, err := model.FindOneProjectRef(j.ProjectID)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "can't find project '%s'", j.ProjectID))
+		return
+	}
+	if projectRef == nil {
+		j.AddError(errors.Errorf("project '%s' not found", j.ProjectID))
+		return
+	}
+	if !projectRef.Enabled || !projectRef.PRTestingEnabled || !projectRef.CommitQueue.Enabled {
+		return
+	}
+
+	cq, err := commitqueue.FindOneId(j.ProjectID)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if cq == nil {
+		return
+	}
+	next, ok := cq.Next()
+	if !ok {
+		return
+	}
+
+	githubToken, err := j.env.Settings().GetGithubOauthToken()
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	j.urlBase = j.env.Settings().Ui.Url
+	if j.sender == nil {
+		j.sender, err = j.env.GetSender(evergreen.SenderGithubStatus)
+		if err != nil {
+			j.AddError(err)
+			return
+		}
+	}
+
+	prNum, err := strconv.Atoi(next.Issue)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "'%s' is not a pull request number", next.Issue))
+		j.dequeue(cq, next, "")
+		return
+	}
+
+	if next.Version == "" {
+		j.AddError(j.startItem(ctx, cq, next, prNum, projectRef, githubToken))
+		return
+	}
+	j.AddError(j.finishItem(ctx, cq, next, prNum, projectRef, githubToken))
+}
+
+// startItem creates and finalizes the patch that tests a pull request on
+// top of the project's branch.
+func (j *commitQueueJob) startItem(ctx context.Context, cq *commitqueue.CommitQueue, next commitqueue.CommitQueueItem,
+	prNum int, projectRef *model.ProjectRef, githubToken string) error {
+	pr, err := thirdparty.GetGithubPullRequest(ctx, githubToken, projectRef.Owner, projectRef.Repo, prNum)
+	if err != nil {
+		return err
+	}
+	if err = validateCommitQueuePR(pr, projectRef); err != nil {
+		j.dequeue(cq, next, "")
+		if pr.Head != nil && pr.Head.SHA != nil {
+			j.sendStatus(projectRef, *pr.Head.SHA, message.GithubStateFailure, "", err.Error())
+		}
+		return err
+	}
+	headHash := *pr.Head.SHA
+	// GitHub computes whether a pull request can be merged in the
+	// background, so try again later if it hasn't yet
+	if pr.Mergeable == nil {
+		return nil
+	}
+	if !*pr.Mergeable {
+		j.dequeue(cq, next, headHash)
+		j.sendStatus(projectRef, headHash, message.GithubStateFailure, "", "pull request has merge conflicts")
+		return nil
+	}
+
+	branch, err := thirdparty.GetBranchEvent(ctx, githubToken, projectRef.Owner, projectRef.Repo, projectRef.Branch)
+	if err != nil {
+		return errors.Wrapf(err, "can't get the head of branch '%s'", projectRef.Branch)
+	}
+	if branch.Commit == nil || branch.Commit.SHA == nil {
+		return errors.Errorf("branch '%s' has no head", projectRef.Branch)
+	}
+
+	diff, _, err := thirdparty.GetGithubPullRequestDiff(ctx, githubToken, &patch.GithubPatch{
+		BaseOwner: projectRef.Owner,
+		BaseRepo:  projectRef.Repo,
+		PRNumber:  prNum,
+	})
+	if err != nil {
+		return err
+	}
+
+	u, err := findEvergreenUserForPR(*pr.User.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch user")
+	}
+
+	alias := projectRef.CommitQueue.PatchAlias
+	if alias == "" {
+		alias = patch.GithubAlias
+	}
+	description := fmt.Sprintf("Commit Queue Merge: '%s/%s' pull request #%d by %s: %s (%s)",
+		projectRef.Owner, projectRef.Repo, prNum, *pr.User.Login, pr.GetTitle(), pr.GetHTMLURL())
+
+	// the diff of the pull request is applied to the head of the branch,
+	// so the pull request is tested as it would be merged
+	intent, err := patch.NewCliIntent(u.Id, projectRef.Identifier, *branch.Commit.SHA, "", diff,
+		description, true, nil, nil, alias)
+	if err != nil {
+		return errors.Wrap(err, "can't create patch intent")
+	}
+	if err = intent.Insert(); err != nil {
+		return errors.Wrap(err, "can't insert patch intent")
+	}
+
+	// record the patch before creating it, so that another job doesn't
+	// start testing the item again
+	patchID := bson.NewObjectId()
+	if err = cq.SetHeadVersion(next.Issue, patchID.Hex(), headHash); err != nil {
+		return err
+	}
+
+	processor := NewPatchIntentProcessor(patchID, intent)
+	processor.Run(ctx)
+	if err = processor.Error(); err != nil {
+		j.dequeue(cq, next, headHash)
+		j.sendStatus(projectRef, headHash, message.GithubStateFailure, "", "can't create patch")
+		return errors.Wrap(err, "can't create patch")
+	}
+
+	grip.Info(message.Fields{
+		"job":       commitQueueJobName,
+		"job_id":    j.ID(),
+		"project":   projectRef.Identifier,
+		"pr_number": prNum,
+		"patch_id":  patchID.Hex(),
+		"base":      *branch.Commit.SHA,
+		"message":   "testing commit queue item",
+	})
+	j.sendStatus(projectRef, headHash, message.GithubStatePending, patchID.Hex(),
+		fmt.Sprintf("testing on top of %s", projectRef.Branch))
+
+	return nil
+}
+
+// finishItem merges the pull request at the head of the queue if the patch
+// that tests it passed, and removes it from the queue once the patch is
+// done, aborted, or has taken too long.
+func (j *commitQueueJob) finishItem(ctx context.Context, cq *commitqueue.CommitQueue, next commitqueue.CommitQueueItem,
+	prNum int, projectRef *model.ProjectRef, githubToken string) error {
+	if !bson.IsObjectIdHex(next.Version) {
+		j.dequeue(cq, next, next.HeadHash)
+		return errors.Errorf("invalid patch id '%s'", next.Version)
+	}
+	p, err := patch.FindOne(patch.ById(bson.ObjectIdHex(next.Version)))
+	if err != nil {
+		return errors.Wrapf(err, "can't find patch '%s'", next.Version)
+	}
+	timedOut := !next.ProcessingStartTime.IsZero() && time.Since(next.ProcessingStartTime) > commitQueueMaxProcessingTime
+	if p == nil {
+		// the patch is still being created, or was cancelled before
+		// it was finalized
+		if timedOut {
+			j.dequeue(cq, next, next.HeadHash)
+			j.sendStatus(projectRef, next.HeadHash, message.GithubStateFailure, "", "patch was not created")
+		}
+		return nil
+	}
+
+	switch p.Status {
+	case evergreen.PatchSucceeded:
+		pr, err := thirdparty.GetGithubPullRequest(ctx, githubToken, projectRef.Owner, projectRef.Repo, prNum)
+		if err != nil {
+			return err
+		}
+		if err = validateCommitQueuePR(pr, projectRef); err != nil {
+			j.dequeue(cq, next, next.HeadHash)
+			j.sendStatus(projectRef, next.HeadHash, message.GithubStateFailure, next.Version, err.Error())
+			return err
+		}
+		// the user who enqueued the pull request may have lost access to
+		// the repository while it was being tested
+		canWrite, err := thirdparty.GithubUserHasWritePermission(ctx, githubToken, projectRef.Owner, projectRef.Repo, next.EnqueuedBy)
+		if err != nil {
+			return err
+		}
+		if !canWrite {
+			j.dequeue(cq, next, next.HeadHash)
+			j.sendStatus(projectRef, next.HeadHash, message.GithubStateFailure, next.Version,
+				fmt.Sprintf("'%s' can't merge into %s/%s", next.EnqueuedBy, projectRef.Owner, projectRef.Repo))
+			return nil
+		}
+
+		title := fmt.Sprintf("%s (#%d)", pr.GetTitle(), prNum)
+		err = thirdparty.MergePullRequest(ctx, githubToken, projectRef.Owner, projectRef.Repo, prNum,
+			next.HeadHash, projectRef.CommitQueue.MergeMethod, title)
+		j.dequeue(cq, next, next.HeadHash)
+		if err != nil {
+			j.sendStatus(projectRef, next.HeadHash, message.GithubStateError, next.Version, "merge failed")
+			return err
+		}
+		j.sendStatus(projectRef, next.HeadHash, message.GithubStateSuccess, next.Version,
+			fmt.Sprintf("merged into %s", projectRef.Branch))
+
+	case evergreen.PatchFailed:
+		j.dequeue(cq, next, next.HeadHash)
+		j.sendStatus(projectRef, next.HeadHash, message.GithubStateFailure, next.Version, "tasks failed, not merging")
+
+	default:
+		// every task must pass to merge, so a patch with a task that
+		// won't run has failed
+		numStopped, err := task.Count(task.ByVersionAbortedOrDeactivated(p.Version))
+		if err != nil {
+			return errors.Wrapf(err, "can't count stopped tasks of patch '%s'", next.Version)
+		}
+		if numStopped > 0 {
+			j.dequeue(cq, next, next.HeadHash)
+			j.sendStatus(projectRef, next.HeadHash, message.GithubStateFailure, next.Version, "patch was aborted, not merging")
+			return nil
+		}
+
+		if timedOut {
+			j.dequeue(cq, next, next.HeadHash)
+			j.sendStatus(projectRef, next.HeadHash, message.GithubStateFailure, next.Version,
+				fmt.Sprintf("patch didn't finish within %s, not merging", commitQueueMaxProcessingTime))
+			return errors.Wrapf(model.CancelPatch(p, evergreen.User), "can't abort patch '%s'", next.Version)
+		}
+	}
+
+	return nil
+}
+
+// validateCommitQueuePR checks that a pull request can still be merged into
+// the project's branch.
+func validateCommitQueuePR(pr *github.PullRequest, projectRef *model.ProjectRef) error {
+	if pr.Head == nil || pr.Head.SHA == nil || pr.User == nil || pr.User.ID == nil || pr.User.Login == nil {
+		return errors.New("pull request is missing data")
+	}
+	if pr.GetState() != "open" {
+		return errors.New("pull request is not open")
+	}
+	if pr.Base == nil || pr.Base.GetRef() != projectRef.Branch {
+		return errors.Errorf("pull request is not against branch '%s'", projectRef.Branch)
+	}
+
+	return nil
+}
+
+func (j *commitQueueJob) dequeue(cq *commitqueue.CommitQueue, item commitqueue.CommitQueueItem, headHash string) {
+	removed, err := cq.Remove(item.Issue)
+	grip.Error(message.WrapError(err, message.Fields{
+		"job":     commitQueueJobName,
+		"job_id":  j.ID(),
+		"project": cq.ProjectID,
+		"item":    item.Issue,
+		"message": "can't remove item from the commit queue",
+	}))
+	grip.InfoWhen(removed, message.Fields{
+		"job":       commitQueueJobName,
+		"job_id":    j.ID(),
+		"project":   cq.ProjectID,
+		"item":      item.Issue,
+		"version":   item.Version,
+		"head_hash": headHash,
+		"message":   "removed item from the commit queue",
+	})
+}
+
+func (j *commitQueueJob) sendStatus(projectRef *model.ProjectRef, ref string, state message.GithubState, version, description string) {
+	status := message.GithubStatus{
+		Owner:       projectRef.Owner,
+		Repo:        projectRef.Repo,
+		Ref:         ref,
+		Context:     commitQueueStatusContext,
+		State:       state,
+		URL:         fmt.Sprintf("%s/waterfall/%s", j.urlBase, projectRef.Identifier),
+		Description: description,
+	}
+	if version != "" {
+		status.URL = fmt.Sprintf("%s/version/%s", j.urlBase, version)
+	}
+
+	j.sender.Send(message.NewGithubStatusMessageWithRepo(level.Notice, status))
+}
@@ -0,0 +1,163 @@
+package units
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/mongodb/grip/send"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type commitQueueJobSuite struct {
+	env *mock.Environment
+
+	suite.Suite
+}
+
+func TestCommitQueueJob(t *testing.T) {
+	suite.Run(t, new(commitQueueJobSuite))
+}
+
+func (s *commitQueueJobSuite) SetupSuite() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func (s *commitQueueJobSuite) SetupTest() {
+	s.NoError(db.ClearCollections(evergreen.ConfigCollection, model.ProjectRefCollection, commitqueue.Collection,
+		patch.Collection, task.Collection))
+	s.env = &mock.Environment{}
+	s.Require().NoError(s.env.Configure(context.Background(), "", nil))
+
+	ref := model.ProjectRef{
+		Identifier:       "mci",
+		Owner:            "evergreen-ci",
+		Repo:             "evergreen",
+		Branch:           "master",
+		Enabled:          true,
+		PRTestingEnabled: true,
+	}
+	s.Require().NoError(ref.Insert())
+	_, err := commitqueue.Enqueue("mci", commitqueue.CommitQueueItem{Issue: "1"})
+	s.Require().NoError(err)
+}
+
+func (s *commitQueueJobSuite) TestDisabledFlag() {
+	s.Require().NoError(evergreen.SetServiceFlags(evergreen.ServiceFlags{CommitQueueDisabled: true}))
+
+	j := NewCommitQueueJob(s.env, "mci", "1")
+	j.Run(context.Background())
+	s.NoError(j.Error())
+
+	cq, err := commitqueue.FindOneId("mci")
+	s.NoError(err)
+	s.Require().Len(cq.Queue, 1)
+	s.Empty(cq.Queue[0].Version)
+}
+
+func (s *commitQueueJobSuite) TestCommitQueueNotEnabled() {
+	j := NewCommitQueueJob(s.env, "mci", "1")
+	j.Run(context.Background())
+	s.NoError(j.Error())
+
+	cq, err := commitqueue.FindOneId("mci")
+	s.NoError(err)
+	s.Require().Len(cq.Queue, 1)
+	s.Empty(cq.Queue[0].Version)
+}
+
+func (s *commitQueueJobSuite) TestMissingProject() {
+	j := NewCommitQueueJob(s.env, "other", "1")
+	j.Run(context.Background())
+	s.Error(j.Error())
+}
+
+func (s *commitQueueJobSuite) TestInvalidItemIsRemoved() {
+	_, err := commitqueue.Enqueue("other", commitqueue.CommitQueueItem{Issue: "not-a-pr"})
+	s.Require().NoError(err)
+	ref := model.ProjectRef{
+		Identifier:       "other",
+		Owner:            "evergreen-ci",
+		Repo:             "evergreen",
+		Branch:           "release",
+		Enabled:          true,
+		PRTestingEnabled: true,
+		CommitQueue:      model.CommitQueueParams{Enabled: true},
+	}
+	s.Require().NoError(ref.Insert())
+
+	j := NewCommitQueueJob(s.env, "other", "1")
+	j.Run(context.Background())
+	s.Error(j.Error())
+
+	cq, err := commitqueue.FindOneId("other")
+	s.NoError(err)
+	s.Empty(cq.Queue)
+}
+
+func (s *commitQueueJobSuite) startedItem(startTime time.Time) (*commitQueueJob, *commitqueue.CommitQueue, *patch.Patch) {
+	p := &patch.Patch{
+		Id:      bson.NewObjectId(),
+		Project: "mci",
+		Status:  evergreen.PatchStarted,
+	}
+	p.Version = p.Id.Hex()
+	s.Require().NoError(p.Insert())
+
+	cq, err := commitqueue.FindOneId("mci")
+	s.Require().NoError(err)
+	s.Require().NoError(cq.SetHeadVersion("1", p.Id.Hex(), "abcdef"))
+	cq.Queue[0].ProcessingStartTime = startTime
+
+	j := makeCommitQueueJob()
+	j.ProjectID = "mci"
+	j.sender = send.MakeInternalLogger()
+
+	return j, cq, p
+}
+
+func (s *commitQueueJobSuite) TestAbortedPatchIsRemoved() {
+	j, cq, p := s.startedItem(time.Now())
+	s.Require().NoError((&task.Task{Id: "t1", Version: p.Version, Activated: true}).Insert())
+	s.Require().NoError((&task.Task{Id: "t2", Version: p.Version, Activated: true, Aborted: true}).Insert())
+
+	next, _ := cq.Next()
+	s.NoError(j.finishItem(context.Background(), cq, next, 1, &model.ProjectRef{Identifier: "mci"}, ""))
+
+	cq, err := commitqueue.FindOneId("mci")
+	s.NoError(err)
+	s.Empty(cq.Queue)
+}
+
+func (s *commitQueueJobSuite) TestRunningPatchStaysAtHead() {
+	j, cq, p := s.startedItem(time.Now())
+	s.Require().NoError((&task.Task{Id: "t1", Version: p.Version, Activated: true}).Insert())
+
+	next, _ := cq.Next()
+	s.NoError(j.finishItem(context.Background(), cq, next, 1, &model.ProjectRef{Identifier: "mci"}, ""))
+
+	cq, err := commitqueue.FindOneId("mci")
+	s.NoError(err)
+	s.Len(cq.Queue, 1)
+}
+
+func (s *commitQueueJobSuite) TestTimedOutPatchIsRemoved() {
+	j, cq, p := s.startedItem(time.Now().Add(-commitQueueMaxProcessingTime - time.Minute))
+	s.Require().NoError((&task.Task{Id: "t1", Version: p.Version, Activated: true}).Insert())
+
+	next, _ := cq.Next()
+	s.NoError(j.finishItem(context.Background(), cq, next, 1, &model.ProjectRef{Identifier: "mci"}, ""))
+
+	cq, err := commitqueue.FindOneId("mci")
+	s.NoError(err)
+	s.Empty(cq.Queue)
+}
@@ -206,7 +206,53 @@ func PopulateEventAlertProcessing(parts int) amboy.QueueOperation {
 
 		ts := util.RoundPartOfHour(parts).Format(tsFormat)
 
-		return errors.Wrap(queue.Put(NewEventMetaJob(queue, ts)), "failed to queue event-metajob")
+		catcher := grip.NewBasicCatcher()
+		catcher.Add(errors.Wrap(queue.Put(NewEventMetaJob(queue, ts)), "failed to queue event-metajob"))
+		catcher.Add(errors.Wrap(queue.Put(NewEventDigestJob(queue, ts)), "failed to queue event digest job"))
+		catcher.Add(errors.Wrap(queue.Put(NewEventRetryJob(queue, ts)), "failed to queue event retry job"))
+
+		return catcher.Resolve()
+	}
+}
+
+// PopulateCommitQueueJobs adds a job to advance the commit queue of each
+// project that has its commit queue enabled.
+func PopulateCommitQueueJobs(env evergreen.Environment) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if flags.CommitQueueDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "commit queue is disabled",
+				"impact":  "pull requests are not merged",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		projects, err := model.FindProjectRefsWithCommitQueueEnabled()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ts := util.RoundPartOfMinute(0).Format(tsFormat)
+
+		catcher := grip.NewBasicCatcher()
+		for _, proj := range projects {
+			catcher.Add(queue.Put(NewCommitQueueJob(env, proj.Identifier, ts)))
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+func PopulateEventArchiveJobs() amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		ts := util.RoundPartOfHour(0).Format(tsFormat)
+
+		return queue.Put(NewEventArchiveJob(ts))
 	}
 }
 
@@ -0,0 +1,150 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	eventArchiveJobName = "event-archive"
+
+	// eventArchiveMaxBatches is the most batches of events that a job
+	// archives for each retention policy, so that a large backlog is
+	// archived over several runs.
+	eventArchiveMaxBatches = 100
+)
+
+func init() {
+	registry.AddJobType(eventArchiveJobName, func() amboy.Job { return makeEventArchiveJob() })
+}
+
+// eventArchiveJob moves the events that are older than their resource
+// type's retention policy out of the event log, and into the archive.
+type eventArchiveJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+}
+
+func makeEventArchiveJob() *eventArchiveJob {
+	j := &eventArchiveJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    eventArchiveJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	j.SetPriority(-1)
+
+	return j
+}
+
+func NewEventArchiveJob(ts string) amboy.Job {
+	j := makeEventArchiveJob()
+	j.SetID(fmt.Sprintf("%s:%s", eventArchiveJobName, ts))
+
+	return j
+}
+
+// eventArchiveRule is a set of events to archive from an event log
+// collection, that were logged before the cutoff.
+type eventArchiveRule struct {
+	collection    string
+	resourceTypes []string
+	exclude       bool
+	cutoff        time.Time
+}
+
+// eventArchiveRules returns the sets of events that the retention settings
+// archive. The events of resource types without policies of their own are
+// archived from each collection by the default policy.
+func eventArchiveRules(conf *evergreen.EventRetentionConfig, now time.Time) []eventArchiveRule {
+	rules := []eventArchiveRule{}
+	withPolicies := []string{}
+	for _, policy := range conf.Policies {
+		withPolicies = append(withPolicies, policy.ResourceType)
+		if policy.TTLDays <= 0 {
+			continue
+		}
+		rules = append(rules, eventArchiveRule{
+			collection:    event.LogCollection(policy.ResourceType),
+			resourceTypes: []string{policy.ResourceType},
+			cutoff:        now.AddDate(0, 0, -policy.TTLDays),
+		})
+	}
+
+	if conf.DefaultTTLDays > 0 {
+		for _, collection := range []string{event.AllLogCollection, event.TaskLogCollection} {
+			rules = append(rules, eventArchiveRule{
+				collection:    collection,
+				resourceTypes: withPolicies,
+				exclude:       true,
+				cutoff:        now.AddDate(0, 0, -conf.DefaultTTLDays),
+			})
+		}
+	}
+
+	return rules
+}
+
+func (j *eventArchiveJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	settings, err := evergreen.GetConfig()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving admin settings"))
+		return
+	}
+	conf := settings.EventRetention
+	if err = conf.ValidateAndDefault(); err != nil {
+		j.AddError(errors.Wrap(err, "invalid event retention settings"))
+		return
+	}
+
+	archiver, err := event.NewEventArchiver(&conf)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	archived := map[string]int{}
+	for _, rule := range eventArchiveRules(&conf, time.Now()) {
+		for i := 0; i < eventArchiveMaxBatches; i++ {
+			if ctx.Err() != nil {
+				j.AddError(ctx.Err())
+				return
+			}
+
+			n, err := event.ArchiveEvents(rule.collection,
+				event.ExpiredEvents(rule.resourceTypes, rule.exclude, rule.cutoff, conf.BatchSize), archiver)
+			archived[rule.collection] += n
+			if err != nil {
+				j.AddError(errors.Wrapf(err, "problem archiving events from '%s'", rule.collection))
+				break
+			}
+			if n < conf.BatchSize {
+				break
+			}
+		}
+	}
+
+	grip.Info(message.Fields{
+		"job_id":   j.ID(),
+		"job":      eventArchiveJobName,
+		"source":   "events-processing",
+		"message":  "stats",
+		"archive":  conf.Archive,
+		"archived": archived,
+	})
+}
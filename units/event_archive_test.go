@@ -0,0 +1,48 @@
+package units
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventArchiveRules(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	now := time.Now()
+	assert.Empty(eventArchiveRules(&evergreen.EventRetentionConfig{}, now))
+
+	conf := &evergreen.EventRetentionConfig{
+		DefaultTTLDays: 90,
+		Policies: []evergreen.EventRetentionPolicy{
+			{ResourceType: event.ResourceTypeScheduler, TTLDays: 7},
+			{ResourceType: event.ResourceTypeAdmin},
+			{ResourceType: event.EventTaskSystemInfo, TTLDays: 30},
+		},
+	}
+	rules := eventArchiveRules(conf, now)
+	require.Len(rules, 4)
+
+	assert.Equal(event.AllLogCollection, rules[0].collection)
+	assert.Equal([]string{event.ResourceTypeScheduler}, rules[0].resourceTypes)
+	assert.False(rules[0].exclude)
+	assert.Equal(now.AddDate(0, 0, -7), rules[0].cutoff)
+
+	assert.Equal(event.TaskLogCollection, rules[1].collection)
+	assert.Equal([]string{event.EventTaskSystemInfo}, rules[1].resourceTypes)
+
+	// admin events have no TTL, so are kept forever rather than archived
+	// by the default policy
+	for _, rule := range rules[2:] {
+		assert.True(rule.exclude)
+		assert.Equal([]string{event.ResourceTypeScheduler, event.ResourceTypeAdmin, event.EventTaskSystemInfo}, rule.resourceTypes)
+		assert.Equal(now.AddDate(0, 0, -90), rule.cutoff)
+	}
+	assert.Equal(event.AllLogCollection, rules[2].collection)
+	assert.Equal(event.TaskLogCollection, rules[3].collection)
+}
@@ -32,6 +32,9 @@ func notificationIsEnabled(flags *evergreen.ServiceFlags, n *notification.Notifi
 	case event.GithubPullRequestSubscriberType:
 		return !flags.GithubStatusAPIDisabled
 
+	case event.GitlabMergeRequestSubscriberType:
+		return !flags.GitlabStatusAPIDisabled
+
 	case event.JIRAIssueSubscriberType, event.JIRACommentSubscriberType:
 		return !flags.JIRANotificationsDisabled
 
@@ -44,6 +47,9 @@ func notificationIsEnabled(flags *evergreen.ServiceFlags, n *notification.Notifi
 	case event.SlackSubscriberType:
 		return !flags.SlackNotificationsDisabled
 
+	case event.MSTeamsSubscriberType, event.ChatWebhookSubscriberType:
+		return !flags.WebhookNotificationsDisabled
+
 	default:
 		grip.Alert(message.Fields{
 			"message": "notificationIsEnabled saw unknown subscriber type",
@@ -165,6 +171,10 @@ func (j *eventMetaJob) dispatchLoop(ctx context.Context) error {
 func (j *eventMetaJob) dispatch(notifications []notification.Notification) error {
 	catcher := grip.NewSimpleCatcher()
 	for i := range notifications {
+		if notifications[i].IsHeld() {
+			// sent later by the digest job
+			continue
+		}
 		if notificationIsEnabled(j.flags, &notifications[i]) {
 			catcher.Add(j.q.Put(newEventNotificationJob(notifications[i].ID)))
 		} else {
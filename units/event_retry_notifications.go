@@ -0,0 +1,120 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	eventRetryJobName = "event-retry-notifications"
+)
+
+func init() {
+	registry.AddJobType(eventRetryJobName, func() amboy.Job { return makeEventRetryJob() })
+}
+
+// eventRetryJob queues the failed notifications that are due to be retried
+// to be sent again, and the deferred notifications whose subscribers' quiet
+// hours have ended to be sent.
+type eventRetryJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+	q        amboy.Queue
+}
+
+func makeEventRetryJob() *eventRetryJob {
+	j := &eventRetryJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    eventRetryJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+
+	return j
+}
+
+func NewEventRetryJob(q amboy.Queue, ts string) amboy.Job {
+	j := makeEventRetryJob()
+	j.q = q
+
+	j.SetID(fmt.Sprintf("%s:%s", eventRetryJobName, ts))
+
+	return j
+}
+
+func (j *eventRetryJob) Run(_ context.Context) {
+	defer j.MarkComplete()
+
+	if j.q == nil {
+		j.q = evergreen.GetEnvironment().RemoteQueue()
+	}
+	if j.q == nil || !j.q.Started() {
+		j.AddError(errors.New("evergreen environment not setup correctly"))
+		return
+	}
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving admin settings"))
+		return
+	}
+	if flags.EventProcessingDisabled {
+		return
+	}
+
+	due, err := notification.FindRetriesDue(time.Now())
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	for _, n := range due {
+		// retries that are already queued have the same job ID, and
+		// aren't queued again
+		err = j.q.Put(newEventNotificationRetryJob(n.ID, len(n.Attempts)))
+		grip.Debug(message.WrapError(err, message.Fields{
+			"job_id":          j.ID(),
+			"job":             eventRetryJobName,
+			"notification_id": n.ID,
+			"message":         "retry not queued",
+		}))
+	}
+
+	deferred, err := notification.FindDeferredDue(time.Now())
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	for _, n := range deferred {
+		err = j.q.Put(newEventNotificationDeferredJob(n.ID))
+		grip.Debug(message.WrapError(err, message.Fields{
+			"job_id":          j.ID(),
+			"job":             eventRetryJobName,
+			"notification_id": n.ID,
+			"message":         "deferred notification not queued",
+		}))
+	}
+
+	grip.Info(message.Fields{
+		"job_id":   j.ID(),
+		"job":      eventRetryJobName,
+		"source":   "events-processing",
+		"message":  "stats",
+		"retries":  len(due),
+		"deferred": len(deferred),
+	})
+}
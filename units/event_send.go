@@ -3,10 +3,13 @@ package units
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/model/trigger"
+	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/amboy/dependency"
 	"github.com/mongodb/amboy/job"
@@ -33,6 +36,9 @@ type eventNotificationJob struct {
 	flags    *evergreen.ServiceFlags
 
 	NotificationID string `bson:"notification_id" json:"notification_id" yaml:"notification_id"`
+	// Replay is true for jobs that send a failed notification again
+	// because a user asked for it to be.
+	Replay bool `bson:"replay" json:"replay" yaml:"replay"`
 }
 
 func makeEventNotificationJob() *eventNotificationJob {
@@ -57,6 +63,37 @@ func newEventNotificationJob(id string) amboy.Job {
 	return j
 }
 
+// newEventNotificationRetryJob creates a job to retry a notification that
+// has been attempted the number of times.
+func newEventNotificationRetryJob(id string, attempts int) amboy.Job {
+	j := makeEventNotificationJob()
+	j.NotificationID = id
+
+	j.SetID(fmt.Sprintf("%s:%s:attempt-%d", eventNotificationJobName, id, attempts+1))
+	return j
+}
+
+// newEventNotificationDeferredJob creates a job to send a notification that
+// was deferred until its subscriber's quiet hours ended.
+func newEventNotificationDeferredJob(id string) amboy.Job {
+	j := makeEventNotificationJob()
+	j.NotificationID = id
+
+	j.SetID(fmt.Sprintf("%s:%s:deferred", eventNotificationJobName, id))
+	return j
+}
+
+// NewEventNotificationReplayJob creates a job to send a failed notification
+// again.
+func NewEventNotificationReplayJob(id, ts string) amboy.Job {
+	j := makeEventNotificationJob()
+	j.NotificationID = id
+	j.Replay = true
+
+	j.SetID(fmt.Sprintf("%s:%s:replay-%s", eventNotificationJobName, id, ts))
+	return j
+}
+
 func (j *eventNotificationJob) setup() error {
 	if len(j.NotificationID) == 0 {
 		return errors.New("notification ID is not valid")
@@ -96,10 +133,30 @@ func (j *eventNotificationJob) Run(_ context.Context) {
 	}
 
 	if err = j.checkDegradedMode(n); err != nil {
+		if n.IsRetried() {
+			j.AddError(n.RecordAttempt(err, j.Replay, time.Now()))
+			return
+		}
 		j.AddError(n.MarkError(err))
 		return
 	}
 
+	if !j.Replay && n.DeferredUntil.IsZero() {
+		if until := quietUntil(n, time.Now()); !until.IsZero() {
+			err = n.Defer(until)
+			if err == nil {
+				return
+			}
+			// it's better to disturb the subscriber than to lose the
+			// notification
+			grip.Error(message.WrapError(err, message.Fields{
+				"job_id":          j.ID(),
+				"notification_id": n.ID,
+				"message":         "failed to defer notification, sending it now",
+			}))
+		}
+	}
+
 	err = j.send(n)
 	grip.Error(message.WrapError(err, message.Fields{
 		"job_id":            j.ID(),
@@ -108,10 +165,56 @@ func (j *eventNotificationJob) Run(_ context.Context) {
 		"message":           "send failed",
 	}))
 	j.AddError(err)
+	if n.IsRetried() {
+		j.AddError(n.RecordAttempt(err, j.Replay, time.Now()))
+		return
+	}
 	j.AddError(n.MarkSent())
 	j.AddError(n.MarkError(err))
 }
 
+// quietUntil returns the time that the quiet hours of the owner of the
+// notification's subscription end, if it's during them and the notification
+// isn't urgent. Quiet hours only apply to the email and Slack notifications
+// of subscriptions that belong to users.
+func quietUntil(n *notification.Notification, now time.Time) time.Time {
+	if n.SubscriptionID == "" {
+		return time.Time{}
+	}
+	switch n.Subscriber.Type {
+	case event.EmailSubscriberType, event.SlackSubscriberType:
+	default:
+		return time.Time{}
+	}
+
+	sub, err := event.FindSubscriptionByIDString(n.SubscriptionID)
+	if err != nil || sub == nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"job":             eventNotificationJobName,
+			"notification_id": n.ID,
+			"subscription_id": n.SubscriptionID,
+			"message":         "can't find subscription to check quiet hours",
+		}))
+		return time.Time{}
+	}
+	if sub.OwnerType != event.OwnerTypePerson || trigger.IsUrgent(sub) {
+		return time.Time{}
+	}
+
+	u, err := user.FindOne(user.ById(sub.Owner))
+	if err != nil || u == nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"job":             eventNotificationJobName,
+			"notification_id": n.ID,
+			"user":            sub.Owner,
+			"message":         "can't find user to check quiet hours",
+		}))
+		return time.Time{}
+	}
+
+	return u.Settings.QuietUntil(now)
+}
+
 func (j *eventNotificationJob) send(n *notification.Notification) error {
 	c, err := n.Composer()
 	if err != nil {
@@ -140,7 +243,16 @@ func (j *eventNotificationJob) send(n *notification.Notification) error {
 		return errors.Wrap(err, "error setting level in sender")
 	}
 
-	err = sender.SetErrorHandler(getSendErrorHandler(n))
+	// the errors of notifications whose attempts are recorded are returned,
+	// rather than saved by the error handler
+	var sendErr error
+	errHandler := getSendErrorHandler(n)
+	if n.IsRetried() {
+		errHandler = func(err error, _ message.Composer) {
+			sendErr = err
+		}
+	}
+	err = sender.SetErrorHandler(errHandler)
 	grip.Error(message.WrapError(err, message.Fields{
 		"message":           "failed to set error handler",
 		"notification_id":   n.ID,
@@ -148,7 +260,7 @@ func (j *eventNotificationJob) send(n *notification.Notification) error {
 	}))
 	sender.Send(c)
 
-	return nil
+	return sendErr
 }
 
 func (j *eventNotificationJob) checkDegradedMode(n *notification.Notification) error {
@@ -156,6 +268,9 @@ func (j *eventNotificationJob) checkDegradedMode(n *notification.Notification) e
 	case event.GithubPullRequestSubscriberType:
 		return checkFlag(j.flags.GithubStatusAPIDisabled)
 
+	case event.GitlabMergeRequestSubscriberType:
+		return checkFlag(j.flags.GitlabStatusAPIDisabled)
+
 	case event.SlackSubscriberType:
 		return checkFlag(j.flags.SlackNotificationsDisabled)
 
@@ -165,7 +280,7 @@ func (j *eventNotificationJob) checkDegradedMode(n *notification.Notification) e
 	case event.JIRACommentSubscriberType:
 		return checkFlag(j.flags.JIRANotificationsDisabled)
 
-	case event.EvergreenWebhookSubscriberType:
+	case event.EvergreenWebhookSubscriberType, event.MSTeamsSubscriberType, event.ChatWebhookSubscriberType:
 		return checkFlag(j.flags.WebhookNotificationsDisabled)
 
 	case event.EmailSubscriberType:
@@ -0,0 +1,134 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	eventDigestJobName = "event-send-digests"
+)
+
+func init() {
+	registry.AddJobType(eventDigestJobName, func() amboy.Job { return makeEventDigestJob() })
+}
+
+// eventDigestJob combines the notifications held for digests that are due
+// into one notification for each digest, and queues them to be sent.
+type eventDigestJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+	q        amboy.Queue
+	flags    *evergreen.ServiceFlags
+}
+
+func makeEventDigestJob() *eventDigestJob {
+	j := &eventDigestJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    eventDigestJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+
+	return j
+}
+
+func NewEventDigestJob(q amboy.Queue, ts string) amboy.Job {
+	j := makeEventDigestJob()
+	j.q = q
+
+	j.SetID(fmt.Sprintf("%s:%s", eventDigestJobName, ts))
+
+	return j
+}
+
+func (j *eventDigestJob) Run(_ context.Context) {
+	defer j.MarkComplete()
+
+	if j.q == nil {
+		j.q = evergreen.GetEnvironment().RemoteQueue()
+	}
+	if j.q == nil || !j.q.Started() {
+		j.AddError(errors.New("evergreen environment not setup correctly"))
+		return
+	}
+
+	var err error
+	j.flags, err = evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving admin settings"))
+		return
+	}
+	if j.flags.EventProcessingDisabled {
+		return
+	}
+
+	held, err := notification.FindHeld()
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	digests := notification.DueDigests(held, time.Now())
+	for key, notifications := range digests {
+		j.AddError(errors.Wrapf(j.sendDigest(notifications), "problem sending digest '%s'", key))
+	}
+
+	grip.Info(message.Fields{
+		"job_id":  j.ID(),
+		"job":     eventDigestJobName,
+		"source":  "events-processing",
+		"message": "stats",
+		"held":    len(held),
+		"digests": len(digests),
+	})
+}
+
+func (j *eventDigestJob) sendDigest(notifications []notification.Notification) error {
+	digest, err := notification.NewDigest(notifications)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// a previous run may have created the digest but failed to mark the
+	// notifications in it
+	existing, err := notification.Find(digest.ID)
+	if err != nil {
+		return errors.Wrap(err, "problem finding digest")
+	}
+	if existing == nil {
+		if err = notification.InsertMany(*digest); err != nil {
+			return errors.Wrap(err, "problem saving digest")
+		}
+	}
+
+	ids := make([]string, 0, len(notifications))
+	for _, n := range notifications {
+		ids = append(ids, n.ID)
+	}
+	if err = notification.MarkDigested(ids, digest.ID); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if existing != nil && !existing.SentAt.IsZero() {
+		return nil
+	}
+	if !notificationIsEnabled(j.flags, digest) {
+		return errors.WithStack(digest.MarkError(errors.New("sender disabled")))
+	}
+
+	return errors.WithStack(j.q.Put(newEventNotificationJob(digest.ID)))
+}
@@ -0,0 +1,216 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/level"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/mongodb/grip/sometimes"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const gitlabStatusUpdateJobName = "gitlab-status-update"
+
+func init() {
+	registry.AddJobType(gitlabStatusUpdateJobName, func() amboy.Job { return makeGitlabStatusUpdateJob() })
+}
+
+// gitlabStatusUpdateJob sets the commit status of the head of a GitLab merge
+// request before the patch for it runs, which the patch and build outcome
+// subscriptions can't do.
+type gitlabStatusUpdateJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+	env      evergreen.Environment
+	urlBase  string
+	sender   send.Sender
+
+	FetchID    string `bson:"fetch_id" json:"fetch_id" yaml:"fetch_id"`
+	UpdateType string `bson:"update_type" json:"update_type" yaml:"update_type"`
+}
+
+func makeGitlabStatusUpdateJob() *gitlabStatusUpdateJob {
+	j := &gitlabStatusUpdateJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    gitlabStatusUpdateJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	j.SetPriority(1)
+	return j
+}
+
+// NewGitlabStatusUpdateJobForNewPatch creates a job to report a newly
+// created patch to GitLab as pending, with description "preparing to run
+// tasks"
+func NewGitlabStatusUpdateJobForNewPatch(version string) amboy.Job {
+	job := makeGitlabStatusUpdateJob()
+	job.FetchID = version
+	job.UpdateType = githubUpdateTypeNewPatch
+
+	job.SetID(fmt.Sprintf("%s:%s-%s-%s", gitlabStatusUpdateJobName, job.UpdateType, version, time.Now().String()))
+
+	return job
+}
+
+// NewGitlabStatusUpdateJobForExternalPatch prompts on GitLab for a user to
+// manually authorize this patch
+func NewGitlabStatusUpdateJobForExternalPatch(patchID string) amboy.Job {
+	job := makeGitlabStatusUpdateJob()
+	job.FetchID = patchID
+	job.UpdateType = githubUpdateTypeRequestAuth
+
+	job.SetID(fmt.Sprintf("%s:%s-%s-%s", gitlabStatusUpdateJobName, job.UpdateType, patchID, time.Now().String()))
+
+	return job
+}
+
+// NewGitlabStatusUpdateJobForBadConfig marks a merge request as failed
+// because the evergreen configuration is bad
+func NewGitlabStatusUpdateJobForBadConfig(intentID string) amboy.Job {
+	job := makeGitlabStatusUpdateJob()
+	job.FetchID = intentID
+	job.UpdateType = githubUpdateTypeBadConfig
+
+	job.SetID(fmt.Sprintf("%s:%s-%s-%s", gitlabStatusUpdateJobName, job.UpdateType, intentID, time.Now().String()))
+
+	return job
+}
+
+func (j *gitlabStatusUpdateJob) preamble() error {
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+	uiConfig := evergreen.UIConfig{}
+	if err := uiConfig.Get(); err != nil {
+		return err
+	}
+	j.urlBase = uiConfig.Url
+	if len(j.urlBase) == 0 {
+		return errors.New("UI URL is empty")
+	}
+
+	if j.sender == nil {
+		var err error
+		j.sender, err = j.env.GetSender(evergreen.SenderGitlabStatus)
+		if err != nil {
+			return err
+		}
+	}
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		return errors.Wrap(err, "error retrieving admin settings")
+	}
+	if flags.GitlabStatusAPIDisabled {
+		grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+			"job":     gitlabStatusUpdateJobName,
+			"message": "gitlab status updates are disabled, not updating status",
+		})
+		return errors.New("gitlab status updates are disabled, not updating status")
+	}
+
+	return nil
+}
+
+func (j *gitlabStatusUpdateJob) fetch() (*util.GitlabStatus, error) {
+	var patchDoc *patch.Patch
+	var err error
+	status := util.GitlabStatus{Name: "evergreen"}
+
+	switch j.UpdateType {
+	case githubUpdateTypeBadConfig:
+		var intent patch.Intent
+		intent, err = patch.FindIntent(j.FetchID, patch.GitlabIntentType)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't fetch patch intent")
+		}
+		patchDoc = intent.NewPatch()
+		if patchDoc == nil {
+			return nil, errors.New("patch is missing")
+		}
+
+		var projectRef *model.ProjectRef
+		projectRef, err = model.FindOneProjectRefByGitlabPathAndBranchWithPRTesting(
+			patchDoc.GitlabPatchData.ProjectPath, patchDoc.GitlabPatchData.BaseBranch)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't fetch project ref")
+		}
+		if projectRef == nil {
+			return nil, errors.New("can't find project ref")
+		}
+
+		status.URL = fmt.Sprintf("%s/waterfall/%s", j.urlBase, projectRef.Identifier)
+		status.State = util.GitlabStateFailed
+		status.Description = "project config was invalid"
+
+	case githubUpdateTypeNewPatch:
+		status.URL = fmt.Sprintf("%s/version/%s", j.urlBase, j.FetchID)
+		status.State = util.GitlabStatePending
+		status.Description = "preparing to run tasks"
+
+	case githubUpdateTypeRequestAuth:
+		status.URL = fmt.Sprintf("%s/patch/%s", j.urlBase, j.FetchID)
+		status.State = util.GitlabStateFailed
+		status.Description = "patch must be manually authorized"
+
+	default:
+		return nil, errors.Errorf("unknown update type '%s'", j.UpdateType)
+	}
+
+	if patchDoc == nil {
+		if !bson.IsObjectIdHex(j.FetchID) {
+			return nil, errors.Errorf("invalid patch id '%s'", j.FetchID)
+		}
+		patchDoc, err = patch.FindOne(patch.ById(bson.ObjectIdHex(j.FetchID)))
+		if err != nil {
+			return nil, err
+		}
+		if patchDoc == nil {
+			return nil, errors.New("can't find patch")
+		}
+	}
+
+	status.ProjectPath = patchDoc.GitlabPatchData.ProjectPath
+	status.SHA = patchDoc.GitlabPatchData.HeadHash
+	return &status, nil
+}
+
+func (j *gitlabStatusUpdateJob) Run(_ context.Context) {
+	defer j.MarkComplete()
+
+	j.AddError(j.preamble())
+	if j.HasErrors() {
+		return
+	}
+
+	status, err := j.fetch()
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	c := util.NewGitlabStatusMessage(*status)
+	if !c.Loggable() {
+		j.AddError(errors.Errorf("status message is invalid: %+v", status))
+		return
+	}
+	j.AddError(c.SetPriority(level.Notice))
+
+	j.sender.Send(c)
+}
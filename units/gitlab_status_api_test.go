@@ -0,0 +1,173 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip/send"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type gitlabStatusUpdateSuite struct {
+	env      *mock.Environment
+	patchDoc *patch.Patch
+	cancel   context.CancelFunc
+
+	suite.Suite
+}
+
+func TestGitlabStatusUpdate(t *testing.T) {
+	suite.Run(t, new(gitlabStatusUpdateSuite))
+}
+
+func (s *gitlabStatusUpdateSuite) SetupSuite() {
+	testConfig := testutil.TestConfig()
+	db.SetGlobalSessionProvider(testConfig.SessionFactory())
+}
+
+func (s *gitlabStatusUpdateSuite) SetupTest() {
+	s.NoError(db.ClearCollections(evergreen.ConfigCollection, patch.Collection, patch.IntentCollection, model.ProjectRefCollection))
+
+	uiConfig := evergreen.UIConfig{}
+	uiConfig.Url = "https://example.com"
+	s.Require().NoError(uiConfig.Set())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.env = &mock.Environment{}
+	s.Require().NoError(s.env.Configure(ctx, filepath.Join(evergreen.FindEvergreenHome(), testutil.TestDir, testutil.TestSettings), nil))
+
+	id := bson.NewObjectId()
+	s.patchDoc = &patch.Patch{
+		Id:         id,
+		Version:    id.Hex(),
+		Status:     evergreen.PatchCreated,
+		CreateTime: time.Now().Truncate(time.Millisecond),
+		GitlabPatchData: patch.GitlabPatch{
+			MRNumber:        7,
+			ProjectPath:     "evergreen-ci/evergreen",
+			BaseBranch:      "master",
+			HeadProjectPath: "someone/evergreen",
+			HeadHash:        "da1560886d4f094c3e6c9ef40349f7d38b5d27d7",
+		},
+	}
+	s.NoError(s.patchDoc.Insert())
+}
+
+func (s *gitlabStatusUpdateSuite) TearDownTest() {
+	s.cancel()
+	evergreen.ResetEnvironment()
+}
+
+func (s *gitlabStatusUpdateSuite) TestRunInDegradedMode() {
+	flags := evergreen.ServiceFlags{
+		GitlabStatusAPIDisabled: true,
+	}
+	s.Require().NoError(evergreen.SetServiceFlags(flags))
+
+	job, ok := NewGitlabStatusUpdateJobForNewPatch(s.patchDoc.Version).(*gitlabStatusUpdateJob)
+	s.Require().True(ok)
+	job.env = s.env
+	job.Run(context.Background())
+
+	s.Error(job.Error())
+	s.Contains(job.Error().Error(), "gitlab status updates are disabled, not updating status")
+}
+
+func (s *gitlabStatusUpdateSuite) TestForPatchCreated() {
+	job, ok := NewGitlabStatusUpdateJobForNewPatch(s.patchDoc.Version).(*gitlabStatusUpdateJob)
+	s.Require().True(ok)
+	s.Require().Equal(githubUpdateTypeNewPatch, job.UpdateType)
+	job.env = s.env
+	job.Run(context.Background())
+	s.False(job.HasErrors())
+
+	status := s.msgToStatus(s.env.InternalSender)
+
+	s.Equal("evergreen-ci/evergreen", status.ProjectPath)
+	s.Equal("da1560886d4f094c3e6c9ef40349f7d38b5d27d7", status.SHA)
+	s.Equal(fmt.Sprintf("https://example.com/version/%s", s.patchDoc.Version), status.URL)
+	s.Equal("preparing to run tasks", status.Description)
+	s.Equal("evergreen", status.Name)
+	s.Equal(util.GitlabStatePending, status.State)
+}
+
+func (s *gitlabStatusUpdateSuite) TestForBadConfig() {
+	event := &patch.GitlabMergeRequestEvent{
+		User:    patch.GitlabUser{ID: 2, Username: "someone"},
+		Project: patch.GitlabProject{ID: 1, PathWithNamespace: "evergreen-ci/evergreen"},
+	}
+	event.ObjectAttributes.IID = 7
+	event.ObjectAttributes.AuthorID = 2
+	event.ObjectAttributes.TargetBranch = "master"
+	event.ObjectAttributes.Source = patch.GitlabProject{ID: 2, PathWithNamespace: "someone/evergreen"}
+	event.ObjectAttributes.LastCommit.ID = "da1560886d4f094c3e6c9ef40349f7d38b5d27d7"
+	intent, err := patch.NewGitlabIntent("1", event)
+	s.Require().NoError(err)
+	s.NoError(intent.Insert())
+
+	ref := model.ProjectRef{
+		Identifier:       "mci",
+		PRTestingEnabled: true,
+		Owner:            "evergreen-ci",
+		Repo:             "evergreen",
+		Branch:           "master",
+		RepoKind:         model.GitRepoType,
+		RepoURL:          "https://gitlab.example.com/evergreen-ci/evergreen.git",
+		Enabled:          true,
+	}
+	s.NoError(ref.Insert())
+
+	job, ok := NewGitlabStatusUpdateJobForBadConfig(intent.ID()).(*gitlabStatusUpdateJob)
+	s.Require().True(ok)
+	s.Require().Equal(githubUpdateTypeBadConfig, job.UpdateType)
+	job.env = s.env
+	job.Run(context.Background())
+	s.False(job.HasErrors())
+
+	status := s.msgToStatus(s.env.InternalSender)
+
+	s.Equal("evergreen-ci/evergreen", status.ProjectPath)
+	s.Equal("da1560886d4f094c3e6c9ef40349f7d38b5d27d7", status.SHA)
+	s.Equal("https://example.com/waterfall/mci", status.URL)
+	s.Equal("project config was invalid", status.Description)
+	s.Equal(util.GitlabStateFailed, status.State)
+}
+
+func (s *gitlabStatusUpdateSuite) TestRequestForAuth() {
+	job, ok := NewGitlabStatusUpdateJobForExternalPatch(s.patchDoc.Version).(*gitlabStatusUpdateJob)
+	s.Require().True(ok)
+	s.Require().Equal(githubUpdateTypeRequestAuth, job.UpdateType)
+	job.env = s.env
+	job.Run(context.Background())
+	s.False(job.HasErrors())
+
+	status := s.msgToStatus(s.env.InternalSender)
+
+	s.Equal(fmt.Sprintf("https://example.com/patch/%s", s.patchDoc.Version), status.URL)
+	s.Equal("patch must be manually authorized", status.Description)
+	s.Equal(util.GitlabStateFailed, status.State)
+}
+
+func (s *gitlabStatusUpdateSuite) msgToStatus(sender *send.InternalSender) *util.GitlabStatus {
+	msg, ok := sender.GetMessageSafe()
+	s.Require().True(ok)
+	raw := msg.Message
+	s.Require().NotNil(raw)
+	status, ok := raw.Raw().(*util.GitlabStatus)
+	s.Require().True(ok)
+
+	return status
+}
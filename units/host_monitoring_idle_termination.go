@@ -7,6 +7,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/amboy/dependency"
@@ -139,6 +140,10 @@ func (j *idleHostJob) Run(ctx context.Context) {
 		j.Terminated = true
 		tjob := NewHostTerminationJob(j.env, *j.host)
 		tjob.Run(ctx)
-		j.AddError(tjob.Error())
+		if err := tjob.Error(); err != nil {
+			j.AddError(err)
+			return
+		}
+		event.LogHostIdleTerminated(j.host.Id, idleTime)
 	}
 }
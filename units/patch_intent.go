@@ -105,10 +105,18 @@ func (j *patchIntentProcessor) Run(ctx context.Context) {
 
 	if err = j.finishPatch(ctx, patchDoc, githubOauthToken); err != nil {
 		j.AddError(err)
-		if j.IntentType == patch.GithubIntentType && strings.HasPrefix(err.Error(), errInvalidPatchedConfig) {
-			update := NewGithubStatusUpdateJobForBadConfig(j.intent.ID())
-			update.Run(ctx)
-			j.AddError(update.Error())
+		if strings.HasPrefix(err.Error(), errInvalidPatchedConfig) {
+			var update amboy.Job
+			switch j.IntentType {
+			case patch.GithubIntentType:
+				update = NewGithubStatusUpdateJobForBadConfig(j.intent.ID())
+			case patch.GitlabIntentType:
+				update = NewGitlabStatusUpdateJobForBadConfig(j.intent.ID())
+			}
+			if update != nil {
+				update.Run(ctx)
+				j.AddError(update.Error())
+			}
 		}
 		return
 	}
@@ -138,6 +146,21 @@ func (j *patchIntentProcessor) Run(ctx context.Context) {
 			patchDoc.GithubPatchData.BaseOwner, patchDoc.GithubPatchData.BaseRepo,
 			patchDoc.GithubPatchData.PRNumber))
 	}
+
+	if j.IntentType == patch.GitlabIntentType {
+		var update amboy.Job
+		if len(patchDoc.Version) == 0 {
+			update = NewGitlabStatusUpdateJobForExternalPatch(patchDoc.Id.Hex())
+
+		} else {
+			update = NewGitlabStatusUpdateJobForNewPatch(patchDoc.Id.Hex())
+		}
+		update.Run(ctx)
+		j.AddError(update.Error())
+
+		j.AddError(model.AbortPatchesWithGitlabPatchData(patchDoc.CreateTime,
+			patchDoc.GitlabPatchData.ProjectPath, patchDoc.GitlabPatchData.MRNumber))
+	}
 }
 
 func (j *patchIntentProcessor) finishPatch(ctx context.Context, patchDoc *patch.Patch, githubOauthToken string) error {
@@ -153,6 +176,10 @@ func (j *patchIntentProcessor) finishPatch(ctx context.Context, patchDoc *patch.
 		canFinalize, err = j.buildGithubPatchDoc(ctx, patchDoc, githubOauthToken)
 		catcher.Add(err)
 
+	case patch.GitlabIntentType:
+		canFinalize, err = j.buildGitlabPatchDoc(ctx, patchDoc)
+		catcher.Add(err)
+
 	default:
 		return errors.Errorf("Intent type '%s' is unknown", j.IntentType)
 	}
@@ -232,6 +259,7 @@ func (j *patchIntentProcessor) finishPatch(ctx context.Context, patchDoc *patch.
 	}
 	patchDoc.PatchedConfig = string(projectYamlBytes)
 
+	patchDoc.FilterByPaths = filterPatchByPaths(j.intent.GetType(), j.intent.GetAlias(), patchDoc)
 	project.BuildProjectTVPairs(patchDoc, j.intent.GetAlias())
 
 	if j.intent.ShouldFinalizePatch() && len(patchDoc.Tasks) == 0 &&
@@ -269,6 +297,21 @@ func (j *patchIntentProcessor) finishPatch(ctx context.Context, patchDoc *patch.
 			catcher.Add(errors.Wrap(err, "failed to insert build subscription for Github PR"))
 		}
 	}
+	if patchDoc.IsGitlabMRPatch() {
+		glSub := event.NewGitlabStatusAPISubscriber(event.GitlabMergeRequestSubscriber{
+			ProjectPath: patchDoc.GitlabPatchData.ProjectPath,
+			MRNumber:    patchDoc.GitlabPatchData.MRNumber,
+			Ref:         patchDoc.GitlabPatchData.HeadHash,
+		})
+		patchSub := event.NewPatchOutcomeSubscription(j.PatchID.Hex(), glSub)
+		if err = patchSub.Upsert(); err != nil {
+			catcher.Add(errors.Wrap(err, "failed to insert patch subscription for Gitlab MR"))
+		}
+		buildSub := event.NewBuildOutcomeSubscriptionByVersion(j.PatchID.Hex(), glSub)
+		if err = buildSub.Upsert(); err != nil {
+			catcher.Add(errors.Wrap(err, "failed to insert build subscription for Gitlab MR"))
+		}
+	}
 	if catcher.HasErrors() {
 		grip.Error(message.WrapError(catcher.Resolve(), message.Fields{
 			"message":     "failed to save subscription, patch will not notify",
@@ -420,6 +463,92 @@ func (j *patchIntentProcessor) buildGithubPatchDoc(ctx context.Context, patchDoc
 	return isMember, nil
 }
 
+func (j *patchIntentProcessor) buildGitlabPatchDoc(ctx context.Context, patchDoc *patch.Patch) (bool, error) {
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		return false, errors.Wrap(err, "gitlab mr testing is disabled, error retrieving admin settings")
+	}
+	if flags.GitlabMRTestingDisabled {
+		grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+			"job":     patchIntentJobName,
+			"message": "gitlab mr testing is disabled, not processing merge request",
+
+			"intent_type": j.IntentType,
+			"intent_id":   j.IntentID,
+		})
+		return false, errors.New("gitlab mr testing is disabled, not processing merge request")
+	}
+	defer j.intent.SetProcessed()
+
+	settings := j.env.Settings().Gitlab
+	if settings.Token == "" {
+		return false, errors.New("Gitlab MR testing not configured correctly; requires a Gitlab API token")
+	}
+
+	mr := &patchDoc.GitlabPatchData
+	projectRef, err := model.FindOneProjectRefByGitlabPathAndBranchWithPRTesting(mr.ProjectPath, mr.BaseBranch)
+	if err != nil {
+		return false, errors.Wrapf(err, "Could not fetch project ref for gitlab project '%s' with branch '%s'",
+			mr.ProjectPath, mr.BaseBranch)
+	}
+	if projectRef == nil {
+		return false, errors.Errorf("Could not find project ref for gitlab project '%s' with branch '%s'",
+			mr.ProjectPath, mr.BaseBranch)
+	}
+
+	// merge requests from forks run code from outside of the project, so
+	// they must be authorized by hand, like pull requests from users
+	// outside of the github organization
+	isInternal := mr.HeadProjectPath == mr.ProjectPath
+
+	apiCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	patchDoc.Githash, err = thirdparty.GetGitlabMergeBase(apiCtx, settings.URL, settings.Token,
+		mr.ProjectPath, mr.BaseBranch, mr.HeadHash)
+	if err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"message":     "gitlab API failure",
+			"source":      "patch intents",
+			"job":         j.ID(),
+			"patch_id":    j.PatchID,
+			"project":     mr.ProjectPath,
+			"head_repo":   mr.HeadProjectPath,
+			"mr_number":   mr.MRNumber,
+			"intent_type": j.IntentType,
+			"intent_id":   j.IntentID,
+		}))
+		return false, err
+	}
+
+	patchContent, summaries, err := thirdparty.GetGitlabMergeRequestDiff(ctx, settings.URL, settings.Token, mr)
+	if err != nil {
+		return isInternal, err
+	}
+
+	patchFileID := fmt.Sprintf("%s_%s", patchDoc.Id.Hex(), patchDoc.Githash)
+	patchDoc.Patches = append(patchDoc.Patches, patch.ModulePatch{
+		ModuleName: "",
+		Githash:    patchDoc.Githash,
+		PatchSet: patch.PatchSet{
+			PatchFileId: patchFileID,
+			Summary:     summaries,
+		},
+	})
+	patchDoc.Project = projectRef.Identifier
+
+	if err = db.WriteGridFile(patch.GridFSPrefix, patchFileID, strings.NewReader(patchContent)); err != nil {
+		return isInternal, errors.Wrap(err, "failed to write patch file to db")
+	}
+
+	j.user, err = findOrCreatePatchUser(evergreen.GitlabPatchUser, "Gitlab Merge Requests")
+	if err != nil {
+		return isInternal, errors.Wrap(err, "failed to fetch user")
+	}
+	patchDoc.Author = j.user.Id
+
+	return isInternal, nil
+}
+
 func findEvergreenUserForPR(githubUID int) (*user.DBUser, error) {
 	// try and find a user by github uid
 	u, err := user.FindByGithubUID(githubUID)
@@ -431,19 +560,25 @@ func findEvergreenUserForPR(githubUID int) (*user.DBUser, error) {
 	}
 
 	// Otherwise, use the github patch user
-	u, err = user.FindOne(user.ById(evergreen.GithubPatchUser))
+	return findOrCreatePatchUser(evergreen.GithubPatchUser, "Github Pull Requests")
+}
+
+// findOrCreatePatchUser returns the user that owns patches which can't be
+// attributed to an evergreen user, creating it if it doesn't exist.
+func findOrCreatePatchUser(id, displayName string) (*user.DBUser, error) {
+	u, err := user.FindOne(user.ById(id))
 	if err != nil {
 		return u, err
 	}
 	// and if that user doesn't exist, make it
 	if u == nil {
 		u = &user.DBUser{
-			Id:       evergreen.GithubPatchUser,
-			DispName: "Github Pull Requests",
+			Id:       id,
+			DispName: displayName,
 			APIKey:   util.RandomString(),
 		}
 		if err = u.Insert(); err != nil {
-			return nil, errors.Wrap(err, "failed to create github pull request user")
+			return nil, errors.Wrapf(err, "failed to create patch user '%s'", id)
 		}
 	}
 
@@ -487,3 +622,17 @@ func authAndFetchPRMergeBase(ctx context.Context, patchDoc *patch.Patch, require
 
 	return isMember, nil
 }
+
+// filterPatchByPaths returns true if the tasks of a patch should be filtered
+// by the paths that it changes, which are only those that the user didn't
+// pick: the tasks of pull and merge requests, and 'all' tasks on 'all'
+// variants of patches from the command line.
+func filterPatchByPaths(intentType, alias string, patchDoc *patch.Patch) bool {
+	if intentType != patch.CliIntentType {
+		return true
+	}
+
+	return alias == "" &&
+		len(patchDoc.BuildVariants) == 1 && patchDoc.BuildVariants[0] == "all" &&
+		len(patchDoc.Tasks) == 1 && patchDoc.Tasks[0] == "all"
+}
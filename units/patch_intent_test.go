@@ -20,6 +20,7 @@ import (
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/mongodb/amboy/registry"
 	"github.com/mongodb/grip/send"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -485,3 +486,16 @@ func (s *PatchIntentUnitsSuite) verifyGithubSubscriptions(patchDoc *patch.Patch)
 	s.True(foundPatch)
 	s.True(foundBuild)
 }
+
+func TestFilterPatchByPaths(t *testing.T) {
+	assert := assert.New(t)
+
+	all := &patch.Patch{BuildVariants: []string{"all"}, Tasks: []string{"all"}}
+	picked := &patch.Patch{BuildVariants: []string{"all"}, Tasks: []string{"compile"}}
+
+	assert.True(filterPatchByPaths(patch.CliIntentType, "", all))
+	assert.False(filterPatchByPaths(patch.CliIntentType, "", picked))
+	assert.False(filterPatchByPaths(patch.CliIntentType, "my-alias", all))
+	assert.False(filterPatchByPaths(patch.CliIntentType, "my-alias", &patch.Patch{}))
+	assert.True(filterPatchByPaths(patch.GithubIntentType, patch.GithubAlias, &patch.Patch{}))
+}
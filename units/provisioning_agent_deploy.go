@@ -266,6 +266,10 @@ func (j *agentDeployJob) startAgentOnRemote(ctx context.Context, settings *everg
 		"--cleanup",
 	}
 
+	if hostObj.Distro.CommandPluginDir != "" {
+		agentCmdParts = append(agentCmdParts, fmt.Sprintf("--command_plugin_directory='%s'", hostObj.Distro.CommandPluginDir))
+	}
+
 	// build the command to run on the remote machine
 	remoteCmd := strings.Join(agentCmdParts, " ")
 	grip.Info(message.Fields{
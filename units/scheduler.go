@@ -70,6 +70,8 @@ func (j *distroSchedulerJob) Run(ctx context.Context) {
 		DistroID:         j.DistroID,
 		TaskFinder:       settings.Scheduler.TaskFinder,
 		HostAllocator:    settings.Scheduler.HostAllocator,
+		TaskPrioritizer:  settings.Scheduler.TaskPrioritizer,
+		ProjectWeights:   settings.Scheduler.GetProjectWeights(),
 		FreeHostFraction: settings.Scheduler.FreeHostFraction,
 	}
 
@@ -127,7 +127,7 @@ func (j *collectHostIdleDataJob) Run(ctx context.Context) {
 		}
 	}
 
-	if calc, ok := j.manager.(cloud.CostCalculator); ok {
+	if calc, ok := cloud.GetCostCalculator(j.manager, j.settings); ok {
 		cost, err = calc.CostForDuration(ctx, j.host, j.StartTime, j.FinishTime)
 		if err != nil {
 			j.AddError(err)
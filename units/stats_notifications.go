@@ -93,6 +93,22 @@ func (j *notificationsStatsCollector) Run(ctx context.Context) {
 
 	msg["pending_notifications_by_type"] = stats
 
+	heldStats, err := notification.CollectHeldNotificationStats()
+	j.AddError(errors.Wrap(err, "failed to collect held notification stats"))
+	if j.HasErrors() {
+		return
+	}
+
+	msg["held_notifications_by_type"] = heldStats
+
+	deferredStats, err := notification.CollectDeferredNotificationStats()
+	j.AddError(errors.Wrap(err, "failed to collect deferred notification stats"))
+	if j.HasErrors() {
+		return
+	}
+
+	msg["deferred_notifications_by_type"] = deferredStats
+
 	if ctx.Err() == nil {
 		j.logger.Info(msg)
 	}
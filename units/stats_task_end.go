@@ -102,7 +102,7 @@ func (j *collectTaskEndDataJob) Run(ctx context.Context) {
 		j.AddError(err)
 		grip.Error(message.WrapErrorf(err, "Error loading provider for host %s cost calculation", j.task.HostId))
 	} else {
-		if calc, ok := manager.(cloud.CostCalculator); ok {
+		if calc, ok := cloud.GetCostCalculator(manager, settings); ok {
 			cost, err = calc.CostForDuration(ctx, j.host, j.task.StartTime, j.task.FinishTime)
 			if err != nil {
 				j.AddError(err)
@@ -0,0 +1,113 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/pkg/errors"
+)
+
+const chatWebhookTimeout = 10 * time.Second
+
+// ChatWebhook is a JSON message to post to the incoming webhook of a chat
+// service, like Microsoft Teams or Mattermost.
+type ChatWebhook struct {
+	URL  string `bson:"url"`
+	Body []byte `bson:"body"`
+}
+
+type chatWebhookMessage struct {
+	raw ChatWebhook
+
+	message.Base
+}
+
+func NewChatWebhookMessage(webhookURL string, body []byte) message.Composer {
+	return &chatWebhookMessage{
+		raw: ChatWebhook{
+			URL:  webhookURL,
+			Body: body,
+		},
+	}
+}
+
+func (w *chatWebhookMessage) Loggable() bool {
+	if len(w.raw.URL) == 0 || len(w.raw.Body) == 0 {
+		return false
+	}
+
+	_, err := url.Parse(w.raw.URL)
+	return err == nil
+}
+
+func (w *chatWebhookMessage) Raw() interface{} {
+	return &w.raw
+}
+
+func (w *chatWebhookMessage) String() string {
+	return string(w.raw.Body)
+}
+
+type chatWebhookLogger struct {
+	client *http.Client
+	*send.Base
+}
+
+// NewChatWebhookLogger returns a sender that posts chat webhook messages.
+func NewChatWebhookLogger(name string) (send.Sender, error) {
+	s := &chatWebhookLogger{
+		Base: send.NewBase(name),
+	}
+
+	return s, nil
+}
+
+func (w *chatWebhookLogger) Send(m message.Composer) {
+	if w.Level().ShouldLog(m) {
+		if err := w.send(m); err != nil {
+			w.ErrorHandler(err, m)
+		}
+	}
+}
+
+func (w *chatWebhookLogger) send(m message.Composer) error {
+	raw, ok := m.Raw().(*ChatWebhook)
+	if !ok {
+		return errors.New("chat webhook sender received unexpected composer")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, raw.URL, bytes.NewReader(raw.Body))
+	if err != nil {
+		return errors.Wrap(err, "chat webhook failed to create http request")
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	ctx, cancel := context.WithTimeout(req.Context(), chatWebhookTimeout)
+	defer cancel()
+
+	req = req.WithContext(ctx)
+
+	client := w.client
+	if client == nil {
+		client = GetHTTPClient()
+		defer PutHTTPClient(client)
+	}
+
+	resp, err := client.Do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return errors.Wrap(err, "chat webhook failed to send message")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("chat webhook response status was %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package util
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mongodb/grip/message"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatWebhookComposer(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(NewChatWebhookMessage("", []byte(`{"text": "hi"}`)).Loggable())
+	assert.False(NewChatWebhookMessage("https://example.com", nil).Loggable())
+
+	m := NewChatWebhookMessage("https://example.com", []byte(`{"text": "hi"}`))
+	assert.True(m.Loggable())
+	assert.Equal(`{"text": "hi"}`, m.String())
+	raw, ok := m.Raw().(*ChatWebhook)
+	assert.True(ok)
+	assert.Equal("https://example.com", raw.URL)
+}
+
+func TestChatWebhookSender(t *testing.T) {
+	assert := assert.New(t)
+
+	var body []byte
+	var contentType string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		contentType = r.Header.Get("Content-Type")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sender, err := NewChatWebhookLogger("evergreen")
+	assert.NoError(err)
+	s, ok := sender.(*chatWebhookLogger)
+	assert.True(ok)
+	s.client = server.Client()
+
+	var sendErr error
+	assert.NoError(s.SetErrorHandler(func(err error, _ message.Composer) { sendErr = err }))
+
+	s.Send(NewChatWebhookMessage(server.URL, []byte(`{"text": "hi"}`)))
+	assert.NoError(sendErr)
+	assert.Equal(`{"text": "hi"}`, string(body))
+	assert.Equal("application/json", contentType)
+
+	status = http.StatusBadRequest
+	s.Send(NewChatWebhookMessage(server.URL, []byte(`{"text": "hi"}`)))
+	assert.EqualError(sendErr, "chat webhook response status was 400 Bad Request")
+}
@@ -0,0 +1,161 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/pkg/errors"
+)
+
+const gitlabStatusTimeout = 10 * time.Second
+
+const (
+	GitlabStatePending = "pending"
+	GitlabStateRunning = "running"
+	GitlabStateSuccess = "success"
+	GitlabStateFailed  = "failed"
+	GitlabStateCancel  = "canceled"
+)
+
+// GitlabStatus is a commit status to set on a GitLab project, which GitLab
+// shows on the merge requests that contain the commit.
+type GitlabStatus struct {
+	// ProjectPath is the namespaced path of the project, e.g. group/repo
+	ProjectPath string `bson:"project_path" json:"-"`
+	SHA         string `bson:"sha" json:"-"`
+	Ref         string `bson:"ref,omitempty" json:"ref,omitempty"`
+	State       string `bson:"state" json:"state"`
+	Name        string `bson:"name" json:"name"`
+	Description string `bson:"description" json:"description"`
+	URL         string `bson:"url" json:"target_url"`
+}
+
+func (s *GitlabStatus) Valid() bool {
+	if s.ProjectPath == "" || s.SHA == "" || s.Name == "" {
+		return false
+	}
+
+	switch s.State {
+	case GitlabStatePending, GitlabStateRunning, GitlabStateSuccess, GitlabStateFailed, GitlabStateCancel:
+	default:
+		return false
+	}
+
+	if s.URL != "" {
+		_, err := url.Parse(s.URL)
+		return err == nil
+	}
+	return true
+}
+
+type gitlabStatusMessage struct {
+	raw GitlabStatus
+
+	message.Base
+}
+
+func NewGitlabStatusMessage(status GitlabStatus) message.Composer {
+	return &gitlabStatusMessage{raw: status}
+}
+
+func (m *gitlabStatusMessage) Loggable() bool {
+	return m.raw.Valid()
+}
+
+func (m *gitlabStatusMessage) Raw() interface{} {
+	return &m.raw
+}
+
+func (m *gitlabStatusMessage) String() string {
+	str := fmt.Sprintf("%s@%s %s %s", m.raw.ProjectPath, m.raw.SHA, m.raw.Name, m.raw.State)
+	if len(m.raw.Description) > 0 {
+		str += " " + m.raw.Description
+	}
+	if len(m.raw.URL) > 0 {
+		str += " (" + m.raw.URL + ")"
+	}
+
+	return str
+}
+
+type gitlabStatusLogger struct {
+	baseURL string
+	token   string
+	client  *http.Client
+	*send.Base
+}
+
+// NewGitlabStatusLogger returns a sender that sets commit statuses with the
+// API of the GitLab instance at baseURL.
+func NewGitlabStatusLogger(name, baseURL, token string) (send.Sender, error) {
+	if baseURL == "" || token == "" {
+		return nil, errors.New("gitlab status sender requires a url and a token")
+	}
+
+	s := &gitlabStatusLogger{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		Base:    send.NewBase(name),
+	}
+
+	return s, nil
+}
+
+func (s *gitlabStatusLogger) Send(m message.Composer) {
+	if s.Level().ShouldLog(m) {
+		if err := s.send(m); err != nil {
+			s.ErrorHandler(err, m)
+		}
+	}
+}
+
+func (s *gitlabStatusLogger) send(m message.Composer) error {
+	raw, ok := m.Raw().(*GitlabStatus)
+	if !ok {
+		return errors.New("gitlab status sender received unexpected composer")
+	}
+
+	body, err := json.Marshal(raw)
+	if err != nil {
+		return errors.Wrap(err, "gitlab status failed to marshal status")
+	}
+	statusURL := fmt.Sprintf("%s/api/v4/projects/%s/statuses/%s", s.baseURL, url.PathEscape(raw.ProjectPath), raw.SHA)
+	req, err := http.NewRequest(http.MethodPost, statusURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "gitlab status failed to create http request")
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("PRIVATE-TOKEN", s.token)
+
+	ctx, cancel := context.WithTimeout(req.Context(), gitlabStatusTimeout)
+	defer cancel()
+
+	req = req.WithContext(ctx)
+
+	client := s.client
+	if client == nil {
+		client = GetHTTPClient()
+		defer PutHTTPClient(client)
+	}
+
+	resp, err := client.Do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return errors.Wrap(err, "gitlab status failed to send status")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("gitlab status response status was %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	return nil
+}
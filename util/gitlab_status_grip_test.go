@@ -0,0 +1,85 @@
+package util
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mongodb/grip/message"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitlabStatusComposer(t *testing.T) {
+	assert := assert.New(t)
+
+	status := GitlabStatus{
+		ProjectPath: "group/repo",
+		SHA:         "abcdef",
+		State:       GitlabStateSuccess,
+		Name:        "evergreen",
+		Description: "tasks passed",
+		URL:         "https://example.com/version/1",
+	}
+	m := NewGitlabStatusMessage(status)
+	assert.True(m.Loggable())
+	assert.Equal("group/repo@abcdef evergreen success tasks passed (https://example.com/version/1)", m.String())
+	raw, ok := m.Raw().(*GitlabStatus)
+	assert.True(ok)
+	assert.Equal(status, *raw)
+
+	status.State = "error"
+	assert.False(NewGitlabStatusMessage(status).Loggable())
+	status.State = GitlabStateFailed
+	status.SHA = ""
+	assert.False(NewGitlabStatusMessage(status).Loggable())
+}
+
+func TestGitlabStatusSender(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewGitlabStatusLogger("evergreen", "https://gitlab.example.com", "")
+	assert.Error(err)
+
+	var path, token string
+	body := map[string]string{}
+	status := http.StatusCreated
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		token = r.Header.Get("PRIVATE-TOKEN")
+		data, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sender, err := NewGitlabStatusLogger("evergreen", server.URL+"/", "token")
+	assert.NoError(err)
+	s, ok := sender.(*gitlabStatusLogger)
+	assert.True(ok)
+	s.client = server.Client()
+
+	var sendErr error
+	assert.NoError(s.SetErrorHandler(func(err error, _ message.Composer) { sendErr = err }))
+
+	s.Send(NewGitlabStatusMessage(GitlabStatus{
+		ProjectPath: "group/repo",
+		SHA:         "abcdef",
+		Ref:         "feature",
+		State:       GitlabStatePending,
+		Name:        "evergreen",
+		URL:         "https://example.com/version/1",
+	}))
+	assert.NoError(sendErr)
+	assert.Equal("/api/v4/projects/group%2Frepo/statuses/abcdef", path)
+	assert.Equal("token", token)
+	assert.Equal("pending", body["state"])
+	assert.Equal("evergreen", body["name"])
+	assert.Equal("feature", body["ref"])
+	assert.Equal("https://example.com/version/1", body["target_url"])
+
+	status = http.StatusForbidden
+	s.Send(NewGitlabStatusMessage(GitlabStatus{ProjectPath: "group/repo", SHA: "abcdef", State: GitlabStateFailed, Name: "evergreen"}))
+	assert.EqualError(sendErr, "gitlab status response status was 403 Forbidden")
+}
@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/cloud"
@@ -21,6 +22,8 @@ var distroSyntaxValidators = []distroValidator{
 	ensureValidExpansions,
 	ensureStaticHostsAreNotSpawnable,
 	ensureValidContainerPool,
+	ensureValidTaskPrioritizer,
+	ensureValidCommandPluginDir,
 }
 
 // CheckDistro checks if the distro configuration syntax is valid. Returns
@@ -190,3 +193,34 @@ func ensureValidContainerPool(ctx context.Context, d *distro.Distro, s *evergree
 	}
 	return nil
 }
+
+// ensureValidTaskPrioritizer checks that a distro's task prioritizer, if
+// it has one, is supported.
+func ensureValidTaskPrioritizer(ctx context.Context, d *distro.Distro, s *evergreen.Settings) []ValidationError {
+	if d.TaskPrioritizer != "" && !util.StringSliceContains(evergreen.TaskPrioritizers, d.TaskPrioritizer) {
+		return []ValidationError{
+			{
+				Message: fmt.Sprintf("distro '%s' has unsupported task prioritizer '%s'; supported prioritizers are %v",
+					d.Id, d.TaskPrioritizer, evergreen.TaskPrioritizers),
+				Level: Error,
+			},
+		}
+	}
+	return nil
+}
+
+// ensureValidCommandPluginDir checks that a distro's command plugin
+// directory can be passed to the agent, which is started with its
+// arguments in single quotes.
+func ensureValidCommandPluginDir(ctx context.Context, d *distro.Distro, s *evergreen.Settings) []ValidationError {
+	if strings.ContainsAny(d.CommandPluginDir, "'\n") {
+		return []ValidationError{
+			{
+				Message: fmt.Sprintf("distro '%s' command plugin directory '%s' cannot contain quotes or newlines",
+					d.Id, d.CommandPluginDir),
+				Level: Error,
+			},
+		}
+	}
+	return nil
+}
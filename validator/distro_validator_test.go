@@ -322,3 +322,32 @@ func TestEnsureValidContainerPool(t *testing.T) {
 	err = ensureValidContainerPool(ctx, d4, conf)
 	assert.Nil(err)
 }
+
+func TestEnsureValidTaskPrioritizer(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.Nil(ensureValidTaskPrioritizer(ctx, &distro.Distro{Id: "d1"}, conf))
+	assert.Nil(ensureValidTaskPrioritizer(ctx, &distro.Distro{Id: "d1", TaskPrioritizer: "comparator"}, conf))
+	assert.Nil(ensureValidTaskPrioritizer(ctx, &distro.Distro{Id: "d1", TaskPrioritizer: "fairshare"}, conf))
+
+	errs := ensureValidTaskPrioritizer(ctx, &distro.Distro{Id: "d1", TaskPrioritizer: "random"}, conf)
+	assert.Len(errs, 1)
+	assert.Equal(Error, errs[0].Level)
+}
+
+func TestEnsureValidCommandPluginDir(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.Nil(ensureValidCommandPluginDir(ctx, &distro.Distro{Id: "d1"}, conf))
+	assert.Nil(ensureValidCommandPluginDir(ctx, &distro.Distro{Id: "d1", CommandPluginDir: "/opt/evergreen plugins"}, conf))
+
+	for _, dir := range []string{"/opt/it's", "/opt/'; rm -rf ~; '", "/opt/a\nb"} {
+		errs := ensureValidCommandPluginDir(ctx, &distro.Distro{Id: "d1", CommandPluginDir: dir}, conf)
+		assert.Len(errs, 1, dir)
+		assert.Equal(Error, errs[0].Level)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/thirdparty"
@@ -35,6 +36,24 @@ func GetPatchedProject(ctx context.Context, p *patch.Patch, githubOauthToken str
 		hash = p.GithubPatchData.HeadHash
 	}
 
+	if p.IsGitlabMRPatch() {
+		// the head of a merge request already includes its changes to the
+		// project file
+		gitlab := evergreen.GetEnvironment().Settings().Gitlab
+		projectFileBytes, err = thirdparty.GetGitlabFile(ctx, gitlab.URL, gitlab.Token,
+			p.GitlabPatchData.ProjectPath, projectRef.RemotePath, p.GitlabPatchData.HeadHash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not get gitlab file at '%s'@%s: %s",
+				p.GitlabPatchData.ProjectPath, projectRef.RemotePath, p.GitlabPatchData.HeadHash)
+		}
+
+		project := &model.Project{}
+		if err = model.LoadProjectInto(projectFileBytes, projectRef.Identifier, project); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return project, nil
+	}
+
 	githubFile, err := thirdparty.GetGithubFile(ctx, githubOauthToken, projectRef.Owner,
 		projectRef.Repo, projectRef.RemotePath, hash)
 	if err != nil {
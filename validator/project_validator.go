@@ -46,6 +46,7 @@ var projectSyntaxValidators = []projectValidator{
 	ensureHasNecessaryBVFields,
 	checkDependencyGraph,
 	validatePluginCommands,
+	validateCommandPlugins,
 	ensureHasNecessaryProjectFields,
 	verifyTaskDependencies,
 	verifyTaskRequirements,
@@ -595,13 +596,21 @@ func validateCommands(section string, project *model.Project,
 
 	for _, cmd := range commands {
 		commandName := fmt.Sprintf("'%v' command", cmd.Command)
-		_, err := command.Render(cmd, project.Functions)
+		_, err := command.RenderWithPlugins(cmd, project.Functions, project.CommandPlugins)
 		if err != nil {
 			if cmd.Function != "" {
 				commandName = fmt.Sprintf("'%v' function", cmd.Function)
 			}
 			errs = append(errs, ValidationError{Message: fmt.Sprintf("%v section in %v: %v", section, commandName, err)})
 		}
+		if cmd.Retry != nil {
+			errs = append(errs, validateCommandRetryPolicy(section, commandName, cmd.Retry)...)
+		}
+		if len(cmd.CacheFiles) > 0 && cmd.CacheKey == "" {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("%v section in %v: cache_files requires a cache_key", section, commandName),
+			})
+		}
 		if cmd.Type != "" {
 			if cmd.Type != model.SystemCommandType &&
 				cmd.Type != model.TestCommandType &&
@@ -614,6 +623,32 @@ func validateCommands(section string, project *model.Project,
 	return errs
 }
 
+// validateCommandRetryPolicy ensures that a command's retry policy has
+// a positive number of attempts, a valid backoff, and non-zero exit codes.
+func validateCommandRetryPolicy(section, commandName string, policy *model.CommandRetryPolicy) []ValidationError {
+	errs := []ValidationError{}
+
+	if policy.Attempts < 1 {
+		errs = append(errs, ValidationError{
+			Message: fmt.Sprintf("%v section in %v: retry attempts must be positive", section, commandName),
+		})
+	}
+	if _, err := policy.GetBackoff(); err != nil {
+		errs = append(errs, ValidationError{
+			Message: fmt.Sprintf("%v section in %v: %v", section, commandName, err),
+		})
+	}
+	for _, code := range policy.OnExitCodes {
+		if code == 0 {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("%v section in %v: cannot retry on exit code 0", section, commandName),
+			})
+		}
+	}
+
+	return errs
+}
+
 // Ensures there any plugin commands referenced in a project's configuration
 // are specified in a valid format
 func validatePluginCommands(project *model.Project) []ValidationError {
@@ -678,6 +713,41 @@ func validatePluginCommands(project *model.Project) []ValidationError {
 	return errs
 }
 
+// Ensures that the external command plugins declared by the project
+// have valid, unique names that do not shadow built-in commands.
+func validateCommandPlugins(project *model.Project) []ValidationError {
+	errs := []ValidationError{}
+	seen := map[string]bool{}
+
+	for _, name := range project.CommandPlugins {
+		if name == "" {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("project '%v' declares a command plugin with an empty name",
+					project.Identifier),
+			})
+			continue
+		}
+		if util.IndexWhiteSpace(name) != -1 {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("command plugin '%v' has invalid name: name contains white space", name),
+			})
+		}
+		if _, ok := command.GetCommandFactory(name); ok {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("command plugin '%v' has the same name as a built-in command", name),
+			})
+		}
+		if seen[name] {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("command plugin '%v' is declared more than once", name),
+			})
+		}
+		seen[name] = true
+	}
+
+	return errs
+}
+
 // Ensures there aren't any duplicate task names for this project
 func validateProjectTaskNames(project *model.Project) []ValidationError {
 	errs := []ValidationError{}
@@ -779,7 +849,8 @@ func verifyTaskDependencies(project *model.Project) []ValidationError {
 
 			// check that the status is valid
 			switch dep.Status {
-			case evergreen.TaskSucceeded, evergreen.TaskFailed, model.AllStatuses, "":
+			case evergreen.TaskSucceeded, evergreen.TaskFailed, evergreen.TaskSetupFailed,
+				evergreen.TaskSystemFailed, model.AllStatuses, "":
 				// these are all valid
 			default:
 				errs = append(errs,
@@ -1917,3 +1917,90 @@ func TestValidateCreateHosts(t *testing.T) {
 	errs = validateCreateHosts(&p)
 	assert.Len(errs, 1)
 }
+
+func TestValidateCommandPlugins(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+  command_plugins:
+  - myteam.deploy
+  tasks:
+  - name: t_1
+    commands:
+    - command: myteam.deploy
+      params:
+        target: staging
+  buildvariants:
+  - name: "bv"
+    tasks:
+    - name: t_1
+  `
+	var p model.Project
+	err := model.LoadProjectInto([]byte(yml), "id", &p)
+	require.NoError(err)
+	assert.Equal([]string{"myteam.deploy"}, p.CommandPlugins)
+	assert.Len(validateCommandPlugins(&p), 0)
+	assert.Len(validatePluginCommands(&p), 0)
+
+	// error: misspelled plugin name is not declared
+	p.Tasks[0].Commands[0].Command = "myteam.deplyo"
+	assert.Len(validatePluginCommands(&p), 1)
+
+	// error: plugins cannot shadow built-in commands or be declared twice
+	p.CommandPlugins = []string{"shell.exec", "my plugin", "myteam.deploy", "myteam.deploy", ""}
+	assert.Len(validateCommandPlugins(&p), 4)
+}
+
+func TestValidateCommandCacheFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	project := &model.Project{
+		Tasks: []model.ProjectTask{
+			{
+				Name: "compile",
+				Commands: []model.PluginCommandConf{
+					{
+						Command:    "shell.exec",
+						CacheFiles: []string{"package.lock"},
+						Params:     map[string]interface{}{"script": "npm install"},
+					},
+				},
+			},
+		},
+	}
+	assert.Len(validatePluginCommands(project), 1)
+
+	project.Tasks[0].Commands[0].CacheKey = "npm"
+	assert.Len(validatePluginCommands(project), 0)
+}
+
+func TestValidateCommandRetryPolicy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+  tasks:
+  - name: t_1
+    commands:
+    - command: shell.exec
+      retry:
+        attempts: 3
+        backoff: 10s
+        on_exit_codes: [1, 255]
+      params:
+        script: curl example.com
+  buildvariants:
+  - name: "bv"
+    tasks:
+    - name: t_1
+  `
+	var p model.Project
+	require.NoError(model.LoadProjectInto([]byte(yml), "id", &p))
+	require.NotNil(p.Tasks[0].Commands[0].Retry)
+	assert.Equal(3, p.Tasks[0].Commands[0].Retry.Attempts)
+	assert.Len(validatePluginCommands(&p), 0)
+
+	p.Tasks[0].Commands[0].Retry = &model.CommandRetryPolicy{Attempts: 0, Backoff: "soon", OnExitCodes: []int{0}}
+	assert.Len(validatePluginCommands(&p), 3)
+}